@@ -32,6 +32,21 @@ type ClusterClaimSpec struct {
 	// +kubebuilder:validation:Type=string
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// Selector restricts the claim to clusters whose ClusterDeployment labels match. The
+	// "hive.openshift.io/cluster-deployment-customization" label holds the name of the
+	// ClusterDeploymentCustomization from the pool's inventory that was used to create the cluster.
+	// If no running cluster in the pool matches, the pool resumes a matching hibernating cluster or, if
+	// there is none, creates clusters that match, and the claim remains Pending until one is running. The
+	// claim remains Pending indefinitely only if no cluster the pool has or could create matches.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// MinVersion is the minimum OpenShift version, in the form "[MAJOR].[MINOR]", of the cluster to be
+	// claimed. Clusters whose version is not yet known do not match.
+	// +optional
+	// +kubebuilder:validation:Pattern="^[0-9]+\\.[0-9]+$"
+	MinVersion string `json:"minVersion,omitempty"`
 }
 
 // ClusterClaimStatus defines the observed state of ClusterClaim.
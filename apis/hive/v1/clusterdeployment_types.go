@@ -187,10 +187,26 @@ type ClusterDeploymentSpec struct {
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernationSchedule describes recurring windows during which the cluster should be running. Whenever the
+	// schedule opens a window, Hive sets PowerState to Running; whenever it closes one, Hive sets PowerState to
+	// Hibernating. PowerState may still be changed manually in between; the schedule only acts at window boundaries.
+	// For ClusterDeployments belonging to a ClusterPool, the schedule has no effect until the cluster is claimed.
+	// HibernateAfter still applies alongside a schedule: a cluster hibernated by HibernateAfter during a window
+	// stays hibernated until the next window opens.
+	// +optional
+	HibernationSchedule *HibernationSchedule `json:"hibernationSchedule,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
 
+	// Upgrade is the release an installed cluster should be running. Hive requests the upgrade by updating the
+	// cluster's ClusterVersion, and reports its progress in the ClusterUpgrading and ClusterUpgradeFailed conditions
+	// and in Status.Upgrade. Removing Upgrade does not roll the cluster back; it only stops Hive managing the
+	// cluster's version.
+	// +optional
+	Upgrade *ClusterUpgrade `json:"upgrade,omitempty"`
+
 	// BoundServiceAccountSignkingKeySecretRef refers to a Secret that contains a
 	// 'bound-service-account-signing-key.key' data key pointing to the private
 	// key that will be used to sign ServiceAccount objects. Primarily used to
@@ -199,6 +215,43 @@ type ClusterDeploymentSpec struct {
 	BoundServiceAccountSignkingKeySecretRef *corev1.LocalObjectReference `json:"boundServiceAccountSigningKeySecretRef,omitempty"`
 }
 
+// HibernationSchedule describes when a cluster should be running. Outside of the RunWindows the cluster is
+// hibernated.
+type HibernationSchedule struct {
+	// TimeZone is the IANA time zone name (e.g. "Europe/Berlin") in which RunWindows are evaluated.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// RunWindows are the windows during which the cluster should be running.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	RunWindows []ScheduleWindow `json:"runWindows"`
+}
+
+// ClusterUpgrade describes the release to which an installed cluster should be upgraded. Exactly one of Version and
+// ImageSetRef must be set.
+type ClusterUpgrade struct {
+	// Version is the OpenShift version to upgrade to, e.g. "4.12.3". The version must be one of the available
+	// updates of the cluster's channel unless Force is set.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ImageSetRef is a reference to a ClusterImageSet whose release image the cluster should be upgraded to.
+	// +optional
+	ImageSetRef *ClusterImageSetReference `json:"imageSetRef,omitempty"`
+
+	// Channel is the update channel to set on the cluster's ClusterVersion, e.g. "stable-4.12". When omitted, the
+	// cluster's channel is left unchanged.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// Force requests the upgrade even when the version is not an available update of the cluster's channel, the
+	// release image cannot be verified, or the cluster reports that it is not upgradeable.
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
 // ClusterInstallLocalReference provides reference to an object that implements
 // the hivecontract ClusterInstall. The namespace of the object is same as the
 // ClusterDeployment.
@@ -358,6 +411,12 @@ type ClusterDeploymentStatus struct {
 	// +optional
 	PowerState ClusterPowerState `json:"powerState,omitempty"`
 
+	// HibernatedDuration is the total time the cluster has spent hibernating, not counting its current hibernation,
+	// which started at the last transition of the Hibernating condition if that condition is true. Hibernations that
+	// ended before Hive started recording this field are not counted.
+	// +optional
+	HibernatedDuration *metav1.Duration `json:"hibernatedDuration,omitempty"`
+
 	// ProvisionRef is a reference to the last ClusterProvision created for the deployment
 	// +optional
 	ProvisionRef *corev1.LocalObjectReference `json:"provisionRef,omitempty"`
@@ -366,6 +425,57 @@ type ClusterDeploymentStatus struct {
 	// perform the installation.
 	// +optional
 	Platform *PlatformStatus `json:"platformStatus,omitempty"`
+
+	// HibernationSchedule reports the state of the HibernationSchedule, if one is configured.
+	// +optional
+	HibernationSchedule *HibernationScheduleStatus `json:"hibernationSchedule,omitempty"`
+
+	// Upgrade reports the version of the cluster while Spec.Upgrade is set.
+	// +optional
+	Upgrade *ClusterUpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// ClusterUpgradeStatus reports the version of a cluster whose upgrades are managed by Hive, as observed in the
+// cluster's ClusterVersion.
+type ClusterUpgradeStatus struct {
+	// CurrentVersion is the most recent version to which the cluster completed updating.
+	// +optional
+	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// DesiredVersion is the version the cluster is updating, or has updated, to.
+	// +optional
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+
+	// DesiredImage is the release image of DesiredVersion.
+	// +optional
+	DesiredImage string `json:"desiredImage,omitempty"`
+
+	// Channel is the update channel of the cluster.
+	// +optional
+	Channel string `json:"channel,omitempty"`
+
+	// StartedTime is the time the cluster started updating to DesiredVersion.
+	// +optional
+	StartedTime *metav1.Time `json:"startedTime,omitempty"`
+
+	// CompletionTime is the time the cluster completed updating to DesiredVersion.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// HibernationScheduleStatus reports the state of a ClusterDeployment's HibernationSchedule.
+type HibernationScheduleStatus struct {
+	// LastTransitionTime is the most recent schedule boundary at which Hive applied the scheduled power state.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// NextTransitionTime is the time at which the schedule will next change the cluster's power state.
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+
+	// NextPowerState is the power state the schedule will apply at NextTransitionTime.
+	// +optional
+	NextPowerState ClusterPowerState `json:"nextPowerState,omitempty"`
 }
 
 // ClusterDeploymentCondition contains details for the current condition of a cluster deployment
@@ -439,6 +549,10 @@ const (
 	// RelocationFailedCondition indicates if a relocation to another Hive instance has failed
 	RelocationFailedCondition ClusterDeploymentConditionType = "RelocationFailed"
 
+	// HibernationScheduleInvalidCondition is set when the ClusterDeployment's HibernationSchedule cannot be
+	// evaluated, e.g. because its TimeZone is unknown. The schedule has no effect while this condition is True.
+	HibernationScheduleInvalidCondition ClusterDeploymentConditionType = "HibernationScheduleInvalid"
+
 	// ClusterHibernatingCondition is set when the ClusterDeployment is either
 	// transitioning to/from a hibernating state or is in a hibernating state.
 	ClusterHibernatingCondition ClusterDeploymentConditionType = "Hibernating"
@@ -483,6 +597,17 @@ const (
 	ClusterInstallStoppedClusterDeploymentCondition         ClusterDeploymentConditionType = "ClusterInstallStopped"
 	ClusterInstallRequirementsMetClusterDeploymentCondition ClusterDeploymentConditionType = "ClusterInstallRequirementsMet"
 
+	// ClusterUpgradingCondition is True while the cluster is upgrading to the release requested by Spec.Upgrade.
+	ClusterUpgradingCondition ClusterDeploymentConditionType = "ClusterUpgrading"
+
+	// ClusterUpgradeFailedCondition is True when the upgrade requested by Spec.Upgrade cannot be requested, or
+	// the cluster reports that it is failing to apply it.
+	ClusterUpgradeFailedCondition ClusterDeploymentConditionType = "ClusterUpgradeFailed"
+
+	// ClusterOperatorsDegradedCondition is True when the ClusterState of the cluster reports that any of its
+	// cluster operators is degraded or unavailable.
+	ClusterOperatorsDegradedCondition ClusterDeploymentConditionType = "ClusterOperatorsDegraded"
+
 	// ClusterImageSetNotFoundCondition is a legacy condition type that is not intended to be used
 	// in production.  This type is never used by hive.
 	ClusterImageSetNotFoundCondition ClusterDeploymentConditionType = "ClusterImageSetNotFound"
@@ -502,6 +627,45 @@ var PositivePolarityClusterDeploymentConditions = []ClusterDeploymentConditionTy
 	ProvisionedCondition,
 }
 
+// HibernationScheduleInvalid condition reasons
+const (
+	// HibernationScheduleReasonInvalid is used when the HibernationSchedule cannot be evaluated.
+	HibernationScheduleReasonInvalid = "InvalidSchedule"
+	// HibernationScheduleReasonValid is used when the HibernationSchedule is valid or has been removed.
+	HibernationScheduleReasonValid = "ValidSchedule"
+)
+
+// ClusterUpgrading and ClusterUpgradeFailed condition reasons
+const (
+	// UpgradeNotRequestedReason is used when Spec.Upgrade is not set.
+	UpgradeNotRequestedReason = "UpgradeNotRequested"
+	// UpgradeRequestedReason is used when the upgrade has been requested but the cluster has not started it.
+	UpgradeRequestedReason = "UpgradeRequested"
+	// UpgradeInProgressReason is used while the cluster is upgrading.
+	UpgradeInProgressReason = "UpgradeInProgress"
+	// UpgradeCompletedReason is used when the cluster is running the requested release.
+	UpgradeCompletedReason = "UpgradeCompleted"
+	// UpgradeNotFailingReason is used when there is no problem with the requested upgrade.
+	UpgradeNotFailingReason = "UpgradeNotFailing"
+	// UpgradeVersionNotAvailableReason is used when the requested version is not an available update of the
+	// cluster's channel.
+	UpgradeVersionNotAvailableReason = "VersionNotAvailable"
+	// UpgradeClusterImageSetNotFoundReason is used when the requested ClusterImageSet does not exist.
+	UpgradeClusterImageSetNotFoundReason = "ClusterImageSetNotFound"
+	// UpgradeClusterVersionFailingReason is used when the cluster's ClusterVersion reports that it is failing.
+	UpgradeClusterVersionFailingReason = "ClusterVersionFailing"
+)
+
+// ClusterOperatorsDegraded condition reasons
+const (
+	// ClusterOperatorsHealthyReason is used when no cluster operator is degraded or unavailable.
+	ClusterOperatorsHealthyReason = "ClusterOperatorsHealthy"
+	// ClusterOperatorsDegradedReason is used when a cluster operator is degraded.
+	ClusterOperatorsDegradedReason = "ClusterOperatorsDegraded"
+	// ClusterOperatorsUnavailableReason is used when a cluster operator is unavailable.
+	ClusterOperatorsUnavailableReason = "ClusterOperatorsUnavailable"
+)
+
 // Cluster hibernating and ready reasons
 const (
 	// HibernatingReasonResumingOrRunning is used as the reason for the Hibernating condition when the cluster
@@ -571,6 +735,8 @@ const (
 	ProvisionedReasonProvisionStopped = "ProvisionStopped"
 	// ProvisionedReasonProvisioned is set when the provision is successful.
 	ProvisionedReasonProvisioned = "Provisioned"
+	// ProvisionedReasonDeprovisionPending is set while the deprovision of a deleted cluster is held off by its deprovision grace period.
+	ProvisionedReasonDeprovisionPending = "DeprovisionPending"
 	// ProvisionedReasonDeprovisioning is set when we start to deprovision the cluster.
 	ProvisionedReasonDeprovisioning = "Deprovisioning"
 	// ProvisionedReasonDeprovisionFailed means the deprovision failed terminally.
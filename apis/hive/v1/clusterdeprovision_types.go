@@ -32,6 +32,11 @@ type ClusterDeprovisionStatus struct {
 	// Conditions includes more detailed status for the cluster deprovision
 	// +optional
 	Conditions []ClusterDeprovisionCondition `json:"conditions,omitempty"`
+
+	// OrphanedResources lists the cloud resources still tagged with the infraID of the cluster once the uninstall
+	// has completed, when deprovisions are verified. The list is truncated if there are many such resources.
+	// +optional
+	OrphanedResources []string `json:"orphanedResources,omitempty"`
 }
 
 // ClusterDeprovisionPlatform contains platform-specific configuration for the
@@ -207,6 +212,9 @@ const (
 
 	// DeprovisionFailedClusterDeprovisionCondition is true when deprovision attempt failed
 	DeprovisionFailedClusterDeprovisionCondition ClusterDeprovisionConditionType = "DeprovisionFailed"
+	// OrphanedResourcesClusterDeprovisionCondition is true when cloud resources tagged with the infraID of the
+	// cluster remain once the uninstall has completed
+	OrphanedResourcesClusterDeprovisionCondition ClusterDeprovisionConditionType = "OrphanedResources"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
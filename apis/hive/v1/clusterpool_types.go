@@ -77,6 +77,22 @@ type ClusterPoolSpec struct {
 	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
 	HibernateAfter *metav1.Duration `json:"hibernateAfter,omitempty"`
 
+	// HibernationSchedule is applied to ClusterDeployments from the pool as they are claimed; until then, power
+	// state is managed by the pool according to RunningCount. Changing it does not affect clusters that have
+	// already been claimed.
+	// +optional
+	HibernationSchedule *HibernationSchedule `json:"hibernationSchedule,omitempty"`
+
+	// SizingSchedule overrides Size, RunningCount and/or MaxConcurrent during recurring windows of time. The first
+	// entry with an open window is in effect; the values it does not set are taken from the spec as usual.
+	// +optional
+	SizingSchedule []ClusterPoolSizingScheduleEntry `json:"sizingSchedule,omitempty"`
+
+	// Autoscaling, if set, makes the pool choose its Size according to recent demand, in place of Size and any
+	// Size set by SizingSchedule.
+	// +optional
+	Autoscaling *ClusterPoolAutoscaling `json:"autoscaling,omitempty"`
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	// +optional
 	InstallAttemptsLimit *int32 `json:"installAttemptsLimit,omitempty"`
@@ -99,6 +115,62 @@ type ClusterPoolSpec struct {
 	Inventory []InventoryEntry `json:"inventory,omitempty"`
 }
 
+// ClusterPoolSizingScheduleEntry overrides some of the pool's sizing parameters during recurring windows of time.
+type ClusterPoolSizingScheduleEntry struct {
+	// TimeZone is the IANA time zone name (e.g. "Europe/Berlin") in which Windows are evaluated.
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Windows are the windows during which this entry is in effect.
+	// +kubebuilder:validation:MinItems=1
+	// +required
+	Windows []ScheduleWindow `json:"windows"`
+
+	// Size, if set, replaces the pool's Size while this entry is in effect.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Size *int32 `json:"size,omitempty"`
+
+	// RunningCount, if set, replaces the pool's RunningCount while this entry is in effect.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RunningCount *int32 `json:"runningCount,omitempty"`
+
+	// MaxConcurrent, if set, replaces the pool's MaxConcurrent while this entry is in effect.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+}
+
+// ClusterPoolAutoscaling configures a ClusterPool to size itself according to demand. The pool's Size is set to
+// the number of claims that were created during the last Window, kept between MinSize and MaxSize. Claims still
+// waiting for a cluster are given clusters of their own on top of Size.
+type ClusterPoolAutoscaling struct {
+	// MinSize is the smallest Size the pool will choose.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MinSize int32 `json:"minSize"`
+
+	// MaxSize is the largest Size the pool will choose.
+	// +kubebuilder:validation:Minimum=0
+	// +required
+	MaxSize int32 `json:"maxSize"`
+
+	// Window is the period over which claims are counted. It should be roughly the time it takes to install a
+	// cluster, so that the pool holds enough clusters to satisfy the claims that arrive while replacements are
+	// being installed. Defaults to one hour.
+	// This is a Duration value; see https://pkg.go.dev/time#ParseDuration for accepted formats.
+	// Note: due to discrepancies in validation vs parsing, we use a Pattern instead of `Format=duration`. See
+	// https://bugzilla.redhat.com/show_bug.cgi?id=2050332
+	// https://github.com/kubernetes/apimachinery/issues/131
+	// https://github.com/kubernetes/apiextensions-apiserver/issues/56
+	// +optional
+	// +kubebuilder:validation:Type=string
+	// +kubebuilder:validation:Pattern="^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
 type HibernationConfig struct {
 	// ResumeTimeout is the maximum amount of time we will wait for an unclaimed ClusterDeployment to resume from
 	// hibernation (e.g. at the behest of runningCount, or in preparation for being claimed). If this time is
@@ -173,6 +245,52 @@ type ClusterPoolStatus struct {
 	// Conditions includes more detailed status for the cluster pool
 	// +optional
 	Conditions []ClusterPoolCondition `json:"conditions,omitempty"`
+
+	// Effective is the sizing currently applied to the pool, taking SizingSchedule into account.
+	// +optional
+	Effective *ClusterPoolEffectiveSizing `json:"effective,omitempty"`
+
+	// RecentClaims are the claims made on the pool within its autoscaling window. They are recorded so that
+	// claims deleted within the window still count towards the pool's demand. Only kept for pools with
+	// Autoscaling.
+	// +optional
+	RecentClaims []ClusterPoolRecentClaim `json:"recentClaims,omitempty"`
+}
+
+// ClusterPoolRecentClaim records a claim made on a ClusterPool.
+type ClusterPoolRecentClaim struct {
+	// Name is the name of the ClusterClaim.
+	Name string `json:"name"`
+
+	// CreationTimestamp is the time the ClusterClaim was created.
+	CreationTimestamp metav1.Time `json:"creationTimestamp"`
+}
+
+// ClusterPoolEffectiveSizing is the sizing currently applied to a ClusterPool.
+type ClusterPoolEffectiveSizing struct {
+	// Size is the number of unclaimed clusters the pool is maintaining.
+	Size int32 `json:"size"`
+
+	// RunningCount is the number of unclaimed clusters the pool is keeping running.
+	RunningCount int32 `json:"runningCount"`
+
+	// MaxConcurrent is the maximum number of clusters the pool will provision or deprovision at a time. Unset if
+	// there is no limit.
+	// +optional
+	MaxConcurrent *int32 `json:"maxConcurrent,omitempty"`
+
+	// SizingScheduleEntry is the index in SizingSchedule of the entry in effect. Unset if no entry is in effect.
+	// +optional
+	SizingScheduleEntry *int32 `json:"sizingScheduleEntry,omitempty"`
+
+	// Autoscaled is true if Size was chosen by Autoscaling.
+	// +optional
+	Autoscaled bool `json:"autoscaled,omitempty"`
+
+	// NextTransition is the next time at which a window of SizingSchedule opens or closes, and therefore the
+	// effective sizing may change.
+	// +optional
+	NextTransition *metav1.Time `json:"nextTransition,omitempty"`
 }
 
 // ClusterPoolCondition contains details for the current condition of a cluster pool
@@ -55,6 +55,11 @@ type ClusterProvisionSpec struct {
 
 	// PrevProvisionName is the name of the previous failed provision attempt.
 	PrevProvisionName *string `json:"prevProvisionName,omitempty"`
+
+	// InstallConfigRemediations are the remediations applied to the install-config for this provision attempt, in
+	// the order in which they are applied. They include the remediations of the previous failed provision attempt.
+	// +optional
+	InstallConfigRemediations []InstallConfigRemediation `json:"installConfigRemediations,omitempty"`
 }
 
 // ClusterProvisionStatus defines the observed state of ClusterProvision.
@@ -65,6 +70,133 @@ type ClusterProvisionStatus struct {
 	// Conditions includes more detailed status for the cluster provision
 	// +optional
 	Conditions []ClusterProvisionCondition `json:"conditions,omitempty"`
+
+	// FailureClassification classifies the failure of the provision from the known errors found in its install log.
+	// It is set when the provision fails.
+	// +optional
+	FailureClassification *InstallFailureClassification `json:"failureClassification,omitempty"`
+
+	// InstallPhases are the phases of the install reached so far, as reported by the installer, in the order in
+	// which they were reached. The last phase is the one the install is in, or was in when it failed.
+	// +optional
+	InstallPhases []ClusterProvisionInstallPhase `json:"installPhases,omitempty"`
+}
+
+// InstallPhase is a phase of an install, as reported by the installer.
+// +kubebuilder:validation:Enum=InfrastructureCreation;Bootstrap;BootstrapComplete;ClusterOperators;InstallComplete
+type InstallPhase string
+
+const (
+	// InstallPhaseInfrastructureCreation is the creation of the cloud resources of the cluster.
+	InstallPhaseInfrastructureCreation InstallPhase = "InfrastructureCreation"
+
+	// InstallPhaseBootstrap is the wait for the bootstrap node to bring up the Kubernetes API and the control plane.
+	InstallPhaseBootstrap InstallPhase = "Bootstrap"
+
+	// InstallPhaseBootstrapComplete is the destruction of the bootstrap resources once bootstrapping has completed.
+	InstallPhaseBootstrapComplete InstallPhase = "BootstrapComplete"
+
+	// InstallPhaseClusterOperators is the wait for the cluster operators to become available.
+	InstallPhaseClusterOperators InstallPhase = "ClusterOperators"
+
+	// InstallPhaseInstallComplete marks the end of the install. It has no duration.
+	InstallPhaseInstallComplete InstallPhase = "InstallComplete"
+)
+
+// ClusterProvisionInstallPhase records when the install was in a phase.
+type ClusterProvisionInstallPhase struct {
+	// Name is the name of the phase.
+	Name InstallPhase `json:"name"`
+
+	// StartTime is when the install reached the phase.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is when the install reached the next phase. It is not set for the phase the install is in.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// InstallFailureCategory is the broad cause of an install failure.
+// +kubebuilder:validation:Enum=Quota;Permissions;DNS;CloudOutage;InstallerBug;Configuration;Unknown
+type InstallFailureCategory string
+
+const (
+	// InstallFailureCategoryQuota indicates that the install exceeded a quota or limit of the cloud account.
+	InstallFailureCategoryQuota InstallFailureCategory = "Quota"
+
+	// InstallFailureCategoryPermissions indicates that the credentials used for the install are invalid or lack
+	// permissions.
+	InstallFailureCategoryPermissions InstallFailureCategory = "Permissions"
+
+	// InstallFailureCategoryDNS indicates a problem with the DNS zones or records of the cluster.
+	InstallFailureCategoryDNS InstallFailureCategory = "DNS"
+
+	// InstallFailureCategoryCloudOutage indicates that the cloud provider failed or throttled requests, or lacked
+	// capacity.
+	InstallFailureCategoryCloudOutage InstallFailureCategory = "CloudOutage"
+
+	// InstallFailureCategoryInstallerBug indicates a failure in the installer or in the cluster it installed.
+	InstallFailureCategoryInstallerBug InstallFailureCategory = "InstallerBug"
+
+	// InstallFailureCategoryConfiguration indicates an invalid install config or cloud environment, such as missing
+	// subnets or an unreachable proxy.
+	InstallFailureCategoryConfiguration InstallFailureCategory = "Configuration"
+
+	// InstallFailureCategoryUnknown indicates that the cause of the failure is not known.
+	InstallFailureCategoryUnknown InstallFailureCategory = "Unknown"
+)
+
+// InstallFailureSeverity is how conclusively a known error explains an install failure.
+// +kubebuilder:validation:Enum=Critical;Error;Warning
+type InstallFailureSeverity string
+
+const (
+	// InstallFailureSeverityCritical indicates an error that explains the failure on its own.
+	InstallFailureSeverityCritical InstallFailureSeverity = "Critical"
+
+	// InstallFailureSeverityError indicates an error that likely explains the failure.
+	InstallFailureSeverityError InstallFailureSeverity = "Error"
+
+	// InstallFailureSeverityWarning indicates a generic symptom of a failure, reported only when no more severe error
+	// is found.
+	InstallFailureSeverityWarning InstallFailureSeverity = "Warning"
+)
+
+// InstallFailureClassification classifies the failure of a provision.
+type InstallFailureClassification struct {
+	// Category is the category of the most severe known error found in the install log.
+	Category InstallFailureCategory `json:"category"`
+
+	// Retryable is whether retrying the install may succeed. When FailedProvisionConfig.RetryReasons is not
+	// set, a failed install is only retried if it is retryable.
+	Retryable bool `json:"retryable"`
+
+	// Matches are the known errors found in the install log, most severe first. The first match is reported in
+	// the ClusterProvisionFailed condition.
+	// +optional
+	Matches []InstallFailureMatch `json:"matches,omitempty"`
+}
+
+// InstallFailureMatch is a known error found in an install log.
+type InstallFailureMatch struct {
+	// Name is the name of the install log regex that matched.
+	Name string `json:"name"`
+
+	// Reason is the reason reported for the error.
+	Reason string `json:"reason"`
+
+	// Message is the message reported for the error.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Category is the category of the error.
+	Category InstallFailureCategory `json:"category"`
+
+	// Severity is the severity of the error.
+	Severity InstallFailureSeverity `json:"severity"`
+
+	// Retryable is whether retrying the install may succeed despite the error.
+	Retryable bool `json:"retryable"`
 }
 
 // ClusterProvisionStage is the stage of provisioning.
@@ -12,6 +12,12 @@ type ClusterRelocateSpec struct {
 
 	// ClusterDeploymentSelector is a LabelSelector indicating which clusters will be relocated.
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// DryRun, when true, stops the clusters from being relocated. Instead, the resources that would be copied to the
+	// destination Hive instance for each matching ClusterDeployment, and the resources that would be skipped, are
+	// reported in the status of the ClusterRelocate.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // KubeconfigSecretReference is a reference to a secret containing the kubeconfig for a remote cluster.
@@ -23,7 +29,92 @@ type KubeconfigSecretReference struct {
 }
 
 // ClusterRelocateStatus defines the observed state of ClusterRelocate.
-type ClusterRelocateStatus struct{}
+type ClusterRelocateStatus struct {
+	// ClusterDeployments reports the relocation of each ClusterDeployment that matches the ClusterRelocate or that
+	// has been relocated by it.
+	// +optional
+	ClusterDeployments []ClusterRelocateClusterDeploymentStatus `json:"clusterDeployments,omitempty"`
+
+	// Matched is the number of matching ClusterDeployments that are not yet relocating.
+	// +optional
+	Matched int32 `json:"matched"`
+
+	// InProgress is the number of ClusterDeployments that are relocating.
+	// +optional
+	InProgress int32 `json:"inProgress"`
+
+	// Completed is the number of ClusterDeployments that have been relocated.
+	// +optional
+	Completed int32 `json:"completed"`
+
+	// Failed is the number of ClusterDeployments whose relocation failed.
+	// +optional
+	Failed int32 `json:"failed"`
+}
+
+// ClusterRelocateState is the state of the relocation of a ClusterDeployment by a ClusterRelocate.
+type ClusterRelocateState string
+
+const (
+	// ClusterRelocateMatched indicates that the ClusterDeployment matches the ClusterRelocate but is not relocating,
+	// e.g. because the ClusterRelocate is a dry run.
+	ClusterRelocateMatched ClusterRelocateState = "Matched"
+	// ClusterRelocateInProgress indicates that the resources of the ClusterDeployment are being copied to the
+	// destination Hive instance.
+	ClusterRelocateInProgress ClusterRelocateState = "InProgress"
+	// ClusterRelocateCompleted indicates that the ClusterDeployment has been relocated.
+	ClusterRelocateCompleted ClusterRelocateState = "Completed"
+	// ClusterRelocateFailed indicates that the ClusterDeployment cannot be relocated.
+	ClusterRelocateFailed ClusterRelocateState = "Failed"
+)
+
+// ClusterRelocateClusterDeploymentStatus reports the relocation of a ClusterDeployment by a ClusterRelocate.
+type ClusterRelocateClusterDeploymentStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// State is the state of the relocation of the ClusterDeployment.
+	State ClusterRelocateState `json:"state"`
+
+	// Reason is a brief CamelCase reason for the state.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human-readable description of the state.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// LastTransitionTime is the time the state last changed.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// ResourcesToCopy summarizes, by kind, the resources that would be copied to the destination Hive instance, in the
+	// order in which they would be copied. Only reported when the ClusterRelocate is a dry run.
+	// +optional
+	ResourcesToCopy []RelocateResourceSummary `json:"resourcesToCopy,omitempty"`
+
+	// IgnoredResources summarizes, by kind, the resources in the namespace of the ClusterDeployment that would not be
+	// copied to the destination Hive instance. Only reported when the ClusterRelocate is a dry run.
+	// +optional
+	IgnoredResources []RelocateResourceSummary `json:"ignoredResources,omitempty"`
+}
+
+// RelocateResourceSummary summarizes the resources of one kind in the namespace of a relocated ClusterDeployment.
+type RelocateResourceSummary struct {
+	// Kind is the kind of the resources.
+	Kind string `json:"kind"`
+
+	// Count is the number of resources of the kind.
+	Count int32 `json:"count"`
+
+	// Names are the names of the first resources of the kind. At most 10 names are listed, so that the status of a
+	// ClusterRelocate matching many ClusterDeployments stays within the size limit of an object.
+	// +optional
+	Names []string `json:"names,omitempty"`
+}
 
 // +genclient:nonNamespaced
 // +genclient
@@ -33,6 +124,10 @@ type ClusterRelocateStatus struct{}
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Selector",type="string",JSONPath=".spec.clusterDeploymentSelector"
+// +kubebuilder:printcolumn:name="DryRun",type="boolean",JSONPath=".spec.dryRun"
+// +kubebuilder:printcolumn:name="InProgress",type="integer",JSONPath=".status.inProgress"
+// +kubebuilder:printcolumn:name="Completed",type="integer",JSONPath=".status.completed"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failed"
 // +kubebuilder:resource:path=clusterrelocates
 type ClusterRelocate struct {
 	metav1.TypeMeta   `json:",inline"`
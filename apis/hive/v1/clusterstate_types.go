@@ -18,6 +18,58 @@ type ClusterStateStatus struct {
 	// ClusterOperators contains the state for every cluster operator in the
 	// target cluster
 	ClusterOperators []ClusterOperatorState `json:"clusterOperators,omitempty"`
+
+	// Health summarizes the state of the cluster operators in the target cluster
+	// +optional
+	Health *ClusterOperatorsHealth `json:"health,omitempty"`
+}
+
+// ClusterOperatorsHealth summarizes the state of the cluster operators in a cluster
+type ClusterOperatorsHealth struct {
+	// Total is the number of cluster operators in the cluster
+	Total int32 `json:"total"`
+
+	// Available is the number of cluster operators that report Available=True
+	Available int32 `json:"available"`
+
+	// Progressing is the number of cluster operators that report Progressing=True
+	Progressing int32 `json:"progressing"`
+
+	// Degraded is the number of cluster operators that report Degraded=True or Available=False
+	Degraded int32 `json:"degraded"`
+
+	// DegradedSince is the earliest time at which one of the currently degraded cluster operators became degraded
+	// or unavailable. It is not set when no cluster operator is degraded.
+	// +optional
+	DegradedSince *metav1.Time `json:"degradedSince,omitempty"`
+
+	// WorstOperator is the cluster operator in the worst state. Unavailable operators are worse than degraded
+	// ones; between operators in the same state, the one that has been in it the longest is worse. It is not set
+	// when no cluster operator is degraded.
+	// +optional
+	WorstOperator *DegradedClusterOperator `json:"worstOperator,omitempty"`
+}
+
+// DegradedClusterOperator describes a cluster operator that is degraded or unavailable
+type DegradedClusterOperator struct {
+	// Name is the name of the cluster operator
+	Name string `json:"name"`
+
+	// Condition is the condition of the cluster operator that reports the problem: Available when the operator
+	// is unavailable and Degraded when it is degraded
+	Condition configv1.ClusterStatusConditionType `json:"condition"`
+
+	// Reason is the reason of the condition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the message of the condition
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Since is the last transition time of the condition
+	// +optional
+	Since *metav1.Time `json:"since,omitempty"`
 }
 
 // ClusterOperatorState summarizes the status of a single cluster operator
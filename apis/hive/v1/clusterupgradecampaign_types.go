@@ -0,0 +1,199 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ClusterUpgradeCampaignSpec defines the desired state of a ClusterUpgradeCampaign.
+type ClusterUpgradeCampaignSpec struct {
+	// ClusterDeploymentSelector selects the installed ClusterDeployments, in any namespace, to upgrade.
+	// +required
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+
+	// Upgrade is the upgrade to request for each selected cluster. The campaign starts the upgrade of a cluster by
+	// setting it as the Upgrade of the cluster's ClusterDeployment.
+	// +required
+	Upgrade ClusterUpgrade `json:"upgrade"`
+
+	// Waves are the ordered waves in which the selected clusters are upgraded. Each cluster is upgraded in the
+	// first wave whose selector matches it; clusters that match no wave are upgraded in a final wave. A wave is not
+	// started until every cluster in the previous waves has completed its upgrade. When empty, all selected
+	// clusters are upgraded in a single wave.
+	// +optional
+	Waves []ClusterUpgradeWave `json:"waves,omitempty"`
+
+	// MaxUnavailable is the maximum number of clusters, or the percentage of the clusters in the current wave
+	// (e.g. "10%"), that are upgrading at once. Percentages are rounded up. Defaults to 1.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// SoakTime is how long to wait after the last cluster of a wave completes its upgrade before starting the
+	// next wave.
+	// +optional
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+
+	// MaxFailures is the number of clusters whose upgrade may fail before the campaign halts. A cluster's upgrade
+	// has failed when its ClusterDeployment reports ClusterUpgradeFailed, or when its ClusterState reports a
+	// degraded or unavailable ClusterOperator after the upgrade completes. A halted campaign starts no further
+	// upgrades, even if the failed clusters recover, until it is resumed by setting Paused and then clearing it. It
+	// halts again if more than MaxFailures upgrades have still failed.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailures int32 `json:"maxFailures,omitempty"`
+
+	// Paused stops the campaign from starting the upgrade of any further clusters. Upgrades already in progress
+	// continue.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// ClusterUpgradeWave is a group of clusters in a ClusterUpgradeCampaign that are upgraded together.
+type ClusterUpgradeWave struct {
+	// Name is the name of the wave.
+	// +required
+	Name string `json:"name"`
+
+	// ClusterDeploymentSelector selects the clusters of the campaign that are upgraded in this wave.
+	// +required
+	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector"`
+}
+
+// ClusterUpgradeCampaignState is the state of a ClusterUpgradeCampaign.
+type ClusterUpgradeCampaignState string
+
+const (
+	// ClusterUpgradeCampaignProgressing indicates that clusters in the current wave are being upgraded.
+	ClusterUpgradeCampaignProgressing ClusterUpgradeCampaignState = "Progressing"
+
+	// ClusterUpgradeCampaignSoaking indicates that the campaign is waiting for the soak time of the previous wave
+	// to pass before starting the next wave.
+	ClusterUpgradeCampaignSoaking ClusterUpgradeCampaignState = "Soaking"
+
+	// ClusterUpgradeCampaignPaused indicates that the campaign is paused.
+	ClusterUpgradeCampaignPaused ClusterUpgradeCampaignState = "Paused"
+
+	// ClusterUpgradeCampaignHalted indicates that the campaign was stopped because too many upgrades failed, or
+	// because the campaign is invalid. The campaign stays halted until it is paused.
+	ClusterUpgradeCampaignHalted ClusterUpgradeCampaignState = "Halted"
+
+	// ClusterUpgradeCampaignCompleted indicates that every selected cluster has completed its upgrade.
+	ClusterUpgradeCampaignCompleted ClusterUpgradeCampaignState = "Completed"
+)
+
+// ClusterUpgradeState is the state of the upgrade of a cluster in a ClusterUpgradeCampaign.
+type ClusterUpgradeState string
+
+const (
+	// ClusterUpgradePending indicates that the campaign has not yet started the upgrade of the cluster.
+	ClusterUpgradePending ClusterUpgradeState = "Pending"
+
+	// ClusterUpgradeUpgrading indicates that the cluster is upgrading.
+	ClusterUpgradeUpgrading ClusterUpgradeState = "Upgrading"
+
+	// ClusterUpgradeCompleted indicates that the cluster has completed its upgrade.
+	ClusterUpgradeCompleted ClusterUpgradeState = "Completed"
+
+	// ClusterUpgradeFailed indicates that the upgrade of the cluster failed.
+	ClusterUpgradeFailed ClusterUpgradeState = "Failed"
+
+	// ClusterUpgradeSkipped indicates that the upgrade of the cluster is managed by another campaign.
+	ClusterUpgradeSkipped ClusterUpgradeState = "Skipped"
+)
+
+// ClusterUpgradeCampaignStatus defines the observed state of a ClusterUpgradeCampaign.
+type ClusterUpgradeCampaignStatus struct {
+	// State is the state of the campaign.
+	// +optional
+	State ClusterUpgradeCampaignState `json:"state,omitempty"`
+
+	// CurrentWave is the name of the wave being upgraded.
+	// +optional
+	CurrentWave string `json:"currentWave,omitempty"`
+
+	// Message is a human-readable description of the state of the campaign.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetedClusters is the number of clusters selected by the campaign.
+	// +optional
+	TargetedClusters int32 `json:"targetedClusters"`
+
+	// UpgradingClusters is the number of clusters that are upgrading.
+	// +optional
+	UpgradingClusters int32 `json:"upgradingClusters"`
+
+	// CompletedClusters is the number of clusters that have completed their upgrade.
+	// +optional
+	CompletedClusters int32 `json:"completedClusters"`
+
+	// FailedClusters is the number of clusters whose upgrade failed.
+	// +optional
+	FailedClusters int32 `json:"failedClusters"`
+
+	// Clusters reports the progress of the upgrade of each cluster selected by the campaign.
+	// +optional
+	Clusters []ClusterUpgradeCampaignClusterStatus `json:"clusters,omitempty"`
+}
+
+// ClusterUpgradeCampaignClusterStatus reports the progress of the upgrade of a cluster in a ClusterUpgradeCampaign.
+type ClusterUpgradeCampaignClusterStatus struct {
+	// Namespace is the namespace of the ClusterDeployment.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the ClusterDeployment.
+	Name string `json:"name"`
+
+	// Wave is the name of the wave in which the cluster is upgraded.
+	Wave string `json:"wave"`
+
+	// State is the state of the upgrade of the cluster.
+	State ClusterUpgradeState `json:"state"`
+
+	// StartedTime is the time the campaign started the upgrade of the cluster.
+	// +optional
+	StartedTime *metav1.Time `json:"startedTime,omitempty"`
+
+	// CompletionTime is the time the cluster completed its upgrade.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Message is a human-readable description of the state of the upgrade of the cluster.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +genclient:nonNamespaced
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgradeCampaign upgrades the clusters of the ClusterDeployments matching a label selector in ordered waves.
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=clusterupgradecampaigns,shortName=cuc,scope=Cluster
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="Wave",type="string",JSONPath=".status.currentWave"
+// +kubebuilder:printcolumn:name="Targeted",type="integer",JSONPath=".status.targetedClusters"
+// +kubebuilder:printcolumn:name="Completed",type="integer",JSONPath=".status.completedClusters"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedClusters"
+type ClusterUpgradeCampaign struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterUpgradeCampaignSpec   `json:"spec,omitempty"`
+	Status ClusterUpgradeCampaignStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterUpgradeCampaignList contains a list of ClusterUpgradeCampaign
+type ClusterUpgradeCampaignList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterUpgradeCampaign `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterUpgradeCampaign{}, &ClusterUpgradeCampaignList{})
+}
@@ -74,6 +74,21 @@ type HiveConfigSpec struct {
 	// DeprovisionsDisabled can be set to true to block deprovision jobs from running.
 	DeprovisionsDisabled *bool `json:"deprovisionsDisabled,omitempty"`
 
+	// VerifyDeprovisions can be set to true to look for cloud resources still tagged with the infraID of a cluster
+	// once its uninstall has completed. Resources found are listed in the status of the ClusterDeprovision, which
+	// gets an OrphanedResources condition. Supported on AWS, GCP and Azure.
+	// +optional
+	VerifyDeprovisions *bool `json:"verifyDeprovisions,omitempty"`
+
+	// DeprovisionGracePeriod is how long the deprovision of a deleted, installed ClusterDeployment is held off, with
+	// the cluster hibernated. Hive records when the deprovision starts in the "hive.openshift.io/deprovision-pending"
+	// annotation of the ClusterDeployment. Removing the annotation during that time cancels the deprovision; the
+	// ClusterDeployment is then removed without touching the cloud resources of the cluster. Can be overridden per
+	// ClusterDeployment with the "hive.openshift.io/deprovision-grace-period" annotation. ClusterPool clusters have no
+	// grace period.
+	// +optional
+	DeprovisionGracePeriod *metav1.Duration `json:"deprovisionGracePeriod,omitempty"`
+
 	// DeleteProtection can be set to "enabled" to turn on automatic delete protection for ClusterDeployments. When
 	// enabled, Hive will add the "hive.openshift.io/protected-delete" annotation to new ClusterDeployments. Once a
 	// ClusterDeployment has been installed, a user must remove the annotation from a ClusterDeployment prior to
@@ -402,11 +417,41 @@ type FailedProvisionConfig struct {
 	// DEPRECATED: This flag is no longer respected and will be removed in the future.
 	SkipGatherLogs bool                      `json:"skipGatherLogs,omitempty"`
 	AWS            *FailedProvisionAWSConfig `json:"aws,omitempty"`
+	// GCP configures uploading the logs of failed installations to a Google Cloud Storage bucket.
+	// Ignored if AWS is set.
+	// +optional
+	GCP *FailedProvisionGCPConfig `json:"gcp,omitempty"`
+	// Azure configures uploading the logs of failed installations to an Azure Blob Storage container.
+	// Ignored if AWS or GCP is set.
+	// +optional
+	Azure *FailedProvisionAzureConfig `json:"azure,omitempty"`
+	// PersistentVolumeClaim configures copying the logs of failed installations to a PersistentVolumeClaim in the
+	// namespace of the ClusterDeployment. Ignored if AWS, GCP or Azure is set.
+	// +optional
+	PersistentVolumeClaim *FailedProvisionPersistentVolumeClaimConfig `json:"persistentVolumeClaim,omitempty"`
 	// RetryReasons is a list of installFailingReason strings from the [additional-]install-log-regexes ConfigMaps.
 	// If specified, Hive will only retry a failed installation if it results in one of the listed reasons. If
-	// omitted (not the same thing as empty!), Hive will retry regardless of the failure reason. (The total number
-	// of install attempts is still constrained by ClusterDeployment.Spec.InstallAttemptsLimit.)
+	// omitted (not the same thing as empty!), Hive will retry unless the failure is classified as not retryable
+	// by the install-log-regexes ConfigMaps. (The total number of install attempts is still constrained by
+	// ClusterDeployment.Spec.InstallAttemptsLimit.)
 	RetryReasons *[]string `json:"retryReasons,omitempty"`
+	// InstallConfigRemediations are changes to make to the install-config of the next install attempt when an
+	// installation attempt fails for given reasons, e.g. to use other zones or another instance type. A remediation
+	// applied to an attempt is also applied to all the attempts that follow it.
+	// +optional
+	InstallConfigRemediations []InstallConfigRemediation `json:"installConfigRemediations,omitempty"`
+}
+
+// InstallConfigRemediation is a change to make to the install-config of the next install attempt when an
+// installation attempt fails for one of the given reasons.
+type InstallConfigRemediation struct {
+	// Name identifies the remediation on the ClusterProvisions it is applied to.
+	Name string `json:"name"`
+	// Reasons is a list of installFailingReason strings from the [additional-]install-log-regexes ConfigMaps.
+	// The remediation is applied to the next install attempt when an attempt fails with one of these reasons.
+	Reasons []string `json:"reasons"`
+	// InstallConfigPatches is a list of patches to apply to the install-config.
+	InstallConfigPatches []PatchEntity `json:"installConfigPatches"`
 }
 
 // ManageDNSConfig contains the domain being managed, and the cloud-specific
@@ -450,13 +495,54 @@ type FailedProvisionAWSConfig struct {
 	// +optional
 	Region string `json:"region,omitempty"`
 
-	// ServiceEndpoint is the url to connect to an S3 compatible provider.
+	// ServiceEndpoint is the url to connect to an S3 compatible provider, such as MinIO.
+	// When set, buckets are addressed by path rather than by virtual host.
 	ServiceEndpoint string `json:"serviceEndpoint,omitempty"`
 
 	// Bucket is the S3 bucket to store the logs in.
 	Bucket string `json:"bucket,omitempty"`
 }
 
+// FailedProvisionGCPConfig contains GCP-specific info to upload log files.
+type FailedProvisionGCPConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Google Cloud Storage. It will need permission to create objects in the bucket.
+	// Secret should have a key named 'osServiceAccount.json'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// Bucket is the Google Cloud Storage bucket to store the logs in.
+	Bucket string `json:"bucket"`
+}
+
+// FailedProvisionAzureConfig contains Azure-specific info to upload log files.
+type FailedProvisionAzureConfig struct {
+	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
+	// Azure Blob Storage. It will need permission to write blobs in the container, e.g. through the
+	// "Storage Blob Data Contributor" role.
+	// Secret should have a key named 'osServicePrincipal.json'.
+	CredentialsSecretRef corev1.LocalObjectReference `json:"credentialsSecretRef"`
+
+	// StorageAccount is the name of the storage account containing the container.
+	StorageAccount string `json:"storageAccount"`
+
+	// Container is the Blob Storage container to store the logs in.
+	Container string `json:"container"`
+
+	// CloudName is the name of the Azure cloud environment which can be used to configure the Azure SDK
+	// with the appropriate Azure API endpoints.
+	// If empty, the value is equal to "AzurePublicCloud".
+	// +optional
+	CloudName azure.CloudEnvironment `json:"cloudName,omitempty"`
+}
+
+// FailedProvisionPersistentVolumeClaimConfig contains the PersistentVolumeClaim to copy log files to.
+type FailedProvisionPersistentVolumeClaimConfig struct {
+	// ClaimName is the name of the PersistentVolumeClaim to copy the logs to. The claim is mounted by the install
+	// pods of the ClusterDeployments in its namespace, so it should allow the ReadWriteMany access mode if several
+	// ClusterDeployments share the namespace. Logs are not kept for ClusterDeployments in namespaces without the claim.
+	ClaimName string `json:"claimName"`
+}
+
 // ManageDNSAWSConfig contains AWS-specific info to manage a given domain.
 type ManageDNSAWSConfig struct {
 	// CredentialsSecretRef references a secret in the TargetNamespace that will be used to authenticate with
@@ -531,7 +617,7 @@ type ControllerConfig struct {
 	Replicas *int32 `json:"replicas,omitempty"`
 }
 
-// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
+// +kubebuilder:validation:Enum=clusterDeployment;clusterrelocate;clusterstate;clusterUpgradeCampaign;clusterversion;controlPlaneCerts;dnsendpoint;dnszone;remoteingress;remotemachineset;machinepool;syncidentityprovider;unreachable;velerobackup;clusterprovision;clusterDeprovision;clusterpool;clusterpoolnamespace;hibernation;clusterclaim;metrics;clustersync
 type ControllerName string
 
 func (controllerName ControllerName) String() string {
@@ -577,6 +663,8 @@ const (
 	AWSPrivateLinkControllerName       ControllerName = "awsprivatelink"
 	HiveControllerName                 ControllerName = "hive"
 
+	ClusterUpgradeCampaignControllerName ControllerName = "clusterUpgradeCampaign"
+
 	// DeprecatedRemoteMachinesetControllerName was deprecated but can be used to disable the
 	// MachinePool controller which supercedes it for compatability.
 	DeprecatedRemoteMachinesetControllerName ControllerName = "remotemachineset"
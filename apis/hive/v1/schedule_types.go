@@ -0,0 +1,34 @@
+package v1
+
+// ScheduleWeekday is a day of the week on which a ScheduleWindow opens.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type ScheduleWeekday string
+
+const (
+	ScheduleMonday    ScheduleWeekday = "Monday"
+	ScheduleTuesday   ScheduleWeekday = "Tuesday"
+	ScheduleWednesday ScheduleWeekday = "Wednesday"
+	ScheduleThursday  ScheduleWeekday = "Thursday"
+	ScheduleFriday    ScheduleWeekday = "Friday"
+	ScheduleSaturday  ScheduleWeekday = "Saturday"
+	ScheduleSunday    ScheduleWeekday = "Sunday"
+)
+
+// ScheduleWindow is a recurring, weekly window of time. For example, a window with Days Monday through Friday,
+// Start "08:00" and End "19:00" is open during business hours on weekdays.
+type ScheduleWindow struct {
+	// Days are the days of the week on which the window opens. When empty, the window opens every day.
+	// +optional
+	Days []ScheduleWeekday `json:"days,omitempty"`
+
+	// Start is the time of day, in 24-hour "HH:MM" format, at which the window opens.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +required
+	Start string `json:"start"`
+
+	// End is the time of day, in 24-hour "HH:MM" format, at which the window closes. If End is not later than
+	// Start, the window closes at End on the following day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// +required
+	End string `json:"end"`
+}
@@ -4,6 +4,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // SyncSetResourceApplyMode is a string representing the mode with which to
@@ -23,7 +24,7 @@ const (
 
 // SyncSetApplyBehavior is a string representing the behavior to use when
 // aplying a syncset to target cluster.
-// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate
+// +kubebuilder:validation:Enum="";Apply;CreateOnly;CreateOrUpdate;ServerSideApply
 type SyncSetApplyBehavior string
 
 const (
@@ -42,6 +43,31 @@ const (
 	// is not added to the target resource with the "lastApplied" value. It allows
 	// for syncing larger resources, but loses the ability to sync map entry deletes.
 	CreateOrUpdateSyncSetApplyBehavior SyncSetApplyBehavior = "CreateOrUpdate"
+
+	// ServerSideApplySyncSetApplyBehavior results in resources getting applied
+	// to the target cluster using Kubernetes server-side apply. Hive only owns
+	// the fields set in the syncset resource, and no annotation with the
+	// "lastApplied" value is added to the target resource.
+	ServerSideApplySyncSetApplyBehavior SyncSetApplyBehavior = "ServerSideApply"
+)
+
+// DefaultSyncSetFieldManager is the field manager used for server-side apply
+// when the syncset does not specify one.
+const DefaultSyncSetFieldManager = "hive"
+
+// SyncSetDriftDetection is a string representing what to do when resources
+// synced by a syncset have been changed in the target cluster.
+// +kubebuilder:validation:Enum="";Correct;Report
+type SyncSetDriftDetection string
+
+const (
+	// CorrectSyncSetDriftDetection results in drift being recorded and the
+	// drifted resources being re-applied as usual.
+	CorrectSyncSetDriftDetection SyncSetDriftDetection = "Correct"
+
+	// ReportSyncSetDriftDetection results in drift being recorded, but the
+	// drifted resources being left alone until the syncset is changed.
+	ReportSyncSetDriftDetection SyncSetDriftDetection = "Report"
 )
 
 // SyncSetPatchApplyMode is a string representing the mode with which to apply
@@ -117,6 +143,11 @@ func (t SyncConditionType) String() string {
 }
 
 const (
+	// ApplyConflictSyncCondition indicates that a resource could not be applied using
+	// server-side apply because fields in it are owned by other field managers.
+	// The message lists the conflicting fields.
+	ApplyConflictSyncCondition SyncConditionType = "ApplyConflict"
+
 	// ApplySuccessSyncCondition indicates whether the resource or patch has been applied.
 	ApplySuccessSyncCondition SyncConditionType = "ApplySuccess"
 
@@ -241,8 +272,45 @@ type SyncSetCommonSpec struct {
 	// the use of the 'oc apply' command, allowing larger resources to be synced, but losing
 	// some functionality of the 'oc apply' command such as the ability to remove annotations,
 	// labels, and other map entries in general.
+	// A value of "ServerSideApply" indicates that resources will be applied using Kubernetes
+	// server-side apply, so that Hive only owns the fields that it sets. See ServerSideApply.
 	// +optional
 	ApplyBehavior SyncSetApplyBehavior `json:"applyBehavior,omitempty"`
+
+	// ServerSideApply configures how resources are applied when ApplyBehavior is "ServerSideApply".
+	// +optional
+	ServerSideApply *SyncSetServerSideApply `json:"serverSideApply,omitempty"`
+
+	// DriftDetection, if set, makes Hive check whether the resources and secrets in this syncset
+	// have been changed in the target cluster since they were last applied, each time they are
+	// about to be re-applied. Drift is recorded in the ClusterSync of the cluster.
+	// A value of "Correct" indicates that drifted resources are re-applied as usual.
+	// A value of "Report" indicates that drifted resources are left alone until the syncset changes.
+	// If no value is set, drift is not detected.
+	// +optional
+	DriftDetection SyncSetDriftDetection `json:"driftDetection,omitempty"`
+
+	// EnableResourceTemplates, if true, renders the string values in Resources, and the Name, Namespace
+	// and Patch of Patches, as Go templates for each cluster before they are applied. Templates can
+	// reference .ClusterDeployment, .ClusterMetadata, .Labels and .Annotations of the cluster, e.g.
+	// "{{ .ClusterDeployment.Spec.ClusterName }}" or "{{ index .Labels "region" }}".
+	// +optional
+	EnableResourceTemplates bool `json:"enableResourceTemplates,omitempty"`
+}
+
+// SyncSetServerSideApply configures server-side apply of the resources in a syncset.
+type SyncSetServerSideApply struct {
+	// FieldManager is the name of the field manager that owns the fields applied by the syncset in the
+	// target cluster. Defaults to "hive".
+	// +kubebuilder:validation:MaxLength=128
+	// +optional
+	FieldManager string `json:"fieldManager,omitempty"`
+
+	// Force, if true, takes ownership of fields that are owned by other field managers. When false,
+	// a resource with such fields is not applied and the conflicts are reported in the ClusterSync
+	// of the cluster.
+	// +optional
+	Force bool `json:"force,omitempty"`
 }
 
 // SelectorSyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along
@@ -255,6 +323,79 @@ type SelectorSyncSetSpec struct {
 	// applies to in any namespace.
 	// +optional
 	ClusterDeploymentSelector metav1.LabelSelector `json:"clusterDeploymentSelector,omitempty"`
+
+	// RolloutStrategy, if set, limits how quickly a new generation of the SelectorSyncSet is applied to
+	// clusters to which an earlier generation has already been applied. When unset, a new generation is
+	// applied to all matching clusters at once.
+	// +optional
+	RolloutStrategy *SelectorSyncSetRolloutStrategy `json:"rolloutStrategy,omitempty"`
+}
+
+// SelectorSyncSetRolloutStrategy describes how a new generation of a SelectorSyncSet is rolled out to the
+// clusters that it applies to.
+type SelectorSyncSetRolloutStrategy struct {
+	// BatchSize is the number of clusters, or the percentage of targeted clusters (e.g. "10%"), to which the
+	// new generation is released at a time. Percentages are rounded up. The next batch is not released until
+	// every reachable cluster in the previous batches has been synced.
+	// +kubebuilder:validation:XIntOrString
+	// +required
+	BatchSize intstr.IntOrString `json:"batchSize"`
+
+	// PauseBetweenBatches is the minimum time between the release of one batch and the release of the next.
+	// +optional
+	PauseBetweenBatches *metav1.Duration `json:"pauseBetweenBatches,omitempty"`
+
+	// MaxFailurePercentage halts the rollout if the percentage of synced clusters on which the new generation
+	// failed to apply exceeds it. A halted rollout resumes when the SelectorSyncSet is changed again.
+	// When unset, the rollout is never halted.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxFailurePercentage *int32 `json:"maxFailurePercentage,omitempty"`
+}
+
+// SelectorSyncSetRolloutState is the state of the rollout of a SelectorSyncSet.
+type SelectorSyncSetRolloutState string
+
+const (
+	// SelectorSyncSetRolloutProgressing indicates that the new generation is still being released to clusters.
+	SelectorSyncSetRolloutProgressing SelectorSyncSetRolloutState = "Progressing"
+
+	// SelectorSyncSetRolloutHalted indicates that the rollout was stopped because too many clusters failed to
+	// apply the new generation.
+	SelectorSyncSetRolloutHalted SelectorSyncSetRolloutState = "Halted"
+
+	// SelectorSyncSetRolloutComplete indicates that the new generation has been released to all clusters.
+	SelectorSyncSetRolloutComplete SelectorSyncSetRolloutState = "Complete"
+)
+
+// SelectorSyncSetRolloutStatus reports the progress of rolling out a generation of a SelectorSyncSet.
+type SelectorSyncSetRolloutStatus struct {
+	// Generation is the generation of the SelectorSyncSet being rolled out.
+	Generation int64 `json:"generation"`
+
+	// State is the state of the rollout.
+	State SelectorSyncSetRolloutState `json:"state"`
+
+	// Batches is the number of batches that have been released.
+	Batches int32 `json:"batches"`
+
+	// ReleasedBuckets determines which clusters the generation has been released to. Each cluster is
+	// assigned to one of 10000 buckets by a hash of its UID, and the generation has been released to the
+	// clusters in buckets below ReleasedBuckets.
+	ReleasedBuckets int32 `json:"releasedBuckets"`
+
+	// HeldClusters is the number of clusters that are waiting for the generation to be released to them.
+	// +optional
+	HeldClusters int32 `json:"heldClusters"`
+
+	// LastBatchTime is the time at which the most recent batch was released.
+	// +optional
+	LastBatchTime *metav1.Time `json:"lastBatchTime,omitempty"`
+
+	// Message is a human-readable description of the state of the rollout.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // SyncSetSpec defines the SyncSetCommonSpec resources and patches to sync along with
@@ -269,12 +410,48 @@ type SyncSetSpec struct {
 	ClusterDeploymentRefs []corev1.LocalObjectReference `json:"clusterDeploymentRefs"`
 }
 
+// SyncSetCommonStatus summarizes the results of applying a SyncSet or SelectorSyncSet to the clusters
+// that it targets. It is aggregated from the ClusterSyncs of those clusters.
+type SyncSetCommonStatus struct {
+	// ObservedGeneration is the generation of the syncset for which the cluster counts were computed.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// TargetedClusters is the number of installed clusters that the syncset applies to.
+	// +optional
+	TargetedClusters int32 `json:"targetedClusters"`
+
+	// AppliedClusters is the number of targeted clusters to which the current generation of the syncset
+	// has been applied successfully.
+	// +optional
+	AppliedClusters int32 `json:"appliedClusters"`
+
+	// FailingClusters is the number of targeted clusters on which the most recent attempt to apply the
+	// syncset failed.
+	// +optional
+	FailingClusters int32 `json:"failingClusters"`
+
+	// FailingClusterNames lists up to 10 of the failing clusters, as namespace/name, in alphabetical order.
+	// +optional
+	FailingClusterNames []string `json:"failingClusterNames,omitempty"`
+
+	// FirstFailureMessage is the failure message of the first cluster in FailingClusterNames.
+	// +optional
+	FirstFailureMessage string `json:"firstFailureMessage,omitempty"`
+}
+
 // SyncSetStatus defines the observed state of a SyncSet
 type SyncSetStatus struct {
+	SyncSetCommonStatus `json:",inline"`
 }
 
 // SelectorSyncSetStatus defines the observed state of a SelectorSyncSet
 type SelectorSyncSetStatus struct {
+	SyncSetCommonStatus `json:",inline"`
+
+	// Rollout reports the progress of the most recent rollout when a RolloutStrategy is set.
+	// +optional
+	Rollout *SelectorSyncSetRolloutStatus `json:"rollout,omitempty"`
 }
 
 // +genclient
@@ -285,6 +462,9 @@ type SelectorSyncSetStatus struct {
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=selectorsyncsets,shortName=sss,scope=Cluster
+// +kubebuilder:printcolumn:name="Targeted",type="integer",JSONPath=".status.targetedClusters"
+// +kubebuilder:printcolumn:name="Applied",type="integer",JSONPath=".status.appliedClusters"
+// +kubebuilder:printcolumn:name="Failing",type="integer",JSONPath=".status.failingClusters"
 type SelectorSyncSet struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -300,6 +480,9 @@ type SelectorSyncSet struct {
 // +k8s:openapi-gen=true
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:path=syncsets,shortName=ss,scope=Namespaced
+// +kubebuilder:printcolumn:name="Targeted",type="integer",JSONPath=".status.targetedClusters"
+// +kubebuilder:printcolumn:name="Applied",type="integer",JSONPath=".status.appliedClusters"
+// +kubebuilder:printcolumn:name="Failing",type="integer",JSONPath=".status.failingClusters"
 type SyncSet struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -24,6 +24,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -590,6 +591,11 @@ func (in *ClusterClaimSpec) DeepCopyInto(out *ClusterClaimSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -872,11 +878,21 @@ func (in *ClusterDeploymentSpec) DeepCopyInto(out *ClusterDeploymentSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ClusterUpgrade)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BoundServiceAccountSignkingKeySecretRef != nil {
 		in, out := &in.BoundServiceAccountSignkingKeySecretRef, &out.BoundServiceAccountSignkingKeySecretRef
 		*out = new(corev1.LocalObjectReference)
@@ -933,6 +949,11 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		in, out := &in.InstalledTimestamp, &out.InstalledTimestamp
 		*out = (*in).DeepCopy()
 	}
+	if in.HibernatedDuration != nil {
+		in, out := &in.HibernatedDuration, &out.HibernatedDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.ProvisionRef != nil {
 		in, out := &in.ProvisionRef, &out.ProvisionRef
 		*out = new(corev1.LocalObjectReference)
@@ -943,6 +964,16 @@ func (in *ClusterDeploymentStatus) DeepCopyInto(out *ClusterDeploymentStatus) {
 		*out = new(PlatformStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationScheduleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(ClusterUpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -1118,6 +1149,11 @@ func (in *ClusterDeprovisionStatus) DeepCopyInto(out *ClusterDeprovisionStatus)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OrphanedResources != nil {
+		in, out := &in.OrphanedResources, &out.OrphanedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -1350,6 +1386,31 @@ func (in *ClusterOperatorState) DeepCopy() *ClusterOperatorState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOperatorsHealth) DeepCopyInto(out *ClusterOperatorsHealth) {
+	*out = *in
+	if in.DegradedSince != nil {
+		in, out := &in.DegradedSince, &out.DegradedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.WorstOperator != nil {
+		in, out := &in.WorstOperator, &out.WorstOperator
+		*out = new(DegradedClusterOperator)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOperatorsHealth.
+func (in *ClusterOperatorsHealth) DeepCopy() *ClusterOperatorsHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOperatorsHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPool) DeepCopyInto(out *ClusterPool) {
 	*out = *in
@@ -1378,6 +1439,27 @@ func (in *ClusterPool) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolAutoscaling) DeepCopyInto(out *ClusterPoolAutoscaling) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolAutoscaling.
+func (in *ClusterPoolAutoscaling) DeepCopy() *ClusterPoolAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolClaimLifetime) DeepCopyInto(out *ClusterPoolClaimLifetime) {
 	*out = *in
@@ -1422,6 +1504,36 @@ func (in *ClusterPoolCondition) DeepCopy() *ClusterPoolCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolEffectiveSizing) DeepCopyInto(out *ClusterPoolEffectiveSizing) {
+	*out = *in
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SizingScheduleEntry != nil {
+		in, out := &in.SizingScheduleEntry, &out.SizingScheduleEntry
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NextTransition != nil {
+		in, out := &in.NextTransition, &out.NextTransition
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolEffectiveSizing.
+func (in *ClusterPoolEffectiveSizing) DeepCopy() *ClusterPoolEffectiveSizing {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolEffectiveSizing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolList) DeepCopyInto(out *ClusterPoolList) {
 	*out = *in
@@ -1455,6 +1567,23 @@ func (in *ClusterPoolList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolRecentClaim) DeepCopyInto(out *ClusterPoolRecentClaim) {
+	*out = *in
+	in.CreationTimestamp.DeepCopyInto(&out.CreationTimestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolRecentClaim.
+func (in *ClusterPoolRecentClaim) DeepCopy() *ClusterPoolRecentClaim {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolRecentClaim)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolReference) DeepCopyInto(out *ClusterPoolReference) {
 	*out = *in
@@ -1480,6 +1609,44 @@ func (in *ClusterPoolReference) DeepCopy() *ClusterPoolReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterPoolSizingScheduleEntry) DeepCopyInto(out *ClusterPoolSizingScheduleEntry) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RunningCount != nil {
+		in, out := &in.RunningCount, &out.RunningCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConcurrent != nil {
+		in, out := &in.MaxConcurrent, &out.MaxConcurrent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterPoolSizingScheduleEntry.
+func (in *ClusterPoolSizingScheduleEntry) DeepCopy() *ClusterPoolSizingScheduleEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterPoolSizingScheduleEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 	*out = *in
@@ -1524,6 +1691,23 @@ func (in *ClusterPoolSpec) DeepCopyInto(out *ClusterPoolSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.HibernationSchedule != nil {
+		in, out := &in.HibernationSchedule, &out.HibernationSchedule
+		*out = new(HibernationSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SizingSchedule != nil {
+		in, out := &in.SizingSchedule, &out.SizingSchedule
+		*out = make([]ClusterPoolSizingScheduleEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ClusterPoolAutoscaling)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InstallAttemptsLimit != nil {
 		in, out := &in.InstallAttemptsLimit, &out.InstallAttemptsLimit
 		*out = new(int32)
@@ -1567,6 +1751,18 @@ func (in *ClusterPoolStatus) DeepCopyInto(out *ClusterPoolStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Effective != nil {
+		in, out := &in.Effective, &out.Effective
+		*out = new(ClusterPoolEffectiveSizing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RecentClaims != nil {
+		in, out := &in.RecentClaims, &out.RecentClaims
+		*out = make([]ClusterPoolRecentClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1626,6 +1822,27 @@ func (in *ClusterProvisionCondition) DeepCopy() *ClusterProvisionCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProvisionInstallPhase) DeepCopyInto(out *ClusterProvisionInstallPhase) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProvisionInstallPhase.
+func (in *ClusterProvisionInstallPhase) DeepCopy() *ClusterProvisionInstallPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProvisionInstallPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterProvisionList) DeepCopyInto(out *ClusterProvisionList) {
 	*out = *in
@@ -1709,6 +1926,13 @@ func (in *ClusterProvisionSpec) DeepCopyInto(out *ClusterProvisionSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.InstallConfigRemediations != nil {
+		in, out := &in.InstallConfigRemediations, &out.InstallConfigRemediations
+		*out = make([]InstallConfigRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1737,6 +1961,18 @@ func (in *ClusterProvisionStatus) DeepCopyInto(out *ClusterProvisionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailureClassification != nil {
+		in, out := &in.FailureClassification, &out.FailureClassification
+		*out = new(InstallFailureClassification)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InstallPhases != nil {
+		in, out := &in.InstallPhases, &out.InstallPhases
+		*out = make([]ClusterProvisionInstallPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1756,7 +1992,7 @@ func (in *ClusterRelocate) DeepCopyInto(out *ClusterRelocate) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -1778,6 +2014,40 @@ func (in *ClusterRelocate) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRelocateClusterDeploymentStatus) DeepCopyInto(out *ClusterRelocateClusterDeploymentStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourcesToCopy != nil {
+		in, out := &in.ResourcesToCopy, &out.ResourcesToCopy
+		*out = make([]RelocateResourceSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IgnoredResources != nil {
+		in, out := &in.IgnoredResources, &out.IgnoredResources
+		*out = make([]RelocateResourceSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRelocateClusterDeploymentStatus.
+func (in *ClusterRelocateClusterDeploymentStatus) DeepCopy() *ClusterRelocateClusterDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRelocateClusterDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRelocateList) DeepCopyInto(out *ClusterRelocateList) {
 	*out = *in
@@ -1832,6 +2102,13 @@ func (in *ClusterRelocateSpec) DeepCopy() *ClusterRelocateSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterRelocateStatus) DeepCopyInto(out *ClusterRelocateStatus) {
 	*out = *in
+	if in.ClusterDeployments != nil {
+		in, out := &in.ClusterDeployments, &out.ClusterDeployments
+		*out = make([]ClusterRelocateClusterDeploymentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1936,15 +2213,225 @@ func (in *ClusterStateStatus) DeepCopyInto(out *ClusterStateStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(ClusterOperatorsHealth)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
+func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgrade) DeepCopyInto(out *ClusterUpgrade) {
+	*out = *in
+	if in.ImageSetRef != nil {
+		in, out := &in.ImageSetRef, &out.ImageSetRef
+		*out = new(ClusterImageSetReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgrade.
+func (in *ClusterUpgrade) DeepCopy() *ClusterUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCampaign) DeepCopyInto(out *ClusterUpgradeCampaign) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCampaign.
+func (in *ClusterUpgradeCampaign) DeepCopy() *ClusterUpgradeCampaign {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCampaign)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgradeCampaign) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCampaignClusterStatus) DeepCopyInto(out *ClusterUpgradeCampaignClusterStatus) {
+	*out = *in
+	if in.StartedTime != nil {
+		in, out := &in.StartedTime, &out.StartedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCampaignClusterStatus.
+func (in *ClusterUpgradeCampaignClusterStatus) DeepCopy() *ClusterUpgradeCampaignClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCampaignClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCampaignList) DeepCopyInto(out *ClusterUpgradeCampaignList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterUpgradeCampaign, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCampaignList.
+func (in *ClusterUpgradeCampaignList) DeepCopy() *ClusterUpgradeCampaignList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCampaignList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterUpgradeCampaignList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCampaignSpec) DeepCopyInto(out *ClusterUpgradeCampaignSpec) {
+	*out = *in
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	in.Upgrade.DeepCopyInto(&out.Upgrade)
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]ClusterUpgradeWave, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCampaignSpec.
+func (in *ClusterUpgradeCampaignSpec) DeepCopy() *ClusterUpgradeCampaignSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCampaignSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeCampaignStatus) DeepCopyInto(out *ClusterUpgradeCampaignStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterUpgradeCampaignClusterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeCampaignStatus.
+func (in *ClusterUpgradeCampaignStatus) DeepCopy() *ClusterUpgradeCampaignStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeCampaignStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeStatus) DeepCopyInto(out *ClusterUpgradeStatus) {
+	*out = *in
+	if in.StartedTime != nil {
+		in, out := &in.StartedTime, &out.StartedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeStatus.
+func (in *ClusterUpgradeStatus) DeepCopy() *ClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterUpgradeWave) DeepCopyInto(out *ClusterUpgradeWave) {
+	*out = *in
+	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateStatus.
-func (in *ClusterStateStatus) DeepCopy() *ClusterStateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterUpgradeWave.
+func (in *ClusterUpgradeWave) DeepCopy() *ClusterUpgradeWave {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterStateStatus)
+	out := new(ClusterUpgradeWave)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2254,6 +2741,26 @@ func (in *DNSZoneStatus) DeepCopy() *DNSZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DegradedClusterOperator) DeepCopyInto(out *DegradedClusterOperator) {
+	*out = *in
+	if in.Since != nil {
+		in, out := &in.Since, &out.Since
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DegradedClusterOperator.
+func (in *DegradedClusterOperator) DeepCopy() *DegradedClusterOperator {
+	if in == nil {
+		return nil
+	}
+	out := new(DegradedClusterOperator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentConfig) DeepCopyInto(out *DeploymentConfig) {
 	*out = *in
@@ -2292,6 +2799,23 @@ func (in *FailedProvisionAWSConfig) DeepCopy() *FailedProvisionAWSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionAzureConfig) DeepCopyInto(out *FailedProvisionAzureConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionAzureConfig.
+func (in *FailedProvisionAzureConfig) DeepCopy() *FailedProvisionAzureConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionAzureConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 	*out = *in
@@ -2300,6 +2824,21 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 		*out = new(FailedProvisionAWSConfig)
 		**out = **in
 	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(FailedProvisionGCPConfig)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(FailedProvisionAzureConfig)
+		**out = **in
+	}
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(FailedProvisionPersistentVolumeClaimConfig)
+		**out = **in
+	}
 	if in.RetryReasons != nil {
 		in, out := &in.RetryReasons, &out.RetryReasons
 		*out = new([]string)
@@ -2309,6 +2848,13 @@ func (in *FailedProvisionConfig) DeepCopyInto(out *FailedProvisionConfig) {
 			copy(*out, *in)
 		}
 	}
+	if in.InstallConfigRemediations != nil {
+		in, out := &in.InstallConfigRemediations, &out.InstallConfigRemediations
+		*out = make([]InstallConfigRemediation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -2322,6 +2868,39 @@ func (in *FailedProvisionConfig) DeepCopy() *FailedProvisionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionGCPConfig) DeepCopyInto(out *FailedProvisionGCPConfig) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionGCPConfig.
+func (in *FailedProvisionGCPConfig) DeepCopy() *FailedProvisionGCPConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionGCPConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailedProvisionPersistentVolumeClaimConfig) DeepCopyInto(out *FailedProvisionPersistentVolumeClaimConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedProvisionPersistentVolumeClaimConfig.
+func (in *FailedProvisionPersistentVolumeClaimConfig) DeepCopy() *FailedProvisionPersistentVolumeClaimConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedProvisionPersistentVolumeClaimConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeatureGateSelection) DeepCopyInto(out *FeatureGateSelection) {
 	*out = *in
@@ -2440,6 +3019,53 @@ func (in *HibernationConfig) DeepCopy() *HibernationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationSchedule) DeepCopyInto(out *HibernationSchedule) {
+	*out = *in
+	if in.RunWindows != nil {
+		in, out := &in.RunWindows, &out.RunWindows
+		*out = make([]ScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationSchedule.
+func (in *HibernationSchedule) DeepCopy() *HibernationSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationScheduleStatus) DeepCopyInto(out *HibernationScheduleStatus) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextTransitionTime != nil {
+		in, out := &in.NextTransitionTime, &out.NextTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationScheduleStatus.
+func (in *HibernationScheduleStatus) DeepCopy() *HibernationScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HiveConfig) DeepCopyInto(out *HiveConfig) {
 	*out = *in
@@ -2552,6 +3178,16 @@ func (in *HiveConfigSpec) DeepCopyInto(out *HiveConfigSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.VerifyDeprovisions != nil {
+		in, out := &in.VerifyDeprovisions, &out.VerifyDeprovisions
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DeprovisionGracePeriod != nil {
+		in, out := &in.DeprovisionGracePeriod, &out.DeprovisionGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.DisabledControllers != nil {
 		in, out := &in.DisabledControllers, &out.DisabledControllers
 		*out = make([]string, len(*in))
@@ -2663,6 +3299,69 @@ func (in *IdentityProviderStatus) DeepCopy() *IdentityProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallConfigRemediation) DeepCopyInto(out *InstallConfigRemediation) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstallConfigPatches != nil {
+		in, out := &in.InstallConfigPatches, &out.InstallConfigPatches
+		*out = make([]PatchEntity, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallConfigRemediation.
+func (in *InstallConfigRemediation) DeepCopy() *InstallConfigRemediation {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallConfigRemediation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallFailureClassification) DeepCopyInto(out *InstallFailureClassification) {
+	*out = *in
+	if in.Matches != nil {
+		in, out := &in.Matches, &out.Matches
+		*out = make([]InstallFailureMatch, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallFailureClassification.
+func (in *InstallFailureClassification) DeepCopy() *InstallFailureClassification {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallFailureClassification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstallFailureMatch) DeepCopyInto(out *InstallFailureMatch) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstallFailureMatch.
+func (in *InstallFailureMatch) DeepCopy() *InstallFailureMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(InstallFailureMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InventoryEntry) DeepCopyInto(out *InventoryEntry) {
 	*out = *in
@@ -3345,6 +4044,48 @@ func (in *ReleaseImageVerificationConfigMapReference) DeepCopy() *ReleaseImageVe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RelocateResourceSummary) DeepCopyInto(out *RelocateResourceSummary) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RelocateResourceSummary.
+func (in *RelocateResourceSummary) DeepCopy() *RelocateResourceSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RelocateResourceSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]ScheduleWeekday, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecretMapping) DeepCopyInto(out *SecretMapping) {
 	*out = *in
@@ -3464,7 +4205,7 @@ func (in *SelectorSyncSet) DeepCopyInto(out *SelectorSyncSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3519,11 +4260,63 @@ func (in *SelectorSyncSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStatus) DeepCopyInto(out *SelectorSyncSetRolloutStatus) {
+	*out = *in
+	if in.LastBatchTime != nil {
+		in, out := &in.LastBatchTime, &out.LastBatchTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStatus.
+func (in *SelectorSyncSetRolloutStatus) DeepCopy() *SelectorSyncSetRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorSyncSetRolloutStrategy) DeepCopyInto(out *SelectorSyncSetRolloutStrategy) {
+	*out = *in
+	out.BatchSize = in.BatchSize
+	if in.PauseBetweenBatches != nil {
+		in, out := &in.PauseBetweenBatches, &out.PauseBetweenBatches
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.MaxFailurePercentage != nil {
+		in, out := &in.MaxFailurePercentage, &out.MaxFailurePercentage
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorSyncSetRolloutStrategy.
+func (in *SelectorSyncSetRolloutStrategy) DeepCopy() *SelectorSyncSetRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorSyncSetRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetSpec) DeepCopyInto(out *SelectorSyncSetSpec) {
 	*out = *in
 	in.SyncSetCommonSpec.DeepCopyInto(&out.SyncSetCommonSpec)
 	in.ClusterDeploymentSelector.DeepCopyInto(&out.ClusterDeploymentSelector)
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(SelectorSyncSetRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3540,6 +4333,12 @@ func (in *SelectorSyncSetSpec) DeepCopy() *SelectorSyncSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SelectorSyncSetStatus) DeepCopyInto(out *SelectorSyncSetStatus) {
 	*out = *in
+	in.SyncSetCommonStatus.DeepCopyInto(&out.SyncSetCommonStatus)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(SelectorSyncSetRolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -3737,7 +4536,7 @@ func (in *SyncSet) DeepCopyInto(out *SyncSet) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -3779,6 +4578,11 @@ func (in *SyncSetCommonSpec) DeepCopyInto(out *SyncSetCommonSpec) {
 		*out = make([]SecretMapping, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServerSideApply != nil {
+		in, out := &in.ServerSideApply, &out.ServerSideApply
+		*out = new(SyncSetServerSideApply)
+		**out = **in
+	}
 	return
 }
 
@@ -3792,6 +4596,27 @@ func (in *SyncSetCommonSpec) DeepCopy() *SyncSetCommonSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetCommonStatus) DeepCopyInto(out *SyncSetCommonStatus) {
+	*out = *in
+	if in.FailingClusterNames != nil {
+		in, out := &in.FailingClusterNames, &out.FailingClusterNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetCommonStatus.
+func (in *SyncSetCommonStatus) DeepCopy() *SyncSetCommonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetCommonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetList) DeepCopyInto(out *SyncSetList) {
 	*out = *in
@@ -3869,6 +4694,22 @@ func (in *SyncSetObjectStatus) DeepCopy() *SyncSetObjectStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSetServerSideApply) DeepCopyInto(out *SyncSetServerSideApply) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSetServerSideApply.
+func (in *SyncSetServerSideApply) DeepCopy() *SyncSetServerSideApply {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSetServerSideApply)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetSpec) DeepCopyInto(out *SyncSetSpec) {
 	*out = *in
@@ -3894,6 +4735,7 @@ func (in *SyncSetSpec) DeepCopy() *SyncSetSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncSetStatus) DeepCopyInto(out *SyncSetStatus) {
 	*out = *in
+	in.SyncSetCommonStatus.DeepCopyInto(&out.SyncSetCommonStatus)
 	return
 }
 
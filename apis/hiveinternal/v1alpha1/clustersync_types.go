@@ -49,6 +49,11 @@ type ClusterSyncStatus struct {
 	// recently handled the ClusterSync. If the hive-clustersync statefulset is scaled up or down, the
 	// controlling replica can change, potentially causing logs to be spread across multiple pods.
 	ControlledByReplica *int64 `json:"controlledByReplica,omitempty"`
+
+	// DriftEvents lists the most recent times that resources synced to the cluster by syncsets with drift
+	// detection enabled were found to have been changed in the cluster. Newest first, at most 20.
+	// +optional
+	DriftEvents []SyncDriftEvent `json:"driftEvents,omitempty"`
 }
 
 // SyncStatus is the status of applying a specific SyncSet or SelectorSyncSet to the cluster.
@@ -78,6 +83,56 @@ type SyncStatus struct {
 	// FirstSuccessTime is the time when the SyncSet or SelectorSyncSet was first successfully applied to the cluster.
 	// +optional
 	FirstSuccessTime *metav1.Time `json:"firstSuccessTime,omitempty"`
+
+	// ResourceHashes holds, for each resource and secret applied when drift detection is enabled, a hash of the
+	// fields that the SyncSet or SelectorSyncSet sets, as they were in the cluster right after they were applied.
+	// +optional
+	ResourceHashes []SyncResourceHash `json:"resourceHashes,omitempty"`
+
+	// Conditions describe problems applying the SyncSet or SelectorSyncSet that need more detail than
+	// FailureMessage, such as an ApplyConflict condition listing the fields that could not be applied
+	// using server-side apply because other field managers own them.
+	// +optional
+	Conditions []hivev1.SyncCondition `json:"conditions,omitempty"`
+}
+
+// SyncResourceHash is a hash of the fields of a resource in the cluster that are set by a SyncSet or SelectorSyncSet.
+type SyncResourceHash struct {
+	SyncResourceReference `json:",inline"`
+
+	// Hash is the hash of the fields as they were in the cluster right after they were applied.
+	Hash string `json:"hash"`
+
+	// DriftedHash is the hash of the fields when drift was last reported for a SyncSet or SelectorSyncSet that
+	// does not correct drift, or "deleted" if the resource had been deleted. Drift is not reported again until
+	// the fields change again.
+	// +optional
+	DriftedHash string `json:"driftedHash,omitempty"`
+}
+
+// SyncDriftEvent records that a resource synced to the cluster was found to have been changed in the cluster since
+// it was last applied.
+type SyncDriftEvent struct {
+	// SyncSetKind is the kind of the syncset that synced the resource, either SyncSet or SelectorSyncSet.
+	SyncSetKind string `json:"syncSetKind"`
+
+	// SyncSetName is the name of the syncset that synced the resource.
+	SyncSetName string `json:"syncSetName"`
+
+	// Resource is the resource that drifted.
+	Resource SyncResourceReference `json:"resource"`
+
+	// Time is when the drift was detected.
+	Time metav1.Time `json:"time"`
+
+	// FieldsChanged lists the paths of the fields set by the syncset whose values in the cluster differ from the syncset.
+	// They are compared with the syncset rather than with the resource as it was last applied, so they may include
+	// fields that the cluster defaults or normalizes, and are not necessarily the fields that were changed.
+	// +optional
+	FieldsChanged []string `json:"fieldsChanged,omitempty"`
+
+	// Corrected is true if the resource was re-applied.
+	Corrected bool `json:"corrected"`
 }
 
 // SyncResourceReference is a reference to a resource that is synced to a cluster via a SyncSet or SelectorSyncSet.
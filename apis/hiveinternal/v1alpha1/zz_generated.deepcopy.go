@@ -215,6 +215,13 @@ func (in *ClusterSyncStatus) DeepCopyInto(out *ClusterSyncStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.DriftEvents != nil {
+		in, out := &in.DriftEvents, &out.DriftEvents
+		*out = make([]SyncDriftEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -335,6 +342,46 @@ func (in *FakeClusterInstallStatus) DeepCopy() *FakeClusterInstallStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncDriftEvent) DeepCopyInto(out *SyncDriftEvent) {
+	*out = *in
+	out.Resource = in.Resource
+	in.Time.DeepCopyInto(&out.Time)
+	if in.FieldsChanged != nil {
+		in, out := &in.FieldsChanged, &out.FieldsChanged
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncDriftEvent.
+func (in *SyncDriftEvent) DeepCopy() *SyncDriftEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncDriftEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncResourceHash) DeepCopyInto(out *SyncResourceHash) {
+	*out = *in
+	out.SyncResourceReference = in.SyncResourceReference
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncResourceHash.
+func (in *SyncResourceHash) DeepCopy() *SyncResourceHash {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncResourceHash)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncResourceReference) DeepCopyInto(out *SyncResourceReference) {
 	*out = *in
@@ -364,6 +411,18 @@ func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 		in, out := &in.FirstSuccessTime, &out.FirstSuccessTime
 		*out = (*in).DeepCopy()
 	}
+	if in.ResourceHashes != nil {
+		in, out := &in.ResourceHashes, &out.ResourceHashes
+		*out = make([]SyncResourceHash, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.SyncCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
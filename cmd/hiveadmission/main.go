@@ -4,6 +4,7 @@ import (
 	admissionCmd "github.com/openshift/generic-admission-server/pkg/cmd"
 	log "github.com/sirupsen/logrus"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -31,12 +32,15 @@ func main() {
 		hivevalidatingwebhooks.NewSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewSelectorSyncSetValidatingAdmissionHook(decoder),
 		hivevalidatingwebhooks.NewClusterDeploymentCustomizationValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewInstallLogRegexesValidatingAdmissionHook(decoder),
+		hivevalidatingwebhooks.NewClusterUpgradeCampaignValidatingAdmissionHook(decoder),
 	)
 }
 
 func createDecoder() *admission.Decoder {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
 	decoder, err := admission.NewDecoder(scheme)
 	if err != nil {
 		log.WithError(err).Fatal("could not create a decoder")
@@ -43,6 +43,7 @@ import (
 	"github.com/openshift/hive/pkg/controller/clusterrelocate"
 	"github.com/openshift/hive/pkg/controller/clusterstate"
 	"github.com/openshift/hive/pkg/controller/clustersync"
+	"github.com/openshift/hive/pkg/controller/clusterupgradecampaign"
 	"github.com/openshift/hive/pkg/controller/clusterversion"
 	"github.com/openshift/hive/pkg/controller/controlplanecerts"
 	"github.com/openshift/hive/pkg/controller/dnsendpoint"
@@ -70,29 +71,30 @@ const (
 type controllerSetupFunc func(manager.Manager) error
 
 var controllerFuncs = map[hivev1.ControllerName]controllerSetupFunc{
-	clusterclaim.ControllerName:         clusterclaim.Add,
-	clusterdeployment.ControllerName:    clusterdeployment.Add,
-	clusterdeprovision.ControllerName:   clusterdeprovision.Add,
-	clusterpoolnamespace.ControllerName: clusterpoolnamespace.Add,
-	clusterprovision.ControllerName:     clusterprovision.Add,
-	clusterrelocate.ControllerName:      clusterrelocate.Add,
-	clusterstate.ControllerName:         clusterstate.Add,
-	clustersync.ControllerName:          clustersync.Add,
-	clusterversion.ControllerName:       clusterversion.Add,
-	controlplanecerts.ControllerName:    controlplanecerts.Add,
-	dnsendpoint.ControllerName:          dnsendpoint.Add,
-	dnszone.ControllerName:              dnszone.Add,
-	fakeclusterinstall.ControllerName:   fakeclusterinstall.Add,
-	metrics.ControllerName:              metrics.Add,
-	remoteingress.ControllerName:        remoteingress.Add,
-	machinepool.ControllerName:          machinepool.Add,
-	syncidentityprovider.ControllerName: syncidentityprovider.Add,
-	unreachable.ControllerName:          unreachable.Add,
-	velerobackup.ControllerName:         velerobackup.Add,
-	clusterpool.ControllerName:          clusterpool.Add,
-	hibernation.ControllerName:          hibernation.Add,
-	awsprivatelink.ControllerName:       awsprivatelink.Add,
-	argocdregister.ControllerName:       argocdregister.Add,
+	clusterclaim.ControllerName:           clusterclaim.Add,
+	clusterdeployment.ControllerName:      clusterdeployment.Add,
+	clusterdeprovision.ControllerName:     clusterdeprovision.Add,
+	clusterpoolnamespace.ControllerName:   clusterpoolnamespace.Add,
+	clusterprovision.ControllerName:       clusterprovision.Add,
+	clusterrelocate.ControllerName:        clusterrelocate.Add,
+	clusterstate.ControllerName:           clusterstate.Add,
+	clustersync.ControllerName:            clustersync.Add,
+	clusterupgradecampaign.ControllerName: clusterupgradecampaign.Add,
+	clusterversion.ControllerName:         clusterversion.Add,
+	controlplanecerts.ControllerName:      controlplanecerts.Add,
+	dnsendpoint.ControllerName:            dnsendpoint.Add,
+	dnszone.ControllerName:                dnszone.Add,
+	fakeclusterinstall.ControllerName:     fakeclusterinstall.Add,
+	metrics.ControllerName:                metrics.Add,
+	remoteingress.ControllerName:          remoteingress.Add,
+	machinepool.ControllerName:            machinepool.Add,
+	syncidentityprovider.ControllerName:   syncidentityprovider.Add,
+	unreachable.ControllerName:            unreachable.Add,
+	velerobackup.ControllerName:           velerobackup.Add,
+	clusterpool.ControllerName:            clusterpool.Add,
+	hibernation.ControllerName:            hibernation.Add,
+	awsprivatelink.ControllerName:         awsprivatelink.Add,
+	argocdregister.ControllerName:         argocdregister.Add,
 }
 
 // disabledControllerEquivalents contains a mapping of old controller names to their new equivalent so that CLI parameters like --controllers and --disabled-controllers continue to work
@@ -13,6 +13,8 @@ import (
 	"github.com/openshift/hive/contrib/pkg/clusterpool"
 	"github.com/openshift/hive/contrib/pkg/createcluster"
 	"github.com/openshift/hive/contrib/pkg/deprovision"
+	"github.com/openshift/hive/contrib/pkg/installlog"
+	"github.com/openshift/hive/contrib/pkg/relocate"
 	"github.com/openshift/hive/contrib/pkg/report"
 	"github.com/openshift/hive/contrib/pkg/testresource"
 	"github.com/openshift/hive/contrib/pkg/verification"
@@ -56,6 +58,8 @@ func newHiveutilCommand() *cobra.Command {
 	cmd.AddCommand(version.NewVersionCommand())
 	cmd.AddCommand(clusterpool.NewClusterPoolCommand())
 	cmd.AddCommand(awsprivatelink.NewAWSPrivateLinkCommand())
+	cmd.AddCommand(relocate.NewRelocateCommand())
+	cmd.AddCommand(installlog.NewInstallLogCommand())
 
 	return cmd
 }
@@ -0,0 +1,183 @@
+package installlog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/installlogregex"
+)
+
+// ClassifyOptions is the set of options for classifying an install log.
+type ClassifyOptions struct {
+	LogFile        string
+	ConfigMapFiles []string
+	Namespace      string
+
+	log log.FieldLogger
+}
+
+// regexSource is the ConfigMap that a set of regexes was loaded from.
+type regexSource struct {
+	name    string
+	regexes []installlogregex.InstallLogRegex
+}
+
+// NewClassifyCommand creates a command that reports which install log regexes match an install log.
+func NewClassifyCommand() *cobra.Command {
+	opt := &ClassifyOptions{log: log.WithField("command", "install-log classify")}
+
+	cmd := &cobra.Command{
+		Use:   "classify LOG_FILE",
+		Short: "reports which install log regexes match an install log",
+		Long: `Reports the regexes that match an install log, the search string and text that matched, and the failure that
+Hive reports for it. Also flags search strings that do not compile and regexes that are never reported because another
+regex always takes precedence over them.
+
+The regexes are read from the given ConfigMap manifests, in order, or else from the install-log-regexes and
+additional-install-log-regexes ConfigMaps of the hive namespace of the current kubeconfig.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.LogFile = args[0]
+			if err := opt.run(os.Stdout); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVar(&opt.ConfigMapFiles, "configmap", nil, "Path to a ConfigMap manifest with install log regexes. May be repeated.")
+	flags.StringVarP(&opt.Namespace, "namespace", "n", constants.DefaultHiveNamespace, "Namespace of the install log regexes ConfigMaps when no manifest is given")
+	return cmd
+}
+
+func (o *ClassifyOptions) run(out io.Writer) error {
+	installLog, err := os.ReadFile(o.LogFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read install log")
+	}
+	sources, err := o.loadRegexes()
+	if err != nil {
+		return err
+	}
+
+	var regexes []installlogregex.InstallLogRegex
+	var regexSources []string
+	var invalid field.ErrorList
+	for _, s := range sources {
+		invalid = append(invalid, installlogregex.Validate(s.regexes, field.NewPath(s.name))...)
+		for range s.regexes {
+			regexSources = append(regexSources, s.name)
+		}
+		regexes = append(regexes, s.regexes...)
+	}
+
+	if len(invalid) > 0 {
+		fmt.Fprintln(out, "Invalid search strings (never match):")
+		for _, err := range invalid {
+			fmt.Fprintf(out, "  %s\n", err.Error())
+		}
+		fmt.Fprintln(out)
+	}
+
+	if shadowed := installlogregex.Shadowed(regexes); len(shadowed) > 0 {
+		fmt.Fprintln(out, "Shadowed regexes (never reported):")
+		for i := range regexes {
+			if j, ok := shadowed[i]; ok {
+				fmt.Fprintf(out, "  %s/%s is shadowed by %s/%s\n", regexSources[i], regexes[i].Name, regexSources[j], regexes[j].Name)
+			}
+		}
+		fmt.Fprintln(out)
+	}
+
+	// Find logs each search string it tries, which would drown the report.
+	quiet := log.New()
+	quiet.SetOutput(io.Discard)
+	matches := installlogregex.Find(string(installLog), regexes, quiet)
+	if len(matches) == 0 {
+		fmt.Fprintln(out, "No regex matches the install log: Hive reports UnknownError (Unknown, retryable)")
+	} else {
+		w := printers.GetNewTabWriter(out)
+		fmt.Fprintln(w, "NAME\tREASON\tCATEGORY\tSEVERITY\tRETRYABLE\tSEARCH STRING\tMATCHED TEXT")
+		for _, m := range matches {
+			f := m.Failure()
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", f.Name, f.Reason, f.Category, f.Severity, strconv.FormatBool(f.Retryable), m.SearchString, strconv.Quote(m.Text))
+		}
+		w.Flush()
+		reported := matches[0].Failure()
+		retryable := "retryable"
+		if !reported.Retryable {
+			retryable = "not retryable"
+		}
+		fmt.Fprintf(out, "\nHive reports %s (%s, %s): %s\n", reported.Reason, reported.Category, retryable, reported.Message)
+	}
+
+	if len(invalid) > 0 {
+		return errors.Errorf("%d search strings do not compile", len(invalid))
+	}
+	return nil
+}
+
+// loadRegexes loads the regexes from the ConfigMap manifests, or from the ConfigMaps in the cluster when no manifest
+// is given.
+func (o *ClassifyOptions) loadRegexes() ([]regexSource, error) {
+	var configMaps []*corev1.ConfigMap
+	if len(o.ConfigMapFiles) > 0 {
+		for _, f := range o.ConfigMapFiles {
+			b, err := os.ReadFile(f)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read %s", f)
+			}
+			cm := &corev1.ConfigMap{}
+			if err := yaml.Unmarshal(b, cm); err != nil {
+				return nil, errors.Wrapf(err, "could not parse ConfigMap in %s", f)
+			}
+			configMaps = append(configMaps, cm)
+		}
+	} else {
+		c, err := utils.GetClient()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get kube client")
+		}
+		for _, name := range []string{installlogregex.ConfigMapName, installlogregex.AdditionalConfigMapName} {
+			cm := &corev1.ConfigMap{}
+			switch err := c.Get(context.Background(), client.ObjectKey{Namespace: o.Namespace, Name: name}, cm); {
+			case apierrors.IsNotFound(err) && name == installlogregex.AdditionalConfigMapName:
+				o.log.WithField("configMap", name).Debug("ConfigMap not found")
+				continue
+			case err != nil:
+				return nil, errors.Wrapf(err, "could not get ConfigMap %s", name)
+			}
+			configMaps = append(configMaps, cm)
+		}
+	}
+
+	var sources []regexSource
+	for _, cm := range configMaps {
+		raw, ok := cm.Data[installlogregex.DataEntryName]
+		if !ok {
+			return nil, errors.Errorf("ConfigMap %s does not have a %q data entry", cm.Name, installlogregex.DataEntryName)
+		}
+		regexes, err := installlogregex.Parse(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse the regexes of ConfigMap %s", cm.Name)
+		}
+		sources = append(sources, regexSource{name: cm.Name, regexes: regexes})
+	}
+	return sources, nil
+}
@@ -0,0 +1,16 @@
+package installlog
+
+import "github.com/spf13/cobra"
+
+// NewInstallLogCommand is the entrypoint to create the 'install-log' subcommand
+func NewInstallLogCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-log",
+		Short: "Utility to work with install logs and the regexes used to find known errors in them",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewClassifyCommand())
+	return cmd
+}
@@ -0,0 +1,21 @@
+package relocate
+
+import "github.com/spf13/cobra"
+
+// NewRelocateCommand is the entrypoint to create the 'relocate' subcommand
+func NewRelocateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "relocate",
+		Short: "Utility to relocate ClusterDeployments to another Hive instance",
+		Long: `Relocates ClusterDeployments to another Hive instance by managing ClusterRelocates.
+All subcommands act on the source Hive instance of the current kubeconfig.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+	cmd.AddCommand(NewStartCommand())
+	cmd.AddCommand(NewStatusCommand())
+	cmd.AddCommand(NewRollbackCommand())
+	return cmd
+}
@@ -0,0 +1,142 @@
+package relocate
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// RollbackOptions is the set of options for rolling back a relocation.
+type RollbackOptions struct {
+	Name         string
+	Wait         bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+
+	log log.FieldLogger
+}
+
+// NewRollbackCommand creates a command that rolls back a relocation.
+func NewRollbackCommand() *cobra.Command {
+	opt := &RollbackOptions{log: log.WithField("command", "relocate rollback")}
+
+	cmd := &cobra.Command{
+		Use:   "rollback RELOCATE_NAME",
+		Short: "rolls back a relocation",
+		Long: `Deletes a ClusterRelocate, and the kubeconfig secret created for it by 'relocate start', so that
+the relocations still in progress are aborted. ClusterDeployments that have already been relocated
+remain in the destination Hive instance.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.Name = args[0]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&opt.Wait, "wait", true, "Wait for the relocations in progress to be aborted")
+	flags.DurationVar(&opt.Timeout, "timeout", 10*time.Minute, "How long to wait for the relocations in progress to be aborted")
+	flags.DurationVar(&opt.PollInterval, "poll-interval", 5*time.Second, "How often to check whether the relocations in progress have been aborted")
+	return cmd
+}
+
+func (o *RollbackOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not get kube client")
+	}
+	return o.rollback(c)
+}
+
+// rollback deletes the ClusterRelocate and the kubeconfig secret created for it, and waits for the relocations in
+// progress to be aborted if requested.
+func (o *RollbackOptions) rollback(c client.Client) error {
+	logger := o.log.WithField("clusterRelocate", o.Name)
+	cr := &hivev1.ClusterRelocate{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: o.Name}, cr); err != nil {
+		return errors.Wrap(err, "could not get ClusterRelocate")
+	}
+
+	relocating, err := relocatingClusterDeployments(c, o.Name)
+	if err != nil {
+		return err
+	}
+	// A relocated ClusterDeployment is reported by its annotation until it is deleted, and by the ClusterRelocate after.
+	completed := sets.NewString(relocating[hivev1.RelocateComplete]...)
+	for _, s := range cr.Status.ClusterDeployments {
+		if s.State == hivev1.ClusterRelocateCompleted {
+			completed.Insert(s.Namespace + "/" + s.Name)
+		}
+	}
+	for _, cd := range completed.List() {
+		logger.WithField("clusterDeployment", cd).Warn("ClusterDeployment has already been relocated and cannot be rolled back")
+	}
+
+	if err := c.Delete(context.Background(), cr); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "could not delete ClusterRelocate")
+	}
+	logger.Info("deleted ClusterRelocate")
+
+	secret := &corev1.Secret{}
+	ref := cr.Spec.KubeconfigSecretRef
+	switch err := c.Get(context.Background(), client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); {
+	case apierrors.IsNotFound(err):
+	case err != nil:
+		return errors.Wrap(err, "could not get kubeconfig secret")
+	case secret.Labels[relocateLabel] == o.Name:
+		if err := c.Delete(context.Background(), secret); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrap(err, "could not delete kubeconfig secret")
+		}
+		logger.WithField("secret", ref.Namespace+"/"+ref.Name).Info("deleted kubeconfig secret")
+	}
+
+	if !o.Wait || len(relocating[hivev1.RelocateOutgoing]) == 0 {
+		return nil
+	}
+	logger.WithField("clusterDeployments", relocating[hivev1.RelocateOutgoing]).Info("waiting for relocations in progress to be aborted")
+	if err := wait.PollImmediate(o.PollInterval, o.Timeout, func() (bool, error) {
+		relocating, err := relocatingClusterDeployments(c, o.Name)
+		if err != nil {
+			return false, err
+		}
+		return len(relocating[hivev1.RelocateOutgoing]) == 0, nil
+	}); err != nil {
+		return errors.Wrap(err, "relocations in progress were not aborted")
+	}
+	logger.Info("relocation rolled back")
+	return nil
+}
+
+// relocatingClusterDeployments returns the ClusterDeployments whose relocate annotation refers to the ClusterRelocate,
+// by relocate status.
+func relocatingClusterDeployments(c client.Client, relocateName string) (map[hivev1.RelocateStatus][]string, error) {
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cds); err != nil {
+		return nil, errors.Wrap(err, "could not list ClusterDeployments")
+	}
+	relocating := map[hivev1.RelocateStatus][]string{}
+	for i := range cds.Items {
+		cd := &cds.Items[i]
+		name, status, err := controllerutils.IsRelocating(cd)
+		if err != nil || name != relocateName {
+			continue
+		}
+		relocating[status] = append(relocating[status], cd.Namespace+"/"+cd.Name)
+	}
+	return relocating, nil
+}
@@ -0,0 +1,141 @@
+package relocate
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcr "github.com/openshift/hive/pkg/test/clusterrelocate"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+func TestRollback(t *testing.T) {
+	scheme := newScheme()
+	crBuilder := testcr.FullBuilder(testRelocateName, scheme).Options(
+		testcr.WithKubeconfigSecret(testSecretNamespace, testSecretName),
+	)
+	secretBuilder := testsecret.FullBuilder(testSecretNamespace, testSecretName, scheme)
+	relocatedCD := func(name string, status hivev1.RelocateStatus) runtime.Object {
+		return testcd.FullBuilder(testNamespace, name, scheme).GenericOptions(
+			testgeneric.WithAnnotation(constants.RelocateAnnotation, testRelocateName+"/"+string(status)),
+		).Build()
+	}
+
+	cases := []struct {
+		name         string
+		existing     []runtime.Object
+		expectErr    bool
+		expectSecret bool
+		// expectedWarnings are the ClusterDeployments expected to be reported as already relocated
+		expectedWarnings []string
+	}{
+		{
+			name: "deletes clusterrelocate and secret it labelled",
+			existing: []runtime.Object{
+				crBuilder.Build(),
+				secretBuilder.Build(testsecret.Generic(testgeneric.WithLabel(relocateLabel, testRelocateName))),
+			},
+		},
+		{
+			name: "keeps unlabelled secret",
+			existing: []runtime.Object{
+				crBuilder.Build(),
+				secretBuilder.Build(),
+			},
+			expectSecret: true,
+		},
+		{
+			name: "keeps secret labelled for another clusterrelocate",
+			existing: []runtime.Object{
+				crBuilder.Build(),
+				secretBuilder.Build(testsecret.Generic(testgeneric.WithLabel(relocateLabel, "other-relocate"))),
+			},
+			expectSecret: true,
+		},
+		{
+			name: "missing secret",
+			existing: []runtime.Object{
+				crBuilder.Build(),
+			},
+		},
+		{
+			name:      "missing clusterrelocate",
+			expectErr: true,
+		},
+		{
+			name: "warns about completed clusters",
+			existing: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithClusterDeploymentStatus(testNamespace, "relocated", hivev1.ClusterRelocateCompleted),
+					testcr.WithClusterDeploymentStatus(testNamespace, "deleted", hivev1.ClusterRelocateCompleted),
+					testcr.WithClusterDeploymentStatus(testNamespace, "outgoing", hivev1.ClusterRelocateInProgress),
+				),
+				relocatedCD("relocated", hivev1.RelocateComplete),
+				relocatedCD("annotated", hivev1.RelocateComplete),
+				relocatedCD("outgoing", hivev1.RelocateOutgoing),
+			},
+			expectedWarnings: []string{
+				testNamespace + "/annotated",
+				testNamespace + "/deleted",
+				testNamespace + "/relocated",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			logger := log.New()
+			logger.SetFormatter(&log.TextFormatter{DisableColors: true, DisableTimestamp: true, DisableQuote: true})
+			buf := &bytes.Buffer{}
+			logger.SetOutput(buf)
+			opt := &RollbackOptions{
+				Name: testRelocateName,
+				log:  logger,
+			}
+
+			err := opt.rollback(c)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			assert.NoError(t, err, "unexpected error")
+
+			err = c.Get(context.Background(), client.ObjectKey{Name: testRelocateName}, &hivev1.ClusterRelocate{})
+			assert.True(t, apierrors.IsNotFound(err), "expected clusterrelocate to be deleted")
+
+			err = c.Get(context.Background(), client.ObjectKey{Namespace: testSecretNamespace, Name: testSecretName}, &corev1.Secret{})
+			if tc.expectSecret {
+				assert.NoError(t, err, "expected secret to be kept")
+			} else {
+				assert.True(t, apierrors.IsNotFound(err), "expected no secret")
+			}
+
+			var warnings []string
+			for _, line := range strings.Split(buf.String(), "\n") {
+				if !strings.HasPrefix(line, "level=warning") {
+					continue
+				}
+				for _, field := range strings.Fields(line) {
+					if strings.HasPrefix(field, "clusterDeployment=") {
+						warnings = append(warnings, strings.TrimPrefix(field, "clusterDeployment="))
+					}
+				}
+			}
+			assert.Equal(t, tc.expectedWarnings, warnings, "unexpected clusters reported as relocated")
+		})
+	}
+}
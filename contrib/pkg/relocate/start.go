@@ -0,0 +1,175 @@
+package relocate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+)
+
+const (
+	// relocateLabel is set on the kubeconfig secrets created for a ClusterRelocate, so that they are deleted when the
+	// relocation is rolled back.
+	relocateLabel = "hive.openshift.io/cluster-relocate"
+
+	kubeconfigKey = "kubeconfig"
+)
+
+// StartOptions is the set of options for starting a relocation.
+type StartOptions struct {
+	Name                  string
+	DestinationKubeconfig string
+	Selector              string
+	SecretNamespace       string
+	DryRun                bool
+	Wait                  bool
+	Timeout               time.Duration
+	PollInterval          time.Duration
+
+	log log.FieldLogger
+}
+
+// NewStartCommand creates a command that starts relocating ClusterDeployments to another Hive instance.
+func NewStartCommand() *cobra.Command {
+	opt := &StartOptions{log: log.WithField("command", "relocate start")}
+
+	cmd := &cobra.Command{
+		Use:   "start RELOCATE_NAME",
+		Short: "starts relocating ClusterDeployments to another Hive instance",
+		Long: `Creates a secret with the kubeconfig of the destination Hive instance and a ClusterRelocate
+that relocates the ClusterDeployments matching the selector to it. By default, waits for the
+relocation to complete and reports the progress of each ClusterDeployment.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.Name = args[0]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opt.DestinationKubeconfig, "destination-kubeconfig", "", "Path to the kubeconfig of the destination Hive instance")
+	flags.StringVarP(&opt.Selector, "selector", "l", "", "Label selector of the ClusterDeployments to relocate, e.g. migrate=hub2")
+	flags.StringVar(&opt.SecretNamespace, "secret-namespace", "", "Namespace in which to create the kubeconfig secret. Defaults to the namespace of the current context")
+	flags.BoolVar(&opt.DryRun, "dry-run", false, "Create a dry-run ClusterRelocate that reports the resources that would be copied without relocating anything")
+	flags.BoolVar(&opt.Wait, "wait", true, "Wait for the relocation to complete")
+	flags.DurationVar(&opt.Timeout, "timeout", time.Hour, "How long to wait for the relocation to complete")
+	flags.DurationVar(&opt.PollInterval, "poll-interval", 5*time.Second, "How often to check the progress of the relocation")
+	cmd.MarkFlagRequired("destination-kubeconfig")
+	cmd.MarkFlagRequired("selector")
+	return cmd
+}
+
+func (o *StartOptions) run() error {
+	kubeconfig, err := os.ReadFile(o.DestinationKubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "could not read destination kubeconfig")
+	}
+	if _, err := clientcmd.Load(kubeconfig); err != nil {
+		return errors.Wrap(err, "invalid destination kubeconfig")
+	}
+	selector, err := metav1.ParseToLabelSelector(o.Selector)
+	if err != nil {
+		return errors.Wrap(err, "invalid selector")
+	}
+	if len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 {
+		return errors.New("selector must not be empty")
+	}
+	if o.SecretNamespace == "" {
+		if o.SecretNamespace, err = utils.DefaultNamespace(); err != nil {
+			return errors.Wrap(err, "cannot determine default namespace")
+		}
+	}
+
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not get kube client")
+	}
+	return o.start(c, kubeconfig, selector)
+}
+
+// start creates the kubeconfig secret and the ClusterRelocate, and waits for the relocation to complete if requested.
+func (o *StartOptions) start(c client.Client, kubeconfig []byte, selector *metav1.LabelSelector) error {
+	cr := &hivev1.ClusterRelocate{}
+	switch err := c.Get(context.Background(), client.ObjectKey{Name: o.Name}, cr); {
+	case err == nil:
+		return fmt.Errorf("ClusterRelocate %s already exists", o.Name)
+	case !apierrors.IsNotFound(err):
+		return errors.Wrap(err, "could not get ClusterRelocate")
+	}
+
+	secret, err := o.applyKubeconfigSecret(c, kubeconfig)
+	if err != nil {
+		return err
+	}
+	cr = &hivev1.ClusterRelocate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: o.Name,
+		},
+		Spec: hivev1.ClusterRelocateSpec{
+			KubeconfigSecretRef: hivev1.KubeconfigSecretReference{
+				Namespace: secret.Namespace,
+				Name:      secret.Name,
+			},
+			ClusterDeploymentSelector: *selector,
+			DryRun:                    o.DryRun,
+		},
+	}
+	if err := c.Create(context.Background(), cr); err != nil {
+		return errors.Wrap(err, "could not create ClusterRelocate")
+	}
+	o.log.WithField("clusterRelocate", o.Name).Info("created ClusterRelocate")
+
+	if !o.Wait {
+		return nil
+	}
+	return waitForRelocate(c, o.Name, o.PollInterval, o.Timeout, o.log)
+}
+
+// applyKubeconfigSecret creates or updates the secret with the kubeconfig of the destination Hive instance.
+func (o *StartOptions) applyKubeconfigSecret(c client.Client, kubeconfig []byte) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: o.SecretNamespace, Name: fmt.Sprintf("%s-kubeconfig", o.Name)}
+	logger := o.log.WithField("secret", key.String())
+	switch err := c.Get(context.Background(), key, secret); {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: key.Namespace,
+				Name:      key.Name,
+				Labels:    map[string]string{relocateLabel: o.Name},
+			},
+			Data: map[string][]byte{kubeconfigKey: kubeconfig},
+		}
+		if err := c.Create(context.Background(), secret); err != nil {
+			return nil, errors.Wrap(err, "could not create kubeconfig secret")
+		}
+		logger.Info("created kubeconfig secret")
+	case err != nil:
+		return nil, errors.Wrap(err, "could not get kubeconfig secret")
+	default:
+		if secret.Labels[relocateLabel] != o.Name {
+			return nil, fmt.Errorf("secret %s already exists and was not created for ClusterRelocate %s", key, o.Name)
+		}
+		secret.Data = map[string][]byte{kubeconfigKey: kubeconfig}
+		if err := c.Update(context.Background(), secret); err != nil {
+			return nil, errors.Wrap(err, "could not update kubeconfig secret")
+		}
+		logger.Info("updated kubeconfig secret")
+	}
+	return secret, nil
+}
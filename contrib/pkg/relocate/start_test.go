@@ -0,0 +1,140 @@
+package relocate
+
+import (
+	"context"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcr "github.com/openshift/hive/pkg/test/clusterrelocate"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testsecret "github.com/openshift/hive/pkg/test/secret"
+)
+
+const (
+	testRelocateName    = "test-relocate"
+	testSecretNamespace = "test-secret-namespace"
+	testSecretName      = testRelocateName + "-kubeconfig"
+	testNamespace       = "test-namespace"
+)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	return scheme
+}
+
+func TestStart(t *testing.T) {
+	scheme := newScheme()
+	kubeconfig := []byte("new-kubeconfig")
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"migrate": "hub2"}}
+
+	cases := []struct {
+		name      string
+		existing  []runtime.Object
+		expectErr bool
+		// expectedKubeconfig is the kubeconfig expected in the secret, if the secret is expected to exist
+		expectedKubeconfig string
+		// expectedSecretRef is the name of the secret referenced by the clusterrelocate, if the clusterrelocate is
+		// expected to exist
+		expectedSecretRef string
+	}{
+		{
+			name:               "creates secret and clusterrelocate",
+			expectedKubeconfig: "new-kubeconfig",
+			expectedSecretRef:  testSecretName,
+		},
+		{
+			name: "reuses secret created for the clusterrelocate",
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testSecretNamespace, testSecretName, scheme).Build(
+					testsecret.Generic(testgeneric.WithLabel(relocateLabel, testRelocateName)),
+					testsecret.WithDataKeyValue(kubeconfigKey, []byte("old-kubeconfig")),
+				),
+			},
+			expectedKubeconfig: "new-kubeconfig",
+			expectedSecretRef:  testSecretName,
+		},
+		{
+			name: "refuses secret not created for the clusterrelocate",
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testSecretNamespace, testSecretName, scheme).Build(
+					testsecret.WithDataKeyValue(kubeconfigKey, []byte("old-kubeconfig")),
+				),
+			},
+			expectErr:          true,
+			expectedKubeconfig: "old-kubeconfig",
+		},
+		{
+			name: "refuses secret created for another clusterrelocate",
+			existing: []runtime.Object{
+				testsecret.FullBuilder(testSecretNamespace, testSecretName, scheme).Build(
+					testsecret.Generic(testgeneric.WithLabel(relocateLabel, "other-relocate")),
+					testsecret.WithDataKeyValue(kubeconfigKey, []byte("old-kubeconfig")),
+				),
+			},
+			expectErr:          true,
+			expectedKubeconfig: "old-kubeconfig",
+		},
+		{
+			name: "refuses to start when clusterrelocate exists",
+			existing: []runtime.Object{
+				testcr.FullBuilder(testRelocateName, scheme).Build(
+					testcr.WithKubeconfigSecret(testSecretNamespace, "other-kubeconfig"),
+				),
+			},
+			expectErr:         true,
+			expectedSecretRef: "other-kubeconfig",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			opt := &StartOptions{
+				Name:            testRelocateName,
+				SecretNamespace: testSecretNamespace,
+				log:             log.WithField("test", t.Name()),
+			}
+
+			err := opt.start(c, kubeconfig, selector)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+
+			secret := &corev1.Secret{}
+			switch err := c.Get(context.Background(), client.ObjectKey{Namespace: testSecretNamespace, Name: testSecretName}, secret); {
+			case tc.expectedKubeconfig == "":
+				assert.True(t, apierrors.IsNotFound(err), "expected no kubeconfig secret")
+			default:
+				require.NoError(t, err, "could not get kubeconfig secret")
+				assert.Equal(t, tc.expectedKubeconfig, string(secret.Data[kubeconfigKey]), "unexpected kubeconfig")
+			}
+
+			cr := &hivev1.ClusterRelocate{}
+			switch err := c.Get(context.Background(), client.ObjectKey{Name: testRelocateName}, cr); {
+			case tc.expectedSecretRef == "":
+				assert.True(t, apierrors.IsNotFound(err), "expected no clusterrelocate")
+			default:
+				require.NoError(t, err, "could not get clusterrelocate")
+				assert.Equal(t, hivev1.KubeconfigSecretReference{Namespace: testSecretNamespace, Name: tc.expectedSecretRef}, cr.Spec.KubeconfigSecretRef, "unexpected kubeconfig secret reference")
+			}
+			if !tc.expectErr {
+				assert.Equal(t, *selector, cr.Spec.ClusterDeploymentSelector, "unexpected selector")
+				assert.Equal(t, testRelocateName, secret.Labels[relocateLabel], "unexpected relocate label on secret")
+			}
+		})
+	}
+}
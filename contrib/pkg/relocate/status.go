@@ -0,0 +1,166 @@
+package relocate
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/printers"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/contrib/pkg/utils"
+)
+
+// StatusOptions is the set of options for reporting the progress of a relocation.
+type StatusOptions struct {
+	Name         string
+	Watch        bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+
+	log log.FieldLogger
+}
+
+// NewStatusCommand creates a command that reports the progress of a relocation.
+func NewStatusCommand() *cobra.Command {
+	opt := &StatusOptions{log: log.WithField("command", "relocate status")}
+
+	cmd := &cobra.Command{
+		Use:   "status RELOCATE_NAME",
+		Short: "reports the progress of a relocation",
+		Long:  "Lists the ClusterDeployments matched by a ClusterRelocate with the state of their relocation",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			opt.Name = args[0]
+			if err := opt.run(); err != nil {
+				opt.log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVarP(&opt.Watch, "watch", "w", false, "Wait for the relocation to complete, reporting the progress of each ClusterDeployment")
+	flags.DurationVar(&opt.Timeout, "timeout", time.Hour, "How long to wait for the relocation to complete")
+	flags.DurationVar(&opt.PollInterval, "poll-interval", 5*time.Second, "How often to check the progress of the relocation")
+	return cmd
+}
+
+func (o *StatusOptions) run() error {
+	c, err := utils.GetClient()
+	if err != nil {
+		return errors.Wrap(err, "could not get kube client")
+	}
+	if o.Watch {
+		return waitForRelocate(c, o.Name, o.PollInterval, o.Timeout, o.log)
+	}
+	return o.printStatus(c, os.Stdout)
+}
+
+// printStatus writes the state of the relocation of each ClusterDeployment matched by the ClusterRelocate to out.
+func (o *StatusOptions) printStatus(c client.Client, out io.Writer) error {
+	cr := &hivev1.ClusterRelocate{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: o.Name}, cr); err != nil {
+		return errors.Wrap(err, "could not get ClusterRelocate")
+	}
+	w := printers.GetNewTabWriter(out)
+	defer w.Flush()
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tSTATE\tREASON\tMESSAGE")
+	for _, s := range cr.Status.ClusterDeployments {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", s.Namespace, s.Name, s.State, s.Reason, s.Message)
+	}
+	return nil
+}
+
+// waitForRelocate waits until every ClusterDeployment matched by the ClusterRelocate has been relocated or has failed
+// to relocate, logging each change in the state of their relocations. For a dry run, it waits until every matching
+// ClusterDeployment has been reported, and logs the resources that would be copied.
+func waitForRelocate(c client.Client, name string, interval, timeout time.Duration, logger log.FieldLogger) error {
+	logger = logger.WithField("clusterRelocate", name)
+	reported := map[string]hivev1.ClusterRelocateState{}
+	var failed int
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		cr := &hivev1.ClusterRelocate{}
+		if err := c.Get(context.Background(), client.ObjectKey{Name: name}, cr); err != nil {
+			return false, errors.Wrap(err, "could not get ClusterRelocate")
+		}
+		states := map[string]hivev1.ClusterRelocateState{}
+		for _, s := range cr.Status.ClusterDeployments {
+			key := s.Namespace + "/" + s.Name
+			states[key] = s.State
+			if reported[key] == s.State {
+				continue
+			}
+			reported[key] = s.State
+			cdLog := logger.WithField("clusterDeployment", key).WithField("state", s.State)
+			if s.Reason != "" {
+				cdLog = cdLog.WithField("reason", s.Reason)
+			}
+			cdLog.Info(s.Message)
+			for _, r := range s.ResourcesToCopy {
+				cdLog.WithField("kind", r.Kind).WithField("count", r.Count).WithField("resources", r.Names).Info("would copy")
+			}
+			for _, r := range s.IgnoredResources {
+				cdLog.WithField("kind", r.Kind).WithField("count", r.Count).WithField("resources", r.Names).Info("would skip")
+			}
+		}
+
+		remaining, err := remainingClusterDeployments(c, cr, states)
+		if err != nil {
+			return false, err
+		}
+		failed = int(cr.Status.Failed)
+		logger.WithField("remaining", remaining).
+			WithField("inProgress", cr.Status.InProgress).
+			WithField("completed", cr.Status.Completed).
+			WithField("failed", cr.Status.Failed).
+			Debug("relocation progress")
+		return remaining == 0, nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "relocation did not complete")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d ClusterDeployments failed to relocate", failed)
+	}
+	logger.Info("relocation complete")
+	return nil
+}
+
+// remainingClusterDeployments returns the number of ClusterDeployments matching the ClusterRelocate whose relocation
+// has not finished. A relocated ClusterDeployment is deleted from the source Hive instance, and a failed relocation
+// is finished until the failure is resolved. In a dry run, a ClusterDeployment is finished once it has been reported.
+func remainingClusterDeployments(c client.Client, cr *hivev1.ClusterRelocate, states map[string]hivev1.ClusterRelocateState) (int, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&cr.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		return 0, errors.Wrap(err, "invalid ClusterRelocate selector")
+	}
+	cds := &hivev1.ClusterDeploymentList{}
+	if err := c.List(context.Background(), cds, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, errors.Wrap(err, "could not list ClusterDeployments")
+	}
+	remaining := 0
+	for _, cd := range cds.Items {
+		if cd.DeletionTimestamp != nil {
+			continue
+		}
+		switch states[cd.Namespace+"/"+cd.Name] {
+		case hivev1.ClusterRelocateFailed:
+			continue
+		case hivev1.ClusterRelocateMatched:
+			if cr.Spec.DryRun {
+				continue
+			}
+		}
+		remaining++
+	}
+	return remaining, nil
+}
@@ -0,0 +1,129 @@
+package relocate
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcr "github.com/openshift/hive/pkg/test/clusterrelocate"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestPrintStatus(t *testing.T) {
+	scheme := newScheme()
+	cr := testcr.FullBuilder(testRelocateName, scheme).Build(
+		testcr.WithClusterDeploymentStatus(testNamespace, "cluster1", hivev1.ClusterRelocateCompleted),
+		testcr.WithClusterDeploymentStatus(testNamespace, "cluster2", hivev1.ClusterRelocateInProgress),
+	)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(cr).Build()
+	opt := &StatusOptions{Name: testRelocateName, log: log.WithField("test", t.Name())}
+
+	out := &bytes.Buffer{}
+	if assert.NoError(t, opt.printStatus(c, out), "unexpected error") {
+		var rows [][]string
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			rows = append(rows, strings.Fields(line))
+		}
+		assert.Equal(t, [][]string{
+			{"NAMESPACE", "NAME", "STATE", "REASON", "MESSAGE"},
+			{testNamespace, "cluster1", "Completed"},
+			{testNamespace, "cluster2", "InProgress"},
+		}, rows, "unexpected output")
+	}
+
+	opt.Name = "missing"
+	assert.Error(t, opt.printStatus(c, out), "expected error for missing clusterrelocate")
+}
+
+func TestWaitForRelocate(t *testing.T) {
+	scheme := newScheme()
+	crBuilder := testcr.FullBuilder(testRelocateName, scheme).Options(
+		testcr.WithClusterDeploymentSelector("migrate", "hub2"),
+	)
+	cdBuilder := func(name string) testcd.Builder {
+		return testcd.FullBuilder(testNamespace, name, scheme).GenericOptions(
+			testgeneric.WithLabel("migrate", "hub2"),
+		)
+	}
+
+	cases := []struct {
+		name      string
+		existing  []runtime.Object
+		expectErr bool
+	}{
+		{
+			name: "all relocated",
+			existing: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithClusterDeploymentStatus(testNamespace, "cluster1", hivev1.ClusterRelocateCompleted),
+				),
+				cdBuilder("cluster1").GenericOptions(testgeneric.Deleted()).Build(),
+				testcd.FullBuilder(testNamespace, "unmatched", scheme).Build(),
+			},
+		},
+		{
+			name: "relocation in progress",
+			existing: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithClusterDeploymentStatus(testNamespace, "cluster1", hivev1.ClusterRelocateInProgress),
+				),
+				cdBuilder("cluster1").Build(),
+			},
+			expectErr: true,
+		},
+		{
+			name: "failed relocation",
+			existing: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithClusterDeploymentStatus(testNamespace, "cluster1", hivev1.ClusterRelocateFailed),
+					testcr.WithClusterDeploymentStatus(testNamespace, "cluster2", hivev1.ClusterRelocateCompleted),
+					func(cr *hivev1.ClusterRelocate) { cr.Status.Failed = 1 },
+				),
+				cdBuilder("cluster1").Build(),
+			},
+			expectErr: true,
+		},
+		{
+			name: "dry run reported",
+			existing: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithDryRun(),
+					testcr.WithClusterDeploymentStatus(testNamespace, "cluster1", hivev1.ClusterRelocateMatched),
+				),
+				cdBuilder("cluster1").Build(),
+			},
+		},
+		{
+			name: "dry run not yet reported",
+			existing: []runtime.Object{
+				crBuilder.Build(testcr.WithDryRun()),
+				cdBuilder("cluster1").Build(),
+			},
+			expectErr: true,
+		},
+		{
+			name:      "missing clusterrelocate",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			err := waitForRelocate(c, testRelocateName, 10*time.Millisecond, 50*time.Millisecond, log.WithField("test", t.Name()))
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
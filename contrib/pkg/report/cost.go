@@ -0,0 +1,204 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/clustercost"
+	"github.com/openshift/hive/pkg/constants"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CostReportOptions is the set of options for the desired report.
+type CostReportOptions struct {
+	// ClusterType filters the report to only clusters of the given type.
+	ClusterType string
+	// HiveNamespace is the namespace of the cluster cost ConfigMap.
+	HiveNamespace string
+	// Output is the format of the report: table, csv or json.
+	Output string
+}
+
+// clusterCost is the estimated cost of an installed cluster, and the savings of hibernating it.
+type clusterCost struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	ClusterType string `json:"clusterType"`
+	// ClusterPool is the namespace/name of the ClusterPool the cluster is charged to while it is unclaimed.
+	ClusterPool string `json:"clusterPool,omitempty"`
+	PowerState  string `json:"powerState"`
+	// HourlyCost is the hourly cost of the cluster in its current power state.
+	HourlyCost float64 `json:"hourlyCost"`
+	// RunningHourlyCost is the hourly cost of the cluster while it is running.
+	RunningHourlyCost float64 `json:"runningHourlyCost"`
+	// HibernatingHourlyCost is the hourly cost of the cluster while it is hibernating.
+	HibernatingHourlyCost float64 `json:"hibernatingHourlyCost"`
+	// HibernatedHours is the total time the cluster has spent hibernating, including its current hibernating streak.
+	HibernatedHours float64 `json:"hibernatedHours"`
+	// HibernatedSavings is what the cluster would have cost running while it was hibernating, less what it cost
+	// hibernating, at the current prices.
+	HibernatedSavings float64 `json:"hibernatedSavings"`
+}
+
+func (c clusterCost) values() []string {
+	return []string{
+		c.Namespace,
+		c.Name,
+		c.ClusterType,
+		c.ClusterPool,
+		c.PowerState,
+		amount(c.HourlyCost),
+		amount(c.RunningHourlyCost),
+		amount(c.HibernatingHourlyCost),
+		hours(c.HibernatedHours),
+		amount(c.HibernatedSavings),
+	}
+}
+
+// NewCostReportCommand creates a command that generates and outputs the cost report.
+func NewCostReportCommand() *cobra.Command {
+
+	opt := &CostReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "cost",
+		Short: "Prints a report on the estimated cost of installed clusters and the savings of hibernating them",
+		Long: `Prints, for each installed cluster, its estimated hourly cost running, hibernating and in its current power
+state, from the price table of the cluster-cost-prices ConfigMap in the hive namespace. It also prints the total hours
+the cluster has spent hibernating, as in the hibernation report, and the savings of hibernating: the difference between
+the running and hibernating costs over those hours, at the current prices. Clusters that no price table entry applies to
+are not reported.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.ClusterType, "cluster-type", "", "", "Only include clusters with the given hive.openshift.io/cluster-type label.")
+	flags.StringVarP(&opt.HiveNamespace, "hive-namespace", "", constants.DefaultHiveNamespace, "Namespace of the cluster-cost-prices ConfigMap.")
+	flags.StringVarP(&opt.Output, "output", "o", outputTable, "Output format of the report. Valid values: table,csv,json")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *CostReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *CostReportOptions) Validate(cmd *cobra.Command) error {
+	return validateOutput(o.Output)
+}
+
+// Run executes the command
+func (o *CostReportOptions) Run(dynClient client.Client) error {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+
+	rows, err := o.report(dynClient, time.Now())
+	if err != nil {
+		return err
+	}
+	return writeReport(os.Stdout, o.Output, []string{
+		"NAMESPACE", "NAME", "CLUSTER TYPE", "CLUSTER POOL", "POWER STATE", "HOURLY COST", "RUNNING HOURLY COST", "HIBERNATING HOURLY COST", "HIBERNATED HOURS", "HIBERNATED SAVINGS",
+	}, rows)
+}
+
+// report returns the rows of the report, most expensive first.
+func (o *CostReportOptions) report(dynClient client.Client, now time.Time) ([]clusterCost, error) {
+	prices, found, err := clustercost.Load(dynClient, o.HiveNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("no price table: ConfigMap %s/%s does not exist", o.HiveNamespace, constants.ClusterCostPricesConfigMapName)
+	}
+
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := dynClient.List(context.Background(), cdList); err != nil {
+		return nil, err
+	}
+	mpList := &hivev1.MachinePoolList{}
+	if err := dynClient.List(context.Background(), mpList); err != nil {
+		return nil, err
+	}
+	machinePoolsByCD := clustercost.MachinePoolsByClusterDeployment(mpList.Items)
+
+	var rows []clusterCost
+	unpriced := 0
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil {
+			continue
+		}
+		ct := clusterType(cd)
+		if o.ClusterType != "" && ct != o.ClusterType {
+			continue
+		}
+
+		pools := machinePoolsByCD[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}]
+		running, hibernating, priced := prices.HourlyCosts(cd, pools)
+		if !priced {
+			log.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name).Debug("no price table entry applies to the cluster")
+			unpriced++
+			continue
+		}
+		hourlyCost, _ := prices.HourlyCost(cd, pools)
+		row := clusterCost{
+			Namespace:             cd.Namespace,
+			Name:                  cd.Name,
+			ClusterType:           ct,
+			PowerState:            string(cd.Status.PowerState),
+			HourlyCost:            hourlyCost,
+			RunningHourlyCost:     running,
+			HibernatingHourlyCost: hibernating,
+		}
+		if ref := cd.Spec.ClusterPoolRef; ref != nil && ref.ClaimName == "" {
+			row.ClusterPool = ref.Namespace + "/" + ref.PoolName
+		}
+		row.HibernatedHours = hibernated(cd, now).Hours()
+		row.HibernatedSavings = (running - hibernating) * row.HibernatedHours
+		rows = append(rows, row)
+	}
+	if unpriced > 0 {
+		log.Warnf("%d installed cluster(s) have no price table entry and are not reported", unpriced)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].HourlyCost != rows[j].HourlyCost {
+			return rows[i].HourlyCost > rows[j].HourlyCost
+		}
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows, nil
+}
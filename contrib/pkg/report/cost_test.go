@@ -0,0 +1,171 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcm "github.com/openshift/hive/pkg/test/configmap"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+)
+
+const testPriceTable = `
+- platform: aws
+  hourlyCost: 2
+  hibernatingHourlyCost: 0.5
+- platform: aws
+  instanceType: m5.xlarge
+  hourlyCost: 0.25
+`
+
+func TestCostReport(t *testing.T) {
+	scheme := newScheme()
+	prices := func(namespace, table string) runtime.Object {
+		return testcm.FullBuilder(namespace, constants.ClusterCostPricesConfigMapName, scheme).
+			Build(testcm.WithDataKeyValue(constants.ClusterCostPricesDataKey, table))
+	}
+	cdBuilder := func(name string) testcd.Builder {
+		return testcd.FullBuilder("ns", name, scheme).Options(
+			testcd.Installed(),
+			testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+			testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+		)
+	}
+	workers := func(cdName string, replicas int64) runtime.Object {
+		return testmp.FullBuilder("ns", "worker", cdName, scheme).Build(func(mp *hivev1.MachinePool) {
+			mp.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{InstanceType: "m5.xlarge"}
+			mp.Spec.Replicas = pointer.Int64(replicas)
+		})
+	}
+
+	cases := []struct {
+		name          string
+		existing      []runtime.Object
+		clusterType   string
+		hiveNamespace string
+		expectErr     bool
+		expected      []clusterCost
+	}{
+		{
+			name: "no price table",
+			existing: []runtime.Object{
+				cdBuilder("cd").Build(),
+			},
+			expectErr: true,
+		},
+		{
+			name: "invalid price table",
+			existing: []runtime.Object{
+				prices(constants.DefaultHiveNamespace, "- hourlyCost: 2"),
+				cdBuilder("cd").Build(),
+			},
+			expectErr: true,
+		},
+		{
+			name: "running and hibernating clusters",
+			existing: []runtime.Object{
+				prices(constants.DefaultHiveNamespace, testPriceTable),
+				cdBuilder("running").Build(runningFor(time.Hour), hibernatedBefore(2*time.Hour)),
+				workers("running", 4),
+				cdBuilder("hibernating").Build(hibernatingFor(10 * time.Hour)),
+				workers("hibernating", 4),
+			},
+			expected: []clusterCost{
+				{
+					Namespace:             "ns",
+					Name:                  "running",
+					ClusterType:           "unspecified",
+					PowerState:            "Running",
+					HourlyCost:            3,
+					RunningHourlyCost:     3,
+					HibernatingHourlyCost: 0.5,
+					HibernatedHours:       2,
+					HibernatedSavings:     5,
+				},
+				{
+					Namespace:             "ns",
+					Name:                  "hibernating",
+					ClusterType:           "unspecified",
+					PowerState:            "Hibernating",
+					HourlyCost:            0.5,
+					RunningHourlyCost:     3,
+					HibernatingHourlyCost: 0.5,
+					HibernatedHours:       10,
+					HibernatedSavings:     25,
+				},
+			},
+		},
+		{
+			name: "unclaimed clusters charged to their pool",
+			existing: []runtime.Object{
+				prices(constants.DefaultHiveNamespace, testPriceTable),
+				cdBuilder("unclaimed").Build(testcd.WithUnclaimedClusterPoolReference("pools", "pool")),
+				cdBuilder("claimed").Build(testcd.WithClusterPoolReference("pools", "pool", "claim")),
+			},
+			expected: []clusterCost{
+				{Namespace: "ns", Name: "claimed", ClusterType: "unspecified", HourlyCost: 2, RunningHourlyCost: 2, HibernatingHourlyCost: 0.5},
+				{Namespace: "ns", Name: "unclaimed", ClusterType: "unspecified", ClusterPool: "pools/pool", HourlyCost: 2, RunningHourlyCost: 2, HibernatingHourlyCost: 0.5},
+			},
+		},
+		{
+			name: "unpriced, uninstalled and deleted clusters skipped",
+			existing: []runtime.Object{
+				prices(constants.DefaultHiveNamespace, testPriceTable),
+				cdBuilder("gcp").Build(testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "gcp")),
+				cdBuilder("installing").Build(func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+				cdBuilder("deleted").GenericOptions(testgeneric.Deleted()).Build(),
+			},
+		},
+		{
+			name: "cluster type filter",
+			existing: []runtime.Object{
+				prices(constants.DefaultHiveNamespace, testPriceTable),
+				cdBuilder("ci").Build(testcd.WithLabel(hivev1.HiveClusterTypeLabel, "ci")),
+				cdBuilder("untyped").Build(),
+			},
+			clusterType: "ci",
+			expected: []clusterCost{
+				{Namespace: "ns", Name: "ci", ClusterType: "ci", HourlyCost: 2, RunningHourlyCost: 2, HibernatingHourlyCost: 0.5},
+			},
+		},
+		{
+			name: "price table in another namespace",
+			existing: []runtime.Object{
+				prices("other-hive", testPriceTable),
+				cdBuilder("cd").Build(),
+			},
+			hiveNamespace: "other-hive",
+			expected: []clusterCost{
+				{Namespace: "ns", Name: "cd", ClusterType: "unspecified", HourlyCost: 2, RunningHourlyCost: 2, HibernatingHourlyCost: 0.5},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			hiveNamespace := tc.hiveNamespace
+			if hiveNamespace == "" {
+				hiveNamespace = constants.DefaultHiveNamespace
+			}
+			opt := &CostReportOptions{ClusterType: tc.clusterType, HiveNamespace: hiveNamespace, Output: outputTable}
+			rows, err := opt.report(c, testNow)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, rows, "unexpected report")
+			}
+		})
+	}
+}
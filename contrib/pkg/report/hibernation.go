@@ -0,0 +1,194 @@
+package report
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HibernationReportOptions is the set of options for the desired report.
+type HibernationReportOptions struct {
+	// ClusterType filters the report to only clusters of the given type.
+	ClusterType string
+	// Output is the format of the report: table, csv or json.
+	Output string
+}
+
+// clusterHibernation is the power state of an installed cluster, how long it has been in it and how long the cluster
+// has spent hibernating in total.
+type clusterHibernation struct {
+	Namespace   string `json:"namespace"`
+	Name        string `json:"name"`
+	ClusterType string `json:"clusterType"`
+	PowerState  string `json:"powerState"`
+	// InstalledHours is the time since the cluster was installed.
+	InstalledHours float64 `json:"installedHours"`
+	// HibernatedHours is the total time the cluster has spent hibernating, including its current hibernating streak.
+	HibernatedHours float64 `json:"hibernatedHours"`
+	// HibernatingStreakHours is the time since the cluster last started hibernating, if it is hibernating.
+	HibernatingStreakHours float64 `json:"hibernatingStreakHours"`
+	// RunningStreakHours is the time since the cluster last became ready, if it is running.
+	RunningStreakHours float64 `json:"runningStreakHours"`
+	// HibernateAfter is the spec.hibernateAfter of the cluster, if set.
+	HibernateAfter string `json:"hibernateAfter,omitempty"`
+}
+
+func (c clusterHibernation) values() []string {
+	return []string{
+		c.Namespace,
+		c.Name,
+		c.ClusterType,
+		c.PowerState,
+		hours(c.InstalledHours),
+		hours(c.HibernatedHours),
+		hours(c.HibernatingStreakHours),
+		hours(c.RunningStreakHours),
+		c.HibernateAfter,
+	}
+}
+
+// NewHibernationReportCommand creates a command that generates and outputs the hibernation report.
+func NewHibernationReportCommand() *cobra.Command {
+
+	opt := &HibernationReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "hibernation",
+		Short: "Prints a report on how long installed clusters have spent hibernating or running",
+		Long: `Prints, for each installed cluster, its power state, the total hours it has spent hibernating and the hours
+of its current hibernating or running streak. The total is the status.hibernatedDuration of the ClusterDeployment, which
+Hive adds each hibernation to when the cluster resumes, plus the current hibernating streak, from the Hibernating and
+Ready conditions. See the cost report for the savings of hibernating.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.ClusterType, "cluster-type", "", "", "Only include clusters with the given hive.openshift.io/cluster-type label.")
+	flags.StringVarP(&opt.Output, "output", "o", outputTable, "Output format of the report. Valid values: table,csv,json")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *HibernationReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *HibernationReportOptions) Validate(cmd *cobra.Command) error {
+	return validateOutput(o.Output)
+}
+
+// Run executes the command
+func (o *HibernationReportOptions) Run(dynClient client.Client) error {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+
+	rows, err := o.report(dynClient, time.Now())
+	if err != nil {
+		return err
+	}
+	return writeReport(os.Stdout, o.Output, []string{
+		"NAMESPACE", "NAME", "CLUSTER TYPE", "POWER STATE", "INSTALLED HOURS", "HIBERNATED HOURS", "HIBERNATING STREAK HOURS", "RUNNING STREAK HOURS", "HIBERNATE AFTER",
+	}, rows)
+}
+
+// report returns the rows of the report, longest hibernated first.
+func (o *HibernationReportOptions) report(dynClient client.Client, now time.Time) ([]clusterHibernation, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := dynClient.List(context.Background(), cdList); err != nil {
+		return nil, err
+	}
+
+	var rows []clusterHibernation
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if !cd.Spec.Installed || cd.DeletionTimestamp != nil {
+			continue
+		}
+		ct := clusterType(cd)
+		if o.ClusterType != "" && ct != o.ClusterType {
+			continue
+		}
+
+		row := clusterHibernation{
+			Namespace:   cd.Namespace,
+			Name:        cd.Name,
+			ClusterType: ct,
+			PowerState:  string(cd.Status.PowerState),
+		}
+		installed := cd.CreationTimestamp.Time
+		if cd.Status.InstalledTimestamp != nil {
+			installed = cd.Status.InstalledTimestamp.Time
+		}
+		row.InstalledHours = now.Sub(installed).Hours()
+		row.HibernatedHours = hibernated(cd, now).Hours()
+		row.HibernatingStreakHours = hibernatingStreak(cd, now).Hours()
+		if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ClusterReadyCondition); cond != nil && cond.Status == corev1.ConditionTrue {
+			row.RunningStreakHours = now.Sub(cond.LastTransitionTime.Time).Hours()
+		}
+		if cd.Spec.HibernateAfter != nil {
+			row.HibernateAfter = cd.Spec.HibernateAfter.Duration.String()
+		}
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].HibernatedHours != rows[j].HibernatedHours {
+			return rows[i].HibernatedHours > rows[j].HibernatedHours
+		}
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows, nil
+}
+
+// hibernatingStreak returns the time since the cluster last started hibernating, or zero if it is not hibernating.
+func hibernatingStreak(cd *hivev1.ClusterDeployment, now time.Time) time.Duration {
+	cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if cond == nil || cond.Status != corev1.ConditionTrue {
+		return 0
+	}
+	return now.Sub(cond.LastTransitionTime.Time)
+}
+
+// hibernated returns the total time the cluster has spent hibernating: the duration of its earlier hibernations recorded
+// in its status, and its current hibernating streak.
+func hibernated(cd *hivev1.ClusterDeployment, now time.Time) time.Duration {
+	total := hibernatingStreak(cd, now)
+	if cd.Status.HibernatedDuration != nil {
+		total += cd.Status.HibernatedDuration.Duration
+	}
+	return total
+}
@@ -0,0 +1,144 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+// hibernatingFor sets the ClusterDeployment hibernating, and its Hibernating condition true since the duration ago.
+func hibernatingFor(d time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating)(cd)
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterHibernatingCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(testNow.Add(-d)),
+		})(cd)
+	}
+}
+
+// runningFor sets the ClusterDeployment running, and its Ready condition true since the duration ago.
+func runningFor(d time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		testcd.WithStatusPowerState(hivev1.ClusterPowerStateRunning)(cd)
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterHibernatingCondition,
+			Status:             corev1.ConditionFalse,
+			LastTransitionTime: metav1.NewTime(testNow.Add(-d)),
+		})(cd)
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:               hivev1.ClusterReadyCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(testNow.Add(-d)),
+		})(cd)
+	}
+}
+
+// hibernatedBefore sets the duration the ClusterDeployment spent hibernating before its current power state.
+func hibernatedBefore(d time.Duration) testcd.Option {
+	return func(cd *hivev1.ClusterDeployment) {
+		cd.Status.HibernatedDuration = &metav1.Duration{Duration: d}
+	}
+}
+
+func TestHibernationReport(t *testing.T) {
+	scheme := newScheme()
+	cdBuilder := func(namespace, name string) testcd.Builder {
+		return testcd.FullBuilder(namespace, name, scheme).Options(
+			testcd.InstalledTimestamp(testNow.Add(-10 * time.Hour)),
+		)
+	}
+
+	cases := []struct {
+		name        string
+		existing    []runtime.Object
+		clusterType string
+		expected    []clusterHibernation
+	}{
+		{
+			name: "no clusters",
+		},
+		{
+			name: "hibernating and running clusters",
+			existing: []runtime.Object{
+				cdBuilder("ns", "running").Build(runningFor(2*time.Hour), testcd.WithHibernateAfter(8*time.Hour)),
+				cdBuilder("ns", "short").Build(hibernatingFor(time.Hour)),
+				cdBuilder("ns", "resumed").Build(runningFor(time.Hour), hibernatedBefore(3*time.Hour)),
+				cdBuilder("ns", "repeated").Build(hibernatingFor(time.Hour), hibernatedBefore(5*time.Hour)),
+				cdBuilder("ns", "long").Build(hibernatingFor(4*time.Hour), testcd.WithLabel(hivev1.HiveClusterTypeLabel, "ci")),
+			},
+			expected: []clusterHibernation{
+				{Namespace: "ns", Name: "repeated", ClusterType: "unspecified", PowerState: "Hibernating", InstalledHours: 10, HibernatedHours: 6, HibernatingStreakHours: 1},
+				{Namespace: "ns", Name: "long", ClusterType: "ci", PowerState: "Hibernating", InstalledHours: 10, HibernatedHours: 4, HibernatingStreakHours: 4},
+				{Namespace: "ns", Name: "resumed", ClusterType: "unspecified", PowerState: "Running", InstalledHours: 10, HibernatedHours: 3, RunningStreakHours: 1},
+				{Namespace: "ns", Name: "short", ClusterType: "unspecified", PowerState: "Hibernating", InstalledHours: 10, HibernatedHours: 1, HibernatingStreakHours: 1},
+				{Namespace: "ns", Name: "running", ClusterType: "unspecified", PowerState: "Running", InstalledHours: 10, RunningStreakHours: 2, HibernateAfter: "8h0m0s"},
+			},
+		},
+		{
+			name: "ties ordered by namespace and name",
+			existing: []runtime.Object{
+				cdBuilder("ns-b", "a").Build(),
+				cdBuilder("ns-a", "b").Build(),
+				cdBuilder("ns-a", "a").Build(),
+			},
+			expected: []clusterHibernation{
+				{Namespace: "ns-a", Name: "a", ClusterType: "unspecified", InstalledHours: 10},
+				{Namespace: "ns-a", Name: "b", ClusterType: "unspecified", InstalledHours: 10},
+				{Namespace: "ns-b", Name: "a", ClusterType: "unspecified", InstalledHours: 10},
+			},
+		},
+		{
+			name: "installed hours from creation without installed timestamp",
+			existing: []runtime.Object{
+				testcd.FullBuilder("ns", "cd", scheme).Build(
+					testcd.Installed(),
+					testcd.Generic(testgeneric.WithCreationTimestamp(testNow.Add(-3*time.Hour))),
+				),
+			},
+			expected: []clusterHibernation{
+				{Namespace: "ns", Name: "cd", ClusterType: "unspecified", InstalledHours: 3},
+			},
+		},
+		{
+			name: "uninstalled and deleted clusters skipped",
+			existing: []runtime.Object{
+				testcd.FullBuilder("ns", "installing", scheme).Build(),
+				cdBuilder("ns", "deleted").GenericOptions(testgeneric.Deleted()).Build(hibernatingFor(time.Hour)),
+			},
+		},
+		{
+			name: "cluster type filter",
+			existing: []runtime.Object{
+				cdBuilder("ns", "ci").Build(hibernatingFor(time.Hour), testcd.WithLabel(hivev1.HiveClusterTypeLabel, "ci")),
+				cdBuilder("ns", "other").Build(hibernatingFor(time.Hour), testcd.WithLabel(hivev1.HiveClusterTypeLabel, "other")),
+				cdBuilder("ns", "untyped").Build(hibernatingFor(time.Hour)),
+			},
+			clusterType: "ci",
+			expected: []clusterHibernation{
+				{Namespace: "ns", Name: "ci", ClusterType: "ci", PowerState: "Hibernating", InstalledHours: 10, HibernatedHours: 1, HibernatingStreakHours: 1},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			opt := &HibernationReportOptions{ClusterType: tc.clusterType, Output: outputTable}
+			rows, err := opt.report(c, testNow)
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, rows, "unexpected report")
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package report
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// inventoryKeys are the ClusterDeployment labels the inventory can be grouped by.
+var inventoryKeys = map[string]string{
+	"version":      constants.VersionMajorMinorPatchLabel,
+	"minor":        constants.VersionMajorMinorLabel,
+	"platform":     hivev1.HiveClusterPlatformLabel,
+	"region":       hivev1.HiveClusterRegionLabel,
+	"cluster-type": hivev1.HiveClusterTypeLabel,
+}
+
+// InventoryReportOptions is the set of options for the desired report.
+type InventoryReportOptions struct {
+	// GroupBy are the keys the clusters are counted by.
+	GroupBy []string
+	// Output is the format of the report: table, csv or json.
+	Output string
+}
+
+// inventoryGroup is the number of clusters sharing the same values of the inventory keys.
+type inventoryGroup struct {
+	Group          map[string]string `json:"group"`
+	Clusters       int               `json:"clusters"`
+	Installed      int               `json:"installed"`
+	Hibernating    int               `json:"hibernating"`
+	Deprovisioning int               `json:"deprovisioning"`
+
+	// groupValues are the values of the group, in the order of the keys.
+	groupValues []string
+}
+
+func (g inventoryGroup) values() []string {
+	return append(append([]string{}, g.groupValues...),
+		strconv.Itoa(g.Clusters),
+		strconv.Itoa(g.Installed),
+		strconv.Itoa(g.Hibernating),
+		strconv.Itoa(g.Deprovisioning),
+	)
+}
+
+// NewInventoryReportCommand creates a command that generates and outputs the fleet inventory report.
+func NewInventoryReportCommand() *cobra.Command {
+
+	opt := &InventoryReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Prints a report on the number of clusters by version, platform and region",
+		Long: `Prints the number of clusters, and how many of them are installed, hibernating and deprovisioning, for each
+combination of the values of the --group-by keys. The keys are read from the ClusterDeployment labels:
+
+  version       hive.openshift.io/version-major-minor-patch
+  minor         hive.openshift.io/version-major-minor
+  platform      hive.openshift.io/cluster-platform
+  region        hive.openshift.io/cluster-region
+  cluster-type  hive.openshift.io/cluster-type`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringSliceVar(&opt.GroupBy, "group-by", []string{"version", "platform", "region"}, "Keys to count the clusters by. Valid values: version,minor,platform,region,cluster-type")
+	flags.StringVarP(&opt.Output, "output", "o", outputTable, "Output format of the report. Valid values: table,csv,json")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *InventoryReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *InventoryReportOptions) Validate(cmd *cobra.Command) error {
+	if len(o.GroupBy) == 0 {
+		return fmt.Errorf("at least one --group-by key is required")
+	}
+	for _, key := range o.GroupBy {
+		if _, ok := inventoryKeys[key]; !ok {
+			return fmt.Errorf("unsupported --group-by key %q", key)
+		}
+	}
+	return validateOutput(o.Output)
+}
+
+// Run executes the command
+func (o *InventoryReportOptions) Run(dynClient client.Client) error {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+
+	rows, err := o.report(dynClient)
+	if err != nil {
+		return err
+	}
+	headers := make([]string, 0, len(o.GroupBy)+4)
+	for _, key := range o.GroupBy {
+		headers = append(headers, strings.ToUpper(strings.ReplaceAll(key, "-", " ")))
+	}
+	headers = append(headers, "CLUSTERS", "INSTALLED", "HIBERNATING", "DEPROVISIONING")
+	return writeReport(os.Stdout, o.Output, headers, rows)
+}
+
+// report returns the rows of the report, ordered by the values of the group.
+func (o *InventoryReportOptions) report(dynClient client.Client) ([]inventoryGroup, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := dynClient.List(context.Background(), cdList); err != nil {
+		return nil, err
+	}
+
+	groups := map[string]*inventoryGroup{}
+	for _, cd := range cdList.Items {
+		group := &inventoryGroup{Group: make(map[string]string, len(o.GroupBy))}
+		for _, key := range o.GroupBy {
+			value, ok := cd.Labels[inventoryKeys[key]]
+			if !ok || value == "" {
+				value = "unspecified"
+			}
+			group.Group[key] = value
+			group.groupValues = append(group.groupValues, value)
+		}
+		id := strings.Join(group.groupValues, "\x00")
+		if existing, ok := groups[id]; ok {
+			group = existing
+		} else {
+			groups[id] = group
+		}
+
+		group.Clusters++
+		if cd.Spec.Installed {
+			group.Installed++
+		}
+		if cd.Status.PowerState == hivev1.ClusterPowerStateHibernating {
+			group.Hibernating++
+		}
+		if cd.DeletionTimestamp != nil {
+			group.Deprovisioning++
+		}
+	}
+
+	rows := make([]inventoryGroup, 0, len(groups))
+	for _, group := range groups {
+		rows = append(rows, *group)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		for k := range rows[i].groupValues {
+			if rows[i].groupValues[k] != rows[j].groupValues[k] {
+				return rows[i].groupValues[k] < rows[j].groupValues[k]
+			}
+		}
+		return false
+	})
+	return rows, nil
+}
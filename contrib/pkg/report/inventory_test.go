@@ -0,0 +1,148 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestInventoryReport(t *testing.T) {
+	scheme := newScheme()
+	cdBuilder := func(name, version, platform, region string) testcd.Builder {
+		return testcd.FullBuilder("ns", name, scheme).Options(
+			testcd.WithClusterVersion(version),
+			testcd.WithLabel(hivev1.HiveClusterPlatformLabel, platform),
+			testcd.WithLabel(hivev1.HiveClusterRegionLabel, region),
+		)
+	}
+
+	cases := []struct {
+		name     string
+		existing []runtime.Object
+		groupBy  []string
+		expected []inventoryGroup
+	}{
+		{
+			name:     "no clusters",
+			groupBy:  []string{"version", "platform", "region"},
+			expected: []inventoryGroup{},
+		},
+		{
+			name: "grouped by version, platform and region",
+			existing: []runtime.Object{
+				cdBuilder("cd-1", "4.10.3", "aws", "us-east-1").Build(testcd.Installed()),
+				cdBuilder("cd-2", "4.10.3", "aws", "us-east-1").Build(
+					testcd.Installed(),
+					testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating),
+				),
+				cdBuilder("cd-3", "4.10.3", "aws", "us-east-1").GenericOptions(testgeneric.Deleted()).Build(),
+				cdBuilder("cd-4", "4.10.3", "aws", "us-west-2").Build(testcd.Installed()),
+				cdBuilder("cd-5", "4.9.0", "gcp", "us-east1").Build(testcd.Installed()),
+			},
+			groupBy: []string{"version", "platform", "region"},
+			expected: []inventoryGroup{
+				{
+					Group:          map[string]string{"version": "4.10.3", "platform": "aws", "region": "us-east-1"},
+					Clusters:       3,
+					Installed:      2,
+					Hibernating:    1,
+					Deprovisioning: 1,
+					groupValues:    []string{"4.10.3", "aws", "us-east-1"},
+				},
+				{
+					Group:       map[string]string{"version": "4.10.3", "platform": "aws", "region": "us-west-2"},
+					Clusters:    1,
+					Installed:   1,
+					groupValues: []string{"4.10.3", "aws", "us-west-2"},
+				},
+				{
+					Group:       map[string]string{"version": "4.9.0", "platform": "gcp", "region": "us-east1"},
+					Clusters:    1,
+					Installed:   1,
+					groupValues: []string{"4.9.0", "gcp", "us-east1"},
+				},
+			},
+		},
+		{
+			name: "grouped by cluster type with missing labels",
+			existing: []runtime.Object{
+				cdBuilder("cd-1", "4.10.3", "aws", "us-east-1").Build(testcd.WithLabel(hivev1.HiveClusterTypeLabel, "ci")),
+				cdBuilder("cd-2", "4.10.3", "aws", "us-east-1").Build(testcd.WithLabel(hivev1.HiveClusterTypeLabel, "")),
+				testcd.FullBuilder("ns", "cd-3", scheme).Build(),
+			},
+			groupBy: []string{"cluster-type", "minor"},
+			expected: []inventoryGroup{
+				{
+					Group:       map[string]string{"cluster-type": "ci", "minor": "unspecified"},
+					Clusters:    1,
+					groupValues: []string{"ci", "unspecified"},
+				},
+				{
+					Group:       map[string]string{"cluster-type": "unspecified", "minor": "unspecified"},
+					Clusters:    2,
+					groupValues: []string{"unspecified", "unspecified"},
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			opt := &InventoryReportOptions{GroupBy: tc.groupBy, Output: outputTable}
+			rows, err := opt.report(c)
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, rows, "unexpected report")
+			}
+		})
+	}
+}
+
+func TestInventoryReportValidate(t *testing.T) {
+	cases := []struct {
+		name      string
+		groupBy   []string
+		output    string
+		expectErr bool
+	}{
+		{
+			name:    "valid",
+			groupBy: []string{"version", "minor", "platform", "region", "cluster-type"},
+			output:  outputCSV,
+		},
+		{
+			name:      "no keys",
+			output:    outputTable,
+			expectErr: true,
+		},
+		{
+			name:      "unsupported key",
+			groupBy:   []string{"version", "owner"},
+			output:    outputTable,
+			expectErr: true,
+		},
+		{
+			name:      "unsupported output",
+			groupBy:   []string{"version"},
+			output:    "yaml",
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			opt := &InventoryReportOptions{GroupBy: tc.groupBy, Output: tc.output}
+			err := opt.Validate(nil)
+			if tc.expectErr {
+				assert.Error(t, err, "expected error")
+			} else {
+				assert.NoError(t, err, "unexpected error")
+			}
+		})
+	}
+}
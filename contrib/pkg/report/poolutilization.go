@@ -0,0 +1,214 @@
+package report
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	contributils "github.com/openshift/hive/contrib/pkg/utils"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// PoolUtilizationReportOptions is the set of options for the desired report.
+type PoolUtilizationReportOptions struct {
+	// Namespace filters the report to only the ClusterPools in the namespace.
+	Namespace string
+	// Output is the format of the report: table, csv or json.
+	Output string
+}
+
+// poolUtilization is the utilization of a ClusterPool.
+type poolUtilization struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Size      int32  `json:"size"`
+	Ready     int32  `json:"ready"`
+	Standby   int32  `json:"standby"`
+	// ClaimsServed is the number of claims the pool has made a cluster ready for.
+	ClaimsServed int `json:"claimsServed"`
+	// ClaimsPending is the number of claims still waiting for a cluster.
+	ClaimsPending int `json:"claimsPending"`
+	// AverageWaitSeconds is the average time the served claims waited for their cluster.
+	AverageWaitSeconds float64 `json:"averageWaitSeconds"`
+	// MaxWaitSeconds is the longest time a claim waited, or has been waiting, for its cluster.
+	MaxWaitSeconds float64 `json:"maxWaitSeconds"`
+	// StaleClusters is the number of unclaimed clusters that do not match the current pool configuration, and so are
+	// replaced without ever being claimed.
+	StaleClusters int `json:"staleClusters"`
+}
+
+func (p poolUtilization) values() []string {
+	return []string{
+		p.Namespace,
+		p.Name,
+		strconv.Itoa(int(p.Size)),
+		strconv.Itoa(int(p.Ready)),
+		strconv.Itoa(int(p.Standby)),
+		strconv.Itoa(p.ClaimsServed),
+		strconv.Itoa(p.ClaimsPending),
+		(time.Duration(p.AverageWaitSeconds) * time.Second).String(),
+		(time.Duration(p.MaxWaitSeconds) * time.Second).String(),
+		strconv.Itoa(p.StaleClusters),
+	}
+}
+
+// NewPoolUtilizationReportCommand creates a command that generates and outputs the pool utilization report.
+func NewPoolUtilizationReportCommand() *cobra.Command {
+
+	opt := &PoolUtilizationReportOptions{}
+	cmd := &cobra.Command{
+		Use:   "pool-utilization",
+		Short: "Prints a report on the claims served by each cluster pool and the clusters wasted by stale pool versions",
+		Long: `Prints, for each cluster pool, the claims it served and how long they waited for a running cluster, the
+claims still waiting, and the unclaimed clusters that no longer match the pool configuration. Claims are only
+counted while they exist.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetLevel(log.InfoLevel)
+			if err := opt.Complete(cmd, args); err != nil {
+				return
+			}
+
+			if err := opt.Validate(cmd); err != nil {
+				log.WithError(err).Fatal("invalid options")
+			}
+
+			dynClient, err := contributils.GetClient()
+			if err != nil {
+				log.WithError(err).Fatal("error creating kube clients")
+			}
+
+			err = opt.Run(dynClient)
+			if err != nil {
+				log.WithError(err).Fatal("Error")
+			}
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&opt.Namespace, "namespace", "n", "", "Only include cluster pools in the given namespace.")
+	flags.StringVarP(&opt.Output, "output", "o", outputTable, "Output format of the report. Valid values: table,csv,json")
+	return cmd
+}
+
+// Complete finishes parsing arguments for the command
+func (o *PoolUtilizationReportOptions) Complete(cmd *cobra.Command, args []string) error {
+	return nil
+}
+
+// Validate ensures that option values make sense
+func (o *PoolUtilizationReportOptions) Validate(cmd *cobra.Command) error {
+	return validateOutput(o.Output)
+}
+
+// Run executes the command
+func (o *PoolUtilizationReportOptions) Run(dynClient client.Client) error {
+	if err := apis.AddToScheme(scheme.Scheme); err != nil {
+		return err
+	}
+
+	rows, err := o.report(dynClient, time.Now())
+	if err != nil {
+		return err
+	}
+	return writeReport(os.Stdout, o.Output, []string{
+		"NAMESPACE", "NAME", "SIZE", "READY", "STANDBY", "CLAIMS SERVED", "CLAIMS PENDING", "AVERAGE WAIT", "MAX WAIT", "STALE CLUSTERS",
+	}, rows)
+}
+
+// report returns the rows of the report, ordered by namespace and name.
+func (o *PoolUtilizationReportOptions) report(dynClient client.Client, now time.Time) ([]poolUtilization, error) {
+	var listOpts []client.ListOption
+	if o.Namespace != "" {
+		listOpts = append(listOpts, client.InNamespace(o.Namespace))
+	}
+	poolList := &hivev1.ClusterPoolList{}
+	if err := dynClient.List(context.Background(), poolList, listOpts...); err != nil {
+		return nil, err
+	}
+	claimList := &hivev1.ClusterClaimList{}
+	if err := dynClient.List(context.Background(), claimList, listOpts...); err != nil {
+		return nil, err
+	}
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := dynClient.List(context.Background(), cdList); err != nil {
+		return nil, err
+	}
+
+	pools := make(map[types.NamespacedName]*poolUtilization, len(poolList.Items))
+	poolVersions := make(map[types.NamespacedName]string, len(poolList.Items))
+	for i := range poolList.Items {
+		pool := &poolList.Items[i]
+		key := types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}
+		pools[key] = &poolUtilization{
+			Namespace: pool.Namespace,
+			Name:      pool.Name,
+			Size:      pool.Spec.Size,
+			Ready:     pool.Status.Ready,
+			Standby:   pool.Status.Standby,
+		}
+		poolVersions[key] = controllerutils.CalculatePoolVersion(pool)
+	}
+
+	for _, cd := range cdList.Items {
+		ref := cd.Spec.ClusterPoolRef
+		if ref == nil || ref.ClaimName != "" {
+			continue
+		}
+		key := types.NamespacedName{Namespace: ref.Namespace, Name: ref.PoolName}
+		pool, ok := pools[key]
+		if !ok {
+			continue
+		}
+		if cd.Annotations[constants.ClusterDeploymentPoolSpecHashAnnotation] != poolVersions[key] {
+			pool.StaleClusters++
+		}
+	}
+
+	waits := make(map[types.NamespacedName]time.Duration, len(pools))
+	for _, claim := range claimList.Items {
+		key := types.NamespacedName{Namespace: claim.Namespace, Name: claim.Spec.ClusterPoolName}
+		pool, ok := pools[key]
+		if !ok {
+			continue
+		}
+		var wait time.Duration
+		if cond := controllerutils.FindCondition(claim.Status.Conditions, hivev1.ClusterClaimPendingCondition); cond != nil && cond.Status == corev1.ConditionFalse {
+			pool.ClaimsServed++
+			wait = cond.LastTransitionTime.Sub(claim.CreationTimestamp.Time)
+			waits[key] += wait
+		} else {
+			pool.ClaimsPending++
+			wait = now.Sub(claim.CreationTimestamp.Time)
+		}
+		if wait.Seconds() > pool.MaxWaitSeconds {
+			pool.MaxWaitSeconds = wait.Round(time.Second).Seconds()
+		}
+	}
+
+	rows := make([]poolUtilization, 0, len(pools))
+	for key, pool := range pools {
+		if pool.ClaimsServed > 0 {
+			pool.AverageWaitSeconds = (waits[key] / time.Duration(pool.ClaimsServed)).Round(time.Second).Seconds()
+		}
+		rows = append(rows, *pool)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Namespace != rows[j].Namespace {
+			return rows[i].Namespace < rows[j].Namespace
+		}
+		return rows[i].Name < rows[j].Name
+	})
+	return rows, nil
+}
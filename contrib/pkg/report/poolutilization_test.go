@@ -0,0 +1,157 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	testcc "github.com/openshift/hive/pkg/test/clusterclaim"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+func TestPoolUtilizationReport(t *testing.T) {
+	scheme := newScheme()
+	poolBuilder := func(namespace, name string) testcp.Builder {
+		return testcp.FullBuilder(namespace, name, scheme).Options(
+			testcp.ForAWS("aws-creds", "us-east-1"),
+			testcp.WithSize(3),
+		)
+	}
+	pool := poolBuilder("pools", "pool").Build(func(pool *hivev1.ClusterPool) {
+		pool.Status.Ready = 2
+		pool.Status.Standby = 1
+	})
+	poolVersion := controllerutils.CalculatePoolVersion(pool)
+	// claim creates a claim for the pool created the duration ago, served after waiting for the wait if it is not zero
+	claim := func(namespace, name, poolName string, age, wait time.Duration) runtime.Object {
+		opts := []testcc.Option{
+			testcc.WithPool(poolName),
+			testcc.Generic(testgeneric.WithCreationTimestamp(testNow.Add(-age))),
+		}
+		if wait != 0 {
+			opts = append(opts, testcc.WithCondition(hivev1.ClusterClaimCondition{
+				Type:               hivev1.ClusterClaimPendingCondition,
+				Status:             corev1.ConditionFalse,
+				LastTransitionTime: metav1.NewTime(testNow.Add(-age + wait)),
+			}))
+		}
+		return testcc.FullBuilder(namespace, name, scheme).Build(opts...)
+	}
+
+	cases := []struct {
+		name      string
+		existing  []runtime.Object
+		namespace string
+		expected  []poolUtilization
+	}{
+		{
+			name:     "no pools",
+			expected: []poolUtilization{},
+		},
+		{
+			name: "served and pending claims",
+			existing: []runtime.Object{
+				pool,
+				claim("pools", "served-1", "pool", 2*time.Hour, 10*time.Minute),
+				claim("pools", "served-2", "pool", time.Hour, 20*time.Minute),
+				claim("pools", "pending", "pool", 5*time.Minute, 0),
+				claim("pools", "other-pool", "missing", 2*time.Hour, 0),
+			},
+			expected: []poolUtilization{
+				{
+					Namespace:          "pools",
+					Name:               "pool",
+					Size:               3,
+					Ready:              2,
+					Standby:            1,
+					ClaimsServed:       2,
+					ClaimsPending:      1,
+					AverageWaitSeconds: (15 * time.Minute).Seconds(),
+					MaxWaitSeconds:     (20 * time.Minute).Seconds(),
+				},
+			},
+		},
+		{
+			name: "pending claim waiting longest",
+			existing: []runtime.Object{
+				pool,
+				claim("pools", "served", "pool", 2*time.Hour, 10*time.Minute),
+				claim("pools", "pending", "pool", time.Hour, 0),
+			},
+			expected: []poolUtilization{
+				{
+					Namespace:          "pools",
+					Name:               "pool",
+					Size:               3,
+					Ready:              2,
+					Standby:            1,
+					ClaimsServed:       1,
+					ClaimsPending:      1,
+					AverageWaitSeconds: (10 * time.Minute).Seconds(),
+					MaxWaitSeconds:     time.Hour.Seconds(),
+				},
+			},
+		},
+		{
+			name: "stale unclaimed clusters",
+			existing: []runtime.Object{
+				pool,
+				testcd.FullBuilder("cd-1", "cd-1", scheme).Build(
+					testcd.WithUnclaimedClusterPoolReference("pools", "pool"),
+					testcd.WithPoolVersion(poolVersion),
+				),
+				testcd.FullBuilder("cd-2", "cd-2", scheme).Build(
+					testcd.WithUnclaimedClusterPoolReference("pools", "pool"),
+					testcd.WithPoolVersion("stale"),
+				),
+				testcd.FullBuilder("cd-3", "cd-3", scheme).Build(
+					testcd.WithUnclaimedClusterPoolReference("pools", "pool"),
+				),
+				testcd.FullBuilder("cd-4", "cd-4", scheme).Build(
+					testcd.WithClusterPoolReference("pools", "pool", "claim"),
+					testcd.WithPoolVersion("stale"),
+				),
+				testcd.FullBuilder("cd-5", "cd-5", scheme).Build(
+					testcd.WithUnclaimedClusterPoolReference("pools", "missing"),
+				),
+			},
+			expected: []poolUtilization{
+				{Namespace: "pools", Name: "pool", Size: 3, Ready: 2, Standby: 1, StaleClusters: 2},
+			},
+		},
+		{
+			name: "namespace filter and ordering",
+			existing: []runtime.Object{
+				poolBuilder("pools-b", "pool").Build(),
+				poolBuilder("pools-a", "pool-2").Build(),
+				poolBuilder("pools-a", "pool-1").Build(),
+				claim("pools-b", "served", "pool", time.Hour, time.Minute),
+			},
+			namespace: "pools-a",
+			expected: []poolUtilization{
+				{Namespace: "pools-a", Name: "pool-1", Size: 3},
+				{Namespace: "pools-a", Name: "pool-2", Size: 3},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			opt := &PoolUtilizationReportOptions{Namespace: tc.namespace, Output: outputTable}
+			rows, err := opt.report(c, testNow)
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, rows, "unexpected report")
+			}
+		})
+	}
+}
@@ -1,7 +1,23 @@
 package report
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
 	"github.com/spf13/cobra"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+const (
+	outputTable = "table"
+	outputCSV   = "csv"
+	outputJSON  = "json"
 )
 
 // NewClusterReportCommand creates a command that generates and outputs the cluster report.
@@ -16,5 +32,78 @@ func NewClusterReportCommand() *cobra.Command {
 	}
 	cmd.AddCommand(NewProvisioningReportCommand())
 	cmd.AddCommand(NewDeprovisioningReportCommand())
+	cmd.AddCommand(NewPoolUtilizationReportCommand())
+	cmd.AddCommand(NewHibernationReportCommand())
+	cmd.AddCommand(NewInventoryReportCommand())
+	cmd.AddCommand(NewCostReportCommand())
 	return cmd
 }
+
+// reportRow is a row of a report that can be output as a table, CSV or JSON.
+type reportRow interface {
+	// values returns the values of the row for the table and CSV columns.
+	values() []string
+}
+
+// validateOutput ensures the output format of a report is supported.
+func validateOutput(output string) error {
+	switch output {
+	case outputTable, outputCSV, outputJSON:
+		return nil
+	}
+	return fmt.Errorf("unsupported output %q, valid values: %s, %s, %s", output, outputTable, outputCSV, outputJSON)
+}
+
+// writeReport writes the rows of a report in the output format. Table and CSV outputs have a column per header, and
+// JSON output is the list of rows.
+func writeReport[T reportRow](out io.Writer, output string, headers []string, rows []T) error {
+	switch output {
+	case outputJSON:
+		if rows == nil {
+			rows = []T{}
+		}
+		b, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	case outputCSV:
+		w := csv.NewWriter(out)
+		if err := w.Write(headers); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := w.Write(row.values()); err != nil {
+				return err
+			}
+		}
+		w.Flush()
+		return w.Error()
+	default:
+		w := printers.GetNewTabWriter(out)
+		fmt.Fprintln(w, strings.Join(headers, "\t"))
+		for _, row := range rows {
+			fmt.Fprintln(w, strings.Join(row.values(), "\t"))
+		}
+		return w.Flush()
+	}
+}
+
+// hours formats a number of hours for the table and CSV outputs.
+func hours(h float64) string {
+	return fmt.Sprintf("%.2f", h)
+}
+
+// amount formats a cost for the table and CSV outputs.
+func amount(a float64) string {
+	return fmt.Sprintf("%.2f", a)
+}
+
+// clusterType returns the hive.openshift.io/cluster-type label of a ClusterDeployment, or "unspecified" if it has none.
+func clusterType(cd *hivev1.ClusterDeployment) string {
+	if ct, ok := cd.Labels[hivev1.HiveClusterTypeLabel]; ok {
+		return ct
+	}
+	return "unspecified"
+}
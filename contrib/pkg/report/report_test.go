@@ -0,0 +1,114 @@
+package report
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// testNow is the time the reports are generated at in the tests.
+var testNow = time.Date(2022, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	return scheme
+}
+
+type testRow struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func (r testRow) values() []string {
+	return []string{r.Name, strconv.Itoa(r.Count)}
+}
+
+func TestWriteReport(t *testing.T) {
+	rows := []testRow{{Name: "first", Count: 1}, {Name: "second, with a comma", Count: 22}}
+
+	cases := []struct {
+		name     string
+		output   string
+		rows     []testRow
+		expected string
+	}{
+		{
+			name:   "table",
+			output: outputTable,
+			rows:   rows,
+			expected: `NAME                   COUNT
+first                  1
+second, with a comma   22
+`,
+		},
+		{
+			name:     "table without rows",
+			output:   outputTable,
+			expected: "NAME   COUNT\n",
+		},
+		{
+			name:   "csv",
+			output: outputCSV,
+			rows:   rows,
+			expected: `NAME,COUNT
+first,1
+"second, with a comma",22
+`,
+		},
+		{
+			name:     "csv without rows",
+			output:   outputCSV,
+			expected: "NAME,COUNT\n",
+		},
+		{
+			name:   "json",
+			output: outputJSON,
+			rows:   rows,
+			expected: `[
+  {
+    "name": "first",
+    "count": 1
+  },
+  {
+    "name": "second, with a comma",
+    "count": 22
+  }
+]
+`,
+		},
+		{
+			name:     "json without rows",
+			output:   outputJSON,
+			expected: "[]\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			if assert.NoError(t, writeReport(out, tc.output, []string{"NAME", "COUNT"}, tc.rows), "unexpected error") {
+				assert.Equal(t, tc.expected, out.String(), "unexpected output")
+			}
+		})
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	for _, output := range []string{outputTable, outputCSV, outputJSON} {
+		assert.NoError(t, validateOutput(output), "unexpected error for output %s", output)
+	}
+	err := validateOutput("yaml")
+	if assert.Error(t, err, "expected error for unsupported output") {
+		assert.True(t, strings.Contains(err.Error(), `unsupported output "yaml"`), "unexpected error: %v", err)
+	}
+}
@@ -557,6 +557,33 @@ func NewClient(kubeClient client.Client, secretName, namespace, region string) (
 	return NewClientFromSecret(secret, region)
 }
 
+// NewClientWithS3Endpoint creates a client like NewClient whose S3 operations are sent to the S3 compatible service
+// at endpoint, such as MinIO, addressing buckets by path. The other clients are unaffected.
+func NewClientWithS3Endpoint(kubeClient client.Client, secretName, namespace, region, endpoint string) (Client, error) {
+	var secret *corev1.Secret
+	if secretName != "" {
+		secret = &corev1.Secret{}
+		if err := kubeClient.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+			return nil, err
+		}
+	}
+	s, err := NewSessionFromSecret(secret, region)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create AWS session")
+	}
+	c, err := newClientFromSession(s)
+	if err != nil {
+		return nil, err
+	}
+	ac := c.(*awsClient)
+	ac.s3Client = s3.New(s, &aws.Config{
+		Endpoint:         aws.String(endpoint),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	ac.s3Uploader = s3manager.NewUploaderWithClient(ac.s3Client)
+	return ac, nil
+}
+
 // NewClientFromSecret creates our client wrapper object for the actual AWS clients we use.
 // For authentication the underlying clients will use either the cluster AWS credentials
 // secret if defined (i.e. in the root cluster),
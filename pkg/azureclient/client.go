@@ -10,6 +10,7 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/azure"
 	"github.com/Azure/go-autorest/autorest/azure/auth"
@@ -47,6 +48,10 @@ type Client interface {
 
 	// Images
 	ListImagesByResourceGroup(ctx context.Context, resourceGroupName string) (ImageListResultPage, error)
+
+	// Resources
+	ListResources(ctx context.Context, filter string) (ResourceListResultPage, error)
+	ListResourcesByResourceGroup(ctx context.Context, resourceGroupName string, filter string) (ResourceListResultPage, error)
 }
 
 // ResourceSKUsPage is a page of results from listing resource SKUs.
@@ -69,12 +74,20 @@ type ImageListResultPage interface {
 	Values() []compute.Image
 }
 
+// ResourceListResultPage is a page of results from listing generic resources.
+type ResourceListResultPage interface {
+	NextWithContext(ctx context.Context) error
+	NotDone() bool
+	Values() []resources.GenericResourceExpanded
+}
+
 type azureClient struct {
 	resourceSKUsClient    *compute.ResourceSkusClient
 	recordSetsClient      *dns.RecordSetsClient
 	zonesClient           *dns.ZonesClient
 	virtualMachinesClient *compute.VirtualMachinesClient
 	imagesClient          *compute.ImagesClient
+	resourcesClient       *resources.Client
 }
 
 func (c *azureClient) ListResourceSKUs(ctx context.Context, filter string) (ResourceSKUsPage, error) {
@@ -182,6 +195,16 @@ func (c *azureClient) ListImagesByResourceGroup(ctx context.Context, resourceGro
 	return &page, err
 }
 
+func (c *azureClient) ListResources(ctx context.Context, filter string) (ResourceListResultPage, error) {
+	page, err := c.resourcesClient.List(ctx, filter, "", nil)
+	return &page, err
+}
+
+func (c *azureClient) ListResourcesByResourceGroup(ctx context.Context, resourceGroupName string, filter string) (ResourceListResultPage, error) {
+	page, err := c.resourcesClient.ListByResourceGroup(ctx, resourceGroupName, filter, "", nil)
+	return &page, err
+}
+
 // NewClientFromSecret creates our client wrapper object for interacting with Azure. The Azure creds are read from the
 // specified secret.
 func NewClientFromSecret(secret *corev1.Secret, environmentName string) (Client, error) {
@@ -254,12 +277,16 @@ func newClient(authJSONSource func() ([]byte, error), environmentName string) (*
 	imagesClient := compute.NewImagesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
 	imagesClient.Authorizer = authorizer
 
+	resourcesClient := resources.NewClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	resourcesClient.Authorizer = authorizer
+
 	return &azureClient{
 		resourceSKUsClient:    &resourceSKUsClient,
 		recordSetsClient:      &recordSetsClient,
 		zonesClient:           &zonesClient,
 		virtualMachinesClient: &virtualMachinesClient,
 		imagesClient:          &imagesClient,
+		resourcesClient:       &resourcesClient,
 	}, nil
 }
 
@@ -52,6 +52,10 @@ func (c *FakeHiveV1) ClusterStates(namespace string) v1.ClusterStateInterface {
 	return &FakeClusterStates{c, namespace}
 }
 
+func (c *FakeHiveV1) ClusterUpgradeCampaigns() v1.ClusterUpgradeCampaignInterface {
+	return &FakeClusterUpgradeCampaigns{c}
+}
+
 func (c *FakeHiveV1) DNSZones(namespace string) v1.DNSZoneInterface {
 	return &FakeDNSZones{c, namespace}
 }
@@ -22,6 +22,8 @@ type ClusterRelocateExpansion interface{}
 
 type ClusterStateExpansion interface{}
 
+type ClusterUpgradeCampaignExpansion interface{}
+
 type DNSZoneExpansion interface{}
 
 type HiveConfigExpansion interface{}
@@ -22,6 +22,7 @@ type HiveV1Interface interface {
 	ClusterProvisionsGetter
 	ClusterRelocatesGetter
 	ClusterStatesGetter
+	ClusterUpgradeCampaignsGetter
 	DNSZonesGetter
 	HiveConfigsGetter
 	MachinePoolsGetter
@@ -77,6 +78,10 @@ func (c *HiveV1Client) ClusterStates(namespace string) ClusterStateInterface {
 	return newClusterStates(c, namespace)
 }
 
+func (c *HiveV1Client) ClusterUpgradeCampaigns() ClusterUpgradeCampaignInterface {
+	return newClusterUpgradeCampaigns(c)
+}
+
 func (c *HiveV1Client) DNSZones(namespace string) DNSZoneInterface {
 	return newDNSZones(c, namespace)
 }
@@ -58,6 +58,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterRelocates().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("clusterstates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterStates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("clusterupgradecampaigns"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().ClusterUpgradeCampaigns().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("dnszones"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Hive().V1().DNSZones().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("hiveconfigs"):
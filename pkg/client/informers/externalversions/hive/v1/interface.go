@@ -28,6 +28,8 @@ type Interface interface {
 	ClusterRelocates() ClusterRelocateInformer
 	// ClusterStates returns a ClusterStateInformer.
 	ClusterStates() ClusterStateInformer
+	// ClusterUpgradeCampaigns returns a ClusterUpgradeCampaignInformer.
+	ClusterUpgradeCampaigns() ClusterUpgradeCampaignInformer
 	// DNSZones returns a DNSZoneInformer.
 	DNSZones() DNSZoneInformer
 	// HiveConfigs returns a HiveConfigInformer.
@@ -107,6 +109,11 @@ func (v *version) ClusterStates() ClusterStateInformer {
 	return &clusterStateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterUpgradeCampaigns returns a ClusterUpgradeCampaignInformer.
+func (v *version) ClusterUpgradeCampaigns() ClusterUpgradeCampaignInformer {
+	return &clusterUpgradeCampaignInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSZones returns a DNSZoneInformer.
 func (v *version) DNSZones() DNSZoneInformer {
 	return &dNSZoneInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
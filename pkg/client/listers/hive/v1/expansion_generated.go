@@ -74,6 +74,10 @@ type ClusterStateListerExpansion interface{}
 // ClusterStateNamespaceLister.
 type ClusterStateNamespaceListerExpansion interface{}
 
+// ClusterUpgradeCampaignListerExpansion allows custom methods to be added to
+// ClusterUpgradeCampaignLister.
+type ClusterUpgradeCampaignListerExpansion interface{}
+
 // DNSZoneListerExpansion allows custom methods to be added to
 // DNSZoneLister.
 type DNSZoneListerExpansion interface{}
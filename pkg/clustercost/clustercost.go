@@ -0,0 +1,170 @@
+package clustercost
+
+import (
+	"context"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// PriceTableEntry is an entry of the price table in the cluster cost ConfigMap.
+type PriceTableEntry struct {
+	// Platform is the platform the entry applies to, as in the hive.openshift.io/cluster-platform label of
+	// ClusterDeployments.
+	Platform string `json:"platform"`
+	// Region, when set, restricts the entry to clusters in that region. Entries for a region take precedence over
+	// entries for all regions.
+	Region string `json:"region,omitempty"`
+	// InstanceType is the MachinePool instance type the entry applies to, and its costs are per replica. When empty,
+	// the entry is the cost of the rest of the cluster: control plane, load balancers, storage, etc.
+	InstanceType string `json:"instanceType,omitempty"`
+	// HourlyCost is the cost of an hour of the cluster running.
+	HourlyCost float64 `json:"hourlyCost"`
+	// HibernatingHourlyCost is the cost of an hour of the cluster hibernating.
+	HibernatingHourlyCost float64 `json:"hibernatingHourlyCost,omitempty"`
+}
+
+// PriceTable is the price table of the cluster cost ConfigMap, which the cost of ClusterDeployments is estimated from.
+type PriceTable []PriceTableEntry
+
+// Load loads the price table from the cluster cost ConfigMap in the namespace. It returns false if the ConfigMap does
+// not exist.
+func Load(c client.Client, namespace string) (PriceTable, bool, error) {
+	cm := &corev1.ConfigMap{}
+	err := c.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: constants.ClusterCostPricesConfigMapName}, cm)
+	if apierrors.IsNotFound(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "could not get ConfigMap %s", constants.ClusterCostPricesConfigMapName)
+	}
+	raw, ok := cm.Data[constants.ClusterCostPricesDataKey]
+	if !ok {
+		return nil, false, errors.Errorf("ConfigMap %s does not have a %q data entry", cm.Name, constants.ClusterCostPricesDataKey)
+	}
+	prices, err := Parse(raw)
+	if err != nil {
+		return nil, false, err
+	}
+	return prices, true, nil
+}
+
+// Parse parses and validates the price table of the cluster cost ConfigMap.
+func Parse(raw string) (PriceTable, error) {
+	var prices PriceTable
+	if err := yaml.Unmarshal([]byte(raw), &prices); err != nil {
+		return nil, errors.Wrap(err, "could not parse the price table")
+	}
+	for i, p := range prices {
+		if p.Platform == "" {
+			return nil, errors.Errorf("price table entry %d has no platform", i)
+		}
+		if p.HourlyCost < 0 || p.HibernatingHourlyCost < 0 {
+			return nil, errors.Errorf("price table entry %d has a negative cost", i)
+		}
+	}
+	return prices, nil
+}
+
+// lookup returns the entry for the instance type on the platform in the region, or nil if there is none.
+func (p PriceTable) lookup(platform, region, instanceType string) *PriceTableEntry {
+	var match *PriceTableEntry
+	for i := range p {
+		e := &p[i]
+		if e.Platform != platform || e.InstanceType != instanceType {
+			continue
+		}
+		switch e.Region {
+		case region:
+			return e
+		case "":
+			match = e
+		}
+	}
+	return match
+}
+
+// HourlyCosts estimates the hourly costs of a ClusterDeployment running and hibernating, from the cost of the rest of
+// the cluster and of the replicas of its MachinePools. It returns false if no entry of the price table applies to the
+// cluster.
+func (p PriceTable) HourlyCosts(cd *hivev1.ClusterDeployment, pools []hivev1.MachinePool) (running float64, hibernating float64, priced bool) {
+	platform := cd.Labels[hivev1.HiveClusterPlatformLabel]
+	region := cd.Labels[hivev1.HiveClusterRegionLabel]
+
+	if e := p.lookup(platform, region, ""); e != nil {
+		running += e.HourlyCost
+		hibernating += e.HibernatingHourlyCost
+		priced = true
+	}
+	for i := range pools {
+		instanceType := machinePoolInstanceType(&pools[i])
+		if instanceType == "" {
+			continue
+		}
+		if e := p.lookup(platform, region, instanceType); e != nil {
+			replicas := float64(machinePoolReplicas(&pools[i]))
+			running += e.HourlyCost * replicas
+			hibernating += e.HibernatingHourlyCost * replicas
+			priced = true
+		}
+	}
+	return running, hibernating, priced
+}
+
+// HourlyCost estimates the hourly cost of a ClusterDeployment in its current power state, at the hibernating prices if
+// the cluster is hibernating. It returns false if no entry of the price table applies to the cluster.
+func (p PriceTable) HourlyCost(cd *hivev1.ClusterDeployment, pools []hivev1.MachinePool) (float64, bool) {
+	running, hibernating, priced := p.HourlyCosts(cd, pools)
+	if cd.Status.PowerState == hivev1.ClusterPowerStateHibernating {
+		return hibernating, priced
+	}
+	return running, priced
+}
+
+// MachinePoolsByClusterDeployment groups MachinePools by the ClusterDeployment they belong to.
+func MachinePoolsByClusterDeployment(pools []hivev1.MachinePool) map[types.NamespacedName][]hivev1.MachinePool {
+	byCD := make(map[types.NamespacedName][]hivev1.MachinePool)
+	for _, mp := range pools {
+		key := types.NamespacedName{Namespace: mp.Namespace, Name: mp.Spec.ClusterDeploymentRef.Name}
+		byCD[key] = append(byCD[key], mp)
+	}
+	return byCD
+}
+
+// machinePoolInstanceType returns the instance type of the machines of a MachinePool, or an empty string for platforms
+// without instance types.
+func machinePoolInstanceType(pool *hivev1.MachinePool) string {
+	p := pool.Spec.Platform
+	switch {
+	case p.AWS != nil:
+		return p.AWS.InstanceType
+	case p.Azure != nil:
+		return p.Azure.InstanceType
+	case p.GCP != nil:
+		return p.GCP.InstanceType
+	case p.OpenStack != nil:
+		return p.OpenStack.Flavor
+	case p.IBMCloud != nil:
+		return p.IBMCloud.InstanceType
+	case p.AlibabaCloud != nil:
+		return p.AlibabaCloud.InstanceType
+	}
+	return ""
+}
+
+// machinePoolReplicas returns the number of replicas of a MachinePool. The current number of replicas is used for
+// autoscaling MachinePools.
+func machinePoolReplicas(pool *hivev1.MachinePool) int64 {
+	if pool.Spec.Replicas != nil {
+		return *pool.Spec.Replicas
+	}
+	return int64(pool.Status.Replicas)
+}
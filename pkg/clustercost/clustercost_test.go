@@ -0,0 +1,197 @@
+package clustercost
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcm "github.com/openshift/hive/pkg/test/configmap"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+)
+
+const testPriceTable = `
+- platform: aws
+  hourlyCost: 2
+  hibernatingHourlyCost: 0.5
+- platform: aws
+  region: us-west-2
+  hourlyCost: 3
+  hibernatingHourlyCost: 0.5
+- platform: aws
+  instanceType: m5.xlarge
+  hourlyCost: 0.25
+`
+
+func TestLoad(t *testing.T) {
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+
+	cases := []struct {
+		name          string
+		existing      []runtime.Object
+		expectedFound bool
+		expectedErr   string
+	}{{
+		name: "no configmap",
+	}, {
+		name: "valid price table",
+		existing: []runtime.Object{
+			testcm.FullBuilder(constants.DefaultHiveNamespace, constants.ClusterCostPricesConfigMapName, scheme).
+				Build(testcm.WithDataKeyValue(constants.ClusterCostPricesDataKey, testPriceTable)),
+		},
+		expectedFound: true,
+	}, {
+		name: "missing data entry",
+		existing: []runtime.Object{
+			testcm.FullBuilder(constants.DefaultHiveNamespace, constants.ClusterCostPricesConfigMapName, scheme).Build(),
+		},
+		expectedErr: `does not have a "prices" data entry`,
+	}, {
+		name: "invalid price table",
+		existing: []runtime.Object{
+			testcm.FullBuilder(constants.DefaultHiveNamespace, constants.ClusterCostPricesConfigMapName, scheme).
+				Build(testcm.WithDataKeyValue(constants.ClusterCostPricesDataKey, "- hourlyCost: 2")),
+		},
+		expectedErr: "price table entry 0 has no platform",
+	}}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
+			prices, found, err := Load(c, constants.DefaultHiveNamespace)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedFound, found)
+			if test.expectedFound {
+				assert.Len(t, prices, 3)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name        string
+		raw         string
+		expected    PriceTable
+		expectedErr string
+	}{{
+		name: "valid",
+		raw:  "- platform: aws\n  region: us-east-1\n  instanceType: m5.xlarge\n  hourlyCost: 0.192\n  hibernatingHourlyCost: 0.01",
+		expected: PriceTable{{
+			Platform:              "aws",
+			Region:                "us-east-1",
+			InstanceType:          "m5.xlarge",
+			HourlyCost:            0.192,
+			HibernatingHourlyCost: 0.01,
+		}},
+	}, {
+		name:        "malformed",
+		raw:         "malformed",
+		expectedErr: "could not parse the price table",
+	}, {
+		name:        "no platform",
+		raw:         "- hourlyCost: 1",
+		expectedErr: "price table entry 0 has no platform",
+	}, {
+		name:        "negative cost",
+		raw:         "- platform: aws\n- platform: gcp\n  hibernatingHourlyCost: -1",
+		expectedErr: "price table entry 1 has a negative cost",
+	}}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			prices, err := Parse(test.raw)
+			if test.expectedErr != "" {
+				assert.ErrorContains(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, prices)
+			}
+		})
+	}
+}
+
+func TestHourlyCosts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	prices, err := Parse(testPriceTable)
+	require.NoError(t, err)
+
+	cdBuilder := testcd.FullBuilder("cd-1", "cd-1", scheme).Options(
+		testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+		testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+	)
+	workers := func(instanceType string, replicas int64) hivev1.MachinePool {
+		return *testmp.FullBuilder("cd-1", "worker", "cd-1", scheme).Build(func(mp *hivev1.MachinePool) {
+			mp.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{InstanceType: instanceType}
+			mp.Spec.Replicas = pointer.Int64(replicas)
+		})
+	}
+
+	cases := []struct {
+		name                string
+		cd                  *hivev1.ClusterDeployment
+		pools               []hivev1.MachinePool
+		expectedRunning     float64
+		expectedHibernating float64
+		expectedHourlyCost  float64
+		expectedPriced      bool
+	}{{
+		name:                "cluster and machine pool costs",
+		cd:                  cdBuilder.Build(),
+		pools:               []hivev1.MachinePool{workers("m5.xlarge", 4)},
+		expectedRunning:     3,
+		expectedHibernating: 0.5,
+		expectedHourlyCost:  3,
+		expectedPriced:      true,
+	}, {
+		name:                "region specific cost",
+		cd:                  cdBuilder.Build(testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-west-2")),
+		expectedRunning:     3,
+		expectedHibernating: 0.5,
+		expectedHourlyCost:  3,
+		expectedPriced:      true,
+	}, {
+		name:                "hibernating cluster",
+		cd:                  cdBuilder.Build(testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating)),
+		pools:               []hivev1.MachinePool{workers("m5.xlarge", 4)},
+		expectedRunning:     3,
+		expectedHibernating: 0.5,
+		expectedHourlyCost:  0.5,
+		expectedPriced:      true,
+	}, {
+		name:                "unpriced instance type",
+		cd:                  cdBuilder.Build(),
+		pools:               []hivev1.MachinePool{workers("m5.4xlarge", 4)},
+		expectedRunning:     2,
+		expectedHibernating: 0.5,
+		expectedHourlyCost:  2,
+		expectedPriced:      true,
+	}, {
+		name:  "unpriced platform",
+		cd:    cdBuilder.Build(testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "gcp")),
+		pools: []hivev1.MachinePool{workers("m5.xlarge", 4)},
+	}}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			running, hibernating, priced := prices.HourlyCosts(test.cd, test.pools)
+			assert.Equal(t, test.expectedRunning, running, "unexpected running hourly cost")
+			assert.Equal(t, test.expectedHibernating, hibernating, "unexpected hibernating hourly cost")
+			assert.Equal(t, test.expectedPriced, priced, "unexpected priced")
+			hourlyCost, _ := prices.HourlyCost(test.cd, test.pools)
+			assert.Equal(t, test.expectedHourlyCost, hourlyCost, "unexpected hourly cost")
+		})
+	}
+}
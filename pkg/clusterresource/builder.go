@@ -75,6 +75,9 @@ type Builder struct {
 	// HibernateAfter is the duration after which a running cluster should be automatically hibernated.
 	HibernateAfter *time.Duration
 
+	// HibernationSchedule describes recurring windows outside of which the cluster should be hibernated.
+	HibernationSchedule *hivev1.HibernationSchedule
+
 	// InstallAttemptsLimit is the maximum number of times Hive will attempt to install the cluster.
 	InstallAttemptsLimit *int32
 
@@ -326,6 +329,10 @@ func (o *Builder) generateClusterDeployment() *hivev1.ClusterDeployment {
 		cd.Spec.HibernateAfter = &metav1.Duration{Duration: *o.HibernateAfter}
 	}
 
+	if o.HibernationSchedule != nil {
+		cd.Spec.HibernationSchedule = o.HibernationSchedule.DeepCopy()
+	}
+
 	cd.Spec.InstallAttemptsLimit = o.InstallAttemptsLimit
 
 	if o.Adopt {
@@ -33,6 +33,10 @@ const (
 	// processing of any ClusterDeprovisions.
 	DeprovisionsDisabledEnvVar = "DEPROVISIONS_DISABLED"
 
+	// VerifyDeprovisionsEnvVar is the name of the environment variable used to tell the controller manager to look
+	// for cloud resources left behind once the uninstall of a ClusterDeprovision has completed.
+	VerifyDeprovisionsEnvVar = "VERIFY_DEPROVISIONS"
+
 	// MinBackupPeriodSecondsEnvVar is the name of the environment variable used to tell the controller manager the minimum period of time between backups.
 	MinBackupPeriodSecondsEnvVar = "HIVE_MIN_BACKUP_PERIOD_SECONDS"
 
@@ -178,6 +182,20 @@ const (
 	// protected delete is enabled.
 	ProtectedDeleteEnvVar = "PROTECTED_DELETE"
 
+	// DeprovisionGracePeriodAnnotation is an annotation used on ClusterDeployments to set how long the deprovision of
+	// the ClusterDeployment is held off once it is deleted, with the cluster hibernated. It overrides the grace period
+	// of HiveConfig. The value is a duration such as "24h"; "0s" disables the grace period.
+	DeprovisionGracePeriodAnnotation = "hive.openshift.io/deprovision-grace-period"
+
+	// DeprovisionPendingAnnotation is set by Hive on deleted ClusterDeployments that are within their deprovision
+	// grace period. Its value is the time at which the deprovision starts, in RFC3339 format. Removing the annotation
+	// during the grace period cancels the deprovision and preserves the cloud resources of the cluster.
+	DeprovisionPendingAnnotation = "hive.openshift.io/deprovision-pending"
+
+	// DeprovisionGracePeriodEnvVar is the name of the environment variable used to tell the controller manager how
+	// long the deprovision of deleted ClusterDeployments is held off.
+	DeprovisionGracePeriodEnvVar = "DEPROVISION_GRACE_PERIOD"
+
 	// RelocateAnnotation is an annotation used on ClusterDeployments and DNSZones to indicate that the resource
 	// is involved in a relocation between Hive instances.
 	// The value of the annotation has the format "{ClusterRelocate}/{Status}", where
@@ -188,6 +206,10 @@ const (
 	// An incoming status indicates that the resource is on the destination side of an in-progress relocate.
 	RelocateAnnotation = "hive.openshift.io/relocate"
 
+	// UpgradeCampaignAnnotation is an annotation used on ClusterDeployments to record the name of the
+	// ClusterUpgradeCampaign that set the ClusterDeployment's Spec.Upgrade.
+	UpgradeCampaignAnnotation = "hive.openshift.io/upgrade-campaign"
+
 	// ManagedDomainsFileEnvVar if present, points to a simple text
 	// file that includes a valid managed domain per line. Cluster deployments
 	// requesting that their domains be managed must have a base domain
@@ -347,6 +369,10 @@ const (
 	// in the form "[MAJOR].[MINOR].[PATCH]".
 	VersionMajorMinorPatchLabel = "hive.openshift.io/version-major-minor-patch"
 
+	// ClusterDeploymentCustomizationLabel is a label applied to ClusterDeployments created by a ClusterPool
+	// with an inventory to show the name of the ClusterDeploymentCustomization used to create the cluster.
+	ClusterDeploymentCustomizationLabel = "hive.openshift.io/cluster-deployment-customization"
+
 	// OvirtCredentialsDir is the directory containing Ovirt credentials files.
 	OvirtCredentialsDir = "/.ovirt"
 
@@ -377,6 +403,34 @@ const (
 	// InstallLogsAWSS3BucketEnvVar is the environment variable specifying the S3 bucket to use.
 	InstallLogsAWSS3BucketEnvVar = "HIVE_INSTALL_LOGS_AWS_S3_BUCKET"
 
+	// InstallLogsUploadProviderGCP is used to specify that GCP is the cloud provider to upload logs to.
+	InstallLogsUploadProviderGCP = "gcp"
+
+	// InstallLogsGCPBucketEnvVar is the environment variable specifying the Google Cloud Storage bucket to use.
+	InstallLogsGCPBucketEnvVar = "HIVE_INSTALL_LOGS_GCP_BUCKET"
+
+	// InstallLogsUploadProviderAzure is used to specify that Azure is the cloud provider to upload logs to.
+	InstallLogsUploadProviderAzure = "azure"
+
+	// InstallLogsAzureStorageAccountEnvVar is the environment variable specifying the Azure storage account to use.
+	InstallLogsAzureStorageAccountEnvVar = "HIVE_INSTALL_LOGS_AZURE_STORAGE_ACCOUNT"
+
+	// InstallLogsAzureContainerEnvVar is the environment variable specifying the Azure Blob Storage container to use.
+	InstallLogsAzureContainerEnvVar = "HIVE_INSTALL_LOGS_AZURE_CONTAINER"
+
+	// InstallLogsAzureCloudNameEnvVar is the environment variable specifying the Azure cloud environment to use.
+	InstallLogsAzureCloudNameEnvVar = "HIVE_INSTALL_LOGS_AZURE_CLOUD_NAME"
+
+	// InstallLogsUploadProviderPVC is used to specify that logs are copied to a PersistentVolumeClaim.
+	InstallLogsUploadProviderPVC = "pvc"
+
+	// InstallLogsPVCClaimNameEnvVar is the environment variable specifying the PersistentVolumeClaim to copy logs to.
+	InstallLogsPVCClaimNameEnvVar = "HIVE_INSTALL_LOGS_PVC_CLAIM_NAME"
+
+	// InstallLogsPVCMountDir is the directory where the PersistentVolumeClaim to copy logs to is mounted in the
+	// install pod.
+	InstallLogsPVCMountDir = "/install-logs"
+
 	// HiveFakeClusterAnnotation can be set to true on a cluster deployment to create a fake cluster that never
 	// provisions resources, and all communication with the cluster will be faked.
 	HiveFakeClusterAnnotation = "hive.openshift.io/fake-cluster"
@@ -503,6 +557,14 @@ const (
 	// value when the value is unknown
 	MetricLabelDefaultValue = "unspecified"
 
+	// ClusterCostPricesConfigMapName is the name of the optional ConfigMap in the hive namespace with the price table
+	// used to estimate the cost of ClusterDeployments and ClusterPools.
+	ClusterCostPricesConfigMapName = "cluster-cost-prices"
+
+	// ClusterCostPricesDataKey is the data entry of the ClusterCostPricesConfigMapName ConfigMap that holds the
+	// price table.
+	ClusterCostPricesDataKey = "prices"
+
 	// TrustedCAConfigMapName is the name of the ConfigMap containing the merged CA bundle including the trustedCA from the
 	// cluster proxy object. We'll use this to name the mount also.
 	TrustedCAConfigMapName = "hive-trusted-cabundle"
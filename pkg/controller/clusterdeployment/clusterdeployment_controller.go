@@ -152,6 +152,11 @@ func NewReconciler(mgr manager.Manager, logger log.FieldLogger, rateLimiter flow
 		r.protectedDelete = true
 	}
 
+	if gracePeriod, err := time.ParseDuration(os.Getenv(constants.DeprovisionGracePeriodEnvVar)); err == nil && gracePeriod > 0 {
+		logger.WithField("gracePeriod", gracePeriod).Info("Deprovision grace period enabled")
+		r.deprovisionGracePeriod = gracePeriod
+	}
+
 	verifier, err := LoadReleaseImageVerifier(mgr.GetConfig())
 	if err == nil {
 		logger.Info("Release Image verification enabled")
@@ -284,6 +289,10 @@ type ReconcileClusterDeployment struct {
 	releaseImageVerifier verify.Interface
 
 	protectedDelete bool
+
+	// deprovisionGracePeriod is how long the deprovision of deleted ClusterDeployments is held off, unless overridden
+	// by the deprovision-grace-period annotation.
+	deprovisionGracePeriod time.Duration
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeployment object and makes changes based on the state read
@@ -1365,6 +1374,74 @@ func (r *ReconcileClusterDeployment) ensureClusterDeprovisioned(cd *hivev1.Clust
 	)
 }
 
+// syncDeprovisionGracePeriod holds off the deprovision of a deleted ClusterDeployment for its deprovision grace
+// period, counted from the deletion. Meanwhile the cluster is kept hibernated, and the time at which the deprovision
+// starts is recorded in the deprovision-pending annotation. Removing the annotation cancels the deprovision: Kubernetes
+// cannot undo the deletion, so PreserveOnDelete is set to let the ClusterDeployment go while leaving the cloud
+// resources of the cluster in place. The removal is noticed through the DeprovisionPending reason of the Provisioned
+// condition, which only Hive sets. A nil result means the deprovision can go ahead.
+func (r *ReconcileClusterDeployment) syncDeprovisionGracePeriod(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (*reconcile.Result, error) {
+	gracePeriod := controllerutils.DeprovisionGracePeriod(cd, r.deprovisionGracePeriod, cdLog)
+	if gracePeriod <= 0 {
+		return nil, nil
+	}
+
+	provisioned := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionedCondition)
+	if provisioned != nil {
+		switch provisioned.Reason {
+		case hivev1.ProvisionedReasonDeprovisioning, hivev1.ProvisionedReasonDeprovisionFailed, hivev1.ProvisionedReasonDeprovisioned:
+			// The deprovision has started, so lengthening the grace period now does not stop it.
+			return nil, nil
+		}
+	}
+
+	deprovisionAt := cd.DeletionTimestamp.Add(gracePeriod)
+	remaining := time.Until(deprovisionAt)
+	if remaining <= 0 {
+		return nil, nil
+	}
+
+	pending := provisioned != nil && provisioned.Reason == hivev1.ProvisionedReasonDeprovisionPending
+	deprovisionAtValue := deprovisionAt.UTC().Format(time.RFC3339)
+	markedValue, marked := cd.Annotations[constants.DeprovisionPendingAnnotation]
+	switch {
+	case pending && !marked:
+		cdLog.WithField("annotation", constants.DeprovisionPendingAnnotation).
+			Warn("deprovision cancelled by removal of annotation, setting PreserveOnDelete to keep the cloud resources of the cluster")
+		cd.Spec.PreserveOnDelete = true
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to set PreserveOnDelete")
+			return &reconcile.Result{}, err
+		}
+		return &reconcile.Result{}, nil
+	case markedValue != deprovisionAtValue || cd.Spec.PowerState != hivev1.ClusterPowerStateHibernating:
+		// Keep the cluster hibernated, even if someone tries to resume it, and the annotation in step with the
+		// deletion time and the grace period.
+		cdLog.WithField("deprovisionAt", deprovisionAtValue).Info("deprovision pending, hibernating cluster for the grace period")
+		if cd.Annotations == nil {
+			cd.Annotations = map[string]string{}
+		}
+		cd.Annotations[constants.DeprovisionPendingAnnotation] = deprovisionAtValue
+		cd.Spec.PowerState = hivev1.ClusterPowerStateHibernating
+		if err := r.Update(context.TODO(), cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "failed to mark deprovision pending")
+			return &reconcile.Result{}, err
+		}
+		return &reconcile.Result{}, nil
+	}
+
+	cdLog.WithField("deprovisionAt", deprovisionAtValue).Debug("deprovision pending")
+	return &reconcile.Result{RequeueAfter: remaining}, r.updateCondition(
+		cd,
+		hivev1.ProvisionedCondition,
+		corev1.ConditionFalse,
+		hivev1.ProvisionedReasonDeprovisionPending,
+		fmt.Sprintf("Cluster will be deprovisioned at %s. Remove the %s annotation to cancel the deprovision and keep the cluster",
+			deprovisionAtValue, constants.DeprovisionPendingAnnotation),
+		cdLog,
+	)
+}
+
 func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) (reconcile.Result, error) {
 	switch _, relocateStatus, err := controllerutils.IsRelocating(cd); {
 	case err != nil:
@@ -1387,6 +1464,11 @@ func (r *ReconcileClusterDeployment) syncDeletedClusterDeployment(cd *hivev1.Clu
 		return reconcile.Result{}, nil
 	}
 
+	// Keep the cluster, hibernated, until its deprovision grace period is over or the deprovision is cancelled
+	if result, err := r.syncDeprovisionGracePeriod(cd, cdLog); result != nil {
+		return *result, err
+	}
+
 	dnsZoneGone, err := r.ensureManagedDNSZoneDeleted(cd, cdLog)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -167,6 +167,8 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 		reconcilerSetup               func(*ReconcileClusterDeployment)
 		platformCredentialsValidation func(client.Client, *hivev1.ClusterDeployment, log.FieldLogger) (bool, error)
 		retryReasons                  *[]string
+		installConfigRemediations     []hivev1.InstallConfigRemediation
+		installLogsClaim              string
 	}{
 		{
 			name: "Initialize conditions",
@@ -212,6 +214,55 @@ func TestClusterDeploymentReconcile(t *testing.T) {
 				}})
 			},
 		},
+		{
+			name: "Create provision with install logs claim",
+			existing: []runtime.Object{
+				testInstallConfigSecretAWS(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+				&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: "install-logs"}},
+			},
+			installLogsClaim:      "install-logs",
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					assert.Contains(t, provisions[0].Spec.PodSpec.Containers[0].Env, corev1.EnvVar{
+						Name:  constants.InstallLogsPVCClaimNameEnvVar,
+						Value: "install-logs",
+					}, "expected install logs claim env var")
+					assert.Contains(t, provisions[0].Spec.PodSpec.Volumes, corev1.Volume{
+						Name: "install-logs",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "install-logs"},
+						},
+					}, "expected install logs claim volume")
+				}
+			},
+		},
+		{
+			name: "Create provision without install logs claim missing from namespace",
+			existing: []runtime.Object{
+				testInstallConfigSecretAWS(),
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			installLogsClaim:      "install-logs",
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provisions := getProvisions(c)
+				if assert.Len(t, provisions, 1, "expected provision to exist") {
+					for _, envVar := range provisions[0].Spec.PodSpec.Containers[0].Env {
+						assert.NotEqual(t, constants.InstallLogsUploadProviderEnvVar, envVar.Name, "unexpected install logs provider")
+					}
+					for _, volume := range provisions[0].Spec.PodSpec.Volumes {
+						assert.Nil(t, volume.PersistentVolumeClaim, "unexpected claim volume")
+					}
+				}
+			},
+		},
 		{
 			name: "Provision not created when pending create",
 			existing: []runtime.Object{
@@ -1503,6 +1554,179 @@ platform:
 				assert.NotNil(t, deprovision, "expected deprovision request to be created")
 			},
 		},
+		{
+			name: "Deprovision grace period: deleted cluster hibernated",
+			existing: []runtime.Object{
+				testDeletedInstalledClusterDeployment(time.Now()),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.deprovisionGracePeriod = time.Hour
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Equal(t, hivev1.ClusterPowerStateHibernating, cd.Spec.PowerState, "expected cluster to be hibernated")
+					deprovisionAt, err := time.Parse(time.RFC3339, cd.Annotations[constants.DeprovisionPendingAnnotation])
+					if assert.NoError(t, err, "could not parse deprovision-pending annotation") {
+						assert.WithinDuration(t, time.Now().Add(time.Hour), deprovisionAt, time.Minute, "unexpected deprovision time")
+					}
+				}
+				assert.Nil(t, getDeprovision(c), "unexpected deprovision request")
+			},
+		},
+		{
+			name: "Deprovision grace period: pending deprovision waits",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					deletedAt := time.Now().Truncate(time.Second)
+					cd := testDeletedInstalledClusterDeployment(deletedAt)
+					cd.Annotations = map[string]string{constants.DeprovisionPendingAnnotation: deletedAt.Add(time.Hour).UTC().Format(time.RFC3339)}
+					cd.Spec.PowerState = hivev1.ClusterPowerStateHibernating
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.deprovisionGracePeriod = time.Hour
+			},
+			expectedRequeueAfter: time.Hour,
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionedCondition)
+					if assert.NotNil(t, cond, "missing Provisioned condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "unexpected Provisioned condition status")
+						assert.Equal(t, hivev1.ProvisionedReasonDeprovisionPending, cond.Reason, "unexpected Provisioned condition reason")
+					}
+				}
+				assert.Nil(t, getDeprovision(c), "unexpected deprovision request")
+			},
+		},
+		{
+			name: "Deprovision grace period: resumed cluster hibernated again",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					deletedAt := time.Now().Truncate(time.Second)
+					cd := testDeletedInstalledClusterDeployment(deletedAt)
+					cd.Annotations = map[string]string{constants.DeprovisionPendingAnnotation: deletedAt.Add(time.Hour).UTC().Format(time.RFC3339)}
+					cd.Spec.PowerState = hivev1.ClusterPowerStateRunning
+					cd.Status.Conditions = addOrUpdateClusterDeploymentCondition(*cd, hivev1.ProvisionedCondition,
+						corev1.ConditionFalse, hivev1.ProvisionedReasonDeprovisionPending, "Cluster will be deprovisioned")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.deprovisionGracePeriod = time.Hour
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.Equal(t, hivev1.ClusterPowerStateHibernating, cd.Spec.PowerState, "expected cluster to be hibernated")
+				}
+				assert.Nil(t, getDeprovision(c), "unexpected deprovision request")
+			},
+		},
+		{
+			name: "Deprovision grace period: edited annotation set back",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedInstalledClusterDeployment(time.Now())
+					cd.Annotations = map[string]string{constants.DeprovisionPendingAnnotation: time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)}
+					cd.Spec.PowerState = hivev1.ClusterPowerStateHibernating
+					cd.Status.Conditions = addOrUpdateClusterDeploymentCondition(*cd, hivev1.ProvisionedCondition,
+						corev1.ConditionFalse, hivev1.ProvisionedReasonDeprovisionPending, "Cluster will be deprovisioned")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.deprovisionGracePeriod = time.Hour
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					deprovisionAt, err := time.Parse(time.RFC3339, cd.Annotations[constants.DeprovisionPendingAnnotation])
+					if assert.NoError(t, err, "could not parse deprovision-pending annotation") {
+						assert.WithinDuration(t, time.Now().Add(time.Hour), deprovisionAt, time.Minute, "unexpected deprovision time")
+					}
+				}
+				assert.Nil(t, getDeprovision(c), "unexpected deprovision request")
+			},
+		},
+		{
+			name: "Deprovision grace period: deprovision once grace period is over",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					deletedAt := time.Now().Add(-2 * time.Hour)
+					cd := testDeletedInstalledClusterDeployment(deletedAt)
+					cd.Annotations = map[string]string{constants.DeprovisionPendingAnnotation: deletedAt.Add(time.Hour).UTC().Format(time.RFC3339)}
+					cd.Spec.PowerState = hivev1.ClusterPowerStateHibernating
+					cd.Status.Conditions = addOrUpdateClusterDeploymentCondition(*cd, hivev1.ProvisionedCondition,
+						corev1.ConditionFalse, hivev1.ProvisionedReasonDeprovisionPending, "Cluster will be deprovisioned")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.deprovisionGracePeriod = time.Hour
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+			},
+		},
+		{
+			name: "Deprovision grace period: cancelled by removing the annotation",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedInstalledClusterDeployment(time.Now())
+					cd.Spec.PowerState = hivev1.ClusterPowerStateHibernating
+					cd.Status.Conditions = addOrUpdateClusterDeploymentCondition(*cd, hivev1.ProvisionedCondition,
+						corev1.ConditionFalse, hivev1.ProvisionedReasonDeprovisionPending, "Cluster will be deprovisioned")
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.deprovisionGracePeriod = time.Hour
+			},
+			validate: func(c client.Client, t *testing.T) {
+				cd := getCD(c)
+				if assert.NotNil(t, cd, "missing clusterdeployment") {
+					assert.True(t, cd.Spec.PreserveOnDelete, "expected PreserveOnDelete to be set")
+				}
+				assert.Nil(t, getDeprovision(c), "unexpected deprovision request")
+			},
+		},
+		{
+			name: "Deprovision grace period: none for claimed pool cluster",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testDeletedInstalledClusterDeployment(time.Now())
+					cd.Spec.ClusterPoolRef = &hivev1.ClusterPoolReference{
+						Namespace: testNamespace,
+						PoolName:  "test-pool",
+						ClaimName: "test-claim",
+					}
+					return cd
+				}(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			reconcilerSetup: func(r *ReconcileClusterDeployment) {
+				r.deprovisionGracePeriod = time.Hour
+			},
+			validate: func(c client.Client, t *testing.T) {
+				assert.NotNil(t, getDeprovision(c), "expected deprovision request to be created")
+			},
+		},
 		{
 			name: "Delete old provisions",
 			existing: []runtime.Object{
@@ -2916,6 +3140,59 @@ platform:
 				assert.Equal(t, true, cd.Spec.Installed)
 			},
 		},
+		{
+			name: "no RetryReasons: failure classified as not retryable: no retry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("aReason"), tcp.WithFailureClassification(hivev1.InstallFailureCategoryPermissions, false)),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionTrue, cond.Status, "expected ProvisionStopped to be True")
+						assert.Equal(t, "FailureReasonNotRetryable", cond.Reason, "expected ProvisionStopped Reason to be FailureReasonNotRetryable")
+					}
+				}
+				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
+			},
+		},
+		{
+			name: "no RetryReasons: failure classified as retryable: retry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("aReason"), tcp.WithFailureClassification(hivev1.InstallFailureCategoryQuota, true)),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				if cd := getCD(c); assert.NotNil(t, cd, "no clusterdeployment found") {
+					if cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ProvisionStoppedCondition); assert.NotNil(t, cond, "no ProvisionStopped condition") {
+						assert.Equal(t, corev1.ConditionFalse, cond.Status, "expected ProvisionStopped to be False")
+					}
+				}
+				assert.Len(t, getProvisions(c), 2, "expected 2 ClusterProvisions to exist")
+			},
+		},
+		{
+			name: "RetryReasons: failure classified as not retryable: retry on matching entry",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("aReason"), tcp.WithFailureClassification(hivev1.InstallFailureCategoryPermissions, false)),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			retryReasons:          &[]string{"aReason"},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				assert.Len(t, getProvisions(c), 2, "expected 2 ClusterProvisions to exist")
+			},
+		},
 		{
 			name: "RetryReasons: matching entry: retry",
 			existing: []runtime.Object{
@@ -3086,21 +3363,106 @@ platform:
 				assert.Len(t, getProvisions(c), 1, "expected 1 ClusterProvision to exist")
 			},
 		},
+		{
+			name: "InstallConfigRemediations: matching reason: remediation applied to next provision",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("aReason")),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			installConfigRemediations: []hivev1.InstallConfigRemediation{
+				testInstallConfigRemediation("other-zones", "aReason"),
+				testInstallConfigRemediation("larger-instances", "bReason"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getNewProvision(c)
+				if assert.NotNil(t, provision, "expected a new ClusterProvision") {
+					assert.Equal(t, []string{"other-zones"}, remediationNames(provision), "unexpected remediations")
+				}
+			},
+		},
+		{
+			name: "InstallConfigRemediations: remediations of previous provision carried over",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(
+					tcp.WithFailureReason("bReason"),
+					tcp.WithInstallConfigRemediations(testInstallConfigRemediation("other-zones", "aReason"))),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			installConfigRemediations: []hivev1.InstallConfigRemediation{
+				testInstallConfigRemediation("other-zones", "aReason"),
+				testInstallConfigRemediation("larger-instances", "bReason"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getNewProvision(c)
+				if assert.NotNil(t, provision, "expected a new ClusterProvision") {
+					assert.Equal(t, []string{"other-zones", "larger-instances"}, remediationNames(provision), "unexpected remediations")
+				}
+			},
+		},
+		{
+			name: "InstallConfigRemediations: remediation already applied: not applied again",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(
+					tcp.WithFailureReason("aReason"),
+					tcp.WithInstallConfigRemediations(testInstallConfigRemediation("other-zones", "aReason"))),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			installConfigRemediations: []hivev1.InstallConfigRemediation{
+				testInstallConfigRemediation("other-zones", "aReason"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getNewProvision(c)
+				if assert.NotNil(t, provision, "expected a new ClusterProvision") {
+					assert.Equal(t, []string{"other-zones"}, remediationNames(provision), "unexpected remediations")
+				}
+			},
+		},
+		{
+			name: "InstallConfigRemediations: no matching reason: no remediation",
+			existing: []runtime.Object{
+				testClusterDeploymentWithDefaultConditions(testClusterDeploymentWithInitializedConditions(testClusterDeployment())),
+				testProvision(tcp.WithFailureReason("cReason")),
+				testInstallConfigSecretAWS(),
+				testSecret(corev1.SecretTypeDockerConfigJson, pullSecretSecret, corev1.DockerConfigJsonKey, "{}"),
+				testSecret(corev1.SecretTypeDockerConfigJson, constants.GetMergedPullSecretName(testClusterDeployment()), corev1.DockerConfigJsonKey, "{}"),
+			},
+			installConfigRemediations: []hivev1.InstallConfigRemediation{
+				testInstallConfigRemediation("other-zones", "aReason"),
+			},
+			expectPendingCreation: true,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getNewProvision(c)
+				if assert.NotNil(t, provision, "expected a new ClusterProvision") {
+					assert.Empty(t, provision.Spec.InstallConfigRemediations, "expected no remediations")
+				}
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			logger := log.WithField("controller", "clusterDeployment")
-			if test.retryReasons == nil {
-				readFile = fakeReadFile("")
-			} else {
-				b, _ := json.Marshal(*test.retryReasons)
-				readFile = fakeReadFile(fmt.Sprintf(`
-				{
-					"retryReasons": %s
-				}
-				`, string(b)))
+			fpConfig := hivev1.FailedProvisionConfig{
+				RetryReasons:              test.retryReasons,
+				InstallConfigRemediations: test.installConfigRemediations,
+			}
+			if test.installLogsClaim != "" {
+				fpConfig.PersistentVolumeClaim = &hivev1.FailedProvisionPersistentVolumeClaimConfig{ClaimName: test.installLogsClaim}
 			}
+			fpConfigBytes, _ := json.Marshal(fpConfig)
+			readFile = fakeReadFile(string(fpConfigBytes))
 			fakeClient := fake.NewClientBuilder().WithRuntimeObjects(test.existing...).Build()
 			controllerExpectations := controllerutils.NewExpectations(logger)
 			mockCtrl := gomock.NewController(t)
@@ -3541,6 +3903,12 @@ func testDeletedClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func testDeletedInstalledClusterDeployment(deletedAt time.Time) *hivev1.ClusterDeployment {
+	cd := testClusterDeploymentWithInitializedConditions(testInstalledClusterDeployment(deletedAt.Add(-time.Hour)))
+	cd.DeletionTimestamp = &metav1.Time{Time: deletedAt}
+	return cd
+}
+
 func testDeletedClusterDeploymentWithoutFinalizer() *hivev1.ClusterDeployment {
 	cd := testClusterDeployment()
 	now := metav1.Now()
@@ -4623,6 +4991,34 @@ platform:
 	}
 }
 
+// getNewProvision returns the ClusterProvision created by the reconcile, i.e. the one not created by testProvision.
+func getNewProvision(c client.Client) *hivev1.ClusterProvision {
+	for _, provision := range getProvisions(c) {
+		if provision.Name != provisionName {
+			return provision
+		}
+	}
+	return nil
+}
+
+func remediationNames(provision *hivev1.ClusterProvision) []string {
+	var names []string
+	for _, remediation := range provision.Spec.InstallConfigRemediations {
+		names = append(names, remediation.Name)
+	}
+	return names
+}
+
+func testInstallConfigRemediation(name string, reasons ...string) hivev1.InstallConfigRemediation {
+	return hivev1.InstallConfigRemediation{
+		Name:    name,
+		Reasons: reasons,
+		InstallConfigPatches: []hivev1.PatchEntity{
+			{Op: "remove", Path: "/controlPlane/platform/aws/zones"},
+		},
+	}
+}
+
 func getProvisions(c client.Client) []*hivev1.ClusterProvision {
 	provisionList := &hivev1.ClusterProvisionList{}
 	if err := c.List(context.TODO(), provisionList); err != nil {
@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -149,6 +150,10 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		logger.WithError(err).Error("failed to read failed provision config file")
 		return reconcile.Result{}, err
 	}
+	extraEnvVars, err = r.checkInstallLogsClaim(cd.Namespace, extraEnvVars, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 	extraEnvVars = append(extraEnvVars, getAWSServiceProviderEnvVars(cd, cd.Name)...)
 
 	podSpec, err := install.InstallerPodSpec(
@@ -188,6 +193,11 @@ func (r *ReconcileClusterDeployment) startNewProvision(
 		provision.Spec.PrevProvisionName = &lastFailedProvision.Name
 		provision.Spec.PrevClusterID = lastFailedProvision.Spec.ClusterID
 		provision.Spec.PrevInfraID = lastFailedProvision.Spec.InfraID
+		provision.Spec.InstallConfigRemediations, err = getInstallConfigRemediations(lastFailedProvision, logger)
+		if err != nil {
+			logger.WithError(err).Error("failed to determine the install-config remediations to apply")
+			return reconcile.Result{}, err
+		}
 	}
 
 	logger.WithField("derivedObject", provision.Name).Debug("Setting label on derived object")
@@ -260,8 +270,12 @@ func (r *ReconcileClusterDeployment) shouldRetryBasedOnFailureReason(prov *hivev
 	if err != nil {
 		return false, err
 	}
-	// If no retry reasons are specified, "always" retry
+	// If no retry reasons are specified, retry unless the failure was classified as not retryable
 	if fpConfig.RetryReasons == nil {
+		if c := prov.Status.FailureClassification; c != nil && !c.Retryable {
+			logger.WithField("category", c.Category).Debug("failure classified as not retryable -- not retrying")
+			return false, nil
+		}
 		logger.Debug("no RetryReasons found in FailedProvisionConfig -- allowing retry")
 		return true, nil
 	}
@@ -282,6 +296,41 @@ func (r *ReconcileClusterDeployment) shouldRetryBasedOnFailureReason(prov *hivev
 	return false, nil
 }
 
+// getInstallConfigRemediations returns the remediations to apply to the install-config of the provision attempt that
+// follows the failed provision: the remediations applied to the failed provision, followed by the remediations
+// configured for the reason it failed that were not applied to it yet.
+func getInstallConfigRemediations(prov *hivev1.ClusterProvision, logger log.FieldLogger) ([]hivev1.InstallConfigRemediation, error) {
+	remediations := append([]hivev1.InstallConfigRemediation(nil), prov.Spec.InstallConfigRemediations...)
+	cond := controllerutils.FindCondition(prov.Status.Conditions, hivev1.ClusterProvisionFailedCondition)
+	if cond == nil {
+		return remediations, nil
+	}
+	fpConfig, err := readProvisionFailedConfig()
+	if err != nil {
+		return nil, err
+	}
+	for _, remediation := range fpConfig.InstallConfigRemediations {
+		if !sets.NewString(remediation.Reasons...).Has(cond.Reason) || hasRemediation(remediations, remediation.Name) {
+			continue
+		}
+		logger.WithFields(log.Fields{
+			"remediation": remediation.Name,
+			"reason":      cond.Reason,
+		}).Info("applying install-config remediation to the next provision attempt")
+		remediations = append(remediations, remediation)
+	}
+	return remediations, nil
+}
+
+func hasRemediation(remediations []hivev1.InstallConfigRemediation, name string) bool {
+	for _, remediation := range remediations {
+		if remediation.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
 // setAzureResourceGroupFromMetadata unmarshals `pm.Raw`, a representation of the installer ClusterMetadata type,
 // and looks for the Azure ResourceGroupName therein. If found, the value is copied into the Azure platform-specific
 // section of `cm`, hive's representation of the cluster metadata. The `cd` is only used to validate that we're
@@ -615,6 +664,30 @@ func (r *ReconcileClusterDeployment) copyInstallLogSecret(destNamespace string,
 	return controllerutils.CopySecret(r, src, dest, nil, nil)
 }
 
+// checkInstallLogsClaim drops the install log env vars if they configure copying the logs to a PersistentVolumeClaim
+// that does not exist in the namespace. An install pod mounting a missing claim would never be scheduled.
+func (r *ReconcileClusterDeployment) checkInstallLogsClaim(namespace string, extraEnvVars []corev1.EnvVar, logger log.FieldLogger) ([]corev1.EnvVar, error) {
+	var claimName string
+	for _, envVar := range extraEnvVars {
+		if envVar.Name == constants.InstallLogsPVCClaimNameEnvVar {
+			claimName = envVar.Value
+		}
+	}
+	if claimName == "" {
+		return extraEnvVars, nil
+	}
+	logger = logger.WithField("claim", claimName)
+	switch err := r.Get(context.TODO(), types.NamespacedName{Namespace: namespace, Name: claimName}, &corev1.PersistentVolumeClaim{}); {
+	case apierrors.IsNotFound(err):
+		logger.Warn("install logs claim does not exist in the namespace, logs of failed installs will not be kept")
+		return []corev1.EnvVar{}, nil
+	case err != nil:
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not get install logs claim")
+		return nil, err
+	}
+	return extraEnvVars, nil
+}
+
 // NOTE: Ugly-but-simple way to mock os.ReadFile for test purposes.
 // https://stackoverflow.com/questions/20923938/how-would-i-mock-a-call-to-ioutil-readfile-in-go/37035375
 // This variable is overridden by fakeReadFile.
@@ -646,8 +719,10 @@ func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 	if err != nil || fpConfig == nil {
 		return extraEnvVars, err
 	}
-	if awsSpec := fpConfig.AWS; awsSpec != nil {
-		// By default we will try to gather logs on failed installs:
+	// By default we will try to gather logs on failed installs:
+	switch {
+	case fpConfig.AWS != nil:
+		awsSpec := fpConfig.AWS
 		extraEnvVars = []corev1.EnvVar{
 			{
 				Name:  constants.InstallLogsUploadProviderEnvVar,
@@ -670,6 +745,57 @@ func getInstallLogEnvVars(secretPrefix string) ([]corev1.EnvVar, error) {
 				Value: awsSpec.Bucket,
 			},
 		}
+	case fpConfig.GCP != nil:
+		gcpSpec := fpConfig.GCP
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderGCP,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: secretPrefix + "-" + gcpSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsGCPBucketEnvVar,
+				Value: gcpSpec.Bucket,
+			},
+		}
+	case fpConfig.Azure != nil:
+		azureSpec := fpConfig.Azure
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderAzure,
+			},
+			{
+				Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
+				Value: secretPrefix + "-" + azureSpec.CredentialsSecretRef.Name,
+			},
+			{
+				Name:  constants.InstallLogsAzureStorageAccountEnvVar,
+				Value: azureSpec.StorageAccount,
+			},
+			{
+				Name:  constants.InstallLogsAzureContainerEnvVar,
+				Value: azureSpec.Container,
+			},
+			{
+				Name:  constants.InstallLogsAzureCloudNameEnvVar,
+				Value: azureSpec.CloudName.Name(),
+			},
+		}
+	case fpConfig.PersistentVolumeClaim != nil:
+		extraEnvVars = []corev1.EnvVar{
+			{
+				Name:  constants.InstallLogsUploadProviderEnvVar,
+				Value: constants.InstallLogsUploadProviderPVC,
+			},
+			{
+				Name:  constants.InstallLogsPVCClaimNameEnvVar,
+				Value: fpConfig.PersistentVolumeClaim.ClaimName,
+			},
+		}
 	}
 
 	return extraEnvVars, nil
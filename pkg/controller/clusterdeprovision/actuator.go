@@ -14,3 +14,10 @@ type Actuator interface {
 	// TestCredentials returns nil if the credential check succeeds. Otherwise returns the error.
 	TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error
 }
+
+// OrphanedResourceFinder is implemented by the actuators that can look for the cloud resources left behind once the
+// uninstall of a cluster has completed.
+type OrphanedResourceFinder interface {
+	// FindOrphanedResources returns the cloud resources that are still tagged with the infraID of the cluster.
+	FindOrphanedResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]string, error)
+}
@@ -0,0 +1,393 @@
+package clusterdeprovision
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2019-12-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2018-05-01/resources"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gcpcompute "google.golang.org/api/compute/v1"
+	gcpdns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
+	"github.com/openshift/hive/pkg/gcpclient"
+	"github.com/openshift/hive/pkg/ibmclient"
+	"github.com/openshift/hive/pkg/openstackclient"
+)
+
+func TestActuatorTestCredentials(t *testing.T) {
+	credsRef := &corev1.LocalObjectReference{Name: "creds"}
+	tests := []struct {
+		name      string
+		platform  hivev1.ClusterDeprovisionPlatform
+		setupMock func(m *mocks, err error)
+		clientErr error
+		callErr   error
+		expectErr bool
+	}{
+		{
+			name: "gcp credentials usable",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				GCP: &hivev1.GCPClusterDeprovision{Region: "us-east1", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockGCPClient.EXPECT().
+					ListComputeZones(gcpclient.ListComputeZonesOptions{MaxResults: 1, Filter: "(region eq '.*us-east1.*')"}).
+					Return(nil, err)
+			},
+		},
+		{
+			name: "gcp credentials rejected",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				GCP: &hivev1.GCPClusterDeprovision{Region: "us-east1", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockGCPClient.EXPECT().ListComputeZones(gomock.Any()).Return(nil, err)
+			},
+			callErr:   fmt.Errorf("invalid_grant"),
+			expectErr: true,
+		},
+		{
+			name: "gcp client cannot be built",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				GCP: &hivev1.GCPClusterDeprovision{Region: "us-east1", CredentialsSecretRef: credsRef},
+			},
+			clientErr: fmt.Errorf("failed to fetch GCP credentials secret"),
+			expectErr: true,
+		},
+		{
+			name: "azure credentials usable",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				Azure: &hivev1.AzureClusterDeprovision{CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockAzureClient.EXPECT().ListAllVirtualMachines(gomock.Any(), "true").Return(compute.VirtualMachineListResultPage{}, err)
+			},
+		},
+		{
+			name: "azure credentials rejected",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				Azure: &hivev1.AzureClusterDeprovision{CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockAzureClient.EXPECT().ListAllVirtualMachines(gomock.Any(), gomock.Any()).Return(compute.VirtualMachineListResultPage{}, err)
+			},
+			callErr:   fmt.Errorf("AADSTS7000215: Invalid client secret provided"),
+			expectErr: true,
+		},
+		{
+			name: "ibm cloud credentials usable",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				IBMCloud: &hivev1.IBMClusterDeprovision{Region: "us-south", CredentialsSecretRef: *credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockIBMClient.EXPECT().GetAuthenticatorAPIKeyDetails(gomock.Any()).Return(nil, err)
+			},
+		},
+		{
+			name: "ibm cloud credentials rejected",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				IBMCloud: &hivev1.IBMClusterDeprovision{Region: "us-south", CredentialsSecretRef: *credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockIBMClient.EXPECT().GetAuthenticatorAPIKeyDetails(gomock.Any()).Return(nil, err)
+			},
+			callErr:   fmt.Errorf("Provided API key could not be found"),
+			expectErr: true,
+		},
+		{
+			name: "openstack credentials usable",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				OpenStack: &hivev1.OpenStackClusterDeprovision{Cloud: "openstack", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockOpenStackClient.EXPECT().ListServers(gomock.Any(), "test-infra-id").Return(nil, err)
+			},
+		},
+		{
+			name: "openstack credentials rejected",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				OpenStack: &hivev1.OpenStackClusterDeprovision{Cloud: "openstack", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks, err error) {
+				m.mockOpenStackClient.EXPECT().ListServers(gomock.Any(), gomock.Any()).Return(nil, err)
+			},
+			callErr:   fmt.Errorf("Authentication failed"),
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t, false)
+			if test.setupMock != nil {
+				test.setupMock(mocks, test.callErr)
+			}
+			testActuators := []Actuator{
+				&gcpActuator{gcpClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (gcpclient.Client, error) {
+					return mocks.mockGCPClient, test.clientErr
+				}},
+				&azureActuator{azureClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (azureclient.Client, error) {
+					return mocks.mockAzureClient, test.clientErr
+				}},
+				&ibmCloudActuator{ibmCloudClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (ibmclient.API, error) {
+					return mocks.mockIBMClient, test.clientErr
+				}},
+				&openStackActuator{openStackClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (openstackclient.API, error) {
+					return mocks.mockOpenStackClient, test.clientErr
+				}},
+			}
+			deprovision := testClusterDeprovision()
+			deprovision.Spec.Platform = test.platform
+
+			var actuator Actuator
+			for _, a := range testActuators {
+				if a.CanHandle(deprovision) {
+					require.Nil(t, actuator, "more than one actuator can handle the deprovision")
+					actuator = a
+				}
+			}
+			require.NotNil(t, actuator, "no actuator can handle the deprovision")
+
+			err := actuator.TestCredentials(deprovision, mocks.fakeKubeClient, log.WithField("test", test.name))
+			if test.expectErr {
+				assert.Error(t, err, "expected error testing credentials")
+			} else {
+				assert.NoError(t, err, "unexpected error testing credentials")
+			}
+		})
+	}
+}
+
+func TestActuatorFindOrphanedResources(t *testing.T) {
+	credsRef := &corev1.LocalObjectReference{Name: "creds"}
+	gcpFilter := `labels.kubernetes-io-cluster-test-infra-id = "owned"`
+	gcpNameFilter := `name : "test-infra-id-*"`
+	expectNoGCPLabelledResources := func(m *mocks) {
+		m.mockGCPClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any()).Return(nil)
+		m.mockGCPClient.EXPECT().ListComputeDisks(gomock.Any(), gomock.Any()).Return(nil)
+		m.mockGCPClient.EXPECT().ListComputeImages(gomock.Any()).Return(&gcpcompute.ImageList{}, nil)
+	}
+	expectNoGCPLoadBalancerResources := func(m *mocks) {
+		m.mockGCPClient.EXPECT().ListComputeForwardingRules(gcpclient.ListComputeForwardingRulesOptions{Filter: gcpNameFilter}, gomock.Any()).Return(nil)
+		m.mockGCPClient.EXPECT().ListComputeTargetPools(gcpclient.ListComputeTargetPoolsOptions{Filter: gcpNameFilter}, gomock.Any()).Return(nil)
+		m.mockGCPClient.EXPECT().ListComputeBackendServices(gcpclient.ListComputeBackendServicesOptions{Filter: gcpNameFilter}, gomock.Any()).Return(nil)
+		m.mockGCPClient.EXPECT().ListComputeHealthChecks(gcpclient.ListComputeHealthChecksOptions{Filter: gcpNameFilter}, gomock.Any()).Return(nil)
+		m.mockGCPClient.EXPECT().ListComputeHTTPHealthChecks(gcpclient.ListComputeHTTPHealthChecksOptions{Filter: gcpNameFilter}, gomock.Any()).Return(nil)
+	}
+	azureFilter := "tagName eq 'kubernetes.io_cluster.test-infra-id' and tagValue eq 'owned'"
+	tests := []struct {
+		name              string
+		platform          hivev1.ClusterDeprovisionPlatform
+		setupMock         func(m *mocks)
+		expectErr         bool
+		expectedResources []string
+	}{
+		{
+			name: "gcp labelled resources",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				GCP: &hivev1.GCPClusterDeprovision{Region: "us-east1", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks) {
+				m.mockGCPClient.EXPECT().ListComputeInstances(gcpclient.ListComputeInstancesOptions{Filter: gcpFilter}, gomock.Any()).
+					DoAndReturn(func(_ gcpclient.ListComputeInstancesOptions, fn func(*gcpcompute.InstanceAggregatedList) error) error {
+						return fn(&gcpcompute.InstanceAggregatedList{Items: map[string]gcpcompute.InstancesScopedList{
+							"zones/us-east1-b": {Instances: []*gcpcompute.Instance{{SelfLink: "instances/test-infra-id-master-0"}}},
+						}})
+					})
+				m.mockGCPClient.EXPECT().ListComputeDisks(gcpclient.ListComputeDisksOptions{Filter: gcpFilter}, gomock.Any()).
+					DoAndReturn(func(_ gcpclient.ListComputeDisksOptions, fn func(*gcpcompute.DiskAggregatedList) error) error {
+						return fn(&gcpcompute.DiskAggregatedList{Items: map[string]gcpcompute.DisksScopedList{
+							"zones/us-east1-b": {Disks: []*gcpcompute.Disk{{SelfLink: "disks/test-infra-id-master-0"}}},
+							"zones/us-east1-c": {},
+						}})
+					})
+				m.mockGCPClient.EXPECT().ListComputeImages(gcpclient.ListComputeImagesOptions{Filter: gcpFilter}).
+					Return(&gcpcompute.ImageList{Items: []*gcpcompute.Image{{SelfLink: "images/test-infra-id-rhcos-image"}}, NextPageToken: "next"}, nil)
+				m.mockGCPClient.EXPECT().ListComputeImages(gcpclient.ListComputeImagesOptions{Filter: gcpFilter, PageToken: "next"}).
+					Return(&gcpcompute.ImageList{}, nil)
+				expectNoGCPLoadBalancerResources(m)
+				m.mockGCPClient.EXPECT().GetManagedZone("test-infra-id-private-zone").
+					Return(nil, &googleapi.Error{Code: http.StatusNotFound})
+			},
+			expectedResources: []string{
+				"instances/test-infra-id-master-0",
+				"disks/test-infra-id-master-0",
+				"images/test-infra-id-rhcos-image",
+			},
+		},
+		{
+			name: "gcp load balancer resources and private zone",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				GCP: &hivev1.GCPClusterDeprovision{Region: "us-east1", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks) {
+				expectNoGCPLabelledResources(m)
+				m.mockGCPClient.EXPECT().ListComputeForwardingRules(gcpclient.ListComputeForwardingRulesOptions{Filter: gcpNameFilter}, gomock.Any()).
+					DoAndReturn(func(_ gcpclient.ListComputeForwardingRulesOptions, fn func(*gcpcompute.ForwardingRuleAggregatedList) error) error {
+						return fn(&gcpcompute.ForwardingRuleAggregatedList{Items: map[string]gcpcompute.ForwardingRulesScopedList{
+							"regions/us-east1": {ForwardingRules: []*gcpcompute.ForwardingRule{{SelfLink: "forwardingRules/test-infra-id-api"}}},
+						}})
+					})
+				m.mockGCPClient.EXPECT().ListComputeTargetPools(gcpclient.ListComputeTargetPoolsOptions{Filter: gcpNameFilter}, gomock.Any()).
+					DoAndReturn(func(_ gcpclient.ListComputeTargetPoolsOptions, fn func(*gcpcompute.TargetPoolAggregatedList) error) error {
+						return fn(&gcpcompute.TargetPoolAggregatedList{Items: map[string]gcpcompute.TargetPoolsScopedList{
+							"regions/us-east1": {TargetPools: []*gcpcompute.TargetPool{{SelfLink: "targetPools/test-infra-id-api"}}},
+						}})
+					})
+				m.mockGCPClient.EXPECT().ListComputeBackendServices(gcpclient.ListComputeBackendServicesOptions{Filter: gcpNameFilter}, gomock.Any()).
+					DoAndReturn(func(_ gcpclient.ListComputeBackendServicesOptions, fn func(*gcpcompute.BackendServiceAggregatedList) error) error {
+						return fn(&gcpcompute.BackendServiceAggregatedList{Items: map[string]gcpcompute.BackendServicesScopedList{
+							"regions/us-east1": {BackendServices: []*gcpcompute.BackendService{{SelfLink: "backendServices/test-infra-id-api-internal"}}},
+						}})
+					})
+				m.mockGCPClient.EXPECT().ListComputeHealthChecks(gcpclient.ListComputeHealthChecksOptions{Filter: gcpNameFilter}, gomock.Any()).
+					DoAndReturn(func(_ gcpclient.ListComputeHealthChecksOptions, fn func(*gcpcompute.HealthChecksAggregatedList) error) error {
+						return fn(&gcpcompute.HealthChecksAggregatedList{Items: map[string]gcpcompute.HealthChecksScopedList{
+							"global": {HealthChecks: []*gcpcompute.HealthCheck{{SelfLink: "healthChecks/test-infra-id-api-internal"}}},
+						}})
+					})
+				m.mockGCPClient.EXPECT().ListComputeHTTPHealthChecks(gcpclient.ListComputeHTTPHealthChecksOptions{Filter: gcpNameFilter}, gomock.Any()).
+					DoAndReturn(func(_ gcpclient.ListComputeHTTPHealthChecksOptions, fn func(*gcpcompute.HttpHealthCheckList) error) error {
+						return fn(&gcpcompute.HttpHealthCheckList{Items: []*gcpcompute.HttpHealthCheck{{SelfLink: "httpHealthChecks/test-infra-id-api"}}})
+					})
+				m.mockGCPClient.EXPECT().GetManagedZone("test-infra-id-private-zone").
+					Return(&gcpdns.ManagedZone{Name: "test-infra-id-private-zone"}, nil)
+			},
+			expectedResources: []string{
+				"forwardingRules/test-infra-id-api",
+				"targetPools/test-infra-id-api",
+				"backendServices/test-infra-id-api-internal",
+				"healthChecks/test-infra-id-api-internal",
+				"httpHealthChecks/test-infra-id-api",
+				"managedZones/test-infra-id-private-zone",
+			},
+		},
+		{
+			name: "gcp private zone lookup fails",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				GCP: &hivev1.GCPClusterDeprovision{Region: "us-east1", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks) {
+				expectNoGCPLabelledResources(m)
+				expectNoGCPLoadBalancerResources(m)
+				m.mockGCPClient.EXPECT().GetManagedZone("test-infra-id-private-zone").
+					Return(nil, &googleapi.Error{Code: http.StatusForbidden})
+			},
+			expectErr: true,
+		},
+		{
+			name: "gcp listing fails",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				GCP: &hivev1.GCPClusterDeprovision{Region: "us-east1", CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks) {
+				m.mockGCPClient.EXPECT().ListComputeInstances(gomock.Any(), gomock.Any()).Return(fmt.Errorf("rateLimitExceeded"))
+			},
+			expectErr: true,
+		},
+		{
+			name: "azure resources in resource group and tagged",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				Azure: &hivev1.AzureClusterDeprovision{CredentialsSecretRef: credsRef, ResourceGroupName: pointer.String("test-rg")},
+			},
+			setupMock: func(m *mocks) {
+				m.mockAzureClient.EXPECT().ListResourcesByResourceGroup(gomock.Any(), "test-rg", "").
+					Return(newMockResourcePage(m, "/resourceGroups/test-rg/disk", "/resourceGroups/test-rg/nic"), nil)
+				m.mockAzureClient.EXPECT().ListResources(gomock.Any(), azureFilter).
+					Return(newMockResourcePage(m, "/resourceGroups/test-rg/nic", "/resourceGroups/shared/lb"), nil)
+			},
+			expectedResources: []string{
+				"/resourceGroups/shared/lb",
+				"/resourceGroups/test-rg/disk",
+				"/resourceGroups/test-rg/nic",
+			},
+		},
+		{
+			name: "azure resource group deleted",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				Azure: &hivev1.AzureClusterDeprovision{CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks) {
+				m.mockAzureClient.EXPECT().ListResourcesByResourceGroup(gomock.Any(), "test-infra-id-rg", "").
+					Return(nil, autorest.DetailedError{StatusCode: http.StatusNotFound})
+				m.mockAzureClient.EXPECT().ListResources(gomock.Any(), azureFilter).
+					Return(newMockResourcePage(m), nil)
+			},
+			expectedResources: []string{},
+		},
+		{
+			name: "azure listing fails",
+			platform: hivev1.ClusterDeprovisionPlatform{
+				Azure: &hivev1.AzureClusterDeprovision{CredentialsSecretRef: credsRef},
+			},
+			setupMock: func(m *mocks) {
+				m.mockAzureClient.EXPECT().ListResourcesByResourceGroup(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, autorest.DetailedError{StatusCode: http.StatusForbidden})
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mocks := setupDefaultMocks(t, false)
+			test.setupMock(mocks)
+			testFinders := []OrphanedResourceFinder{
+				&gcpActuator{gcpClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (gcpclient.Client, error) {
+					return mocks.mockGCPClient, nil
+				}},
+				&azureActuator{azureClientFn: func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (azureclient.Client, error) {
+					return mocks.mockAzureClient, nil
+				}},
+			}
+			deprovision := testClusterDeprovision()
+			deprovision.Spec.Platform = test.platform
+
+			var finder OrphanedResourceFinder
+			for _, f := range testFinders {
+				if f.(Actuator).CanHandle(deprovision) {
+					finder = f
+				}
+			}
+			require.NotNil(t, finder, "no finder can handle the deprovision")
+
+			resources, err := finder.FindOrphanedResources(deprovision, mocks.fakeKubeClient, log.WithField("test", test.name))
+			if test.expectErr {
+				assert.Error(t, err, "expected error looking for orphaned resources")
+			} else {
+				assert.NoError(t, err, "unexpected error looking for orphaned resources")
+				assert.Equal(t, test.expectedResources, resources, "unexpected orphaned resources")
+			}
+		})
+	}
+}
+
+// newMockResourcePage returns a single page of Azure resources with the given IDs.
+func newMockResourcePage(m *mocks, ids ...string) azureclient.ResourceListResultPage {
+	var values []resources.GenericResourceExpanded
+	for _, id := range ids {
+		values = append(values, resources.GenericResourceExpanded{ID: pointer.String(id)})
+	}
+	page := mockazure.NewMockResourceListResultPage(m.mockCtrl)
+	gomock.InOrder(
+		page.EXPECT().NotDone().Return(true),
+		page.EXPECT().NextWithContext(gomock.Any()).Return(nil),
+		page.EXPECT().NotDone().Return(false),
+	)
+	page.EXPECT().Values().Return(values)
+	return page
+}
@@ -1,12 +1,16 @@
 package clusterdeprovision
 
 import (
+	"fmt"
 	"os"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/aws/aws-sdk-go/service/sts"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -15,6 +19,27 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
+var (
+	// awsOrphanedResourceTypes are the types of regional resources, as named by the resource groups tagging API, that
+	// are looked for once the uninstall has completed. Instances are left out since terminated instances remain
+	// visible for a while after they are deleted.
+	awsOrphanedResourceTypes = []string{
+		"ec2:elastic-ip",
+		"ec2:internet-gateway",
+		"ec2:natgateway",
+		"ec2:network-interface",
+		"ec2:route-table",
+		"ec2:security-group",
+		"ec2:snapshot",
+		"ec2:subnet",
+		"ec2:volume",
+		"ec2:vpc",
+		"elasticloadbalancing:loadbalancer",
+		"elasticloadbalancing:targetgroup",
+		"s3",
+	}
+)
+
 func init() {
 	registerActuator(&awsActuator{awsClientFn: getAWSClient})
 }
@@ -22,10 +47,13 @@ func init() {
 // Ensure AWSActuator implements the Actuator interface. This will fail at compile time when false.
 var _ Actuator = &awsActuator{}
 
+// Ensure AWSActuator implements the OrphanedResourceFinder interface. This will fail at compile time when false.
+var _ OrphanedResourceFinder = &awsActuator{}
+
 // AWSActuator manages getting the desired state, getting the current state and reconciling the two.
 type awsActuator struct {
-	// awsClientFn is the function to build an AWS client, here for testing
-	awsClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (awsclient.Client, error)
+	// awsClientFn is the function to build an AWS client for a region, here for testing
+	awsClientFn func(*hivev1.ClusterDeprovision, string, client.Client, log.FieldLogger) (awsclient.Client, error)
 }
 
 // CanHandle returns true if the actuator can handle a particular ClusterDeprovision
@@ -35,7 +63,7 @@ func (a *awsActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) b
 
 // TestCredentials ensures that the the aws credentials are usable.
 func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
-	awsClient, err := a.awsClientFn(clusterDeprovision, c, logger)
+	awsClient, err := a.awsClientFn(clusterDeprovision, clusterDeprovision.Spec.Platform.AWS.Region, c, logger)
 	if err != nil {
 		return err
 	}
@@ -51,9 +79,58 @@ func (a *awsActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovis
 	return nil
 }
 
-func getAWSClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
+// FindOrphanedResources returns the ARNs of the resources of the region of the cluster, and of the hosted zones, that
+// are still tagged as owned by the cluster.
+func (a *awsActuator) FindOrphanedResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]string, error) {
+	region := clusterDeprovision.Spec.Platform.AWS.Region
+	route53Region := constants.AWSRoute53Region
+	if strings.HasPrefix(region, constants.AWSChinaRegionPrefix) {
+		route53Region = constants.AWSChinaRoute53Region
+	}
+
+	var arns []string
+	for _, search := range []struct {
+		region        string
+		resourceTypes []string
+	}{
+		{region: region, resourceTypes: awsOrphanedResourceTypes},
+		{region: route53Region, resourceTypes: []string{"route53:hostedzone"}},
+	} {
+		awsClient, err := a.awsClientFn(clusterDeprovision, search.region, c, logger)
+		if err != nil {
+			return nil, err
+		}
+		found, err := findAWSResourcesOwnedBy(awsClient, clusterDeprovision.Spec.InfraID, search.resourceTypes, logger.WithField("region", search.region))
+		if err != nil {
+			return nil, err
+		}
+		arns = append(arns, found...)
+	}
+	return arns, nil
+}
+
+func findAWSResourcesOwnedBy(awsClient awsclient.Client, infraID string, resourceTypes []string, logger log.FieldLogger) ([]string, error) {
+	tagFilter := &resourcegroupstaggingapi.TagFilter{
+		Key:    aws.String(fmt.Sprintf("kubernetes.io/cluster/%s", infraID)),
+		Values: []*string{aws.String("owned")},
+	}
+	logger.WithField("filter", fmt.Sprintf("%s=owned", aws.StringValue(tagFilter.Key))).Debug("Searching for resources by tag")
+	var arns []string
+	err := awsClient.GetResourcesPages(&resourcegroupstaggingapi.GetResourcesInput{
+		ResourceTypeFilters: aws.StringSlice(resourceTypes),
+		TagFilters:          []*resourcegroupstaggingapi.TagFilter{tagFilter},
+	}, func(resp *resourcegroupstaggingapi.GetResourcesOutput, lastPage bool) bool {
+		for _, resource := range resp.ResourceTagMappingList {
+			arns = append(arns, aws.StringValue(resource.ResourceARN))
+		}
+		return true
+	})
+	return arns, err
+}
+
+func getAWSClient(cd *hivev1.ClusterDeprovision, region string, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
 	options := awsclient.Options{
-		Region: cd.Spec.Platform.AWS.Region,
+		Region: region,
 		CredentialsSource: awsclient.CredentialsSource{
 			Secret: &awsclient.SecretCredentialsSource{
 				Namespace: cd.Namespace,
@@ -0,0 +1,109 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/azureclient"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+func init() {
+	registerActuator(&azureActuator{azureClientFn: getAzureClient})
+}
+
+// Ensure azureActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &azureActuator{}
+
+// Ensure azureActuator implements the OrphanedResourceFinder interface. This will fail at compile time when false.
+var _ OrphanedResourceFinder = &azureActuator{}
+
+type azureActuator struct {
+	// azureClientFn is the function to build an Azure client, here for testing
+	azureClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (azureclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *azureActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.Azure != nil
+}
+
+// TestCredentials ensures that the Azure credentials are usable by listing the virtual machines of the subscription.
+// Only the first page is fetched.
+func (a *azureActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	azureClient, err := a.azureClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+
+	_, err = azureClient.ListAllVirtualMachines(context.TODO(), "true")
+	return err
+}
+
+// FindOrphanedResources returns the IDs of the resources left in the resource group of the cluster, and of the
+// resources of the subscription that are still tagged as owned by the cluster.
+func (a *azureActuator) FindOrphanedResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]string, error) {
+	azureClient, err := a.azureClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	infraID := clusterDeprovision.Spec.InfraID
+	resourceGroupName := fmt.Sprintf("%s-rg", infraID)
+	if rg := clusterDeprovision.Spec.Platform.Azure.ResourceGroupName; rg != nil && *rg != "" {
+		resourceGroupName = *rg
+	}
+
+	ids := sets.NewString()
+	ctx := context.TODO()
+	logger.WithField("resourceGroup", resourceGroupName).Debug("Searching for resources in resource group")
+	page, err := azureClient.ListResourcesByResourceGroup(ctx, resourceGroupName, "")
+	if err := collectAzureResourceIDs(ctx, page, err, ids); err != nil {
+		if detailedErr, ok := err.(autorest.DetailedError); !ok || detailedErr.StatusCode != http.StatusNotFound {
+			return nil, errors.Wrap(err, "failed to list resources of resource group")
+		}
+		logger.WithField("resourceGroup", resourceGroupName).Debug("Resource group not found")
+	}
+
+	filter := fmt.Sprintf("tagName eq 'kubernetes.io_cluster.%s' and tagValue eq 'owned'", infraID)
+	logger.WithField("filter", filter).Debug("Searching for resources by tag")
+	page, err = azureClient.ListResources(ctx, filter)
+	if err := collectAzureResourceIDs(ctx, page, err, ids); err != nil {
+		return nil, errors.Wrap(err, "failed to list tagged resources")
+	}
+	return ids.List(), nil
+}
+
+// collectAzureResourceIDs adds the IDs of the resources of all the pages, starting with the given page, to ids.
+func collectAzureResourceIDs(ctx context.Context, page azureclient.ResourceListResultPage, err error, ids sets.String) error {
+	for ; err == nil && page.NotDone(); err = page.NextWithContext(ctx) {
+		for _, resource := range page.Values() {
+			ids.Insert(to.String(resource.ID))
+		}
+	}
+	return err
+}
+
+func getAzureClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (azureclient.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Azure.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch Azure credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch Azure credentials secret")
+	}
+	var cloudName string
+	if cd.Spec.Platform.Azure.CloudName != nil {
+		cloudName = cd.Spec.Platform.Azure.CloudName.Name()
+	}
+	return azureclient.NewClientFromSecret(secret, cloudName)
+}
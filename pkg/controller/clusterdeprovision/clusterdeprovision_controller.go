@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
@@ -40,6 +41,19 @@ const (
 	jobHashAnnotation             = "hive.openshift.io/jobhash"
 	authenticationFailedReason    = "AuthenticationFailed"
 	authenticationSucceededReason = "AuthenticationSucceeded"
+
+	orphanedResourcesFoundReason          = "OrphanedResourcesFound"
+	orphanedResourcesNotFoundReason       = "NoOrphanedResources"
+	orphanedResourcesRecheckPendingReason = "OrphanedResourcesRecheckPending"
+	orphanedResourcesSearchFailedReason   = "OrphanedResourcesSearchFailed"
+
+	// orphanedResourcesRecheckDelay is how long to wait before looking again for resources found right after the
+	// uninstall. The cloud APIs used to find them lag the actual deletions, so resources deleted by the uninstall can
+	// still be listed for a while.
+	orphanedResourcesRecheckDelay = 5 * time.Minute
+
+	// maxOrphanedResources is the maximum number of orphaned resources listed in the status of a ClusterDeprovision.
+	maxOrphanedResources = 50
 )
 
 var (
@@ -76,6 +90,16 @@ func Add(mgr manager.Manager) error {
 		logger.WithError(err).Error("could not get controller configurations")
 		return err
 	}
+	// Read the metrics config from hiveconfig and set values for mapClusterTypeLabelToValue, if present
+	mConfig, err := hivemetrics.ReadMetricsConfig()
+	if err != nil {
+		logger.WithError(err).Error("error reading metrics config")
+		return err
+	}
+	// Register the metrics. This is done here to ensure we define the metrics with optional label support after we have
+	// read the hiveconfig, and we register them only once.
+	registerMetrics(mConfig, logger)
+
 	r, err := newReconciler(mgr, clientRateLimiter)
 	if err != nil {
 		return err
@@ -95,10 +119,21 @@ func newReconciler(mgr manager.Manager, rateLimiter flowcontrol.RateLimiter) (re
 			return nil, err
 		}
 	}
+	verifyDeprovisions := false
+	if val, ok := os.LookupEnv(constants.VerifyDeprovisionsEnvVar); ok {
+		var err error
+		verifyDeprovisions, err = strconv.ParseBool(val)
+		if err != nil {
+			log.WithError(err).WithField(constants.VerifyDeprovisionsEnvVar, os.Getenv(constants.VerifyDeprovisionsEnvVar)).
+				Error("error parsing bool from env var")
+			return nil, err
+		}
+	}
 	return &ReconcileClusterDeprovision{
 		Client:               controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &rateLimiter),
 		scheme:               mgr.GetScheme(),
 		deprovisionsDisabled: deprovisionsDisabled,
+		verifyDeprovisions:   verifyDeprovisions,
 	}, nil
 }
 
@@ -142,6 +177,7 @@ type ReconcileClusterDeprovision struct {
 	client.Client
 	scheme               *runtime.Scheme
 	deprovisionsDisabled bool
+	verifyDeprovisions   bool
 }
 
 // Reconcile reads that state of the cluster for a ClusterDeprovision object and makes changes based on the state read
@@ -350,6 +386,20 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 		)
 		instance.Status.Conditions = conditions
 
+		orphanedResourcesFound := false
+		if r.verifyDeprovisions {
+			var recheckAfter time.Duration
+			orphanedResourcesFound, recheckAfter = r.verifyDeprovision(instance, actuator, rLog)
+			if recheckAfter > 0 {
+				rLog.WithField("recheckAfter", recheckAfter).Info("waiting to look for orphaned resources again")
+				if err := r.Status().Update(context.TODO(), instance); err != nil {
+					rLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating request status")
+					return reconcile.Result{}, err
+				}
+				return reconcile.Result{RequeueAfter: recheckAfter}, nil
+			}
+		}
+
 		// jobDuration calculates the time elapsed since the uninstall job started for deprovision job
 		jobDuration := existingJob.Status.CompletionTime.Time.Sub(existingJob.Status.StartTime.Time)
 		rLog.WithField("duration", jobDuration.Seconds()).Debug("uninstall job completed")
@@ -360,6 +410,9 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 			return reconcile.Result{}, err
 		}
 		metricUninstallJobDuration.Observe(float64(jobDuration.Seconds()))
+		if orphanedResourcesFound {
+			metricDeprovisionsWithOrphanedResources.Observe(cd, map[string]string{"platform": deprovisionPlatform(instance)}, 1)
+		}
 		return reconcile.Result{}, nil
 	}
 
@@ -411,6 +464,111 @@ func (r *ReconcileClusterDeprovision) Reconcile(ctx context.Context, request rec
 	return reconcile.Result{}, nil
 }
 
+// verifyDeprovision looks for the cloud resources left behind by the uninstall and records them in the status of the
+// ClusterDeprovision. It returns true if resources were left behind. The deprovision is completed even so, in order
+// not to block the deletion of the ClusterDeployment, and likewise if the resources cannot be looked for.
+// Resources found by the first look may be ones whose deletion the cloud APIs have yet to catch up with, so they are
+// only reported once they are found again after orphanedResourcesRecheckDelay. Until then, a non-zero duration after
+// which to look again is returned.
+func (r *ReconcileClusterDeprovision) verifyDeprovision(instance *hivev1.ClusterDeprovision, actuator Actuator, rLog log.FieldLogger) (bool, time.Duration) {
+	finder, ok := actuator.(OrphanedResourceFinder)
+	if !ok {
+		rLog.Debug("deprovision verification is not supported for this provider")
+		return false, 0
+	}
+
+	cond := controllerutils.FindCondition(instance.Status.Conditions, hivev1.OrphanedResourcesClusterDeprovisionCondition)
+	recheckPending := cond != nil && cond.Reason == orphanedResourcesRecheckPendingReason
+	if recheckPending {
+		if wait := orphanedResourcesRecheckDelay - time.Since(cond.LastTransitionTime.Time); wait > 0 {
+			return false, wait
+		}
+	}
+
+	orphanedResources, err := finder.FindOrphanedResources(instance, r.Client, rLog)
+	if err != nil {
+		// Looking for resources may need cloud permissions the uninstall does not, so failing to look must not leave
+		// the ClusterDeployment stuck deleting.
+		rLog.WithError(err).Error("could not look for orphaned resources, completing the deprovision without verifying it")
+		setOrphanedResourcesUnknown(instance, orphanedResourcesSearchFailedReason, controllerutils.ErrorScrub(err))
+		return false, 0
+	}
+	status, reason, message := corev1.ConditionFalse, orphanedResourcesNotFoundReason, "No resources tagged with the infraID remain"
+	switch {
+	case len(orphanedResources) > 0 && !recheckPending:
+		rLog.WithField("orphanedResources", orphanedResources).Info("resources tagged with the infraID found right after the uninstall, looking again later")
+		message = fmt.Sprintf("%d resources tagged with the infraID found right after the uninstall, looking again later", len(orphanedResources))
+		setOrphanedResourcesUnknown(instance, orphanedResourcesRecheckPendingReason, message)
+		return false, orphanedResourcesRecheckDelay
+	case len(orphanedResources) > 0:
+		rLog.WithField("orphanedResources", orphanedResources).Warn("resources tagged with the infraID remain after the uninstall")
+		status, reason = corev1.ConditionTrue, orphanedResourcesFoundReason
+		message = fmt.Sprintf("%d resources tagged with the infraID remain after the uninstall", len(orphanedResources))
+		if len(orphanedResources) > maxOrphanedResources {
+			orphanedResources = orphanedResources[:maxOrphanedResources]
+		}
+	}
+	instance.Status.OrphanedResources = orphanedResources
+	instance.Status.Conditions, _ = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.OrphanedResourcesClusterDeprovisionCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	return status == corev1.ConditionTrue, 0
+}
+
+// setOrphanedResourcesUnknown sets the OrphanedResources condition to Unknown, adding it if the ClusterDeprovision
+// does not have it yet.
+func setOrphanedResourcesUnknown(instance *hivev1.ClusterDeprovision, reason, message string) {
+	if controllerutils.FindCondition(instance.Status.Conditions, hivev1.OrphanedResourcesClusterDeprovisionCondition) == nil {
+		now := metav1.Now()
+		instance.Status.Conditions = append(instance.Status.Conditions, hivev1.ClusterDeprovisionCondition{
+			Type:               hivev1.OrphanedResourcesClusterDeprovisionCondition,
+			Status:             corev1.ConditionUnknown,
+			Reason:             reason,
+			Message:            message,
+			LastTransitionTime: now,
+			LastProbeTime:      now,
+		})
+		return
+	}
+	instance.Status.Conditions, _ = controllerutils.SetClusterDeprovisionConditionWithChangeCheck(
+		instance.Status.Conditions,
+		hivev1.OrphanedResourcesClusterDeprovisionCondition,
+		corev1.ConditionUnknown,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+// deprovisionPlatform returns the name of the platform of the cluster being deprovisioned.
+func deprovisionPlatform(instance *hivev1.ClusterDeprovision) string {
+	p := instance.Spec.Platform
+	switch {
+	case p.AlibabaCloud != nil:
+		return constants.PlatformAlibabaCloud
+	case p.AWS != nil:
+		return constants.PlatformAWS
+	case p.Azure != nil:
+		return constants.PlatformAzure
+	case p.GCP != nil:
+		return constants.PlatformGCP
+	case p.OpenStack != nil:
+		return constants.PlatformOpenStack
+	case p.VSphere != nil:
+		return constants.PlatformVSphere
+	case p.Ovirt != nil:
+		return constants.PlatformOvirt
+	case p.IBMCloud != nil:
+		return constants.PlatformIBMCloud
+	}
+	return constants.PlatformUnknown
+}
+
 func generateOwnershipUniqueKeys(owner hivev1.MetaRuntimeObject) []*controllerutils.OwnershipUniqueKey {
 	return []*controllerutils.OwnershipUniqueKey{
 		{
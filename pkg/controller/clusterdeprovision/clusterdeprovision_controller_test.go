@@ -3,10 +3,15 @@ package clusterdeprovision
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,10 +26,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/apis/hive/v1/metricsconfig"
 	awsclient "github.com/openshift/hive/pkg/awsclient"
 	"github.com/openshift/hive/pkg/constants"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
@@ -38,6 +45,7 @@ const (
 
 func init() {
 	log.SetLevel(log.DebugLevel)
+	registerMetrics(&metricsconfig.MetricsConfig{}, log.WithField("controller", "clusterDeprovision"))
 }
 
 func TestClusterDeprovisionReconcile(t *testing.T) {
@@ -58,6 +66,14 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 		validate                       func(t *testing.T, c client.Client)
 		expectErr                      bool
 		deprovisionsDisabled           bool
+		verifyDeprovisions             bool
+		// orphanedResources are the ARNs of the resources found by the deprovision verification.
+		orphanedResources []string
+		getResourcesError error
+		// resourcesNotSearched is true when the deprovision verification is expected to wait before looking for
+		// orphaned resources again.
+		resourcesNotSearched bool
+		expectedRequeueAfter time.Duration
 	}{
 		{
 			name: "no-op deleting",
@@ -152,6 +168,144 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 				validateCompleted(t, c)
 			},
 		},
+		{
+			name:        "completed without orphaned resources",
+			deprovision: testClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			},
+			mockGetCallerIdentity: true,
+			verifyDeprovisions:    true,
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateOrphanedResources(t, c, "", "", nil)
+			},
+		},
+		{
+			name:        "orphaned resources found right after the uninstall are looked for again later",
+			deprovision: testClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			},
+			mockGetCallerIdentity: true,
+			verifyDeprovisions:    true,
+			orphanedResources: []string{
+				"arn:aws:ec2:us-east-1:123456789012:volume/vol-0123456789abcdef0",
+			},
+			expectedRequeueAfter: orphanedResourcesRecheckDelay,
+			validate: func(t *testing.T, c client.Client) {
+				validateNotCompleted(t, c)
+				validateOrphanedResources(t, c, corev1.ConditionUnknown, orphanedResourcesRecheckPendingReason, nil)
+			},
+		},
+		{
+			name:        "orphaned resources not looked for again before the delay",
+			deprovision: testClusterDeprovisionWithRecheckPending(time.Minute),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			},
+			mockGetCallerIdentity: true,
+			verifyDeprovisions:    true,
+			resourcesNotSearched:  true,
+			validate: func(t *testing.T, c client.Client) {
+				validateNotCompleted(t, c)
+				validateOrphanedResources(t, c, corev1.ConditionUnknown, orphanedResourcesRecheckPendingReason, nil)
+			},
+		},
+		{
+			name:        "completed with orphaned resources found again",
+			deprovision: testClusterDeprovisionWithRecheckPending(orphanedResourcesRecheckDelay),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			},
+			mockGetCallerIdentity: true,
+			verifyDeprovisions:    true,
+			orphanedResources: []string{
+				"arn:aws:ec2:us-east-1:123456789012:volume/vol-0123456789abcdef0",
+				"arn:aws:route53:::hostedzone/Z0123456789ABCDEFGHIJ",
+			},
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateOrphanedResources(t, c, corev1.ConditionTrue, orphanedResourcesFoundReason, []string{
+					"arn:aws:ec2:us-east-1:123456789012:volume/vol-0123456789abcdef0",
+					"arn:aws:route53:::hostedzone/Z0123456789ABCDEFGHIJ",
+				})
+				assert.NoError(t, testutil.GatherAndCompare(metrics.Registry, strings.NewReader(`
+# HELP hive_cluster_deprovisions_with_orphaned_resources_total Counter incremented every time cloud resources tagged with the infraID of a cluster remain once its uninstall has completed.
+# TYPE hive_cluster_deprovisions_with_orphaned_resources_total counter
+hive_cluster_deprovisions_with_orphaned_resources_total{cluster_type="unspecified",platform="aws"} 1
+`), "hive_cluster_deprovisions_with_orphaned_resources_total"), "unexpected orphaned resources metric")
+			},
+		},
+		{
+			name:        "completed when orphaned resources are gone on second look",
+			deprovision: testClusterDeprovisionWithRecheckPending(orphanedResourcesRecheckDelay),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			},
+			mockGetCallerIdentity: true,
+			verifyDeprovisions:    true,
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateOrphanedResources(t, c, corev1.ConditionFalse, orphanedResourcesNotFoundReason, nil)
+			},
+		},
+		{
+			name:        "completed when orphaned resources cannot be looked for",
+			deprovision: testClusterDeprovision(),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			},
+			mockGetCallerIdentity: true,
+			verifyDeprovisions:    true,
+			getResourcesError:     awserr.New("AccessDeniedException", "not authorized to perform: tag:GetResources", fmt.Errorf("")),
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateOrphanedResources(t, c, corev1.ConditionUnknown, orphanedResourcesSearchFailedReason, nil)
+			},
+		},
+		{
+			name:        "completed when orphaned resources cannot be looked for again",
+			deprovision: testClusterDeprovisionWithRecheckPending(orphanedResourcesRecheckDelay),
+			deployment:  testDeletedClusterDeployment(),
+			existing: []runtime.Object{
+				testUninstallJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}),
+			},
+			mockGetCallerIdentity: true,
+			verifyDeprovisions:    true,
+			getResourcesError:     awserr.New("ThrottlingException", "", fmt.Errorf("")),
+			validate: func(t *testing.T, c client.Client) {
+				validateCompleted(t, c)
+				validateOrphanedResources(t, c, corev1.ConditionUnknown, orphanedResourcesSearchFailedReason, nil)
+			},
+		},
 		{
 			name:        "error on failed delete",
 			deprovision: testClusterDeprovision(),
@@ -291,19 +445,43 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 					Return(nil, test.expectedGetCallerIdentityError)
 			}
 
+			if test.verifyDeprovisions && !test.resourcesNotSearched {
+				mocks.mockAWSClient.EXPECT().
+					GetResourcesPages(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(input *resourcegroupstaggingapi.GetResourcesInput, fn func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+						// Return the resources of the services searched for
+						out := &resourcegroupstaggingapi.GetResourcesOutput{}
+						for _, arn := range test.orphanedResources {
+							service := strings.Split(arn, ":")[2]
+							for _, resourceType := range input.ResourceTypeFilters {
+								if strings.HasPrefix(aws.StringValue(resourceType), service) {
+									out.ResourceTagMappingList = append(out.ResourceTagMappingList, &resourcegroupstaggingapi.ResourceTagMapping{
+										ResourceARN: aws.String(arn),
+									})
+									break
+								}
+							}
+						}
+						fn(out, true)
+						return test.getResourcesError
+					}).
+					MinTimes(1)
+			}
+
 			r := &ReconcileClusterDeprovision{
 				Client:               mocks.fakeKubeClient,
 				scheme:               scheme.Scheme,
 				deprovisionsDisabled: test.deprovisionsDisabled,
+				verifyDeprovisions:   test.verifyDeprovisions,
 			}
 
 			// Save the list of actuators so that it can be restored at the end of this test
 			actuatorsSaved := actuators
-			actuators = []Actuator{&awsActuator{awsClientFn: func(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
+			actuators = []Actuator{&awsActuator{awsClientFn: func(clusterDeprovision *hivev1.ClusterDeprovision, region string, c client.Client, logger log.FieldLogger) (awsclient.Client, error) {
 				return mocks.mockAWSClient, nil
 			}}}
 
-			_, err := r.Reconcile(context.TODO(), reconcile.Request{
+			result, err := r.Reconcile(context.TODO(), reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      testName,
 					Namespace: testNamespace,
@@ -322,6 +500,11 @@ func TestClusterDeprovisionReconcile(t *testing.T) {
 			} else {
 				assert.Nil(t, err, "Unexpected error: %v", err)
 			}
+			if test.resourcesNotSearched {
+				assert.True(t, result.RequeueAfter > 0 && result.RequeueAfter < orphanedResourcesRecheckDelay, "unexpected requeue after %v", result.RequeueAfter)
+			} else {
+				assert.Equal(t, test.expectedRequeueAfter, result.RequeueAfter, "unexpected requeue after")
+			}
 
 		})
 	}
@@ -348,6 +531,21 @@ func testClusterDeprovision() *hivev1.ClusterDeprovision {
 	}
 }
 
+// testClusterDeprovisionWithRecheckPending returns a ClusterDeprovision for which orphaned resources were found the
+// given duration ago.
+func testClusterDeprovisionWithRecheckPending(since time.Duration) *hivev1.ClusterDeprovision {
+	req := testClusterDeprovision()
+	found := metav1.NewTime(time.Now().Add(-since))
+	req.Status.Conditions = append(req.Status.Conditions, hivev1.ClusterDeprovisionCondition{
+		Type:               hivev1.OrphanedResourcesClusterDeprovisionCondition,
+		Status:             corev1.ConditionUnknown,
+		Reason:             orphanedResourcesRecheckPendingReason,
+		LastTransitionTime: found,
+		LastProbeTime:      found,
+	})
+	return req
+}
+
 func testDeletedClusterDeployment() *hivev1.ClusterDeployment {
 	now := metav1.Now()
 	cd := testClusterDeployment()
@@ -456,3 +654,20 @@ func validateCompleted(t *testing.T, c client.Client) {
 		t.Errorf("request is expected to be in completed state")
 	}
 }
+
+// validateOrphanedResources validates the orphaned resources of the ClusterDeprovision. An empty expectedStatus means
+// that no OrphanedResources condition is expected.
+func validateOrphanedResources(t *testing.T, c client.Client, expectedStatus corev1.ConditionStatus, expectedReason string, expectedResources []string) {
+	req := &hivev1.ClusterDeprovision{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, req)
+	require.NoError(t, err, "unexpected error getting ClusterDeprovision")
+
+	cond := controllerutils.FindCondition(req.Status.Conditions, hivev1.OrphanedResourcesClusterDeprovisionCondition)
+	if expectedStatus == "" {
+		assert.Nil(t, cond, "expected no OrphanedResources condition")
+	} else if assert.NotNil(t, cond, "expected an OrphanedResources condition") {
+		assert.Equal(t, expectedStatus, cond.Status, "unexpected OrphanedResources condition status")
+		assert.Equal(t, expectedReason, cond.Reason, "unexpected OrphanedResources condition reason")
+	}
+	assert.Equal(t, expectedResources, req.Status.OrphanedResources, "unexpected orphaned resources")
+}
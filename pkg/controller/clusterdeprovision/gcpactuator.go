@@ -0,0 +1,190 @@
+package clusterdeprovision
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	compute "google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/gcpclient"
+)
+
+func init() {
+	registerActuator(&gcpActuator{gcpClientFn: getGCPClient})
+}
+
+// Ensure gcpActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &gcpActuator{}
+
+// Ensure gcpActuator implements the OrphanedResourceFinder interface. This will fail at compile time when false.
+var _ OrphanedResourceFinder = &gcpActuator{}
+
+type gcpActuator struct {
+	// gcpClientFn is the function to build a GCP client, here for testing
+	gcpClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (gcpclient.Client, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *gcpActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.GCP != nil
+}
+
+// TestCredentials ensures that the GCP credentials are usable by listing the compute zones of the region of the
+// cluster.
+func (a *gcpActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	gcpClient, err := a.gcpClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+
+	_, err = gcpClient.ListComputeZones(gcpclient.ListComputeZonesOptions{
+		MaxResults: 1,
+		Filter:     fmt.Sprintf("(region eq '.*%s.*')", clusterDeprovision.Spec.Platform.GCP.Region),
+	})
+	return err
+}
+
+// FindOrphanedResources returns the self links of the instances, disks and images of the project that are still
+// labelled as owned by the cluster, of the load balancer resources named after the infraID, and of the private DNS
+// zone of the cluster.
+func (a *gcpActuator) FindOrphanedResources(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) ([]string, error) {
+	gcpClient, err := a.gcpClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := fmt.Sprintf("labels.kubernetes-io-cluster-%s = \"owned\"", clusterDeprovision.Spec.InfraID)
+	logger.WithField("filter", filter).Debug("Searching for resources by label")
+
+	var selfLinks []string
+	err = gcpClient.ListComputeInstances(gcpclient.ListComputeInstancesOptions{Filter: filter}, func(list *compute.InstanceAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, instance := range scopedList.Instances {
+				selfLinks = append(selfLinks, instance.SelfLink)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcpClient.ListComputeDisks(gcpclient.ListComputeDisksOptions{Filter: filter}, func(list *compute.DiskAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, disk := range scopedList.Disks {
+				selfLinks = append(selfLinks, disk.SelfLink)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts := gcpclient.ListComputeImagesOptions{Filter: filter}
+	for {
+		images, err := gcpClient.ListComputeImages(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch compute images")
+		}
+		for _, image := range images.Items {
+			selfLinks = append(selfLinks, image.SelfLink)
+		}
+		if images.NextPageToken == "" {
+			break
+		}
+		opts.PageToken = images.NextPageToken
+	}
+
+	// Forwarding rules, target pools, backend services and health checks cannot all be labelled, so they are found by
+	// the infraID prefix of their names, as the installer does when destroying the cluster.
+	nameFilter := fmt.Sprintf("name : \"%s-*\"", clusterDeprovision.Spec.InfraID)
+	logger.WithField("filter", nameFilter).Debug("Searching for load balancer resources by name")
+	err = gcpClient.ListComputeForwardingRules(gcpclient.ListComputeForwardingRulesOptions{Filter: nameFilter}, func(list *compute.ForwardingRuleAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, rule := range scopedList.ForwardingRules {
+				selfLinks = append(selfLinks, rule.SelfLink)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcpClient.ListComputeTargetPools(gcpclient.ListComputeTargetPoolsOptions{Filter: nameFilter}, func(list *compute.TargetPoolAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, pool := range scopedList.TargetPools {
+				selfLinks = append(selfLinks, pool.SelfLink)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcpClient.ListComputeBackendServices(gcpclient.ListComputeBackendServicesOptions{Filter: nameFilter}, func(list *compute.BackendServiceAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, service := range scopedList.BackendServices {
+				selfLinks = append(selfLinks, service.SelfLink)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcpClient.ListComputeHealthChecks(gcpclient.ListComputeHealthChecksOptions{Filter: nameFilter}, func(list *compute.HealthChecksAggregatedList) error {
+		for _, scopedList := range list.Items {
+			for _, check := range scopedList.HealthChecks {
+				selfLinks = append(selfLinks, check.SelfLink)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = gcpClient.ListComputeHTTPHealthChecks(gcpclient.ListComputeHTTPHealthChecksOptions{Filter: nameFilter}, func(list *compute.HttpHealthCheckList) error {
+		for _, check := range list.Items {
+			selfLinks = append(selfLinks, check.SelfLink)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The records of the cluster are in its private zone, which the uninstall deletes along with them.
+	zoneName := clusterDeprovision.Spec.InfraID + "-private-zone"
+	zone, err := gcpClient.GetManagedZone(zoneName)
+	if err != nil {
+		if gcpErr, ok := err.(*googleapi.Error); ok && gcpErr.Code == http.StatusNotFound {
+			return selfLinks, nil
+		}
+		return nil, errors.Wrapf(err, "failed to fetch managed zone %s", zoneName)
+	}
+	selfLinks = append(selfLinks, "managedZones/"+zone.Name)
+	return selfLinks, nil
+}
+
+func getGCPClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (gcpclient.Client, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.GCP.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch GCP credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch GCP credentials secret")
+	}
+	return gcpclient.NewClientFromSecret(secret)
+}
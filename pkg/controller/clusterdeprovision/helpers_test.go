@@ -14,14 +14,18 @@ import (
 	mockazure "github.com/openshift/hive/pkg/azureclient/mock"
 	ofake "github.com/openshift/hive/pkg/client/fake"
 	mockgcp "github.com/openshift/hive/pkg/gcpclient/mock"
+	mockibm "github.com/openshift/hive/pkg/ibmclient/mock"
+	mockopenstack "github.com/openshift/hive/pkg/openstackclient/mock"
 )
 
 type mocks struct {
-	fakeKubeClient  client.Client
-	mockCtrl        *gomock.Controller
-	mockAWSClient   *mockaws.MockClient
-	mockGCPClient   *mockgcp.MockClient
-	mockAzureClient *mockazure.MockClient
+	fakeKubeClient      client.Client
+	mockCtrl            *gomock.Controller
+	mockAWSClient       *mockaws.MockClient
+	mockGCPClient       *mockgcp.MockClient
+	mockAzureClient     *mockazure.MockClient
+	mockIBMClient       *mockibm.MockAPI
+	mockOpenStackClient *mockopenstack.MockAPI
 }
 
 // setupDefaultMocks is an easy way to setup all of the default mocks
@@ -41,6 +45,8 @@ func setupDefaultMocks(t *testing.T, failDelete bool, initObjs ...runtime.Object
 	mocks.mockAWSClient = mockaws.NewMockClient(mocks.mockCtrl)
 	mocks.mockGCPClient = mockgcp.NewMockClient(mocks.mockCtrl)
 	mocks.mockAzureClient = mockazure.NewMockClient(mocks.mockCtrl)
+	mocks.mockIBMClient = mockibm.NewMockAPI(mocks.mockCtrl)
+	mocks.mockOpenStackClient = mockopenstack.NewMockAPI(mocks.mockCtrl)
 
 	return mocks
 }
@@ -0,0 +1,32 @@
+package clusterdeprovision
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/openshift/hive/apis/hive/v1/metricsconfig"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+)
+
+var (
+	// Declare the metrics which allow optional labels to be added.
+	// They are defined later once the hive config has been read.
+	metricDeprovisionsWithOrphanedResources hivemetrics.CounterVecWithDynamicLabels
+)
+
+func registerMetrics(mConfig *metricsconfig.MetricsConfig, log log.FieldLogger) {
+	mapClusterTypeLabelToValue := hivemetrics.GetOptionalClusterTypeLabels(mConfig)
+
+	// metricDeprovisionsWithOrphanedResources is not labeled with the cluster, so that it does not gain a series for
+	// every deprovisioned cluster. The clusters are named in the logs.
+	metricDeprovisionsWithOrphanedResources = *hivemetrics.NewCounterVecWithDynamicLabels(
+		&prometheus.CounterOpts{
+			Name: "hive_cluster_deprovisions_with_orphaned_resources_total",
+			Help: "Counter incremented every time cloud resources tagged with the infraID of a cluster remain once its uninstall has completed.",
+		},
+		[]string{"platform"},
+		mapClusterTypeLabelToValue,
+	)
+
+	metricDeprovisionsWithOrphanedResources.Register()
+}
@@ -0,0 +1,60 @@
+package clusterdeprovision
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/openstackclient"
+)
+
+func init() {
+	registerActuator(&openStackActuator{openStackClientFn: getOpenStackClient})
+}
+
+// Ensure openStackActuator implements the Actuator interface. This will fail at compile time when false.
+var _ Actuator = &openStackActuator{}
+
+type openStackActuator struct {
+	// openStackClientFn is the function to build an OpenStack client, here for testing
+	openStackClientFn func(*hivev1.ClusterDeprovision, client.Client, log.FieldLogger) (openstackclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeprovision
+func (a *openStackActuator) CanHandle(clusterDeprovision *hivev1.ClusterDeprovision) bool {
+	return clusterDeprovision.Spec.Platform.OpenStack != nil
+}
+
+// TestCredentials ensures that the OpenStack credentials are usable by listing the servers of the cluster.
+func (a *openStackActuator) TestCredentials(clusterDeprovision *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) error {
+	openStackClient, err := a.openStackClientFn(clusterDeprovision, c, logger)
+	if err != nil {
+		return err
+	}
+
+	_, err = openStackClient.ListServers(context.TODO(), clusterDeprovision.Spec.InfraID)
+	return err
+}
+
+func getOpenStackClient(cd *hivev1.ClusterDeprovision, c client.Client, logger log.FieldLogger) (openstackclient.API, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch OpenStack credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch OpenStack credentials secret")
+	}
+	buf := &bytes.Buffer{}
+	if ref := cd.Spec.Platform.OpenStack.CertificatesSecretRef; ref != nil && ref.Name != "" {
+		if err := controllerutils.TrustBundleFromSecretToWriter(c, cd.Namespace, ref.Name, buf); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to load trust bundle from CertificatesSecretRef")
+			return nil, errors.Wrap(err, "failed to load trust bundle from CertificatesSecretRef")
+		}
+	}
+	return openstackclient.NewClientFromSecret(secret, cd.Spec.Platform.OpenStack.Cloud, buf.Bytes())
+}
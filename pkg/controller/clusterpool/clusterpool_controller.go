@@ -8,19 +8,21 @@ import (
 	"reflect"
 	"sort"
 	"strings"
+	"time"
 
 	yamlpatch "github.com/krishicks/yaml-patch"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
-	"github.com/davegardnerisme/deephash"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/flowcontrol"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -49,6 +51,7 @@ const (
 	icSecretDependent               = "install config template secret"
 	cdClusterPoolIndex              = "spec.clusterpool.namespacedname"
 	claimClusterPoolIndex           = "spec.clusterpoolname"
+	defaultAutoscalingWindow        = time.Hour
 )
 
 var (
@@ -287,7 +290,7 @@ type ReconcileClusterPool struct {
 
 // Reconcile reads the state of the ClusterPool, checks if we currently have enough ClusterDeployments waiting, and
 // attempts to reach the desired state if not.
-func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.Request) (result reconcile.Result, returnErr error) {
 	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterPool", request.NamespacedName)
 	logger.Info("reconciling cluster pool")
 	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
@@ -300,6 +303,7 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		if apierrors.IsNotFound(err) {
 			logger.Info("pool not found")
 			r.expectations.DeleteExpectations(request.NamespacedName.String())
+			clearAutoscalingMetrics(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -308,9 +312,21 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	}
 	logger = controllerutils.AddLogFields(controllerutils.MetaObjectLogTagger{Object: clp}, logger)
 
-	if clp.Spec.RunningCount != clp.Spec.Size && poolAlwaysRunning(clp) {
-		return reconcile.Result{}, errors.New("Hibernation is not supported on Openstack, VShpere and Ovirt. Must set runningCount==size.")
+	sizing := effectiveSizing(clp, time.Now(), logger)
+
+	// Come back when the SizingSchedule or Autoscaling may next change the effective sizing, however we return.
+	var requeueAt time.Time
+	if sizing.NextTransition != nil {
+		requeueAt = sizing.NextTransition.Time
 	}
+	defer func() {
+		if returnErr != nil || requeueAt.IsZero() {
+			return
+		}
+		if after := time.Until(requeueAt); result.RequeueAfter == 0 || after < result.RequeueAfter {
+			result.RequeueAfter = after
+		}
+	}()
 
 	// Initialize cluster pool conditions if not set
 	newConditions, changed := controllerutils.InitializeClusterPoolConditions(clp.Status.Conditions, clusterPoolConditions)
@@ -326,7 +342,8 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 
 	// If the pool is deleted, clear finalizer once all ClusterDeployments have been deleted.
 	if clp.DeletionTimestamp != nil {
-		return reconcile.Result{}, r.reconcileDeletedPool(clp, logger)
+		clearAutoscalingMetrics(clp.Namespace, clp.Name)
+		return reconcile.Result{}, r.reconcileDeletedPool(clp, sizing, logger)
 	}
 
 	// Add finalizer if not already present
@@ -344,7 +361,7 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, nil
 	}
 
-	poolVersion := calculatePoolVersion(clp)
+	poolVersion := controllerutils.CalculatePoolVersion(clp)
 
 	cds, err := getAllClusterDeploymentsForPool(r.Client, clp, poolVersion, logger)
 	if err != nil {
@@ -361,13 +378,18 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		return reconcile.Result{}, err
 	}
 
+	recentClaims, nextScaleDown := autoscale(clp, claims, sizing, time.Now(), logger)
+	if !nextScaleDown.IsZero() && (requeueAt.IsZero() || nextScaleDown.Before(requeueAt)) {
+		requeueAt = nextScaleDown
+	}
+
 	claims.SyncClusterDeploymentAssignments(r.Client, cds, logger)
 	cds.SyncClaimAssignments(r.Client, claims, logger)
 	if err := cdcs.SyncClusterDeploymentCustomizationAssignments(r.Client, clp, cds, logger); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if setStatusCounts(clp, cds) {
+	if setStatusCounts(clp, cds, sizing, recentClaims) {
 		if err := r.Status().Update(context.Background(), clp); err != nil {
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update ClusterPool status")
 			return reconcile.Result{}, errors.Wrap(err, "could not update ClusterPool status")
@@ -397,8 +419,8 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	availableCurrent := math.MaxInt32
-	if clp.Spec.MaxConcurrent != nil {
-		availableCurrent = int(*clp.Spec.MaxConcurrent) - len(cds.Installing()) - len(cds.Deleting())
+	if sizing.MaxConcurrent != nil {
+		availableCurrent = int(*sizing.MaxConcurrent) - len(cds.Installing()) - len(cds.Deleting())
 		if availableCurrent < 0 {
 			availableCurrent = 0
 		}
@@ -419,12 +441,13 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 
 	// drift will indicate how many clusters we need to add or delete to get back to steady state
 	// of the pool's Size. This needs to take into account the clusters we're creating to satisfy
-	// the immediate demand of pending claims.
-	switch drift := len(cds.Unassigned(true)) - int(clp.Spec.Size) - len(claims.Unassigned()); {
+	// the immediate demand of pending claims, except those no cluster from this pool could satisfy.
+	pendingClaims := len(claims.Unassigned()) - len(unsatisfiableClaims(claims, cds, cdcs, clp))
+	switch drift := len(cds.Unassigned(true)) - int(sizing.Size) - pendingClaims; {
 	// activity quota exceeded, so no action
 	case availableCurrent <= 0:
 		logger.WithFields(log.Fields{
-			"MaxConcurrent": *clp.Spec.MaxConcurrent,
+			"MaxConcurrent": *sizing.MaxConcurrent,
 			"Available":     availableCurrent,
 		}).Info("Cannot create/delete clusters as max concurrent quota exceeded.")
 	// If too few, create new InstallConfig and ClusterDeployment.
@@ -462,7 +485,7 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 		metricStaleClusterDeploymentsDeleted.WithLabelValues(clp.Namespace, clp.Name).Inc()
 	}
 
-	if err := r.reconcileRunningClusters(clp, cds, len(claims.Unassigned()), logger); err != nil {
+	if err := r.reconcileRunningClusters(sizing, cds, claims.Unassigned(), logger); err != nil {
 		log.WithError(err).Error("error updating hibernating/running state")
 		return reconcile.Result{}, err
 	}
@@ -485,18 +508,16 @@ func (r *ReconcileClusterPool) Reconcile(ctx context.Context, request reconcile.
 
 // reconcileRunningClusters ensures the oldest unassigned clusters are set to running, and the
 // remainder are set to hibernating. The number of clusters we set to running is determined by
-// adding the cluster's configured runningCount to the number of unsatisfied claims for which we're
-// spinning up new clusters.
+// adding the pool's effective runningCount to the number of unsatisfied claims for which we're
+// spinning up new clusters. In addition, the oldest cluster matching each unsatisfied claim with a
+// Selector or MinVersion is set to running.
 func (r *ReconcileClusterPool) reconcileRunningClusters(
-	clp *hivev1.ClusterPool,
+	sizing *hivev1.ClusterPoolEffectiveSizing,
 	cds *cdCollection,
-	extraRunning int,
+	unassignedClaims []*hivev1.ClusterClaim,
 	logger log.FieldLogger,
 ) error {
-	// If we're creating excess clusters to satisfy unassigned claims, add that many
-	// to the runningCount. They'll get snatched up immediately, bringing the number
-	// of running clusters back down to runningCount once the pool reaches steady state.
-	runningCount := int(clp.Spec.RunningCount) + extraRunning
+	runningCount := int(sizing.RunningCount)
 	// Exclude broken clusters
 	cdList := cds.Unassigned(false)
 	// Sort by age, oldest first, for FIFO purposes. Include secondary sort by namespace/name as
@@ -510,11 +531,35 @@ func (r *ReconcileClusterPool) reconcileRunningClusters(
 				cdList[i].Name < cdList[j].Name
 		},
 	)
+	// A claim with requirements can only be satisfied by a matching cluster, so resume the oldest
+	// matching cluster for each such claim. For any other unassigned claim, add one to the
+	// runningCount. Those clusters will get snatched up immediately, bringing the number of
+	// running clusters back down to runningCount once the pool reaches steady state.
+	wanted := sets.NewString()
+	for _, claim := range unassignedClaims {
+		if !claimHasRequirements(claim) {
+			runningCount++
+			continue
+		}
+		for _, cd := range cdList {
+			if wanted.Has(cd.Name) {
+				continue
+			}
+			if match, _ := claimMatchesCluster(claim, cd); match {
+				wanted.Insert(cd.Name)
+				break
+			}
+		}
+	}
+	numRunning := 0
 	for i := 0; i < len(cdList); i++ {
 		cd := cdList[i]
 		var desiredPowerState hivev1.ClusterPowerState
-		if i < runningCount {
+		if wanted.Has(cd.Name) {
 			desiredPowerState = hivev1.ClusterPowerStateRunning
+		} else if numRunning < runningCount {
+			desiredPowerState = hivev1.ClusterPowerStateRunning
+			numRunning++
 		} else {
 			desiredPowerState = hivev1.ClusterPowerStateHibernating
 		}
@@ -535,27 +580,114 @@ func (r *ReconcileClusterPool) reconcileRunningClusters(
 	return nil
 }
 
-// calculatePoolVersion computes a hash of the important (to ClusterDeployments) fields of the
-// ClusterPool.Spec. This is annotated on ClusterDeployments when the pool creates them, which
-// subsequently allows us to tell whether all unclaimed CDs are up to date and set a condition
-// accordingly.
-// NOTE: If we change this algorithm, we're guaranteed to think that all CDs are stale at the
-// moment that code update rolls out. We may wish to consider a way to support detecting the old
-// value as "current" in that case.
-func calculatePoolVersion(clp *hivev1.ClusterPool) string {
-	ba := []byte{}
-	ba = append(ba, deephash.Hash(clp.Spec.Platform)...)
-	ba = append(ba, deephash.Hash(clp.Spec.BaseDomain)...)
-	ba = append(ba, deephash.Hash(clp.Spec.ImageSetRef)...)
-	ba = append(ba, deephash.Hash(clp.Spec.InstallConfigSecretTemplateRef)...)
-	// Inventory changes the behavior of cluster pool, thus it needs to be in the pool version.
-	// But to avoid redployment of clusters if inventory changes, a fixed string is added to pool version.
-	// https://github.com/openshift/hive/blob/master/docs/enhancements/clusterpool-inventory.md#pool-version
-	if clp.Spec.Inventory != nil {
-		ba = append(ba, []byte("hasInventory")...)
-	}
-	// Hash of hashes to ensure fixed length
-	return fmt.Sprintf("%x", deephash.Hash(ba))
+// effectiveSizing determines the Size, RunningCount and MaxConcurrent to apply to the pool at the given time. They
+// come from the first entry of the pool's SizingSchedule with an open window, falling back to the pool's spec.
+// Entries whose time zone or windows cannot be evaluated are skipped.
+func effectiveSizing(clp *hivev1.ClusterPool, now time.Time, logger log.FieldLogger) *hivev1.ClusterPoolEffectiveSizing {
+	sizing := &hivev1.ClusterPoolEffectiveSizing{
+		Size:          clp.Spec.Size,
+		RunningCount:  clp.Spec.RunningCount,
+		MaxConcurrent: clp.Spec.MaxConcurrent,
+	}
+	var nextTransition time.Time
+	for i, entry := range clp.Spec.SizingSchedule {
+		logger := logger.WithField("sizingScheduleEntry", i)
+		loc, err := controllerutils.LoadScheduleLocation(entry.TimeZone)
+		if err != nil {
+			logger.WithError(err).Warn("ignoring sizing schedule entry with invalid time zone")
+			continue
+		}
+		state, err := controllerutils.EvaluateScheduleWindows(entry.Windows, loc, now)
+		if err != nil {
+			logger.WithError(err).Warn("ignoring sizing schedule entry with invalid windows")
+			continue
+		}
+		// Any entry's transition may change which entry is in effect.
+		if !state.NextTransition.IsZero() && (nextTransition.IsZero() || state.NextTransition.Before(nextTransition)) {
+			nextTransition = state.NextTransition
+		}
+		if !state.Active || sizing.SizingScheduleEntry != nil {
+			continue
+		}
+		index := int32(i)
+		sizing.SizingScheduleEntry = &index
+		if entry.Size != nil {
+			sizing.Size = *entry.Size
+		}
+		if entry.RunningCount != nil {
+			sizing.RunningCount = *entry.RunningCount
+		}
+		if entry.MaxConcurrent != nil {
+			sizing.MaxConcurrent = entry.MaxConcurrent
+		}
+	}
+	if !nextTransition.IsZero() {
+		t := metav1.NewTime(nextTransition)
+		sizing.NextTransition = &t
+	}
+	return sizing
+}
+
+// autoscale sets the effective Size of a pool with Autoscaling configured to the number of claims that were created
+// within the autoscaling window, clamped to the configured bounds. Claims still waiting for a cluster are served by
+// the clusters the pool creates for them on top of that Size. Claims deleted within the window are counted from the
+// pool's record of recent claims, which autoscale returns updated for the pool status. It also returns the time at
+// which the oldest of the recent claims leaves the window, and the Size may therefore drop; or the zero time if there
+// is no such claim.
+func autoscale(clp *hivev1.ClusterPool, claims *claimCollection, sizing *hivev1.ClusterPoolEffectiveSizing, now time.Time, logger log.FieldLogger) ([]hivev1.ClusterPoolRecentClaim, time.Time) {
+	autoscaling := clp.Spec.Autoscaling
+	if autoscaling == nil {
+		clearAutoscalingMetrics(clp.Namespace, clp.Name)
+		return nil, time.Time{}
+	}
+	window := defaultAutoscalingWindow
+	if autoscaling.Window != nil && autoscaling.Window.Duration > 0 {
+		window = autoscaling.Window.Duration
+	}
+	since := now.Add(-window)
+	created := map[string]metav1.Time{}
+	for _, claim := range clp.Status.RecentClaims {
+		if !claim.CreationTimestamp.Time.Before(since) {
+			created[claim.Name] = claim.CreationTimestamp
+		}
+	}
+	for _, claim := range claims.CreatedSince(since) {
+		created[claim.Name] = claim.CreationTimestamp
+	}
+	var recent []hivev1.ClusterPoolRecentClaim
+	for name, t := range created {
+		recent = append(recent, hivev1.ClusterPoolRecentClaim{Name: name, CreationTimestamp: t})
+	}
+	// Oldest first, so the record is stable and the first claim is the next to leave the window.
+	sort.Slice(recent, func(i, j int) bool {
+		if !recent[i].CreationTimestamp.Equal(&recent[j].CreationTimestamp) {
+			return recent[i].CreationTimestamp.Before(&recent[j].CreationTimestamp)
+		}
+		return recent[i].Name < recent[j].Name
+	})
+	pending := len(claims.Unassigned())
+	size := len(recent)
+	if size > int(autoscaling.MaxSize) {
+		size = int(autoscaling.MaxSize)
+	}
+	if size < int(autoscaling.MinSize) {
+		size = int(autoscaling.MinSize)
+	}
+	logger.WithFields(log.Fields{
+		"recentClaims":  len(recent),
+		"pendingClaims": pending,
+		"size":          size,
+	}).Debug("autoscaling pool")
+	metricAutoscalingRecentClaims.WithLabelValues(clp.Namespace, clp.Name).Set(float64(len(recent)))
+	metricAutoscalingPendingClaims.WithLabelValues(clp.Namespace, clp.Name).Set(float64(pending))
+	metricAutoscalingSize.WithLabelValues(clp.Namespace, clp.Name).Set(float64(size))
+	sizing.Size = int32(size)
+	sizing.Autoscaled = true
+
+	if len(recent) == 0 {
+		return nil, time.Time{}
+	}
+	return recent, recent[0].CreationTimestamp.Add(window)
 }
 
 func minIntVarible(v1 int, vn ...int) (m int) {
@@ -789,6 +921,10 @@ func (r *ReconcileClusterPool) createCluster(
 					return nil, errors.Errorf("ClusterDeploymentCustomization %s is already reserved", cdc.Name)
 				}
 				cd.Spec.ClusterPoolRef.CustomizationRef = &corev1.LocalObjectReference{Name: cdc.Name}
+				if cd.Labels == nil {
+					cd.Labels = map[string]string{}
+				}
+				cd.Labels[constants.ClusterDeploymentCustomizationLabel] = cdc.Name
 			}
 		} else if secretTmp := isInstallConfigSecret(obj); secretTmp != nil {
 			secret = secretTmp
@@ -965,7 +1101,7 @@ func (r *ReconcileClusterPool) deleteBrokenClusters(cds *cdCollection, maxToDele
 	return nil
 }
 
-func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
+func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, sizing *hivev1.ClusterPoolEffectiveSizing, logger log.FieldLogger) error {
 	if !controllerutils.HasFinalizer(pool, finalizer) {
 		return nil
 	}
@@ -987,8 +1123,8 @@ func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, lo
 
 	// Adhere to MaxConcurrent when cleaning up.
 	availableConcurrent := math.MaxInt32
-	if pool.Spec.MaxConcurrent != nil {
-		availableConcurrent = int(*pool.Spec.MaxConcurrent) - len(cds.Installing()) - len(cds.Deleting())
+	if sizing.MaxConcurrent != nil {
+		availableConcurrent = int(*sizing.MaxConcurrent) - len(cds.Installing()) - len(cds.Deleting())
 	}
 
 	// Clean up marked-for-deletion claimed clusters first. It really doesn't matter; the tie-breaker I'm using
@@ -1019,7 +1155,7 @@ func (r *ReconcileClusterPool) reconcileDeletedPool(pool *hivev1.ClusterPool, lo
 	}
 
 	changedCond := setDeletionPossibleCondition(pool, cds)
-	changedCounts := setStatusCounts(pool, cds)
+	changedCounts := setStatusCounts(pool, cds, pool.Status.Effective, pool.Status.RecentClaims)
 	if changedCond || changedCounts {
 		if err := r.Status().Update(context.Background(), pool); err != nil {
 			return errors.Wrap(err, "could not update ClusterPool status")
@@ -1047,12 +1183,6 @@ func poolReference(pool *hivev1.ClusterPool) hivev1.ClusterPoolReference {
 	}
 }
 
-// poolAlwaysRunning returns true if the Platrform, cloud provider, machines can only be in running state
-func poolAlwaysRunning(pool *hivev1.ClusterPool) bool {
-	p := pool.Spec.Platform
-	return p.OpenStack != nil || p.Ovirt != nil || p.VSphere != nil
-}
-
 func (r *ReconcileClusterPool) getCredentialsSecret(pool *hivev1.ClusterPool, secretName string, logger log.FieldLogger) (*corev1.Secret, error) {
 	credsSecret := &corev1.Secret{}
 	if err := r.Client.Get(
@@ -1149,15 +1279,18 @@ func setDeletionPossibleCondition(pool *hivev1.ClusterPool, cds *cdCollection) b
 	return changed
 }
 
-// setStatusCounts sets the Size, Standby, and Ready status fields in clp according to cds.
-// The caller is responsible for pushing the changes back to the server.
+// setStatusCounts sets the Size, Standby, and Ready status fields in clp according to cds, along with the
+// effective sizing and the record of recent claims. The caller is responsible for pushing the changes back to the server.
 // The return indicates whether anything changed.
-func setStatusCounts(clp *hivev1.ClusterPool, cds *cdCollection) bool {
+func setStatusCounts(clp *hivev1.ClusterPool, cds *cdCollection, sizing *hivev1.ClusterPoolEffectiveSizing, recentClaims []hivev1.ClusterPoolRecentClaim) bool {
 	origStatus := clp.Status.DeepCopy()
 	clp.Status.Size = int32(len(cds.Unassigned(true)))
 	clp.Status.Standby = int32(len(cds.Standby()))
 	clp.Status.Ready = int32(len(cds.Assignable()))
-	return !reflect.DeepEqual(origStatus, &clp.Status)
+	clp.Status.Effective = sizing
+	clp.Status.RecentClaims = recentClaims
+	// Semantic comparison, because NextTransition loses its time zone when the status is stored.
+	return !apiequality.Semantic.DeepEqual(origStatus, &clp.Status)
 }
 
 func (r *ReconcileClusterPool) verifyClusterImageSet(pool *hivev1.ClusterPool, logger log.FieldLogger) error {
@@ -21,6 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -50,7 +51,7 @@ func TestReconcileClusterPool(t *testing.T) {
 	corev1.AddToScheme(scheme)
 	rbacv1.AddToScheme(scheme)
 
-	// See calculatePoolVersion. If this changes, the easiest way to figure out the new value is
+	// See controllerutils.CalculatePoolVersion. If this changes, the easiest way to figure out the new value is
 	// to pull it from the test failure :)
 	initialPoolVersion := "182b591e56ca056b"
 
@@ -112,12 +113,24 @@ func TestReconcileClusterPool(t *testing.T) {
 
 	nowish := time.Now()
 
+	poolSchedule := &hivev1.HibernationSchedule{
+		TimeZone:   "Europe/Berlin",
+		RunWindows: []hivev1.ScheduleWindow{{Start: "08:00", End: "19:00"}},
+	}
+	staleSchedule := &hivev1.HibernationSchedule{
+		RunWindows: []hivev1.ScheduleWindow{{Start: "00:00", End: "06:00"}},
+	}
+	// A window that ends when it starts wraps to the next day, so this one is always open.
+	alwaysOpen := []hivev1.ScheduleWindow{{Start: "00:00", End: "00:00"}}
+
 	tests := []struct {
 		name                               string
 		existing                           []runtime.Object
 		noClusterImageSet                  bool
 		noCredsSecret                      bool
 		expectError                        bool
+		expectRequeueAfter                 bool
+		expectedRecentClaims               []string
 		expectedPools                      []string
 		expectedTotalClusters              int
 		expectedObservedSize               int32
@@ -143,9 +156,17 @@ func TestReconcileClusterPool(t *testing.T) {
 		// Map, keyed by claim name, of expected Status.Conditions['Pending'].Reason.
 		// (The clusterpool controller always sets this condition's Status to True.)
 		// Not checked if nil.
-		expectedClaimPendingReasons      map[string]string
+		expectedClaimPendingReasons map[string]string
+		// Map, keyed by claim name, of the name of the cluster expected to be assigned to the claim.
+		expectedClaimedClusters map[string]string
+		// Map, keyed by cluster name, of the expected Spec.PowerState.
+		expectedPowerStates              map[string]hivev1.ClusterPowerState
 		expectedInventoryAssignmentOrder []string
 		expectPoolVersionChanged         bool
+		// Tested on all claimed clusters.
+		expectedClaimedHibernationSchedule *hivev1.HibernationSchedule
+		// Not checked if nil.
+		expectedEffectiveSizing *hivev1.ClusterPoolEffectiveSizing
 	}{
 		{
 			name: "initialize conditions",
@@ -1502,6 +1523,391 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedUnassignedClaims:    1,
 			expectedClaimPendingReasons: map[string]string{"test-claim": "ThisShouldNotChange"},
 		},
+		{
+			name: "assign claim to cluster matching selector",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(testcd.Running(), testcd.WithLabel("team", "ci")),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "ci"}}),
+				),
+			},
+			expectedTotalClusters:       3,
+			expectedObservedSize:        2,
+			expectedObservedReady:       2,
+			expectedAssignedClaims:      1,
+			expectedAssignedCDs:         1,
+			expectedRunning:             1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "ClusterAssigned"},
+			expectedClaimedClusters:     map[string]string{"test-claim": "c2"},
+		},
+		{
+			name: "assign claim to cluster matching customization",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithCustomization("test-cdc-1")),
+				unclaimedCDBuilder("c2").Build(testcd.Running(), testcd.WithCustomization("test-cdc-2")),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithSelector(&metav1.LabelSelector{
+						MatchLabels: map[string]string{constants.ClusterDeploymentCustomizationLabel: "test-cdc-2"},
+					}),
+				),
+			},
+			expectedTotalClusters:       3,
+			expectedObservedSize:        2,
+			expectedObservedReady:       2,
+			expectedAssignedClaims:      1,
+			expectedAssignedCDs:         1,
+			expectedRunning:             1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "ClusterAssigned"},
+			expectedClaimedClusters:     map[string]string{"test-claim": "c2"},
+		},
+		{
+			name: "assign claim to cluster satisfying minVersion",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithLabel(constants.VersionMajorMinorLabel, "4.9")),
+				unclaimedCDBuilder("c2").Build(testcd.Running(), testcd.WithLabel(constants.VersionMajorMinorLabel, "4.11")),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithMinVersion("4.10"),
+				),
+			},
+			expectedTotalClusters:       3,
+			expectedObservedSize:        2,
+			expectedObservedReady:       2,
+			expectedAssignedClaims:      1,
+			expectedAssignedCDs:         1,
+			expectedRunning:             1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "ClusterAssigned"},
+			expectedClaimedClusters:     map[string]string{"test-claim": "c2"},
+		},
+		{
+			name: "claims with and without requirements",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithLabel("team", "ci")),
+				unclaimedCDBuilder("c2").Build(testcd.Running()),
+				// The older claim has no requirements, but must not take the only cluster the newer one can use.
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish.Add(-time.Second))),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+					testclaim.WithSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "ci"}}),
+				),
+			},
+			expectedTotalClusters:  4,
+			expectedObservedSize:   2,
+			expectedObservedReady:  2,
+			expectedAssignedClaims: 2,
+			expectedAssignedCDs:    2,
+			expectedRunning:        2,
+			expectedClaimPendingReasons: map[string]string{
+				"test-claim-1": "ClusterAssigned",
+				"test-claim-2": "ClusterAssigned",
+			},
+			expectedClaimedClusters: map[string]string{"test-claim-1": "c2", "test-claim-2": "c1"},
+		},
+		{
+			name: "no clusters matching selector",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithLabel("team", "qe")),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "ci"}}),
+				),
+			},
+			expectedTotalClusters:       1,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedUnassignedClaims:    1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "NoMatchingClusters"},
+		},
+		{
+			name: "add cluster for claim matching pool labels",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithClusterDeploymentLabels(map[string]string{"team": "ci"})),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithLabel("team", "qe")),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "ci"}}),
+				),
+			},
+			expectedTotalClusters:    2,
+			expectedObservedSize:     1,
+			expectedObservedReady:    1,
+			expectedUnassignedClaims: 1,
+			// The new cluster is started for the claim
+			expectedRunning:             1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "NoMatchingClusters"},
+		},
+		{
+			name: "no clusters satisfying minVersion",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Running(), testcd.WithLabel(constants.VersionMajorMinorLabel, "4.9")),
+				// Version not yet known
+				unclaimedCDBuilder("c2").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithMinVersion("4.10"),
+				),
+			},
+			expectedTotalClusters:       2,
+			expectedObservedSize:        2,
+			expectedObservedReady:       2,
+			expectedUnassignedClaims:    1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "NoMatchingClusters"},
+		},
+		{
+			name: "invalid claim selector",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithSelector(&metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "team", Operator: "Bogus"}},
+					}),
+				),
+			},
+			expectedTotalClusters:       1,
+			expectedObservedSize:        1,
+			expectedObservedReady:       1,
+			expectedUnassignedClaims:    1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "InvalidRequirements"},
+		},
+		{
+			name: "resume cluster matching claim selector",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(2)),
+				unclaimedCDBuilder("c1").Build(testcd.Running()),
+				unclaimedCDBuilder("c2").Build(testcd.Installed(), testcd.WithLabel("team", "ci")),
+				testclaim.FullBuilder(testNamespace, "test-claim", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.WithSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "ci"}}),
+				),
+			},
+			expectedTotalClusters:    3,
+			expectedObservedSize:     2,
+			expectedObservedReady:    1,
+			expectedUnassignedClaims: 1,
+			// c1 is hibernated, because the claim can only use c2
+			expectedRunning:             1,
+			expectedClaimPendingReasons: map[string]string{"test-claim": "NoClusters"},
+			expectedPowerStates: map[string]hivev1.ClusterPowerState{
+				"c1": hivev1.ClusterPowerStateHibernating,
+				"c2": hivev1.ClusterPowerStateRunning,
+			},
+		},
+		{
+			name: "sizing schedule overrides size and runningCount",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithSizingSchedule(hivev1.ClusterPoolSizingScheduleEntry{
+						Windows:      alwaysOpen,
+						Size:         pointer.Int32(3),
+						RunningCount: pointer.Int32(2),
+					}),
+				),
+			},
+			expectedTotalClusters: 3,
+			expectedRunning:       2,
+			expectedEffectiveSizing: &hivev1.ClusterPoolEffectiveSizing{
+				Size:                3,
+				RunningCount:        2,
+				SizingScheduleEntry: pointer.Int32(0),
+			},
+		},
+		{
+			name: "sizing schedule requeues when initializing conditions",
+			existing: []runtime.Object{
+				poolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithSizingSchedule(hivev1.ClusterPoolSizingScheduleEntry{
+						Windows: []hivev1.ScheduleWindow{{Start: "08:00", End: "19:00"}},
+						Size:    pointer.Int32(2),
+					}),
+				),
+			},
+			expectedMissingDependenciesStatus: corev1.ConditionUnknown,
+			expectedCapacityStatus:            corev1.ConditionUnknown,
+			expectedCDCurrentStatus:           corev1.ConditionUnknown,
+			expectedInventoryValidStatus:      corev1.ConditionUnknown,
+			expectedDeletionPossibleCondition: &hivev1.ClusterPoolCondition{
+				Status: corev1.ConditionUnknown,
+			},
+			expectRequeueAfter: true,
+		},
+		{
+			name: "sizing schedule overrides size of openstack pool and keeps its running count",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.ForOpenstack(credsSecretName),
+					testcp.WithSize(1),
+					testcp.WithRunningCount(1),
+					testcp.WithSizingSchedule(hivev1.ClusterPoolSizingScheduleEntry{
+						Windows: alwaysOpen,
+						Size:    pointer.Int32(2),
+					}),
+				),
+			},
+			expectPoolVersionChanged: true,
+			expectedTotalClusters:    2,
+			expectedRunning:          1,
+			expectedEffectiveSizing: &hivev1.ClusterPoolEffectiveSizing{
+				Size:                2,
+				RunningCount:        1,
+				SizingScheduleEntry: pointer.Int32(0),
+			},
+		},
+		{
+			name: "first open sizing schedule entry wins",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.WithSize(1),
+					testcp.WithMaxConcurrent(1),
+					testcp.WithSizingSchedule(
+						hivev1.ClusterPoolSizingScheduleEntry{
+							TimeZone: "Mars/Olympus_Mons",
+							Windows:  alwaysOpen,
+							Size:     pointer.Int32(5),
+						},
+						hivev1.ClusterPoolSizingScheduleEntry{
+							Windows:       alwaysOpen,
+							Size:          pointer.Int32(4),
+							MaxConcurrent: pointer.Int32(2),
+						},
+						hivev1.ClusterPoolSizingScheduleEntry{
+							Windows: alwaysOpen,
+							Size:    pointer.Int32(3),
+						},
+					),
+				),
+			},
+			// Limited by the effective MaxConcurrent
+			expectedTotalClusters: 2,
+			expectedEffectiveSizing: &hivev1.ClusterPoolEffectiveSizing{
+				Size:                4,
+				MaxConcurrent:       pointer.Int32(2),
+				SizingScheduleEntry: pointer.Int32(1),
+			},
+		},
+		{
+			name: "autoscaling sizes pool by recent claims",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithAutoscaling(0, 10)),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(
+					testclaim.WithPool(testLeasePoolName),
+					testclaim.Generic(testgeneric.WithCreationTimestamp(nowish)),
+				),
+			},
+			// Size 2, plus one for each pending claim
+			expectedTotalClusters:    4,
+			expectedUnassignedClaims: 2,
+			expectedRunning:          2,
+			expectedEffectiveSizing: &hivev1.ClusterPoolEffectiveSizing{
+				Size:       2,
+				Autoscaled: true,
+			},
+			expectRequeueAfter: true,
+		},
+		{
+			name: "autoscaling does not size pool by old pending claims",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithAutoscaling(0, 10)),
+				testclaim.FullBuilder(testNamespace, "test-claim-1", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				testclaim.FullBuilder(testNamespace, "test-claim-2", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+			},
+			// Only the clusters for the pending claims
+			expectedTotalClusters:    2,
+			expectedUnassignedClaims: 2,
+			expectedRunning:          2,
+			expectedEffectiveSizing: &hivev1.ClusterPoolEffectiveSizing{
+				Size:       0,
+				Autoscaled: true,
+			},
+		},
+		{
+			name: "autoscaling counts recently deleted claims",
+			existing: []runtime.Object{
+				func() runtime.Object {
+					pool := initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithAutoscaling(0, 10))
+					pool.Status.RecentClaims = []hivev1.ClusterPoolRecentClaim{
+						{Name: "deleted-claim", CreationTimestamp: metav1.NewTime(nowish.Add(-time.Minute))},
+						{Name: "expired-claim", CreationTimestamp: metav1.NewTime(nowish.Add(-2 * time.Hour))},
+					}
+					return pool
+				}(),
+			},
+			expectedTotalClusters: 1,
+			expectedEffectiveSizing: &hivev1.ClusterPoolEffectiveSizing{
+				Size:       1,
+				Autoscaled: true,
+			},
+			expectedRecentClaims: []string{"deleted-claim"},
+			expectRequeueAfter:   true,
+		},
+		{
+			name: "autoscaling respects minSize",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithAutoscaling(3, 10)),
+			},
+			expectedTotalClusters: 3,
+			expectedEffectiveSizing: &hivev1.ClusterPoolEffectiveSizing{
+				Size:       3,
+				Autoscaled: true,
+			},
+		},
+		{
+			name: "claim applies pool hibernation schedule",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1), testcp.WithHibernationSchedule(poolSchedule)),
+				testclaim.FullBuilder(testNamespace, "test", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				unclaimedCDBuilder("c1").Build(
+					testcd.Installed(),
+					testcd.Running(),
+					testcd.WithHibernationSchedule("", staleSchedule.RunWindows...),
+				),
+			},
+			expectedTotalClusters:              2,
+			expectedObservedSize:               1,
+			expectedObservedReady:              1,
+			expectedRunning:                    1,
+			expectedAssignedClaims:             1,
+			expectedAssignedCDs:                1,
+			expectedClaimedHibernationSchedule: poolSchedule,
+		},
+		{
+			name: "claim removes stale hibernation schedule",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(testcp.WithSize(1)),
+				testclaim.FullBuilder(testNamespace, "test", scheme).Build(testclaim.WithPool(testLeasePoolName)),
+				unclaimedCDBuilder("c1").Build(
+					testcd.Installed(),
+					testcd.Running(),
+					testcd.WithHibernationSchedule("", staleSchedule.RunWindows...),
+				),
+			},
+			expectedTotalClusters:  2,
+			expectedObservedSize:   1,
+			expectedObservedReady:  1,
+			expectedRunning:        1,
+			expectedAssignedClaims: 1,
+			expectedAssignedCDs:    1,
+		},
 		{
 			name: "do not delete previously claimed clusters",
 			existing: []runtime.Object{
@@ -1838,6 +2244,19 @@ func TestReconcileClusterPool(t *testing.T) {
 			expectedTotalClusters: 4,
 			expectedRunning:       2,
 		},
+		{
+			name: "runningCount < size for openstack pool",
+			existing: []runtime.Object{
+				initializedPoolBuilder.Build(
+					testcp.ForOpenstack(credsSecretName),
+					testcp.WithSize(4),
+					testcp.WithRunningCount(2),
+				),
+			},
+			expectPoolVersionChanged: true,
+			expectedTotalClusters:    4,
+			expectedRunning:          2,
+		},
 		{
 			name: "runningCount == size",
 			existing: []runtime.Object{
@@ -1999,12 +2418,15 @@ func TestReconcileClusterPool(t *testing.T) {
 					},
 				}
 
-				_, err := rcp.Reconcile(context.TODO(), reconcileRequest)
+				result, err := rcp.Reconcile(context.TODO(), reconcileRequest)
 				if test.expectError {
 					assert.Error(t, err, "expected error from reconcile")
 				} else {
 					assert.NoError(t, err, "expected no error from reconcile")
 				}
+				if test.expectRequeueAfter {
+					assert.Positive(t, result.RequeueAfter, "expected reconcile to requeue")
+				}
 			}
 
 			pool := &hivev1.ClusterPool{}
@@ -2018,7 +2440,17 @@ func TestReconcileClusterPool(t *testing.T) {
 			}
 			assert.Equal(t, test.expectedObservedSize, pool.Status.Size, "unexpected observed size")
 			assert.Equal(t, test.expectedObservedReady, pool.Status.Ready, "unexpected observed ready count")
-			currentPoolVersion := calculatePoolVersion(pool)
+			if test.expectedEffectiveSizing != nil {
+				assert.Equal(t, test.expectedEffectiveSizing, pool.Status.Effective, "unexpected effective sizing")
+			}
+			if test.expectedRecentClaims != nil {
+				var recentClaims []string
+				for _, claim := range pool.Status.RecentClaims {
+					recentClaims = append(recentClaims, claim.Name)
+				}
+				assert.Equal(t, test.expectedRecentClaims, recentClaims, "unexpected recent claims")
+			}
+			currentPoolVersion := controllerutils.CalculatePoolVersion(pool)
 			assert.Equal(
 				t, test.expectPoolVersionChanged, currentPoolVersion != expectedPoolVersion,
 				"expectPoolVersionChanged is %t\ninitial %q\nfinal   %q",
@@ -2113,6 +2545,8 @@ func TestReconcileClusterPool(t *testing.T) {
 					actualUnassignedCDs++
 				} else {
 					actualAssignedCDs++
+					assert.Equal(t, test.expectedClaimedHibernationSchedule, cd.Spec.HibernationSchedule,
+						"unexpected HibernationSchedule on claimed cluster")
 				}
 				// Match up copyover fields for any clusters belonging to the pool
 				if poolRef != nil && poolRef.PoolName == testLeasePoolName {
@@ -2124,6 +2558,9 @@ func TestReconcileClusterPool(t *testing.T) {
 						}
 					}
 				}
+				if powerState, ok := test.expectedPowerStates[cd.Name]; ok {
+					assert.Equal(t, powerState, cd.Spec.PowerState, "unexpected PowerState for cluster %s", cd.Name)
+				}
 				switch powerState := cd.Spec.PowerState; powerState {
 				case hivev1.ClusterPowerStateRunning:
 					actualRunning++
@@ -2157,6 +2594,9 @@ func TestReconcileClusterPool(t *testing.T) {
 						}
 					}
 				}
+				if cdName, ok := test.expectedClaimedClusters[claim.Name]; ok {
+					assert.Equal(t, cdName, claim.Spec.Namespace, "wrong cluster assigned to claim %s", claim.Name)
+				}
 				if claim.Spec.Namespace == "" {
 					actualUnassignedClaims++
 				} else {
@@ -3169,3 +3609,239 @@ func Test_isBroken(t *testing.T) {
 		})
 	}
 }
+
+func TestEffectiveSizing(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// A Wednesday
+	wednesday := func(hour int) time.Time {
+		return time.Date(2022, time.June, 1, hour, 0, 0, 0, berlin)
+	}
+	businessHours := hivev1.ClusterPoolSizingScheduleEntry{
+		TimeZone: "Europe/Berlin",
+		Windows: []hivev1.ScheduleWindow{{
+			Days:  []hivev1.ScheduleWeekday{hivev1.ScheduleMonday, hivev1.ScheduleTuesday, hivev1.ScheduleWednesday, hivev1.ScheduleThursday, hivev1.ScheduleFriday},
+			Start: "08:00",
+			End:   "19:00",
+		}},
+		Size:         pointer.Int32(10),
+		RunningCount: pointer.Int32(5),
+	}
+	lunchTime := hivev1.ClusterPoolSizingScheduleEntry{
+		TimeZone: "Europe/Berlin",
+		Windows:  []hivev1.ScheduleWindow{{Start: "12:00", End: "14:00"}},
+		Size:     pointer.Int32(2),
+	}
+	tests := []struct {
+		name     string
+		schedule []hivev1.ClusterPoolSizingScheduleEntry
+		now      time.Time
+		expected *hivev1.ClusterPoolEffectiveSizing
+	}{
+		{
+			name: "no schedule",
+			now:  wednesday(10),
+			expected: &hivev1.ClusterPoolEffectiveSizing{
+				Size:          3,
+				RunningCount:  1,
+				MaxConcurrent: pointer.Int32(2),
+			},
+		},
+		{
+			name:     "in window",
+			schedule: []hivev1.ClusterPoolSizingScheduleEntry{businessHours},
+			now:      wednesday(10),
+			expected: &hivev1.ClusterPoolEffectiveSizing{
+				Size:                10,
+				RunningCount:        5,
+				MaxConcurrent:       pointer.Int32(2),
+				SizingScheduleEntry: pointer.Int32(0),
+				NextTransition:      &metav1.Time{Time: wednesday(19)},
+			},
+		},
+		{
+			name:     "outside window",
+			schedule: []hivev1.ClusterPoolSizingScheduleEntry{businessHours},
+			now:      wednesday(20),
+			expected: &hivev1.ClusterPoolEffectiveSizing{
+				Size:           3,
+				RunningCount:   1,
+				MaxConcurrent:  pointer.Int32(2),
+				NextTransition: &metav1.Time{Time: wednesday(24 + 8)},
+			},
+		},
+		{
+			name:     "earlier entry takes precedence",
+			schedule: []hivev1.ClusterPoolSizingScheduleEntry{businessHours, lunchTime},
+			now:      wednesday(13),
+			expected: &hivev1.ClusterPoolEffectiveSizing{
+				Size:                10,
+				RunningCount:        5,
+				MaxConcurrent:       pointer.Int32(2),
+				SizingScheduleEntry: pointer.Int32(0),
+				NextTransition:      &metav1.Time{Time: wednesday(14)},
+			},
+		},
+		{
+			name:     "later entry applies when earlier is closed",
+			schedule: []hivev1.ClusterPoolSizingScheduleEntry{lunchTime, businessHours},
+			now:      wednesday(10),
+			expected: &hivev1.ClusterPoolEffectiveSizing{
+				Size:                10,
+				RunningCount:        5,
+				MaxConcurrent:       pointer.Int32(2),
+				SizingScheduleEntry: pointer.Int32(1),
+				// When lunchTime opens
+				NextTransition: &metav1.Time{Time: wednesday(12)},
+			},
+		},
+		{
+			name: "invalid entry is ignored",
+			schedule: []hivev1.ClusterPoolSizingScheduleEntry{
+				{TimeZone: "Mars/Olympus_Mons", Windows: businessHours.Windows, Size: pointer.Int32(7)},
+				businessHours,
+			},
+			now: wednesday(10),
+			expected: &hivev1.ClusterPoolEffectiveSizing{
+				Size:                10,
+				RunningCount:        5,
+				MaxConcurrent:       pointer.Int32(2),
+				SizingScheduleEntry: pointer.Int32(1),
+				NextTransition:      &metav1.Time{Time: wednesday(19)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := testcp.BasicBuilder().Build(
+				testcp.WithSize(3),
+				testcp.WithRunningCount(1),
+				testcp.WithMaxConcurrent(2),
+				testcp.WithSizingSchedule(test.schedule...),
+			)
+			actual := effectiveSizing(pool, test.now, log.New())
+			if test.expected.NextTransition != nil && assert.NotNil(t, actual.NextTransition, "expected NextTransition") {
+				assert.True(t, test.expected.NextTransition.Equal(actual.NextTransition),
+					"expected NextTransition %s, got %s", test.expected.NextTransition, actual.NextTransition)
+				test.expected.NextTransition = nil
+				actual.NextTransition = nil
+			}
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestAutoscale(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	now := time.Now()
+	claim := func(name string, age time.Duration, assigned bool) *hivev1.ClusterClaim {
+		opts := []testclaim.Option{
+			testclaim.WithPool(testLeasePoolName),
+			testclaim.Generic(testgeneric.WithCreationTimestamp(now.Add(-age))),
+		}
+		if assigned {
+			opts = append(opts, testclaim.WithCluster(name))
+		}
+		return testclaim.FullBuilder(testNamespace, name, scheme).Build(opts...)
+	}
+	tests := []struct {
+		name                  string
+		claims                []*hivev1.ClusterClaim
+		recorded              []hivev1.ClusterPoolRecentClaim
+		window                time.Duration
+		expectedSize          int32
+		expectedRecent        []string
+		expectedNextScaleDown time.Time
+	}{
+		{
+			name:         "no claims",
+			expectedSize: 1,
+		},
+		{
+			name: "recent and pending claims",
+			claims: []*hivev1.ClusterClaim{
+				claim("recent-assigned", 10*time.Minute, true),
+				claim("recent-pending", 20*time.Minute, false),
+				claim("old-pending", 2*time.Hour, false),
+				claim("old-assigned", 2*time.Hour, true),
+			},
+			// Pending claims are served by clusters of their own, so only count towards Size while recent.
+			expectedSize:          2,
+			expectedRecent:        []string{"recent-pending", "recent-assigned"},
+			expectedNextScaleDown: now.Add(40 * time.Minute),
+		},
+		{
+			name: "recorded claims",
+			claims: []*hivev1.ClusterClaim{
+				claim("recent-assigned", 10*time.Minute, true),
+			},
+			recorded: []hivev1.ClusterPoolRecentClaim{
+				{Name: "recent-assigned", CreationTimestamp: metav1.NewTime(now.Add(-10 * time.Minute))},
+				{Name: "recent-deleted", CreationTimestamp: metav1.NewTime(now.Add(-30 * time.Minute))},
+				{Name: "old-deleted", CreationTimestamp: metav1.NewTime(now.Add(-2 * time.Hour))},
+			},
+			expectedSize:          2,
+			expectedRecent:        []string{"recent-deleted", "recent-assigned"},
+			expectedNextScaleDown: now.Add(30 * time.Minute),
+		},
+		{
+			name: "custom window",
+			claims: []*hivev1.ClusterClaim{
+				claim("recent-assigned", 10*time.Minute, true),
+				claim("older-assigned", 20*time.Minute, true),
+			},
+			window:                15 * time.Minute,
+			expectedSize:          1,
+			expectedNextScaleDown: now.Add(5 * time.Minute),
+		},
+		{
+			name: "capped at maxSize",
+			claims: []*hivev1.ClusterClaim{
+				claim("claim-1", time.Minute, false),
+				claim("claim-2", time.Minute, false),
+				claim("claim-3", time.Minute, false),
+				claim("claim-4", time.Minute, false),
+				claim("claim-5", time.Minute, false),
+			},
+			expectedSize:          4,
+			expectedNextScaleDown: now.Add(59 * time.Minute),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := testcp.FullBuilder(testNamespace, testLeasePoolName, scheme).Build(
+				testcp.WithSize(10),
+				testcp.WithAutoscaling(1, 4),
+			)
+			if test.window != 0 {
+				pool.Spec.Autoscaling.Window = &metav1.Duration{Duration: test.window}
+			}
+			pool.Status.RecentClaims = test.recorded
+			claims := &claimCollection{
+				byClaimName: map[string]*hivev1.ClusterClaim{},
+				byCDName:    map[string]*hivev1.ClusterClaim{},
+			}
+			for _, claim := range test.claims {
+				claims.byClaimName[claim.Name] = claim
+				if claim.Spec.Namespace == "" {
+					claims.unassigned = append(claims.unassigned, claim)
+				} else {
+					claims.byCDName[claim.Spec.Namespace] = claim
+				}
+			}
+			sizing := &hivev1.ClusterPoolEffectiveSizing{Size: pool.Spec.Size}
+			recent, nextScaleDown := autoscale(pool, claims, sizing, now, log.New())
+			assert.Equal(t, test.expectedSize, sizing.Size, "unexpected size")
+			if test.expectedRecent != nil {
+				var recentNames []string
+				for _, claim := range recent {
+					recentNames = append(recentNames, claim.Name)
+				}
+				assert.Equal(t, test.expectedRecent, recentNames, "unexpected recent claims")
+			}
+			assert.True(t, sizing.Autoscaled, "expected Autoscaled")
+			assert.True(t, test.expectedNextScaleDown.Equal(nextScaleDown), "expected next scale down at %s, got %s", test.expectedNextScaleDown, nextScaleDown)
+		})
+	}
+}
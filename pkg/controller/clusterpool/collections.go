@@ -9,10 +9,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/blang/semver/v4"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -91,6 +93,17 @@ func (c *claimCollection) ByName(claimName string) *hivev1.ClusterClaim {
 	return c.byClaimName[claimName]
 }
 
+// CreatedSince returns a list of claims created at or after the given time, in no particular order.
+func (c *claimCollection) CreatedSince(t time.Time) []*hivev1.ClusterClaim {
+	var ret []*hivev1.ClusterClaim
+	for _, claim := range c.byClaimName {
+		if !claim.CreationTimestamp.Time.Before(t) {
+			ret = append(ret, claim)
+		}
+	}
+	return ret
+}
+
 // Unassigned returns a list of claims that are not assigned to clusters yet. The list is sorted by
 // age, oldest first.
 func (c *claimCollection) Unassigned() []*hivev1.ClusterClaim {
@@ -216,6 +229,8 @@ type cdCollection struct {
 	byCDName map[string]*hivev1.ClusterDeployment
 	// This contains only claimed CDs
 	byClaimName map[string]*hivev1.ClusterDeployment
+	// The pool's HibernationSchedule, which is applied to CDs as they are claimed
+	hibernationSchedule *hivev1.HibernationSchedule
 }
 
 // NOTE: This doesn't care about claimed or deleted/deleting status. That's on the caller.
@@ -302,6 +317,7 @@ func getAllClusterDeploymentsForPool(c client.Client, pool *hivev1.ClusterPool,
 		mismatchedPoolVersion: make([]*hivev1.ClusterDeployment, 0),
 		byCDName:              make(map[string]*hivev1.ClusterDeployment),
 		byClaimName:           make(map[string]*hivev1.ClusterDeployment),
+		hibernationSchedule:   pool.Spec.HibernationSchedule,
 	}
 	for i, cd := range cdList.Items {
 		poolRef := cd.Spec.ClusterPoolRef
@@ -544,6 +560,8 @@ func (cds *cdCollection) Assign(c client.Client, cd *hivev1.ClusterDeployment, c
 			cdi.Spec.ClusterPoolRef.ClaimedTimestamp = &now
 			// This may be redundant if we already did it to satisfy runningCount; but no harm.
 			cdi.Spec.PowerState = hivev1.ClusterPowerStateRunning
+			// Use the pool's current HibernationSchedule, which may have changed since the CD was created.
+			cdi.Spec.HibernationSchedule = cds.hibernationSchedule.DeepCopy()
 			if err := c.Update(context.Background(), cdi); err != nil {
 				return err
 			}
@@ -1235,34 +1253,80 @@ func ensureClaimAssignment(c client.Client, claim *hivev1.ClusterClaim, claims *
 	return nil
 }
 
-// assignClustersToClaims iterates over unassigned claims and assignable ClusterDeployments, in order (see
-// claimCollection.Unassigned and cdCollection.Assignable), assigning them to each other, stopping when the
-// first of the two lists is exhausted.
+// assignClustersToClaims iterates over unassigned claims, in order (see claimCollection.Unassigned), assigning
+// each to the first assignable ClusterDeployment (see cdCollection.Assignable) that satisfies the claim's
+// Selector and MinVersion. Claims without requirements prefer clusters that no claim with requirements
+// would accept. Claims that cannot be assigned are marked Pending with a reason explaining why.
 func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCollection, logger log.FieldLogger) error {
-	// ensureClaimAssignment modifies claims.unassigned and cds.assignable, so make a copy of the lists.
-	// copy() limits itself to the size of the destination
-	numToAssign := minIntVarible(len(claims.Unassigned()), len(cds.Assignable()))
-	claimList := make([]*hivev1.ClusterClaim, numToAssign)
+	// ensureClaimAssignment modifies claims.unassigned and cds.assignable, so make copies of the lists.
+	claimList := make([]*hivev1.ClusterClaim, len(claims.Unassigned()))
 	copy(claimList, claims.Unassigned())
-	cdList := make([]*hivev1.ClusterDeployment, numToAssign)
 	// Assignable is sorted by age, oldest first.
+	cdList := make([]*hivev1.ClusterDeployment, len(cds.Assignable()))
 	copy(cdList, cds.Assignable())
+	// Claims without requirements can use any cluster, so offer them the clusters that no claim with
+	// requirements would accept before the rest.
+	var unwanted, wanted []*hivev1.ClusterDeployment
+	for _, cd := range cdList {
+		isWanted := false
+		for _, claim := range claimList {
+			if claimHasRequirements(claim) {
+				if match, _ := claimMatchesCluster(claim, cd); match {
+					isWanted = true
+					break
+				}
+			}
+		}
+		if isWanted {
+			wanted = append(wanted, cd)
+		} else {
+			unwanted = append(unwanted, cd)
+		}
+	}
+	unrestrictedCDList := append(unwanted, wanted...)
+	taken := map[string]bool{}
+	invalid := map[string]error{}
 	var errs []error
-	for i := 0; i < numToAssign; i++ {
-		if err := ensureClaimAssignment(c, claimList[i], claims, cdList[i], cds, logger); err != nil {
-			errs = append(errs, err)
+	for _, claim := range claimList {
+		candidates := cdList
+		if !claimHasRequirements(claim) {
+			candidates = unrestrictedCDList
+		}
+		for _, cd := range candidates {
+			if taken[cd.Name] {
+				continue
+			}
+			match, err := claimMatchesCluster(claim, cd)
+			if err != nil {
+				invalid[claim.Name] = err
+				break
+			}
+			if !match {
+				continue
+			}
+			taken[cd.Name] = true
+			if err := ensureClaimAssignment(c, claim, claims, cd, cds, logger); err != nil {
+				errs = append(errs, err)
+			}
+			break
 		}
 	}
 	// If any unassigned claims remain, mark their status accordingly
 	for _, claim := range claims.Unassigned() {
 		logger := logger.WithField("claim", claim.Name)
-		logger.Debug("no clusters ready to assign to claim")
+		reason, message := "NoClusters", "No clusters in pool are ready to be claimed"
+		if err := invalid[claim.Name]; err != nil {
+			reason, message = "InvalidRequirements", err.Error()
+		} else if claimHasRequirements(claim) && !anyClusterMatchesClaim(claim, cds.Unassigned(false)) {
+			reason, message = "NoMatchingClusters", fmt.Sprintf("No clusters in pool match the claim's requirements (%s)", claimRequirements(claim))
+		}
+		logger.WithField("reason", reason).Debug("no clusters ready to assign to claim")
 		if conds, statusChanged := controllerutils.SetClusterClaimConditionWithChangeCheck(
 			claim.Status.Conditions,
 			hivev1.ClusterClaimPendingCondition,
 			corev1.ConditionTrue,
-			"NoClusters",
-			"No clusters in pool are ready to be claimed",
+			reason,
+			message,
 			controllerutils.UpdateConditionIfReasonOrMessageChange,
 		); statusChanged {
 			claim.Status.Conditions = conds
@@ -1274,3 +1338,125 @@ func assignClustersToClaims(c client.Client, claims *claimCollection, cds *cdCol
 	}
 	return utilerrors.NewAggregate(errs)
 }
+
+// claimHasRequirements returns true if the claim restricts which clusters in the pool may be assigned to it.
+func claimHasRequirements(claim *hivev1.ClusterClaim) bool {
+	return claim.Spec.Selector != nil || claim.Spec.MinVersion != ""
+}
+
+// claimRequirements describes the claim's Selector and MinVersion for use in status messages.
+func claimRequirements(claim *hivev1.ClusterClaim) string {
+	var reqs []string
+	if claim.Spec.Selector != nil {
+		reqs = append(reqs, fmt.Sprintf("selector %s", metav1.FormatLabelSelector(claim.Spec.Selector)))
+	}
+	if claim.Spec.MinVersion != "" {
+		reqs = append(reqs, fmt.Sprintf("minVersion %s", claim.Spec.MinVersion))
+	}
+	return strings.Join(reqs, ", ")
+}
+
+// claimMatchesCluster returns true if the ClusterDeployment satisfies the claim's Selector and MinVersion. An
+// error is returned if the requirements themselves cannot be parsed.
+func claimMatchesCluster(claim *hivev1.ClusterClaim, cd *hivev1.ClusterDeployment) (bool, error) {
+	if claim.Spec.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(claim.Spec.Selector)
+		if err != nil {
+			return false, fmt.Errorf("invalid selector: %w", err)
+		}
+		cdLabels := labels.Set{}
+		for k, v := range cd.Labels {
+			cdLabels[k] = v
+		}
+		// Clusters created before the customization label was introduced only record the customization
+		// in the pool reference.
+		if poolRef := cd.Spec.ClusterPoolRef; poolRef != nil && poolRef.CustomizationRef != nil {
+			cdLabels[constants.ClusterDeploymentCustomizationLabel] = poolRef.CustomizationRef.Name
+		}
+		if !selector.Matches(cdLabels) {
+			return false, nil
+		}
+	}
+	if claim.Spec.MinVersion != "" {
+		minVersion, err := semver.ParseTolerant(claim.Spec.MinVersion)
+		if err != nil {
+			return false, fmt.Errorf("invalid minVersion: %w", err)
+		}
+		// The version label is only set once the cluster has been installed.
+		version, err := semver.ParseTolerant(cd.Labels[constants.VersionMajorMinorLabel])
+		if err != nil || version.LT(minVersion) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// anyClusterMatchesClaim returns true if any of the ClusterDeployments satisfies the claim's requirements.
+func anyClusterMatchesClaim(claim *hivev1.ClusterClaim, cdList []*hivev1.ClusterDeployment) bool {
+	for _, cd := range cdList {
+		if match, _ := claimMatchesCluster(claim, cd); match {
+			return true
+		}
+	}
+	return false
+}
+
+// unsatisfiableClaims returns the unassigned claims whose requirements are met neither by an unclaimed cluster in the
+// pool nor by a cluster the pool could create. Creating clusters for such claims would not serve them, so they are
+// left out of the pool's demand.
+func unsatisfiableClaims(claims *claimCollection, cds *cdCollection, cdcs *cdcCollection, pool *hivev1.ClusterPool) []*hivev1.ClusterClaim {
+	var ret []*hivev1.ClusterClaim
+	creatable, versionKnown := creatableClusters(cds, cdcs, pool)
+	for _, claim := range claims.Unassigned() {
+		if !claimHasRequirements(claim) || anyClusterMatchesClaim(claim, cds.Unassigned(false)) {
+			continue
+		}
+		if !versionKnown && claim.Spec.MinVersion != "" {
+			// The version of new clusters is only known once one is installed; assume it is recent enough.
+			claim = claim.DeepCopy()
+			claim.Spec.MinVersion = ""
+		}
+		if !anyClusterMatchesClaim(claim, creatable) {
+			ret = append(ret, claim)
+		}
+	}
+	return ret
+}
+
+// creatableClusters returns stand-ins for the clusters the pool could create, labelled the way the pool would label
+// them: with the pool's labels and, for pools with an inventory, the customization of each available entry. If an
+// installed cluster is current with the pool, the stand-ins also carry its version label, and versionKnown is true.
+func creatableClusters(cds *cdCollection, cdcs *cdcCollection, pool *hivev1.ClusterPool) (creatable []*hivev1.ClusterDeployment, versionKnown bool) {
+	stale := map[string]bool{}
+	for _, cd := range cds.Stale() {
+		stale[cd.Name] = true
+	}
+	version := ""
+	for _, cd := range cds.Installed() {
+		if v := cd.Labels[constants.VersionMajorMinorLabel]; v != "" && !stale[cd.Name] {
+			version, versionKnown = v, true
+			break
+		}
+	}
+	newCluster := func(customization string) *hivev1.ClusterDeployment {
+		cd := &hivev1.ClusterDeployment{}
+		cd.Labels = map[string]string{}
+		for k, v := range pool.Spec.Labels {
+			cd.Labels[k] = v
+		}
+		if customization != "" {
+			cd.Labels[constants.ClusterDeploymentCustomizationLabel] = customization
+		}
+		if versionKnown {
+			cd.Labels[constants.VersionMajorMinorLabel] = version
+		}
+		return cd
+	}
+	if pool.Spec.Inventory == nil {
+		return []*hivev1.ClusterDeployment{newCluster("")}, versionKnown
+	}
+	for _, cdc := range cdcs.Unassigned() {
+		creatable = append(creatable, newCluster(cdc.Name))
+	}
+	return creatable, versionKnown
+}
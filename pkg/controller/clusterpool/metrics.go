@@ -63,6 +63,20 @@ var (
 		//   so we're having to wait to create CDs to fulfill claims.
 		Buckets: []float64{1, 30, 120, 600, 1800, 3000, 7200},
 	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	// The autoscaling metrics are only reported for pools with Autoscaling configured. The pool's Size is the
+	// number of recent claims, clamped to [MinSize, MaxSize]; pending claims are served on top of it.
+	metricAutoscalingRecentClaims = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_autoscaling_recent_claims",
+		Help: "The number of ClusterClaims created within the autoscaling window of the ClusterPool.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricAutoscalingPendingClaims = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_autoscaling_pending_claims",
+		Help: "The number of ClusterClaims waiting for a cluster from the ClusterPool.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
+	metricAutoscalingSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_clusterpool_autoscaling_size",
+		Help: "The Size chosen for the ClusterPool by autoscaling.",
+	}, []string{"clusterpool_namespace", "clusterpool_name"})
 )
 
 func init() {
@@ -76,4 +90,15 @@ func init() {
 	metrics.Registry.MustRegister(metricClusterDeploymentsBroken)
 	metrics.Registry.MustRegister(metricStaleClusterDeploymentsDeleted)
 	metrics.Registry.MustRegister(metricClaimDelaySeconds)
+	metrics.Registry.MustRegister(metricAutoscalingRecentClaims)
+	metrics.Registry.MustRegister(metricAutoscalingPendingClaims)
+	metrics.Registry.MustRegister(metricAutoscalingSize)
+}
+
+// clearAutoscalingMetrics stops reporting the autoscaling metrics of a ClusterPool, once it no longer autoscales or
+// has been deleted.
+func clearAutoscalingMetrics(namespace, name string) {
+	metricAutoscalingRecentClaims.DeleteLabelValues(namespace, name)
+	metricAutoscalingPendingClaims.DeleteLabelValues(namespace, name)
+	metricAutoscalingSize.DeleteLabelValues(namespace, name)
 }
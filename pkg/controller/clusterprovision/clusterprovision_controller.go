@@ -376,14 +376,20 @@ func (r *ReconcileClusterProvision) reconcileSuccessfulJob(instance *hivev1.Clus
 
 func (r *ReconcileClusterProvision) reconcileFailedJob(instance *hivev1.ClusterProvision, job *batchv1.Job, pLog log.FieldLogger) (reconcile.Result, error) {
 	pLog.Info("install job failed")
-	reason, message := r.parseInstallLog(instance.Spec.InstallLog, pLog)
+	reason, message, classification := r.parseInstallLog(instance.Spec.InstallLog, pLog)
 	if controllerutils.IsDeadlineExceeded(job) && reason == unknownReason {
 		reason, message = "AttemptDeadlineExceeded", "Install job failed due to deadline being exceeded for the attempt"
 	}
+	// Stored by the status update that sets the ClusterProvisionFailed condition.
+	instance.Status.FailureClassification = classification
 	result, err := r.transitionStage(instance, hivev1.ClusterProvisionStageFailed, reason, message, pLog)
 	if err == nil {
 		// Increment a counter metric for this cluster type and error reason:
 		metricInstallErrors.Observe(instance, map[string]string{"reason": reason}, 1)
+		metricInstallFailuresByCategory.Observe(instance, map[string]string{
+			"category":  string(classification.Category),
+			"retryable": strconv.FormatBool(classification.Retryable),
+		}, 1)
 		metricClusterProvisionsTotal.Observe(instance, map[string]string{"result": resultFailure}, 1)
 	}
 	return result, err
@@ -625,4 +631,24 @@ func (r *ReconcileClusterProvision) logProvisionSuccessFailureMetric(
 		"install_attempt": strconv.Itoa(instance.Spec.Attempt),
 	}
 	timeMetric.Observe(cd, fixedLabels, time.Since(instance.CreationTimestamp.Time).Seconds())
+
+	result := resultFailure
+	if stage == hivev1.ClusterProvisionStageComplete {
+		result = resultSuccess
+	}
+	for _, phase := range instance.Status.InstallPhases {
+		// The install is over once it reaches the InstallComplete phase, so there is no duration to observe for it.
+		if phase.Name == hivev1.InstallPhaseInstallComplete {
+			continue
+		}
+		// A phase that is still open when the provision ends is the one the install failed in.
+		end := time.Now()
+		if phase.CompletionTime != nil {
+			end = phase.CompletionTime.Time
+		}
+		metricInstallPhaseSeconds.Observe(cd, map[string]string{
+			"phase":  string(phase.Name),
+			"result": result,
+		}, end.Sub(phase.StartTime.Time).Seconds())
+	}
 }
@@ -122,6 +122,33 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			},
 			expectedStage: hivev1.ClusterProvisionStageComplete,
 		},
+		{
+			name: "completed job with install phases",
+			existing: []runtime.Object{
+				testProvision(
+					tcp.WithJob(installJobName),
+					tcp.WithStage(hivev1.ClusterProvisionStageProvisioning),
+					tcp.WithInstallPhases(
+						hivev1.ClusterProvisionInstallPhase{
+							Name:           hivev1.InstallPhaseBootstrap,
+							StartTime:      metav1.NewTime(time.Now().Add(-time.Hour)),
+							CompletionTime: &metav1.Time{Time: time.Now().Add(-30 * time.Minute)},
+						},
+						hivev1.ClusterProvisionInstallPhase{
+							Name:      hivev1.InstallPhaseInstallComplete,
+							StartTime: metav1.NewTime(time.Now().Add(-30 * time.Minute)),
+						},
+					)),
+				testJob(completed()),
+				testPod("foo", success()),
+			},
+			expectedStage: hivev1.ClusterProvisionStageComplete,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				assert.Len(t, provision.Status.InstallPhases, 2, "expected install phases to be kept")
+			},
+		},
 		{
 			name: "completed job while initializing",
 			existing: []runtime.Object{
@@ -141,6 +168,14 @@ func TestClusterProvisionReconcile(t *testing.T) {
 			},
 			expectedStage:      hivev1.ClusterProvisionStageFailed,
 			expectedFailReason: unknownReason,
+			validate: func(c client.Client, t *testing.T) {
+				provision := getProvision(c)
+				require.NotNil(t, provision, "could not get ClusterProvision")
+				if assert.NotNil(t, provision.Status.FailureClassification, "expected a failure classification") {
+					assert.Equal(t, hivev1.InstallFailureCategoryUnknown, provision.Status.FailureClassification.Category, "unexpected category")
+					assert.True(t, provision.Status.FailureClassification.Retryable, "expected failure to be retryable")
+				}
+			},
 		},
 		{
 			name: "deadline exceeded job",
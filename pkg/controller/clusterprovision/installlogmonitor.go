@@ -2,32 +2,41 @@ package clusterprovision
 
 import (
 	"context"
-	"regexp"
 	"strings"
 
-	"github.com/ghodss/yaml"
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/installlogregex"
 )
 
 const (
-	regexConfigMapName           = "install-log-regexes"
-	additionalRegexConfigMapName = "additional-install-log-regexes"
-	regexDataEntryName           = "regexes"
+	regexConfigMapName           = installlogregex.ConfigMapName
+	additionalRegexConfigMapName = installlogregex.AdditionalConfigMapName
+	regexDataEntryName           = installlogregex.DataEntryName
 	unknownReason                = "UnknownError"
 	logMissingMessage            = "Cluster install failed but installer log was not captured"
 	regexBadMessage              = "Cluster install failed but regex configmap to parse for known reasons could not be used"
 	unknownMessage               = "Cluster install failed but no known errors found in logs"
 )
 
-// parseInstallLog parses install log to monitor for known issues.
-func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string) {
+// unknownFailure is the classification of an install failure with no known errors.
+func unknownFailure() *hivev1.InstallFailureClassification {
+	return &hivev1.InstallFailureClassification{
+		Category:  hivev1.InstallFailureCategoryUnknown,
+		Retryable: true,
+	}
+}
+
+// parseInstallLog parses install log to monitor for known issues. It returns the reason and message of the most severe
+// known error, and the classification of the failure from all the known errors found.
+func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldLogger) (string, string, *hivev1.InstallFailureClassification) {
 	if log == nil {
-		return unknownReason, logMissingMessage
+		return unknownReason, logMissingMessage, unknownFailure()
 	}
 
 	// Load the regex configmap, if we don't have one, there's not much point proceeding here.
@@ -37,23 +46,23 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 		// Even if the error was a transient error in fetching the configmap, we should not block
 		// the continuation of deploying the cluster just so that we can potentially get a
 		// better failure message.
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, unknownFailure()
 	}
 
 	regexesRaw, ok := regexCM.Data[regexDataEntryName]
 	if !ok {
 		pLog.Errorf("%s configmap does not have a %q data entry", regexConfigMapName, regexDataEntryName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, unknownFailure()
 	}
 
-	regexes := []installLogRegex{}
-	if err := yaml.Unmarshal([]byte(regexesRaw), &regexes); err != nil {
+	regexes, err := installlogregex.Parse(regexesRaw)
+	if err != nil {
 		pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
-		return unknownReason, regexBadMessage
+		return unknownReason, regexBadMessage, unknownFailure()
 	}
 
 	// Load additional regex configmap, continue anyway if configmap isn't present
-	additionalRegexes := []installLogRegex{}
+	var additionalRegexes []installlogregex.InstallLogRegex
 	additionalRegexCM := &corev1.ConfigMap{}
 	if additionalRegexCMErr := r.Get(context.TODO(), types.NamespacedName{Name: additionalRegexConfigMapName, Namespace: controllerutils.GetHiveNamespace()}, additionalRegexCM); additionalRegexCMErr != nil {
 		pLog.WithError(additionalRegexCMErr).Errorf("error loading %s configmap", additionalRegexConfigMapName)
@@ -63,7 +72,8 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 			pLog.Errorf("%s configmap does not have a %q data entry", additionalRegexConfigMapName, regexDataEntryName)
 		} else {
 			if additionalRegexesRaw != "" {
-				if err := yaml.Unmarshal([]byte(additionalRegexesRaw), &additionalRegexes); err != nil {
+				additionalRegexes, err = installlogregex.Parse(additionalRegexesRaw)
+				if err != nil {
 					pLog.WithError(err).Errorf("cannot unmarshal data from %s configmap", regexConfigMapName)
 				}
 			}
@@ -79,26 +89,20 @@ func (r *ReconcileClusterProvision) parseInstallLog(log *string, pLog log.FieldL
 	}
 
 	// Scan log contents for known errors
-	combinedRegexes := append(regexes, additionalRegexes...)
-	for _, ilr := range combinedRegexes {
-		ilrLog := pLog.WithField("regexName", ilr.Name)
-		ilrLog.Debug("parsing regex entry")
-		for _, ss := range ilr.SearchRegexStrings {
-			// Make the expression case insensitive.
-			// NOTE: This works correctly on a regex that already has flaggage. E.g. "(?i)(?s)..."
-			// is equivalent to "(?is)..."
-			ss = "(?i)" + ss
-			ssLog := ilrLog.WithField("searchString", ss)
-			ssLog.Debug("matching search string")
-			switch match, err := regexp.Match(ss, []byte(*log)); {
-			case err != nil:
-				ssLog.WithError(err).Error("unable to compile regex")
-			case match:
-				pLog.WithField("reason", ilr.InstallFailingReason).Info("found known install failure string")
-				return ilr.InstallFailingReason, ilr.InstallFailingMessage
-			}
-		}
+	found := installlogregex.Find(*log, append(regexes, additionalRegexes...), pLog)
+	if len(found) == 0 {
+		return unknownReason, *log, unknownFailure()
 	}
 
-	return unknownReason, *log
+	matches := make([]hivev1.InstallFailureMatch, len(found))
+	for i, m := range found {
+		pLog.WithField("reason", m.InstallFailingReason).Info("found known install failure string")
+		matches[i] = m.Failure()
+	}
+	worst := matches[0]
+	return worst.Reason, worst.Message, &hivev1.InstallFailureClassification{
+		Category:  worst.Category,
+		Retryable: worst.Retryable,
+		Matches:   matches,
+	}
 }
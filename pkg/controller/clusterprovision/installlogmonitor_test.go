@@ -6,18 +6,22 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/openshift/hive/apis"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	"github.com/openshift/hive/pkg/installlogregex"
 )
 
 func init() {
@@ -76,6 +80,9 @@ func TestParseInstallLog(t *testing.T) {
 		existing        []runtime.Object
 		expectedReason  string
 		expectedMessage *string
+
+		expectedCategory     hivev1.InstallFailureCategory
+		expectedNotRetryable bool
 	}{
 		{
 			name:           "S3AccessControlListNotSupported",
@@ -108,9 +115,10 @@ func TestParseInstallLog(t *testing.T) {
 			expectedReason: "AWSAccessDeniedSLR",
 		},
 		{
-			name:           "DNS already exists",
-			log:            pointer.String(dnsAlreadyExistsLog),
-			expectedReason: "DNSAlreadyExists",
+			name:             "DNS already exists",
+			log:              pointer.String(dnsAlreadyExistsLog),
+			expectedReason:   "DNSAlreadyExists",
+			expectedCategory: hivev1.InstallFailureCategoryDNS,
 		},
 		{
 			name:           "PendingVerification",
@@ -257,8 +265,9 @@ func TestParseInstallLog(t *testing.T) {
 			expectedReason: "KubeAPIWaitTimeoutRegexes",
 		},
 		{
-			name:           "no log",
-			expectedReason: unknownReason,
+			name:             "no log",
+			expectedReason:   unknownReason,
+			expectedCategory: hivev1.InstallFailureCategoryUnknown,
 		},
 		{
 			name:            "no matching log",
@@ -434,9 +443,11 @@ func TestParseInstallLog(t *testing.T) {
 			expectedReason: "InstallerFailedToDestroyResources",
 		},
 		{
-			name:           "AWSAccountBlocked",
-			log:            pointer.String(awsAccountBlocked),
-			expectedReason: "AWSAccountIsBlocked",
+			name:                 "AWSAccountBlocked",
+			log:                  pointer.String(awsAccountBlocked),
+			expectedReason:       "AWSAccountIsBlocked",
+			expectedCategory:     hivev1.InstallFailureCategoryPermissions,
+			expectedNotRetryable: true,
 		},
 	}
 
@@ -451,13 +462,206 @@ func TestParseInstallLog(t *testing.T) {
 				Client: fakeClient,
 				scheme: scheme.Scheme,
 			}
-			reason, message := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
+			reason, message, classification := r.parseInstallLog(test.log, log.WithFields(log.Fields{}))
 			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
 			if test.expectedMessage != nil {
 				assert.Equal(t, *test.expectedMessage, message)
 			} else {
 				assert.NotEmpty(t, message, "expected message to be not empty")
 			}
+			if assert.NotNil(t, classification, "expected a failure classification") && test.expectedCategory != "" {
+				assert.Equal(t, test.expectedCategory, classification.Category, "unexpected category")
+				assert.Equal(t, !test.expectedNotRetryable, classification.Retryable, "unexpected retryable")
+			}
+		})
+	}
+}
+
+func TestParseInstallLogClassification(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	bothLog := dnsAlreadyExistsLog + "\n" + kubeAPIWaitTimeoutLog
+	tests := []struct {
+		name                   string
+		log                    string
+		regexes                string
+		expectedReason         string
+		expectedClassification *hivev1.InstallFailureClassification
+	}{
+		{
+			name: "defaults",
+			log:  dnsAlreadyExistsLog,
+			regexes: `
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+`,
+			expectedReason: "DNSAlreadyExists",
+			expectedClassification: &hivev1.InstallFailureClassification{
+				Category:  hivev1.InstallFailureCategoryUnknown,
+				Retryable: true,
+				Matches: []hivev1.InstallFailureMatch{{
+					Name:      "DNSAlreadyExists",
+					Reason:    "DNSAlreadyExists",
+					Message:   "DNS record already exists",
+					Category:  hivev1.InstallFailureCategoryUnknown,
+					Severity:  hivev1.InstallFailureSeverityError,
+					Retryable: true,
+				}},
+			},
+		},
+		{
+			name: "unrecognized category and severity",
+			log:  dnsAlreadyExistsLog,
+			regexes: `
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  category: Bogus
+  severity: Bogus
+  retryable: false
+`,
+			expectedReason: "DNSAlreadyExists",
+			expectedClassification: &hivev1.InstallFailureClassification{
+				Category:  hivev1.InstallFailureCategoryUnknown,
+				Retryable: false,
+				Matches: []hivev1.InstallFailureMatch{{
+					Name:      "DNSAlreadyExists",
+					Reason:    "DNSAlreadyExists",
+					Message:   "DNS record already exists",
+					Category:  hivev1.InstallFailureCategoryUnknown,
+					Severity:  hivev1.InstallFailureSeverityError,
+					Retryable: false,
+				}},
+			},
+		},
+		{
+			name: "most severe failure is reported",
+			log:  bothLog,
+			regexes: `
+- name: KubeAPIWaitTimeout
+  searchRegexStrings:
+  - "waiting for Kubernetes API: context deadline exceeded"
+  installFailingReason: KubeAPIWaitTimeout
+  installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+  category: InstallerBug
+  severity: Warning
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  category: DNS
+  severity: Critical
+  retryable: false
+`,
+			expectedReason: "DNSAlreadyExists",
+			expectedClassification: &hivev1.InstallFailureClassification{
+				Category:  hivev1.InstallFailureCategoryDNS,
+				Retryable: false,
+				Matches: []hivev1.InstallFailureMatch{
+					{
+						Name:      "DNSAlreadyExists",
+						Reason:    "DNSAlreadyExists",
+						Message:   "DNS record already exists",
+						Category:  hivev1.InstallFailureCategoryDNS,
+						Severity:  hivev1.InstallFailureSeverityCritical,
+						Retryable: false,
+					},
+					{
+						Name:      "KubeAPIWaitTimeout",
+						Reason:    "KubeAPIWaitTimeout",
+						Message:   "Timeout waiting for the Kubernetes API to begin responding",
+						Category:  hivev1.InstallFailureCategoryInstallerBug,
+						Severity:  hivev1.InstallFailureSeverityWarning,
+						Retryable: true,
+					},
+				},
+			},
+		},
+		{
+			name: "first listed failure is reported for the same severity",
+			log:  bothLog,
+			regexes: `
+- name: KubeAPIWaitTimeout
+  searchRegexStrings:
+  - "waiting for Kubernetes API: context deadline exceeded"
+  installFailingReason: KubeAPIWaitTimeout
+  installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+  category: InstallerBug
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  category: DNS
+  retryable: false
+`,
+			expectedReason: "KubeAPIWaitTimeout",
+			expectedClassification: &hivev1.InstallFailureClassification{
+				Category:  hivev1.InstallFailureCategoryInstallerBug,
+				Retryable: true,
+				Matches: []hivev1.InstallFailureMatch{
+					{
+						Name:      "KubeAPIWaitTimeout",
+						Reason:    "KubeAPIWaitTimeout",
+						Message:   "Timeout waiting for the Kubernetes API to begin responding",
+						Category:  hivev1.InstallFailureCategoryInstallerBug,
+						Severity:  hivev1.InstallFailureSeverityError,
+						Retryable: true,
+					},
+					{
+						Name:      "DNSAlreadyExists",
+						Reason:    "DNSAlreadyExists",
+						Message:   "DNS record already exists",
+						Category:  hivev1.InstallFailureCategoryDNS,
+						Severity:  hivev1.InstallFailureSeverityError,
+						Retryable: false,
+					},
+				},
+			},
+		},
+		{
+			name: "no match",
+			log:  noMatchLog,
+			regexes: `
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  category: DNS
+  retryable: false
+`,
+			expectedReason: unknownReason,
+			expectedClassification: &hivev1.InstallFailureClassification{
+				Category:  hivev1.InstallFailureCategoryUnknown,
+				Retryable: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithRuntimeObjects(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      regexConfigMapName,
+					Namespace: constants.DefaultHiveNamespace,
+				},
+				Data: map[string]string{
+					"regexes": test.regexes,
+				},
+			}).Build()
+			r := &ReconcileClusterProvision{
+				Client: fakeClient,
+				scheme: scheme.Scheme,
+			}
+			reason, _, classification := r.parseInstallLog(pointer.String(test.log), log.WithFields(log.Fields{}))
+			assert.Equal(t, test.expectedReason, reason, "unexpected reason")
+			assert.Equal(t, test.expectedClassification, classification, "unexpected classification")
 		})
 	}
 }
@@ -475,3 +679,11 @@ func buildRegexConfigMap() runtime.Object {
 	}
 	return obj
 }
+
+func TestRegexConfigMapIsValid(t *testing.T) {
+	cm := buildRegexConfigMap().(*corev1.ConfigMap)
+	regexes, err := installlogregex.Parse(cm.Data[regexDataEntryName])
+	require.NoError(t, err, "unexpected error parsing regexes")
+	assert.Empty(t, installlogregex.Validate(regexes, field.NewPath("regexes")), "unexpected invalid regexes")
+	assert.Empty(t, installlogregex.Shadowed(regexes), "unexpected shadowed regexes")
+}
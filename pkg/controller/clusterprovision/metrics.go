@@ -14,8 +14,12 @@ var (
 	metricClusterProvisionsTotal hivemetrics.CounterVecWithDynamicLabels
 	metricInstallErrors          hivemetrics.CounterVecWithDynamicLabels
 
+	metricInstallFailuresByCategory hivemetrics.CounterVecWithDynamicLabels
+
 	metricInstallFailureSeconds hivemetrics.HistogramVecWithDynamicLabels
 	metricInstallSuccessSeconds hivemetrics.HistogramVecWithDynamicLabels
+
+	metricInstallPhaseSeconds hivemetrics.HistogramVecWithDynamicLabels
 )
 
 func registerMetrics(mConfig *metricsconfig.MetricsConfig, log log.FieldLogger) {
@@ -37,6 +41,14 @@ func registerMetrics(mConfig *metricsconfig.MetricsConfig, log log.FieldLogger)
 		[]string{"reason"},
 		mapClusterTypeLabelToValue,
 	)
+	metricInstallFailuresByCategory = *hivemetrics.NewCounterVecWithDynamicLabels(
+		&prometheus.CounterOpts{
+			Name: "hive_install_failures_by_category",
+			Help: "Counter incremented every time a cluster provision fails, by the category of the failure.",
+		},
+		[]string{"category", "retryable"},
+		mapClusterTypeLabelToValue,
+	)
 
 	metricInstallFailureSeconds = *hivemetrics.NewHistogramVecWithDynamicLabels(
 		&prometheus.HistogramOpts{
@@ -56,9 +68,20 @@ func registerMetrics(mConfig *metricsconfig.MetricsConfig, log log.FieldLogger)
 		[]string{"platform", "region", "cluster_version", "workers", "install_attempt"},
 		mapClusterTypeLabelToValue,
 	)
+	metricInstallPhaseSeconds = *hivemetrics.NewHistogramVecWithDynamicLabels(
+		&prometheus.HistogramOpts{
+			Name:    "hive_cluster_provision_install_phase_duration_seconds",
+			Help:    "Time spent by cluster provisions in each phase of the install",
+			Buckets: []float64{60, 300, 600, 1200, 1800, 2400, 3600},
+		},
+		[]string{"phase", "result"},
+		mapClusterTypeLabelToValue,
+	)
 
 	metricInstallErrors.Register()
+	metricInstallFailuresByCategory.Register()
 	metricClusterProvisionsTotal.Register()
 	metricInstallFailureSeconds.Register()
 	metricInstallSuccessSeconds.Register()
+	metricInstallPhaseSeconds.Register()
 }
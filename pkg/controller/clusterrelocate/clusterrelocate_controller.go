@@ -138,9 +138,17 @@ func (r *ReconcileClusterRelocate) clusterRelocateHandlerFunc(a client.Object) (
 		return
 	}
 
+	// Also enqueue the ClusterDeployments reported in the status so that they are removed from it if they no longer match
+	reported := sets.NewString()
+	for _, cdStatus := range clusterRelocate.Status.ClusterDeployments {
+		if cdStatus.State != hivev1.ClusterRelocateCompleted {
+			reported.Insert(cdStatus.Namespace + "/" + cdStatus.Name)
+		}
+	}
+
 	for _, cd := range clusterDeployments.Items {
 		if relocateName, _, _ := controllerutils.IsRelocating(&cd); relocateName != clusterRelocate.Name &&
-			!labelSelector.Matches(labels.Set(cd.Labels)) {
+			!labelSelector.Matches(labels.Set(cd.Labels)) && !reported.Has(cd.Namespace+"/"+cd.Name) {
 			continue
 		}
 		requests = append(requests,
@@ -226,6 +234,9 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 			if err := r.stopRelocating(cd, currentRelocateName, logger); err != nil {
 				return reconcile.Result{}, errors.Wrap(err, "failed to stop relocating")
 			}
+			if err := r.clearClusterDeploymentStatus(currentRelocateName, cd, logger); err != nil {
+				return reconcile.Result{}, err
+			}
 		} else {
 			logger.Debug("skipping deleted clusterdeployment")
 		}
@@ -237,12 +248,19 @@ func (r *ReconcileClusterRelocate) Reconcile(ctx context.Context, request reconc
 		return r.finishRelocateCompletion(cd, currentRelocateName, logger)
 	}
 
-	desiredRelocates, err := r.findMatchingRelocates(cd, logger)
+	desiredRelocates, otherRelocates, err := r.findMatchingRelocates(cd, logger)
 	if err != nil {
 		logger.WithError(err).Error("could not find matching relocates")
 		return reconcile.Result{}, errors.Wrap(err, "could not find matching relocates")
 	}
 
+	// Stop reporting the ClusterDeployment in the status of the ClusterRelocates that it no longer matches
+	for _, cr := range otherRelocates {
+		if err := r.clearClusterDeploymentStatus(cr.Name, cd, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
 	// Do not do any relocation for a ClusterDeployment that does not match with exactly one ClusterRelocate
 	if len(desiredRelocates) != 1 {
 		return r.reconcileNoSingleMatch(cd, currentRelocateName, desiredRelocates, logger)
@@ -265,6 +283,10 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 
 	logger = logger.WithField("clusterRelocate", desiredRelocate.Name)
 
+	if desiredRelocate.Spec.DryRun {
+		return r.reconcileDryRun(cd, oldRelocateStatus, oldRelocateName, desiredRelocate, logger)
+	}
+
 	kubeconfigSecret := &corev1.Secret{}
 	if err := r.Get(
 		context.Background(),
@@ -275,9 +297,9 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 		kubeconfigSecret,
 	); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get kubeconfig secret")
-		r.setRelocationFailedCondition(
+		r.setRelocationFailed(
 			cd,
-			corev1.ConditionTrue,
+			desiredRelocate.Name,
 			"MissingKubeconfigSecret",
 			fmt.Sprintf("missing kubeconfig secret for destination cluster: %v", err),
 			logger,
@@ -289,9 +311,9 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 	destClient, err := r.remoteClusterAPIClientBuilder(kubeconfigSecret).Build()
 	if err != nil {
 		logger.WithError(err).Warn("could not create a client for the destination cluster")
-		r.setRelocationFailedCondition(
+		r.setRelocationFailed(
 			cd,
-			corev1.ConditionTrue,
+			desiredRelocate.Name,
 			"NoConnection",
 			fmt.Sprintf("could not connect to destination cluster: %v", err),
 			logger,
@@ -300,7 +322,7 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 		return reconcile.Result{}, errors.Wrap(err, "could not create a client for the destination cluster")
 	}
 
-	switch proceed, completed, err := r.checkForExistingClusterDeployment(cd, destClient, logger); {
+	switch proceed, completed, err := r.checkForExistingClusterDeployment(cd, desiredRelocate.Name, destClient, logger); {
 	case err != nil:
 		return reconcile.Result{}, err
 	case completed:
@@ -312,12 +334,19 @@ func (r *ReconcileClusterRelocate) reconcileSingleMatch(cd *hivev1.ClusterDeploy
 	if err := r.setRelocateAnnotation(cd, desiredRelocate.Name, hivev1.RelocateOutgoing, logger); err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "could not set relocate status to outgoing")
 	}
+	if err := r.setClusterDeploymentStatus(desiredRelocate.Name, cd, &hivev1.ClusterRelocateClusterDeploymentStatus{
+		State:   hivev1.ClusterRelocateInProgress,
+		Reason:  "Copying",
+		Message: "copying resources to destination cluster",
+	}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
 
 	// Copy resources to destination cluster
 	if err := r.copy(cd, destClient, logger); err != nil {
-		r.setRelocationFailedCondition(
+		r.setRelocationFailed(
 			cd,
-			corev1.ConditionTrue,
+			desiredRelocate.Name,
 			"MoveFailed",
 			err.Error(),
 			logger,
@@ -403,6 +432,13 @@ func (r *ReconcileClusterRelocate) finishRelocateCompletion(cd *hivev1.ClusterDe
 	); err != nil {
 		return reconcile.Result{}, err
 	}
+	if err := r.setClusterDeploymentStatus(relocateName, cd, &hivev1.ClusterRelocateClusterDeploymentStatus{
+		State:   hivev1.ClusterRelocateCompleted,
+		Reason:  "MoveSuccessful",
+		Message: "move completed successfully",
+	}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
 
 	// Delete the ClusterDeployment since it has been successfully relocated to a new Hive instance
 	if err := r.Delete(context.Background(), cd); err != nil {
@@ -416,25 +452,28 @@ func (r *ReconcileClusterRelocate) finishRelocateCompletion(cd *hivev1.ClusterDe
 
 }
 
-func (r *ReconcileClusterRelocate) findMatchingRelocates(cd *hivev1.ClusterDeployment, logger log.FieldLogger) ([]*hivev1.ClusterRelocate, error) {
+// findMatchingRelocates returns the ClusterRelocates that match the ClusterDeployment and the ones that do not.
+func (r *ReconcileClusterRelocate) findMatchingRelocates(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (matches, others []*hivev1.ClusterRelocate, returnErr error) {
 	clusterRelocates := &hivev1.ClusterRelocateList{}
 	if err := r.List(context.Background(), clusterRelocates); err != nil {
-		return nil, errors.Wrap(err, "failed to list clusterrelocates")
+		return nil, nil, errors.Wrap(err, "failed to list clusterrelocates")
 	}
-	var matches []*hivev1.ClusterRelocate
 	for i, cr := range clusterRelocates.Items {
 		labelSelector, err := metav1.LabelSelectorAsSelector(&cr.Spec.ClusterDeploymentSelector)
 		if err != nil {
 			r.logger.WithError(err).
 				WithField("clusterRelocate", cr.Name).
 				Warn("cannot parse clusterdeployment selector")
+			others = append(others, &clusterRelocates.Items[i])
 			continue
 		}
 		if labelSelector.Matches(labels.Set(cd.Labels)) {
 			matches = append(matches, &clusterRelocates.Items[i])
+		} else {
+			others = append(others, &clusterRelocates.Items[i])
 		}
 	}
-	return matches, nil
+	return matches, others, nil
 }
 
 func (r *ReconcileClusterRelocate) stopRelocating(cd *hivev1.ClusterDeployment, currentRelocateName string, logger log.FieldLogger) error {
@@ -479,6 +518,15 @@ func (r *ReconcileClusterRelocate) reconcileNoSingleMatch(cd *hivev1.ClusterDepl
 	if err := r.setRelocationFailedCondition(cd, status, reason, message, logger); err != nil {
 		return reconcile.Result{}, err
 	}
+	for _, cr := range desiredRelocates {
+		if err := r.setClusterDeploymentStatus(cr.Name, cd, &hivev1.ClusterRelocateClusterDeploymentStatus{
+			State:   hivev1.ClusterRelocateFailed,
+			Reason:  reason,
+			Message: message,
+		}, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
 	recordMetricForAbortedRelocate(currentRelocateName, abortedReason)
 	return reconcile.Result{}, nil
 }
@@ -500,7 +548,7 @@ func (r *ReconcileClusterRelocate) reconcileNoSingleMatch(cd *hivev1.ClusterDepl
 // The ClusterDeployment on the destination cluster is for a separate Hive-managed cluster. There is nothing that
 // Hive can or should do to resolve this. The ClusterDeployment cannot be relocated to the destination cluster
 // unless the existing ClusterDeployment on the destination cluster is deleted.
-func (r *ReconcileClusterRelocate) checkForExistingClusterDeployment(cd *hivev1.ClusterDeployment, destClient client.Client, logger log.FieldLogger) (proceed bool, completed bool, returnErr error) {
+func (r *ReconcileClusterRelocate) checkForExistingClusterDeployment(cd *hivev1.ClusterDeployment, relocateName string, destClient client.Client, logger log.FieldLogger) (proceed bool, completed bool, returnErr error) {
 	cdKey := client.ObjectKeyFromObject(cd)
 	destCD := &hivev1.ClusterDeployment{}
 	switch err := destClient.Get(context.Background(), cdKey, destCD); {
@@ -515,9 +563,9 @@ func (r *ReconcileClusterRelocate) checkForExistingClusterDeployment(cd *hivev1.
 	// conflicting ClusterDeployment exists in destination cluster
 	case destCD.Spec.BaseDomain != cd.Spec.BaseDomain:
 		logger.Warn("clusterdeployment in destination cluster does not match the one being relocated")
-		returnErr = r.setRelocationFailedCondition(
+		returnErr = r.setRelocationFailed(
 			cd,
-			corev1.ConditionTrue,
+			relocateName,
 			"ClusterDeploymentMismatch",
 			"The ClusterDeployment in the destination cluster does not match the one being relocated. To relocate this ClusterDeployment, the ClusterDeployment in the destination cluster first must be deleted.",
 			logger,
@@ -581,36 +629,54 @@ func (r *ReconcileClusterRelocate) copy(cd *hivev1.ClusterDeployment, destClient
 // copyResources copies all of the resources of the given object type in the namespace of the ClusterDeployment to the
 // destination cluster
 func (r *ReconcileClusterRelocate) copyResources(cd *hivev1.ClusterDeployment, destClient client.Client, objectList client.ObjectList, logger log.FieldLogger) error {
+	objs, ignored, err := r.listResourcesToCopy(cd, objectList, logger)
+	if err != nil {
+		return err
+	}
+	for _, obj := range ignored {
+		logger.WithField("type", reflect.TypeOf(obj)).WithField("resource", obj.GetName()).
+			Info("resource will not be copied since it is a resource that should be ignored")
+	}
+	for _, obj := range objs {
+		logger := logger.WithField("type", reflect.TypeOf(obj)).WithField("resource", obj.GetName())
+		if err := r.copyResource(obj, destClient, false, logger); err != nil {
+			return errors.Wrapf(err, "could not copy %T resource %q", obj, obj.GetName())
+		}
+	}
+	return nil
+}
+
+// listResourcesToCopy lists the resources of the given object type in the namespace of the ClusterDeployment, split
+// into the resources to copy to the destination cluster and the resources to ignore.
+func (r *ReconcileClusterRelocate) listResourcesToCopy(cd *hivev1.ClusterDeployment, objectList client.ObjectList, logger log.FieldLogger) (toCopy, ignored []client.Object, returnErr error) {
 	logger = logger.WithField("type", reflect.TypeOf(objectList))
 	if err := r.List(context.Background(), objectList, client.InNamespace(cd.Namespace)); err != nil {
 		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list resources")
-		return errors.Wrapf(err, "failed to list %T", objectList)
+		return nil, nil, errors.Wrapf(err, "failed to list %T", objectList)
 	}
 	objs, err := meta.ExtractList(objectList)
 	if err != nil {
 		logger.WithError(err).Error("could not extract resources from list")
-		return errors.Wrapf(err, "could not extract resources from %T", objectList)
-	}
-	for _, obj := range objs {
-		logger := logger.WithField("type", reflect.TypeOf(obj))
-		objMeta, err := meta.Accessor(obj)
-		if err != nil {
-			logger.WithError(err).Error("could not get object meta")
-			return errors.Wrapf(err, "could not get object meta for %T", obj)
+		return nil, nil, errors.Wrapf(err, "could not extract resources from %T", objectList)
+	}
+	for _, o := range objs {
+		logger := logger.WithField("type", reflect.TypeOf(o))
+		obj, ok := o.(client.Object)
+		if !ok {
+			logger.Error("could not get object meta")
+			return nil, nil, errors.Errorf("could not get object meta for %T", o)
 		}
-		logger = logger.WithField("resource", objMeta.GetName())
+		logger = logger.WithField("resource", obj.GetName())
 		switch ignore, err := r.ignoreResource(obj, logger); {
 		case err != nil:
-			return errors.Wrap(err, "could not determine whether to ignore resource")
+			return nil, nil, errors.Wrap(err, "could not determine whether to ignore resource")
 		case ignore:
-			logger.Info("resource will not be copied since it is a resource that should be ignored")
-			continue
-		}
-		if err := r.copyResource(obj, destClient, false, logger); err != nil {
-			return errors.Wrapf(err, "could not copy %T resource %q", obj, objMeta.GetName())
+			ignored = append(ignored, obj)
+		default:
+			toCopy = append(toCopy, obj)
 		}
 	}
-	return nil
+	return toCopy, ignored, nil
 }
 
 func (r *ReconcileClusterRelocate) copyResource(obj runtime.Object, destClient client.Client, failIfExists bool, logger log.FieldLogger) error {
@@ -899,6 +899,7 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 		expectDeleted                     bool
 		expectedRelocationFailedCondition *hivev1.ClusterDeploymentCondition
 		validate                          func(t *testing.T, cd *hivev1.ClusterDeployment)
+		validateClusterRelocate           func(t *testing.T, cr *hivev1.ClusterRelocate)
 	}{
 		{
 			name: "fresh clusterdeployment",
@@ -910,8 +911,9 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 			srcResources: []runtime.Object{
 				crBuilder.Build(),
 			},
-			expectedRelocateStatus: hivev1.RelocateComplete,
-			expectDeleted:          true,
+			expectedRelocateStatus:  hivev1.RelocateComplete,
+			expectDeleted:           true,
+			validateClusterRelocate: validateClusterRelocateState(hivev1.ClusterRelocateCompleted, "MoveSuccessful"),
 		},
 		{
 			name: "clusterdeployment with dnszone already relocating",
@@ -1065,6 +1067,124 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 				Status: corev1.ConditionTrue,
 				Reason: "ClusterDeploymentMismatch",
 			},
+			validateClusterRelocate: validateClusterRelocateState(hivev1.ClusterRelocateFailed, "ClusterDeploymentMismatch"),
+		},
+		{
+			name: "dry run",
+			cd: cdBuilder.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.RelocationFailedCondition,
+				Status: corev1.ConditionUnknown,
+			})),
+			dnsZone: dnsZoneBuilder.Build(),
+			srcResources: []runtime.Object{
+				crBuilder.Build(testcr.WithDryRun()),
+				testsecret.FullBuilder(namespace, "test-secret", scheme).Build(),
+				testcm.FullBuilder(namespace, "kube-root-ca.crt", scheme).Build(),
+			},
+			expectedRelocationFailedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+			},
+			validateClusterRelocate: func(t *testing.T, cr *hivev1.ClusterRelocate) {
+				validateClusterRelocateState(hivev1.ClusterRelocateMatched, "DryRun")(t, cr)
+				assert.Equal(t, int32(1), cr.Status.Matched, "unexpected matched count")
+				if !assert.Len(t, cr.Status.ClusterDeployments, 1) {
+					return
+				}
+				cdStatus := cr.Status.ClusterDeployments[0]
+				assert.Equal(t, []hivev1.RelocateResourceSummary{
+					{Kind: "Secret", Count: 1, Names: []string{"test-secret"}},
+					{Kind: "DNSZone", Count: 1, Names: []string{controllerutils.DNSZoneName(cdName)}},
+					{Kind: "ClusterDeployment", Count: 1, Names: []string{cdName}},
+				}, cdStatus.ResourcesToCopy, "unexpected resources to copy")
+				assert.Equal(t, []hivev1.RelocateResourceSummary{
+					{Kind: "ConfigMap", Count: 1, Names: []string{"kube-root-ca.crt"}},
+				}, cdStatus.IgnoredResources, "unexpected ignored resources")
+			},
+		},
+		{
+			name: "dry run caps reported resource names",
+			cd: cdBuilder.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:   hivev1.RelocationFailedCondition,
+				Status: corev1.ConditionUnknown,
+			})),
+			srcResources: func() []runtime.Object {
+				objs := []runtime.Object{crBuilder.Build(testcr.WithDryRun())}
+				for i := 0; i < 12; i++ {
+					objs = append(objs, testsecret.FullBuilder(namespace, fmt.Sprintf("test-secret-%02d", i), scheme).Build())
+				}
+				return objs
+			}(),
+			expectedRelocationFailedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+			},
+			validateClusterRelocate: func(t *testing.T, cr *hivev1.ClusterRelocate) {
+				if !assert.Len(t, cr.Status.ClusterDeployments, 1) {
+					return
+				}
+				cdStatus := cr.Status.ClusterDeployments[0]
+				if !assert.Len(t, cdStatus.ResourcesToCopy, 2) {
+					return
+				}
+				secrets := cdStatus.ResourcesToCopy[0]
+				assert.Equal(t, "Secret", secrets.Kind, "unexpected kind")
+				assert.Equal(t, int32(12), secrets.Count, "unexpected count of secrets")
+				assert.Len(t, secrets.Names, maxReportedResourceNames, "unexpected number of secret names")
+				assert.Equal(t, "test-secret-00", secrets.Names[0], "unexpected first secret name")
+			},
+		},
+		{
+			name: "dry run aborts outgoing relocation",
+			cd: cdBuilder.Build(
+				testcd.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.RelocationFailedCondition,
+					Status: corev1.ConditionUnknown,
+				}),
+			),
+			dnsZone: dnsZoneBuilder.Build(
+				testdnszone.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithDryRun(),
+					testcr.WithClusterDeploymentStatus(namespace, cdName, hivev1.ClusterRelocateInProgress),
+				),
+			},
+			expectedRelocationFailedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionUnknown,
+			},
+			validateClusterRelocate: func(t *testing.T, cr *hivev1.ClusterRelocate) {
+				validateClusterRelocateState(hivev1.ClusterRelocateMatched, "DryRun")(t, cr)
+				assert.Zero(t, cr.Status.InProgress, "unexpected in progress count")
+			},
+		},
+		{
+			name: "no longer matching",
+			cd: cdBuilder.Build(
+				testcd.Generic(testgeneric.WithLabel(labelKey, "other-value")),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.RelocationFailedCondition,
+					Status: corev1.ConditionUnknown,
+				}),
+			),
+			srcResources: []runtime.Object{
+				crBuilder.Build(
+					testcr.WithDryRun(),
+					testcr.WithClusterDeploymentStatus(namespace, cdName, hivev1.ClusterRelocateMatched),
+					testcr.WithClusterDeploymentStatus(namespace, "relocated-cd", hivev1.ClusterRelocateCompleted),
+				),
+			},
+			expectedRelocationFailedCondition: &hivev1.ClusterDeploymentCondition{
+				Status: corev1.ConditionFalse,
+				Reason: "NoMatchingRelocates",
+			},
+			validateClusterRelocate: func(t *testing.T, cr *hivev1.ClusterRelocate) {
+				if assert.Len(t, cr.Status.ClusterDeployments, 1, "expected only the relocated clusterdeployment") {
+					assert.Equal(t, "relocated-cd", cr.Status.ClusterDeployments[0].Name, "unexpected clusterdeployment")
+				}
+				assert.Equal(t, int32(1), cr.Status.Completed, "unexpected completed count")
+				assert.Zero(t, cr.Status.Matched, "unexpected matched count")
+			},
 		},
 		{
 			name: "incoming",
@@ -1113,9 +1233,13 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 				testdnszone.Generic(withRelocateAnnotation(crName, hivev1.RelocateOutgoing)),
 			),
 			srcResources: []runtime.Object{
-				crBuilder.Build(),
+				crBuilder.Build(testcr.WithClusterDeploymentStatus(namespace, cdName, hivev1.ClusterRelocateInProgress)),
 			},
 			expectDeleted: true,
+			validateClusterRelocate: func(t *testing.T, cr *hivev1.ClusterRelocate) {
+				assert.Empty(t, cr.Status.ClusterDeployments, "expected clusterdeployment to be removed from clusterrelocate status")
+				assert.Zero(t, cr.Status.InProgress, "unexpected in progress count")
+			},
 			expectedRelocationFailedCondition: &hivev1.ClusterDeploymentCondition{
 				Status: corev1.ConditionFalse,
 				Reason: "DontUpdateCondition",
@@ -1208,6 +1332,13 @@ func TestReconcileClusterRelocate_Reconcile_RelocateStatus(t *testing.T) {
 				require.NoError(t, err, "unexpected error during reconcile")
 			}
 
+			if tc.validateClusterRelocate != nil {
+				cr := &hivev1.ClusterRelocate{}
+				err = srcClient.Get(context.Background(), client.ObjectKey{Name: crName}, cr)
+				require.NoError(t, err, "unexpected error fetching clusterrelocate")
+				tc.validateClusterRelocate(t, cr)
+			}
+
 			var dnsZone *hivev1.DNSZone
 			if tc.dnsZone != nil {
 				dnsZone = &hivev1.DNSZone{}
@@ -1262,3 +1393,17 @@ func withRelocateAnnotation(clusterRelocateName string, status hivev1.RelocateSt
 		fmt.Sprintf("%s/%s", clusterRelocateName, status),
 	)
 }
+
+func validateClusterRelocateState(state hivev1.ClusterRelocateState, reason string) func(t *testing.T, cr *hivev1.ClusterRelocate) {
+	return func(t *testing.T, cr *hivev1.ClusterRelocate) {
+		if !assert.Len(t, cr.Status.ClusterDeployments, 1, "unexpected clusterdeployments in clusterrelocate status") {
+			return
+		}
+		cdStatus := cr.Status.ClusterDeployments[0]
+		assert.Equal(t, namespace, cdStatus.Namespace, "unexpected clusterdeployment namespace")
+		assert.Equal(t, cdName, cdStatus.Name, "unexpected clusterdeployment name")
+		assert.Equal(t, state, cdStatus.State, "unexpected relocate state")
+		assert.Equal(t, reason, cdStatus.Reason, "unexpected relocate reason")
+		assert.NotNil(t, cdStatus.LastTransitionTime, "expected last transition time")
+	}
+}
@@ -0,0 +1,190 @@
+package clusterrelocate
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// reconcileDryRun reports the resources that would be copied to the destination cluster for a ClusterDeployment that
+// matches a dry-run ClusterRelocate, without relocating the ClusterDeployment.
+func (r *ReconcileClusterRelocate) reconcileDryRun(cd *hivev1.ClusterDeployment, oldRelocateStatus hivev1.RelocateStatus, oldRelocateName string, desiredRelocate *hivev1.ClusterRelocate, logger log.FieldLogger) (reconcile.Result, error) {
+	// Abort an in-progress relocate when its ClusterRelocate is changed to a dry run
+	if oldRelocateStatus == hivev1.RelocateOutgoing && oldRelocateName == desiredRelocate.Name {
+		logger.Warn("aborting relocation since the clusterrelocate is a dry run")
+		if err := r.stopRelocating(cd, oldRelocateName, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+		recordMetricForAbortedRelocate(oldRelocateName, "dry_run")
+	}
+
+	toCopy, ignored, err := r.planCopy(cd, logger)
+	if err != nil {
+		logger.WithError(err).Error("could not determine the resources to copy")
+		return reconcile.Result{}, err
+	}
+	if err := r.setClusterDeploymentStatus(desiredRelocate.Name, cd, &hivev1.ClusterRelocateClusterDeploymentStatus{
+		State:            hivev1.ClusterRelocateMatched,
+		Reason:           "DryRun",
+		Message:          "clusterrelocate is a dry run",
+		ResourcesToCopy:  toCopy,
+		IgnoredResources: ignored,
+	}, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	return reconcile.Result{}, nil
+}
+
+// maxReportedResourceNames is the number of names of resources of a kind listed in the status of a dry-run
+// ClusterRelocate. The status lists the resources of every matching ClusterDeployment, so the names are capped to keep
+// it within the size limit of an object.
+const maxReportedResourceNames = 10
+
+// planCopy summarizes the resources that copy would copy to the destination cluster, in the order in which it would
+// copy them, and the resources in the namespace of the ClusterDeployment that it would ignore.
+func (r *ReconcileClusterRelocate) planCopy(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (toCopy, ignored []hivev1.RelocateResourceSummary, returnErr error) {
+	for _, t := range typesToCopy() {
+		objs, ignoredObjs, err := r.listResourcesToCopy(cd, t.(client.ObjectList), logger)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to list %T", t)
+		}
+		for _, obj := range objs {
+			toCopy = addToSummary(toCopy, obj)
+		}
+		for _, obj := range ignoredObjs {
+			logger.WithField("type", reflect.TypeOf(obj)).WithField("resource", obj.GetName()).
+				Debug("resource would not be copied since it is a resource that should be ignored")
+			ignored = addToSummary(ignored, obj)
+		}
+	}
+	dnsZone, err := r.dnsZone(cd, logger)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get DNSZone")
+	}
+	if dnsZone != nil {
+		toCopy = addToSummary(toCopy, dnsZone)
+	}
+	toCopy = addToSummary(toCopy, cd)
+	return toCopy, ignored, nil
+}
+
+// addToSummary counts the object in the summary of its kind, adding the summary if the object is not of the kind of the
+// last summary.
+func addToSummary(summaries []hivev1.RelocateResourceSummary, obj client.Object) []hivev1.RelocateResourceSummary {
+	kind := reflect.TypeOf(obj).Elem().Name()
+	if len(summaries) == 0 || summaries[len(summaries)-1].Kind != kind {
+		summaries = append(summaries, hivev1.RelocateResourceSummary{Kind: kind})
+	}
+	summary := &summaries[len(summaries)-1]
+	summary.Count++
+	if len(summary.Names) < maxReportedResourceNames {
+		summary.Names = append(summary.Names, obj.GetName())
+	}
+	return summaries
+}
+
+// setRelocationFailed reports the failure to relocate the ClusterDeployment in its RelocationFailed condition and in
+// the status of the ClusterRelocate.
+func (r *ReconcileClusterRelocate) setRelocationFailed(cd *hivev1.ClusterDeployment, relocateName, reason, message string, logger log.FieldLogger) error {
+	if err := r.setRelocationFailedCondition(cd, corev1.ConditionTrue, reason, message, logger); err != nil {
+		return err
+	}
+	return r.setClusterDeploymentStatus(relocateName, cd, &hivev1.ClusterRelocateClusterDeploymentStatus{
+		State:   hivev1.ClusterRelocateFailed,
+		Reason:  reason,
+		Message: message,
+	}, logger)
+}
+
+// setClusterDeploymentStatus records the relocation of the ClusterDeployment in the status of the ClusterRelocate.
+func (r *ReconcileClusterRelocate) setClusterDeploymentStatus(relocateName string, cd *hivev1.ClusterDeployment, cdStatus *hivev1.ClusterRelocateClusterDeploymentStatus, logger log.FieldLogger) error {
+	cdStatus.Namespace = cd.Namespace
+	cdStatus.Name = cd.Name
+	return r.updateRelocateStatus(relocateName, logger, func(status *hivev1.ClusterRelocateStatus) bool {
+		for i, existing := range status.ClusterDeployments {
+			if existing.Namespace != cd.Namespace || existing.Name != cd.Name {
+				continue
+			}
+			cdStatus.LastTransitionTime = existing.LastTransitionTime
+			if existing.State != cdStatus.State || cdStatus.LastTransitionTime == nil {
+				now := metav1.Now()
+				cdStatus.LastTransitionTime = &now
+			}
+			if reflect.DeepEqual(&existing, cdStatus) {
+				return false
+			}
+			status.ClusterDeployments[i] = *cdStatus
+			return true
+		}
+		now := metav1.Now()
+		cdStatus.LastTransitionTime = &now
+		status.ClusterDeployments = append(status.ClusterDeployments, *cdStatus)
+		return true
+	})
+}
+
+// clearClusterDeploymentStatus removes the ClusterDeployment from the status of the ClusterRelocate, unless the
+// ClusterRelocate has relocated it.
+func (r *ReconcileClusterRelocate) clearClusterDeploymentStatus(relocateName string, cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
+	return r.updateRelocateStatus(relocateName, logger, func(status *hivev1.ClusterRelocateStatus) bool {
+		for i, existing := range status.ClusterDeployments {
+			if existing.Namespace == cd.Namespace && existing.Name == cd.Name && existing.State != hivev1.ClusterRelocateCompleted {
+				status.ClusterDeployments = append(status.ClusterDeployments[:i], status.ClusterDeployments[i+1:]...)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// updateRelocateStatus applies mutate to the status of the ClusterRelocate, and updates the ClusterRelocate if mutate
+// reports that it changed the status.
+func (r *ReconcileClusterRelocate) updateRelocateStatus(relocateName string, logger log.FieldLogger, mutate func(*hivev1.ClusterRelocateStatus) bool) error {
+	logger = logger.WithField("clusterRelocate", relocateName)
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cr := &hivev1.ClusterRelocate{}
+		if err := r.Get(context.Background(), client.ObjectKey{Name: relocateName}, cr); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if !mutate(&cr.Status) {
+			return nil
+		}
+		countClusterDeployments(&cr.Status)
+		return r.Status().Update(context.Background(), cr)
+	})
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "could not update clusterrelocate status")
+		return errors.Wrap(err, "could not update clusterrelocate status")
+	}
+	return nil
+}
+
+func countClusterDeployments(status *hivev1.ClusterRelocateStatus) {
+	status.Matched, status.InProgress, status.Completed, status.Failed = 0, 0, 0, 0
+	for _, cdStatus := range status.ClusterDeployments {
+		switch cdStatus.State {
+		case hivev1.ClusterRelocateMatched:
+			status.Matched++
+		case hivev1.ClusterRelocateInProgress:
+			status.InProgress++
+		case hivev1.ClusterRelocateCompleted:
+			status.Completed++
+		case hivev1.ClusterRelocateFailed:
+			status.Failed++
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	k8slabels "github.com/openshift/hive/pkg/util/labels"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -115,6 +116,7 @@ func (r *ReconcileClusterState) Reconcile(ctx context.Context, request reconcile
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
 			logger.Debug("cluster deployment not found")
+			clearDegradedOperatorsMetric(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -136,10 +138,12 @@ func (r *ReconcileClusterState) Reconcile(ctx context.Context, request reconcile
 
 	if !cd.DeletionTimestamp.IsZero() {
 		logger.Debug("ClusterDeployment resource has been deleted")
+		clearDegradedOperatorsMetric(cd.Namespace, cd.Name)
 		return reconcile.Result{}, nil
 	}
 	if !cd.Spec.Installed {
 		logger.Debug("ClusterDeployment is not yet ready")
+		clearDegradedOperatorsMetric(cd.Namespace, cd.Name)
 		return reconcile.Result{}, nil
 	}
 
@@ -185,7 +189,9 @@ func (r *ReconcileClusterState) Reconcile(ctx context.Context, request reconcile
 		logger.Info("Waiting 60 seconds for cluster state to finish deleting")
 		return reconcile.Result{RequeueAfter: 60 * time.Second}, nil
 	}
-	if st.Status.LastUpdated != nil {
+	// Keep reporting the last known health of the cluster operators until it is next fetched.
+	setDegradedOperatorsMetric(cd, st.Status.Health)
+	if st.Status.LastUpdated != nil && st.Status.Health != nil {
 		timeSinceLastUpdate := time.Since(st.Status.LastUpdated.Time)
 		if timeSinceLastUpdate < statusUpdateInterval {
 			nextUpdateWait := statusUpdateInterval - timeSinceLastUpdate
@@ -211,10 +217,10 @@ func (r *ReconcileClusterState) Reconcile(ctx context.Context, request reconcile
 		logger.WithError(err).Error("failed to list target cluster operators")
 		return reconcile.Result{}, err
 	}
-	return r.syncOperatorStates(clusterOperators.Items, st, logger)
+	return r.syncOperatorStates(clusterOperators.Items, st, cd, logger)
 }
 
-func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterOperator, st *hivev1.ClusterState, logger log.FieldLogger) (reconcile.Result, error) {
+func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterOperator, st *hivev1.ClusterState, cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
 	operatorStates := make([]hivev1.ClusterOperatorState, len(operators))
 	for i, clusterOperator := range operators {
 		operatorStates[i] = hivev1.ClusterOperatorState{
@@ -222,8 +228,14 @@ func (r *ReconcileClusterState) syncOperatorStates(operators []configv1.ClusterO
 			Conditions: clusterOperator.Status.Conditions,
 		}
 	}
-	if operatorStatesChanged(logger, st.Status.ClusterOperators, operatorStates) {
+	health := operatorsHealth(operatorStates)
+	setDegradedOperatorsMetric(cd, health)
+	if err := r.setOperatorsDegradedCondition(cd, health, logger); err != nil {
+		return reconcile.Result{}, err
+	}
+	if operatorStatesChanged(logger, st.Status.ClusterOperators, operatorStates) || !equality.Semantic.DeepEqual(st.Status.Health, health) {
 		st.Status.ClusterOperators = operatorStates
+		st.Status.Health = health
 		now := metav1.Now()
 		st.Status.LastUpdated = &now
 		if err := r.updateStatus(r, st); err != nil {
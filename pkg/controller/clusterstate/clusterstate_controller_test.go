@@ -4,8 +4,10 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,6 +27,7 @@ import (
 	"github.com/openshift/hive/apis"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 )
@@ -54,6 +57,7 @@ func TestClusterStateReconcile(t *testing.T) {
 	}
 	co := clusterOperator
 	uco := unavailableClusterOperator
+	dco := degradedClusterOperator
 
 	tests := []struct {
 		name         string
@@ -142,6 +146,73 @@ func TestClusterStateReconcile(t *testing.T) {
 				validateStatus(t, st.Status, addCond(co("a")), co("b"))
 			},
 		},
+		{
+			name: "healthy operators",
+			existing: []runtime.Object{
+				testClusterState(),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote: []runtime.Object{co("a"), co("b")},
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				assert.Equal(t, &hivev1.ClusterOperatorsHealth{Total: 2, Available: 2}, st.Status.Health, "unexpected health")
+				validateDegradedCondition(t, c, corev1.ConditionFalse, hivev1.ClusterOperatorsHealthyReason)
+				assert.Equal(t, 0., testutil.ToFloat64(metricDegradedOperators.WithLabelValues(testName, testNamespace, "unspecified")), "unexpected degraded operators metric")
+			},
+		},
+		{
+			name: "degraded and unavailable operators",
+			existing: []runtime.Object{
+				testClusterStateWithStatus(co("a"), co("b"), co("c")),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote: []runtime.Object{
+				co("a"),
+				dco("b", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+				uco("c"),
+			},
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				require.NotNil(t, st.Status.Health, "expected health")
+				health := st.Status.Health
+				assert.Equal(t, int32(3), health.Total, "unexpected total")
+				assert.Equal(t, int32(2), health.Available, "unexpected available")
+				assert.Equal(t, int32(2), health.Degraded, "unexpected degraded")
+				if assert.NotNil(t, health.DegradedSince, "expected degraded since") {
+					assert.True(t, health.DegradedSince.Equal(&metav1.Time{Time: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}), "unexpected degraded since")
+				}
+				if assert.NotNil(t, health.WorstOperator, "expected worst operator") {
+					assert.Equal(t, "c", health.WorstOperator.Name, "unavailable operator should be worst")
+					assert.Equal(t, configv1.OperatorAvailable, health.WorstOperator.Condition, "unexpected worst operator condition")
+				}
+				validateDegradedCondition(t, c, corev1.ConditionTrue, hivev1.ClusterOperatorsUnavailableReason)
+				assert.Equal(t, 2., testutil.ToFloat64(metricDegradedOperators.WithLabelValues(testName, testNamespace, "unspecified")), "unexpected degraded operators metric")
+			},
+		},
+		{
+			name: "missing health",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterState {
+					st := testClusterStateWithStatus(co("a"), dco("b", time.Now()))
+					st.Status.Health = nil
+					now := metav1.Now()
+					st.Status.LastUpdated = &now
+					return st
+				}(),
+				testClusterDeployment(),
+				testKubeconfigSecret(),
+			},
+			remote: []runtime.Object{co("a"), dco("b", time.Now())},
+			validate: func(t *testing.T, c client.Client, result reconcile.Result) {
+				st := cs(t, c)
+				if assert.NotNil(t, st.Status.Health, "expected health") {
+					assert.Equal(t, int32(1), st.Status.Health.Degraded, "unexpected degraded")
+				}
+				validateDegradedCondition(t, c, corev1.ConditionTrue, hivev1.ClusterOperatorsDegradedReason)
+			},
+		},
 		{
 			name: "removed remote condition",
 			existing: []runtime.Object{
@@ -215,6 +286,7 @@ func testClusterStateWithStatus(operators ...*configv1.ClusterOperator) *hivev1.
 			Conditions: op.Status.Conditions,
 		})
 	}
+	cs.Status.Health = operatorsHealth(cs.Status.ClusterOperators)
 	return cs
 }
 
@@ -290,6 +362,15 @@ func unavailableClusterOperator(name string) *configv1.ClusterOperator {
 	return op
 }
 
+func degradedClusterOperator(name string, since time.Time) *configv1.ClusterOperator {
+	op := clusterOperator(name)
+	op.Status.Conditions[2].Status = configv1.ConditionTrue
+	op.Status.Conditions[2].Reason = "Degraded"
+	op.Status.Conditions[2].Message = "Degraded"
+	op.Status.Conditions[2].LastTransitionTime = metav1.NewTime(since)
+	return op
+}
+
 func addCond(co *configv1.ClusterOperator) *configv1.ClusterOperator {
 	co.Status.Conditions = append(co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
 		Type:    configv1.OperatorUpgradeable,
@@ -320,3 +401,13 @@ func validateStatus(t *testing.T, status hivev1.ClusterStateStatus, operators ..
 		assert.ElementsMatch(t, status.ClusterOperators[i].Conditions, operators[i].Status.Conditions, "operator conditions don't match")
 	}
 }
+
+func validateDegradedCondition(t *testing.T, c client.Client, status corev1.ConditionStatus, reason string) {
+	cd := &hivev1.ClusterDeployment{}
+	require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Namespace: testNamespace, Name: testName}, cd), "could not get cluster deployment")
+	cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ClusterOperatorsDegradedCondition)
+	if assert.NotNil(t, cond, "expected ClusterOperatorsDegraded condition") {
+		assert.Equal(t, status, cond.Status, "unexpected condition status")
+		assert.Equal(t, reason, cond.Reason, "unexpected condition reason")
+	}
+}
@@ -0,0 +1,148 @@
+package clusterstate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+var (
+	metricDegradedOperators = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployments_degraded_operators",
+		Help: "Number of cluster operators that the cluster reports as degraded or unavailable.",
+	}, []string{"cluster_deployment", "namespace", "cluster_type"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricDegradedOperators)
+}
+
+// operatorsHealth summarizes the state of the given cluster operators.
+func operatorsHealth(operators []hivev1.ClusterOperatorState) *hivev1.ClusterOperatorsHealth {
+	health := &hivev1.ClusterOperatorsHealth{Total: int32(len(operators))}
+	for _, operator := range operators {
+		var problem *hivev1.DegradedClusterOperator
+		for _, cond := range operator.Conditions {
+			switch {
+			case cond.Type == configv1.OperatorAvailable && cond.Status == configv1.ConditionTrue:
+				health.Available++
+			case cond.Type == configv1.OperatorProgressing && cond.Status == configv1.ConditionTrue:
+				health.Progressing++
+			}
+			unavailable := cond.Type == configv1.OperatorAvailable && cond.Status == configv1.ConditionFalse
+			degraded := cond.Type == configv1.OperatorDegraded && cond.Status == configv1.ConditionTrue
+			// An unavailable operator is reported as such even if it is also degraded.
+			if unavailable || (degraded && problem == nil) {
+				problem = &hivev1.DegradedClusterOperator{
+					Name:      operator.Name,
+					Condition: cond.Type,
+					Reason:    cond.Reason,
+					Message:   cond.Message,
+				}
+				if !cond.LastTransitionTime.IsZero() {
+					since := cond.LastTransitionTime
+					problem.Since = &since
+				}
+			}
+		}
+		if problem == nil {
+			continue
+		}
+		health.Degraded++
+		if problem.Since != nil && (health.DegradedSince == nil || problem.Since.Before(health.DegradedSince)) {
+			health.DegradedSince = problem.Since.DeepCopy()
+		}
+		if worseOperator(problem, health.WorstOperator) {
+			health.WorstOperator = problem
+		}
+	}
+	return health
+}
+
+// worseOperator returns whether a is in a worse state than b. Unavailable operators are worse than degraded ones, and
+// between operators in the same state the one that has been in it the longest is worse.
+func worseOperator(a, b *hivev1.DegradedClusterOperator) bool {
+	switch {
+	case b == nil:
+		return true
+	case a.Condition != b.Condition:
+		return a.Condition == configv1.OperatorAvailable
+	case a.Since != nil && b.Since != nil && !a.Since.Equal(b.Since):
+		return a.Since.Before(b.Since)
+	default:
+		return a.Name < b.Name
+	}
+}
+
+// setOperatorsDegradedCondition projects the health of the cluster operators onto the ClusterOperatorsDegraded
+// condition of the ClusterDeployment.
+func (r *ReconcileClusterState) setOperatorsDegradedCondition(cd *hivev1.ClusterDeployment, health *hivev1.ClusterOperatorsHealth, logger log.FieldLogger) error {
+	status := corev1.ConditionFalse
+	reason := hivev1.ClusterOperatorsHealthyReason
+	message := fmt.Sprintf("All %d cluster operators are available and not degraded", health.Total)
+	if worst := health.WorstOperator; worst != nil {
+		status = corev1.ConditionTrue
+		reason = hivev1.ClusterOperatorsDegradedReason
+		state := "degraded"
+		if worst.Condition == configv1.OperatorAvailable {
+			reason = hivev1.ClusterOperatorsUnavailableReason
+			state = "unavailable"
+		}
+		message = fmt.Sprintf("%d of %d cluster operators are degraded or unavailable. ClusterOperator %s is %s",
+			health.Degraded, health.Total, worst.Name, state)
+		if worst.Since != nil {
+			message += fmt.Sprintf(" since %s", worst.Since.UTC().Format(time.RFC3339))
+		}
+		if worst.Message != "" {
+			message += ": " + worst.Message
+		}
+	}
+	conds, changed := controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ClusterOperatorsDegradedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+	if !changed {
+		return nil
+	}
+	cd.Status.Conditions = conds
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update ClusterOperatorsDegraded condition")
+		return err
+	}
+	return nil
+}
+
+// setDegradedOperatorsMetric reports the number of degraded cluster operators of the cluster.
+func setDegradedOperatorsMetric(cd *hivev1.ClusterDeployment, health *hivev1.ClusterOperatorsHealth) {
+	if health == nil {
+		return
+	}
+	metricDegradedOperators.WithLabelValues(
+		cd.Name,
+		cd.Namespace,
+		hivemetrics.GetLabelValue(cd, hivev1.HiveClusterTypeLabel),
+	).Set(float64(health.Degraded))
+}
+
+// clearDegradedOperatorsMetric stops reporting the number of degraded cluster operators of the cluster.
+func clearDegradedOperatorsMetric(namespace, name string) {
+	metricDegradedOperators.DeletePartialMatch(prometheus.Labels{
+		"cluster_deployment": name,
+		"namespace":          namespace,
+	})
+}
@@ -42,6 +42,7 @@ import (
 	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	"github.com/openshift/hive/pkg/resource"
+	"github.com/openshift/hive/pkg/util/syncsettemplate"
 )
 
 const (
@@ -54,6 +55,7 @@ const (
 	labelApply             = "apply"
 	labelCreateOrUpdate    = "createOrUpdate"
 	labelCreateOnly        = "createOnly"
+	labelServerSideApply   = "serverSideApply"
 	metricResultSuccess    = "success"
 	metricResultError      = "error"
 	stsName                = "hive-clustersync"
@@ -108,6 +110,7 @@ func init() {
 	metrics.Registry.MustRegister(metricResourcesApplied)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSetResource)
 	metrics.Registry.MustRegister(metricTimeToApplySyncSets)
+	metrics.Registry.MustRegister(metricDrift)
 }
 
 // Add creates a new clustersync Controller and adds it to the Manager with default RBAC. The Manager will set fields on the Controller
@@ -143,7 +146,16 @@ func Add(mgr manager.Manager) error {
 	r.ordinalID = int64(ordinalID32)
 	logger.WithField("ordinalID", r.ordinalID).Debug("ordinalID set")
 
-	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+	if err := AddToManager(mgr, r, concurrentReconciles, queueRateLimiter); err != nil {
+		return err
+	}
+
+	// The status of each SyncSet and SelectorSyncSet is rolled up from the ClusterSyncs of all clusters, so
+	// only one replica may write it.
+	if r.ordinalID == 0 {
+		return AddSyncSetStatusToManager(mgr, NewSyncSetStatusReconciler(r.Client), concurrentReconciles)
+	}
+	return nil
 }
 
 // NewReconciler returns a new ReconcileClusterSync
@@ -270,10 +282,13 @@ func requestsForSelectorSyncSet(c client.Client, logger log.FieldLogger) handler
 			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments matching SelectorSyncSet")
 			return nil
 		}
-		requests := make([]reconcile.Request, len(cds.Items))
+		var requests []reconcile.Request
 		for i, cd := range cds.Items {
-			requests[i].Namespace = cd.Namespace
-			requests[i].Name = cd.Name
+			if sss.Spec.RolloutStrategy != nil && isHeldByRollout((*SelectorSyncSetAsCommon)(sss), &cds.Items[i]) {
+				// Held clusters are reconciled when a later batch of the rollout releases them
+				continue
+			}
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}})
 		}
 		return requests
 	}
@@ -483,7 +498,7 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 	recobsrv.SetOutcome(hivemetrics.ReconcileOutcomeFullSync)
 
 	// Apply SyncSets
-	syncStatusesForSyncSets, syncSetsNeedRequeue := r.applySyncSets(
+	syncStatusesForSyncSets, syncSetsNeedRequeue, syncSetDriftEvents := r.applySyncSets(
 		cd,
 		"SyncSet",
 		syncSets,
@@ -494,9 +509,10 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		logger,
 	)
 	clusterSync.Status.SyncSets = syncStatusesForSyncSets
+	addDriftEvents(clusterSync, syncSetDriftEvents)
 
 	// Apply SelectorSyncSets
-	syncStatusesForSelectorSyncSets, selectorSyncSetsNeedRequeue := r.applySyncSets(
+	syncStatusesForSelectorSyncSets, selectorSyncSetsNeedRequeue, selectorSyncSetDriftEvents := r.applySyncSets(
 		cd,
 		"SelectorSyncSet",
 		selectorSyncSets,
@@ -507,6 +523,7 @@ func (r *ReconcileClusterSync) Reconcile(ctx context.Context, request reconcile.
 		logger,
 	)
 	clusterSync.Status.SelectorSyncSets = syncStatusesForSelectorSyncSets
+	addDriftEvents(clusterSync, selectorSyncSetDriftEvents)
 
 	setFailedCondition(clusterSync)
 
@@ -564,7 +581,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 	reportSelectorSyncSetMetrics bool,
 	resourceHelper resource.Helper,
 	logger log.FieldLogger,
-) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool) {
+) (newSyncStatuses []hiveintv1alpha1.SyncStatus, requeue bool, driftEvents []hiveintv1alpha1.SyncDriftEvent) {
 	// Sort the syncsets to a consistent ordering. This prevents thrashing in the ClusterSync status due to the order
 	// of the syncset status changing from one reconcile to the next.
 	sort.Slice(syncSets, func(i, j int) bool {
@@ -614,6 +631,11 @@ func (r *ReconcileClusterSync) applySyncSets(
 
 		// Determine if the syncset needs to be applied
 		switch {
+		case indexOfOldStatus >= 0 && oldSyncStatus.ObservedGeneration != syncSet.AsMetaObject().GetGeneration() && isHeldByRollout(syncSet, cd):
+			// Only the current generation of the syncset is available, so it cannot be re-applied either.
+			logger.Debug("skipping apply of syncset since its rollout has not been released to the cluster")
+			newSyncStatuses = append(newSyncStatuses, oldSyncStatus)
+			continue
 		case needToDoFullReapply:
 			logger.Debug("applying syncset because it is time to do a full re-apply")
 		case indexOfOldStatus < 0:
@@ -629,11 +651,20 @@ func (r *ReconcileClusterSync) applySyncSets(
 		}
 
 		// Apply the syncset
-		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(syncSet, resourceHelper, logger)
+		var oldSyncStatusForDrift *hiveintv1alpha1.SyncStatus
+		if indexOfOldStatus >= 0 {
+			oldSyncStatusForDrift = &oldSyncStatus
+		}
+		drift := newDriftDetector(syncSet, syncSetType, oldSyncStatusForDrift, resourceHelper)
+		resourcesApplied, resourcesInSyncSet, syncSetNeedsRequeue, err := r.applySyncSet(cd, syncSet, resourceHelper, drift, logger)
 		newSyncStatus := hiveintv1alpha1.SyncStatus{
 			Name:               syncSet.AsMetaObject().GetName(),
 			ObservedGeneration: syncSet.AsMetaObject().GetGeneration(),
 			Result:             hiveintv1alpha1.SuccessSyncSetResult,
+			ResourceHashes:     drift.resourceHashes(),
+		}
+		if drift != nil {
+			driftEvents = append(driftEvents, drift.events...)
 		}
 		applyMode := syncSet.GetSpec().ResourceApplyMode
 		if applyMode == hivev1.SyncResourceApplyMode {
@@ -647,6 +678,7 @@ func (r *ReconcileClusterSync) applySyncSets(
 		if err != nil {
 			newSyncStatus.Result = hiveintv1alpha1.FailureSyncSetResult
 			newSyncStatus.FailureMessage = err.Error()
+			newSyncStatus.Conditions = applyConflictConditions(err, oldSyncStatus)
 		}
 		if syncSetNeedsRequeue {
 			requeue = true
@@ -738,8 +770,10 @@ func getOldSyncStatus(syncSet CommonSyncSet, syncSetStatuses []hiveintv1alpha1.S
 }
 
 func (r *ReconcileClusterSync) applySyncSet(
+	cd *hivev1.ClusterDeployment,
 	syncSet CommonSyncSet,
 	resourceHelper resource.Helper,
+	drift *driftDetector,
 	logger log.FieldLogger,
 ) (
 	resourcesApplied []hiveintv1alpha1.SyncResourceReference,
@@ -747,7 +781,7 @@ func (r *ReconcileClusterSync) applySyncSet(
 	requeue bool,
 	returnErr error,
 ) {
-	resources, referencesToResources, decodeErr := decodeResources(syncSet, logger)
+	resources, referencesToResources, decodeErr := decodeResources(cd, syncSet, logger)
 	referencesToSecrets := referencesToSecrets(syncSet)
 	resourcesInSyncSet = append(referencesToResources, referencesToSecrets...)
 	if decodeErr != nil {
@@ -764,11 +798,14 @@ func (r *ReconcileClusterSync) applySyncSet(
 	case hivev1.CreateOnlySyncSetApplyBehavior:
 		applyFn = resourceHelper.Create
 		applyFnMetricsLabel = labelCreateOnly
+	case hivev1.ServerSideApplySyncSetApplyBehavior:
+		applyFn = serverSideApplyFn(syncSet, resourceHelper)
+		applyFnMetricsLabel = labelServerSideApply
 	}
 
 	// Apply Resources
 	for i, resource := range resources {
-		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, logger)
+		returnErr, requeue = r.applyResource(i, resource, referencesToResources[i], applyFn, applyFnMetricsLabel, drift, logger)
 		if returnErr != nil {
 			resourcesApplied = referencesToResources[:i]
 			return
@@ -778,7 +815,7 @@ func (r *ReconcileClusterSync) applySyncSet(
 
 	// Apply Secrets
 	for i, secretMapping := range syncSet.GetSpec().Secrets {
-		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, logger)
+		returnErr, requeue = r.applySecret(syncSet, i, secretMapping, referencesToSecrets[i], applyFn, applyFnMetricsLabel, drift, logger)
 		if returnErr != nil {
 			resourcesApplied = append(resourcesApplied, referencesToSecrets[:i]...)
 			return
@@ -788,6 +825,14 @@ func (r *ReconcileClusterSync) applySyncSet(
 
 	// Apply Patches
 	for i, patch := range syncSet.GetSpec().Patches {
+		if syncSet.GetSpec().EnableResourceTemplates {
+			var err error
+			if patch, err = renderPatch(patch, syncsettemplate.DataForClusterDeployment(cd)); err != nil {
+				logger.WithField("patchIndex", i).WithError(err).Warn("error rendering patch template")
+				returnErr = errors.Wrapf(err, "failed to render patch %d", i)
+				return
+			}
+		}
 		returnErr, requeue = r.applyPatch(i, patch, resourceHelper, logger)
 		if returnErr != nil {
 			return
@@ -798,7 +843,7 @@ func (r *ReconcileClusterSync) applySyncSet(
 	return
 }
 
-func decodeResources(syncSet CommonSyncSet, logger log.FieldLogger) (
+func decodeResources(cd *hivev1.ClusterDeployment, syncSet CommonSyncSet, logger log.FieldLogger) (
 	resources []*unstructured.Unstructured, references []hiveintv1alpha1.SyncResourceReference, returnErr error,
 ) {
 	var decodeErrors []error
@@ -809,6 +854,13 @@ func decodeResources(syncSet CommonSyncSet, logger log.FieldLogger) (
 			decodeErrors = append(decodeErrors, errors.Wrapf(err, "failed to decode resource %d", i))
 			continue
 		}
+		if syncSet.GetSpec().EnableResourceTemplates {
+			if err := syncsettemplate.RenderObject(u.Object, syncsettemplate.DataForClusterDeployment(cd)); err != nil {
+				logger.WithField("resourceIndex", i).WithError(err).Warn("error rendering resource template")
+				decodeErrors = append(decodeErrors, errors.Wrapf(err, "failed to render resource %d", i))
+				continue
+			}
+		}
 		resources = append(resources, u)
 		references = append(references, hiveintv1alpha1.SyncResourceReference{
 			APIVersion: u.GetAPIVersion(),
@@ -821,6 +873,21 @@ func decodeResources(syncSet CommonSyncSet, logger log.FieldLogger) (
 	return
 }
 
+// renderPatch returns the patch with its name, namespace and patch rendered as templates.
+func renderPatch(patch hivev1.SyncObjectPatch, data *syncsettemplate.Data) (hivev1.SyncObjectPatch, error) {
+	var err error
+	if patch.Name, err = syncsettemplate.RenderString(patch.Name, data); err != nil {
+		return patch, errors.Wrap(err, "name")
+	}
+	if patch.Namespace, err = syncsettemplate.RenderString(patch.Namespace, data); err != nil {
+		return patch, errors.Wrap(err, "namespace")
+	}
+	if patch.Patch, err = syncsettemplate.RenderString(patch.Patch, data); err != nil {
+		return patch, errors.Wrap(err, "patch")
+	}
+	return patch, nil
+}
+
 func referencesToSecrets(syncSet CommonSyncSet) []hiveintv1alpha1.SyncResourceReference {
 	var references []hiveintv1alpha1.SyncResourceReference
 	for _, secretMapping := range syncSet.GetSpec().Secrets {
@@ -840,6 +907,7 @@ func (r *ReconcileClusterSync) applyResource(
 	reference hiveintv1alpha1.SyncResourceReference,
 	applyFn func(obj []byte) (resource.ApplyResult, error),
 	applyFnMetricsLabel string,
+	drift *driftDetector,
 	logger log.FieldLogger,
 ) (returnErr error, requeue bool) {
 	logger = logger.WithField("resourceIndex", resourceIndex).
@@ -848,7 +916,7 @@ func (r *ReconcileClusterSync) applyResource(
 		WithField("resourceAPIVersion", reference.APIVersion).
 		WithField("resourceKind", reference.Kind)
 	logger.Debug("applying resource")
-	if err := applyToTargetCluster(resource, applyFnMetricsLabel, applyFn, logger); err != nil {
+	if err := applyToTargetCluster(resource, reference, applyFnMetricsLabel, applyFn, drift, logger); err != nil {
 		return errors.Wrapf(err, "failed to apply resource %d", resourceIndex), true
 	}
 	return nil, false
@@ -861,6 +929,7 @@ func (r *ReconcileClusterSync) applySecret(
 	reference hiveintv1alpha1.SyncResourceReference,
 	applyFn func(obj []byte) (resource.ApplyResult, error),
 	applyFnMetricsLabel string,
+	drift *driftDetector,
 	logger log.FieldLogger,
 ) (returnErr error, requeue bool) {
 	logger = logger.WithField("secretIndex", secretIndex).
@@ -896,7 +965,7 @@ func (r *ReconcileClusterSync) applySecret(
 		Labels:      secret.Labels,
 	}
 	logger.Debug("applying secret")
-	if err := applyToTargetCluster(secret, applyFnMetricsLabel, applyFn, logger); err != nil {
+	if err := applyToTargetCluster(secret, reference, applyFnMetricsLabel, applyFn, drift, logger); err != nil {
 		return errors.Wrapf(err, "failed to apply secret %d", secretIndex), true
 	}
 	return nil, false
@@ -928,8 +997,10 @@ func (r *ReconcileClusterSync) applyPatch(
 
 func applyToTargetCluster(
 	obj hivev1.MetaRuntimeObject,
+	reference hiveintv1alpha1.SyncResourceReference,
 	applyFnMetricLabel string,
 	applyFn func(obj []byte) (resource.ApplyResult, error),
+	drift *driftDetector,
 	logger log.FieldLogger,
 ) error {
 	startTime := time.Now()
@@ -947,11 +1018,23 @@ func applyToTargetCluster(
 		return err
 	}
 
+	var desired map[string]interface{}
+	if drift != nil {
+		if err := json.Unmarshal(bytes, &desired); err != nil {
+			logger.WithError(err).Error("error unmarshalling json bytes to check for drift")
+			return err
+		}
+		if !drift.check(desired, reference, logger) {
+			return nil
+		}
+	}
+
 	applyResult, err := applyFn(bytes)
 	// Record the amount of time we took to apply this specific resource. When combined with the metric for duration of
 	// our kube client requests, we can get an idea how much time we're spending cpu bound vs network bound.
 	applyTime := metav1.Now().Sub(startTime).Seconds()
 	if err != nil {
+		err = asApplyConflictError(err, reference)
 		logger.WithError(err).Warn("error applying resource")
 		metricResourcesApplied.WithLabelValues(applyFnMetricLabel, metricResultError).Inc()
 		metricTimeToApplySyncSetResource.WithLabelValues(applyFnMetricLabel, metricResultError).Observe(applyTime)
@@ -959,6 +1042,7 @@ func applyToTargetCluster(
 		logger.WithField("applyResult", applyResult).Debug("resource applied")
 		metricResourcesApplied.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Inc()
 		metricTimeToApplySyncSetResource.WithLabelValues(applyFnMetricLabel, metricResultSuccess).Observe(applyTime)
+		drift.record(desired, reference, logger)
 	}
 	return err
 }
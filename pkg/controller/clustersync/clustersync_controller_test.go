@@ -367,6 +367,53 @@ func TestReconcileClusterSync_ApplyResource(t *testing.T) {
 	}
 }
 
+func TestReconcileClusterSync_ServerSideApply(t *testing.T) {
+	cases := []struct {
+		name                 string
+		serverSideApply      *hivev1.SyncSetServerSideApply
+		expectedFieldManager string
+		expectedForce        bool
+	}{
+		{
+			name:                 "defaults",
+			expectedFieldManager: "hive",
+		},
+		{
+			name:                 "custom field manager",
+			serverSideApply:      &hivev1.SyncSetServerSideApply{FieldManager: "my-manager", Force: true},
+			expectedFieldManager: "my-manager",
+			expectedForce:        true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			scheme := newScheme()
+			resourceToApply := testConfigMap("dest-namespace", "dest-name")
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+				testsyncset.WithApplyBehavior(hivev1.ServerSideApplySyncSetApplyBehavior),
+				testsyncset.WithResources(resourceToApply),
+			)
+			syncSet.Spec.ServerSideApply = tc.serverSideApply
+			rt := newReconcileTest(t, mockCtrl, scheme,
+				cdBuilder(scheme).Build(),
+				clusterSyncBuilder(scheme).Build(),
+				teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+					teststatefulset.WithCurrentReplicas(3),
+					teststatefulset.WithReplicas(3),
+				),
+				syncSet)
+			rt.mockResourceHelper.EXPECT().
+				ServerSideApply(newApplyMatcher(resourceToApply), tc.expectedFieldManager, tc.expectedForce).
+				Return(resource.CreatedApplyResult, nil)
+			rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset")}
+			rt.run(t)
+		})
+	}
+}
+
 func TestGetAndCheckClustersyncStatefulSet(t *testing.T) {
 	scheme := newScheme()
 
@@ -1092,6 +1139,84 @@ func TestReconcileClusterSync_ErrorDecodingResource(t *testing.T) {
 	rt.run(t)
 }
 
+func TestReconcileClusterSync_ApplyResourceTemplates(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	scheme := newScheme()
+	templatedResource := testConfigMap("dest-namespace", "{{ .ClusterDeployment.Name }}-config")
+	templatedResource.Data = map[string]string{
+		"infraID": "{{ .ClusterMetadata.InfraID }}",
+		"region":  "{{ .Labels.region }}",
+	}
+	resourceToApply := testConfigMap("dest-namespace", testCDName+"-config")
+	resourceToApply.Data = map[string]string{
+		"infraID": "test-infra-id",
+		"region":  "us-east-1",
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithApplyMode(hivev1.SyncResourceApplyMode),
+		testsyncset.WithResources(templatedResource),
+		testsyncset.WithPatches(hivev1.SyncObjectPatch{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Namespace:  "{{ index .Labels \"region\" }}",
+			Name:       "dest-name",
+			PatchType:  "merge",
+			Patch:      `{"data": {"namespace": "{{ .ClusterDeployment.Namespace }}"}}`,
+		}),
+	)
+	syncSet.Spec.EnableResourceTemplates = true
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(
+			testcd.WithLabel("region", "us-east-1"),
+			testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "test-infra-id"}),
+		),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	rt.mockResourceHelper.EXPECT().Apply(newApplyMatcher(resourceToApply)).Return(resource.CreatedApplyResult, nil)
+	rt.mockResourceHelper.EXPECT().Patch(
+		types.NamespacedName{Namespace: "us-east-1", Name: "dest-name"},
+		"ConfigMap",
+		"v1",
+		[]byte(`{"data": {"namespace": "`+testNamespace+`"}}`),
+		"merge",
+	).Return(nil)
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withResourcesToDelete(testConfigMapRef("dest-namespace", testCDName+"-config")),
+	)}
+	rt.run(t)
+}
+
+func TestReconcileClusterSync_ErrorRenderingResourceTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	scheme := newScheme()
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments(testCDName),
+		testsyncset.WithGeneration(1),
+		testsyncset.WithResources(testConfigMap("dest-namespace", "{{ .Labels.region }}")),
+	)
+	syncSet.Spec.EnableResourceTemplates = true
+	rt := newReconcileTest(t, mockCtrl, scheme,
+		cdBuilder(scheme).Build(),
+		clusterSyncBuilder(scheme).Build(),
+		teststatefulset.FullBuilder("hive", stsName, scheme).Build(
+			teststatefulset.WithCurrentReplicas(3),
+			teststatefulset.WithReplicas(3),
+		),
+		syncSet)
+	rt.expectedFailedMessage = "SyncSet test-syncset is failing"
+	rt.expectedSyncSetStatuses = []hiveintv1alpha1.SyncStatus{buildSyncStatus("test-syncset",
+		withFailureResult(`failed to render resource 0: metadata.name: template: resource:1:10: executing "resource" at <.Labels.region>: map has no entry for key "region"`),
+		withNoFirstSuccessTime(),
+	)}
+	rt.run(t)
+}
+
 func TestReconcileClusterSync_ErrorApplyingSecret(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	scheme := newScheme()
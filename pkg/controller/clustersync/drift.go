@@ -0,0 +1,275 @@
+package clustersync
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+const (
+	// maxDriftEvents is the maximum number of drift events kept in the status of a ClusterSync.
+	maxDriftEvents = 20
+
+	// deletedDriftedHash is the DriftedHash recorded when drift is reported for a resource that was deleted.
+	deletedDriftedHash = "deleted"
+)
+
+var (
+	metricDrift = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_syncset_drift_total",
+		Help: "Counter incremented each time a resource synced by a syncset with drift detection enabled is found to have been changed in the cluster, labeled by the kind of the resource and whether the drift was corrected.",
+	},
+		[]string{"kind", "corrected"},
+	)
+)
+
+// driftDetector detects resources of a syncset that have been changed in the cluster since the syncset was last
+// applied. A nil driftDetector detects nothing.
+type driftDetector struct {
+	syncSetKind    string
+	syncSetName    string
+	correct        bool
+	resourceHelper resource.Helper
+
+	// oldHashes are the hashes recorded when the syncset was last applied. They are only compared when the same
+	// generation of the syncset is being re-applied.
+	oldHashes []hiveintv1alpha1.SyncResourceHash
+
+	// hashes are the hashes to record for this apply of the syncset.
+	hashes []hiveintv1alpha1.SyncResourceHash
+
+	// events are the drift events found during this apply of the syncset.
+	events []hiveintv1alpha1.SyncDriftEvent
+}
+
+// newDriftDetector returns a driftDetector for the syncset, or nil if drift detection is not enabled for it.
+// oldSyncStatus is the status from the last time the syncset was applied, or nil if it was never applied.
+func newDriftDetector(syncSet CommonSyncSet, syncSetType string, oldSyncStatus *hiveintv1alpha1.SyncStatus, resourceHelper resource.Helper) *driftDetector {
+	mode := syncSet.GetSpec().DriftDetection
+	if mode == "" {
+		return nil
+	}
+	d := &driftDetector{
+		syncSetKind:    syncSetType,
+		syncSetName:    syncSet.AsMetaObject().GetName(),
+		correct:        mode != hivev1.ReportSyncSetDriftDetection,
+		resourceHelper: resourceHelper,
+	}
+	if oldSyncStatus != nil && oldSyncStatus.ObservedGeneration == syncSet.AsMetaObject().GetGeneration() {
+		d.oldHashes = oldSyncStatus.ResourceHashes
+	}
+	return d
+}
+
+// check compares the resource in the cluster with the hash recorded when it was last applied. It returns false if
+// the resource has drifted and must be left alone.
+func (d *driftDetector) check(desired map[string]interface{}, ref hiveintv1alpha1.SyncResourceReference, logger log.FieldLogger) bool {
+	if d == nil {
+		return true
+	}
+	var oldHash *hiveintv1alpha1.SyncResourceHash
+	for i := range d.oldHashes {
+		if d.oldHashes[i].SyncResourceReference == ref {
+			oldHash = &d.oldHashes[i]
+			break
+		}
+	}
+	if oldHash == nil {
+		return true
+	}
+
+	fields := driftFields(desired)
+	live, err := d.resourceHelper.Get(ref.APIVersion, ref.Kind, ref.Namespace, ref.Name)
+	var liveHash string
+	var changed []string
+	switch {
+	case apierrors.IsNotFound(err):
+		// A deleted resource has drifted, but it has no fields to list as changed.
+		liveHash = deletedDriftedHash
+	case err != nil:
+		logger.WithError(err).Warn("could not get resource to check for drift")
+		return true
+	default:
+		liveFields := projectFields(fields, live.Object)
+		if liveHash, err = controllerutils.GetChecksumOfObject(liveFields); err != nil {
+			logger.WithError(err).Warn("could not hash resource to check for drift")
+			return true
+		}
+		if liveHash == oldHash.Hash {
+			return true
+		}
+		changed = changedFields("", fields, liveFields)
+	}
+
+	if !d.correct && liveHash == oldHash.DriftedHash {
+		logger.Debug("leaving alone resource for which drift has already been reported")
+		d.hashes = append(d.hashes, *oldHash)
+		return false
+	}
+	logger.WithField("fieldsChanged", changed).Info("resource has drifted since it was last applied")
+	metricDrift.WithLabelValues(ref.Kind, strconv.FormatBool(d.correct)).Inc()
+	d.events = append(d.events, hiveintv1alpha1.SyncDriftEvent{
+		SyncSetKind:   d.syncSetKind,
+		SyncSetName:   d.syncSetName,
+		Resource:      ref,
+		Time:          metav1.Now(),
+		FieldsChanged: changed,
+		Corrected:     d.correct,
+	})
+	if d.correct {
+		return true
+	}
+	d.hashes = append(d.hashes, hiveintv1alpha1.SyncResourceHash{
+		SyncResourceReference: ref,
+		Hash:                  oldHash.Hash,
+		DriftedHash:           liveHash,
+	})
+	return false
+}
+
+// record records the hash of the resource in the cluster after it has been applied.
+func (d *driftDetector) record(desired map[string]interface{}, ref hiveintv1alpha1.SyncResourceReference, logger log.FieldLogger) {
+	if d == nil {
+		return
+	}
+	live, err := d.resourceHelper.Get(ref.APIVersion, ref.Kind, ref.Namespace, ref.Name)
+	if err != nil {
+		logger.WithError(err).Warn("could not get applied resource to record for drift detection")
+		return
+	}
+	hash, err := controllerutils.GetChecksumOfObject(projectFields(driftFields(desired), live.Object))
+	if err != nil {
+		logger.WithError(err).Warn("could not hash applied resource to record for drift detection")
+		return
+	}
+	d.hashes = append(d.hashes, hiveintv1alpha1.SyncResourceHash{SyncResourceReference: ref, Hash: hash})
+}
+
+// resourceHashes returns the hashes to record in the sync status.
+func (d *driftDetector) resourceHashes() []hiveintv1alpha1.SyncResourceHash {
+	if d == nil {
+		return nil
+	}
+	sort.Slice(d.hashes, func(i, j int) bool {
+		return orderResources(d.hashes[i].SyncResourceReference, d.hashes[j].SyncResourceReference)
+	})
+	return d.hashes
+}
+
+// addDriftEvents adds events to the drift events of the ClusterSync, keeping the newest maxDriftEvents.
+func addDriftEvents(clusterSync *hiveintv1alpha1.ClusterSync, events []hiveintv1alpha1.SyncDriftEvent) {
+	if len(events) == 0 {
+		return
+	}
+	all := append(append([]hiveintv1alpha1.SyncDriftEvent{}, events...), clusterSync.Status.DriftEvents...)
+	if len(all) > maxDriftEvents {
+		all = all[:maxDriftEvents]
+	}
+	clusterSync.Status.DriftEvents = all
+}
+
+// driftFields returns the fields of the desired resource that are checked for drift. These are all of the fields
+// set by the syncset except for the status and the metadata other than labels and annotations.
+func driftFields(desired map[string]interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, len(desired))
+	for k, v := range desired {
+		switch k {
+		case "status":
+		case "metadata":
+			metadata, _ := v.(map[string]interface{})
+			kept := map[string]interface{}{}
+			for _, key := range []string{"labels", "annotations"} {
+				if value, ok := metadata[key]; ok {
+					kept[key] = value
+				}
+			}
+			fields[k] = kept
+		default:
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// projectFields returns the values in live of the fields that are set in desired.
+func projectFields(desired, live interface{}) interface{} {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		projected := make(map[string]interface{}, len(d))
+		for k, v := range d {
+			if lv, ok := l[k]; ok {
+				projected[k] = projectFields(v, lv)
+			}
+		}
+		return projected
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return live
+		}
+		projected := make([]interface{}, len(d))
+		for i := range d {
+			projected[i] = projectFields(d[i], l[i])
+		}
+		return projected
+	default:
+		return live
+	}
+}
+
+// changedFields returns the paths of the fields in desired whose values differ in live. Only hashes are recorded when
+// resources are applied, so these are the fields that differ from the syncset, not necessarily those changed since.
+func changedFields(path string, desired, live interface{}) []string {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return []string{path}
+		}
+		var changed []string
+		for k, v := range d {
+			fieldPath := k
+			if path != "" {
+				fieldPath = path + "." + k
+			}
+			lv, ok := l[k]
+			if !ok {
+				changed = append(changed, fieldPath)
+				continue
+			}
+			changed = append(changed, changedFields(fieldPath, v, lv)...)
+		}
+		sort.Strings(changed)
+		return changed
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(d) {
+			return []string{path}
+		}
+		var changed []string
+		for i := range d {
+			changed = append(changed, changedFields(fmt.Sprintf("%s[%d]", path, i), d[i], l[i])...)
+		}
+		return changed
+	default:
+		if reflect.DeepEqual(desired, live) {
+			return nil
+		}
+		return []string{path}
+	}
+}
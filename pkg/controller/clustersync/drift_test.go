@@ -0,0 +1,332 @@
+package clustersync
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/resource"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+func TestChangedFields(t *testing.T) {
+	desired := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": map[string]interface{}{"a": "1", "b": "2"},
+		},
+		"data": map[string]interface{}{"x": "1", "y": "2"},
+		"list": []interface{}{"p", "q"},
+	}
+	cases := []struct {
+		name     string
+		live     map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "no changes",
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels":          map[string]interface{}{"a": "1", "b": "2", "extra": "ignored"},
+					"resourceVersion": "12",
+				},
+				"data": map[string]interface{}{"x": "1", "y": "2"},
+				"list": []interface{}{"p", "q"},
+			},
+		},
+		{
+			name: "changes",
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"a": "1"},
+				},
+				"data": map[string]interface{}{"x": "1", "y": "3"},
+				"list": []interface{}{"p", "r"},
+			},
+			expected: []string{"data.y", "list[1]", "metadata.labels.b"},
+		},
+		{
+			name: "list length changed",
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{"a": "1", "b": "2"},
+				},
+				"data": map[string]interface{}{"x": "1", "y": "2"},
+				"list": []interface{}{"p"},
+			},
+			expected: []string{"list"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := driftFields(desired)
+			assert.Equal(t, tc.expected, changedFields("", fields, projectFields(fields, tc.live)), "unexpected changed fields")
+		})
+	}
+}
+
+func TestApplySyncSetsDriftDetection(t *testing.T) {
+	const (
+		namespace = "dest-namespace"
+		name      = "dest-name"
+	)
+	ref := testConfigMapRef(namespace, name)
+	configMap := testConfigMap(namespace, name)
+	configMap.Data = map[string]string{"key": "value"}
+	configMapRaw, err := json.Marshal(configMap)
+	require.NoError(t, err, "could not encode config map")
+	liveConfigMap := func(value string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetResourceVersion("1234")
+		u.SetLabels(map[string]string{constants.HiveManagedLabel: "true"})
+		u.Object["data"] = map[string]interface{}{"key": value}
+		return u
+	}
+	hashOf := func(value string) string {
+		live := liveConfigMap(value)
+		desired := liveConfigMap("value").Object
+		hash, err := controllerutils.GetChecksumOfObject(projectFields(driftFields(desired), live.Object))
+		require.NoError(t, err, "could not hash config map")
+		return hash
+	}
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name)
+
+	cases := []struct {
+		name           string
+		driftDetection hivev1.SyncSetDriftDetection
+		oldGeneration  int64
+		oldHash        *hiveintv1alpha1.SyncResourceHash
+		liveValue      string
+		liveErr        error
+		expectCheck    bool
+		expectApply    bool
+		expectedHashes []hiveintv1alpha1.SyncResourceHash
+		expectedEvents []hiveintv1alpha1.SyncDriftEvent
+	}{
+		{
+			name:        "drift detection disabled",
+			liveValue:   "changed",
+			expectApply: true,
+		},
+		{
+			name:           "first apply",
+			driftDetection: hivev1.CorrectSyncSetDriftDetection,
+			liveValue:      "value",
+			expectApply:    true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{SyncResourceReference: ref, Hash: hashOf("value")}},
+		},
+		{
+			name:           "no drift",
+			driftDetection: hivev1.CorrectSyncSetDriftDetection,
+			oldGeneration:  1,
+			oldHash:        &hiveintv1alpha1.SyncResourceHash{SyncResourceReference: ref, Hash: hashOf("value")},
+			liveValue:      "value",
+			expectCheck:    true,
+			expectApply:    true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{SyncResourceReference: ref, Hash: hashOf("value")}},
+		},
+		{
+			name:           "drift corrected",
+			driftDetection: hivev1.CorrectSyncSetDriftDetection,
+			oldGeneration:  1,
+			oldHash:        &hiveintv1alpha1.SyncResourceHash{SyncResourceReference: ref, Hash: hashOf("value")},
+			liveValue:      "changed",
+			expectCheck:    true,
+			expectApply:    true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{SyncResourceReference: ref, Hash: hashOf("value")}},
+			expectedEvents: []hiveintv1alpha1.SyncDriftEvent{{
+				SyncSetKind:   "SyncSet",
+				SyncSetName:   "test-syncset",
+				Resource:      ref,
+				FieldsChanged: []string{"data.key"},
+				Corrected:     true,
+			}},
+		},
+		{
+			name:           "deleted resource corrected",
+			driftDetection: hivev1.CorrectSyncSetDriftDetection,
+			oldGeneration:  1,
+			oldHash:        &hiveintv1alpha1.SyncResourceHash{SyncResourceReference: ref, Hash: hashOf("value")},
+			liveErr:        notFound,
+			expectCheck:    true,
+			expectApply:    true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{SyncResourceReference: ref, Hash: hashOf("value")}},
+			expectedEvents: []hiveintv1alpha1.SyncDriftEvent{{
+				SyncSetKind: "SyncSet",
+				SyncSetName: "test-syncset",
+				Resource:    ref,
+				Corrected:   true,
+			}},
+		},
+		{
+			name:           "drift reported",
+			driftDetection: hivev1.ReportSyncSetDriftDetection,
+			oldGeneration:  1,
+			oldHash:        &hiveintv1alpha1.SyncResourceHash{SyncResourceReference: ref, Hash: hashOf("value")},
+			liveValue:      "changed",
+			expectCheck:    true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{
+				SyncResourceReference: ref,
+				Hash:                  hashOf("value"),
+				DriftedHash:           hashOf("changed"),
+			}},
+			expectedEvents: []hiveintv1alpha1.SyncDriftEvent{{
+				SyncSetKind:   "SyncSet",
+				SyncSetName:   "test-syncset",
+				Resource:      ref,
+				FieldsChanged: []string{"data.key"},
+			}},
+		},
+		{
+			name:           "drift already reported",
+			driftDetection: hivev1.ReportSyncSetDriftDetection,
+			oldGeneration:  1,
+			oldHash: &hiveintv1alpha1.SyncResourceHash{
+				SyncResourceReference: ref,
+				Hash:                  hashOf("value"),
+				DriftedHash:           hashOf("changed"),
+			},
+			liveValue:   "changed",
+			expectCheck: true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{
+				SyncResourceReference: ref,
+				Hash:                  hashOf("value"),
+				DriftedHash:           hashOf("changed"),
+			}},
+		},
+		{
+			name:           "deleted resource reported",
+			driftDetection: hivev1.ReportSyncSetDriftDetection,
+			oldGeneration:  1,
+			oldHash:        &hiveintv1alpha1.SyncResourceHash{SyncResourceReference: ref, Hash: hashOf("value")},
+			liveErr:        notFound,
+			expectCheck:    true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{
+				SyncResourceReference: ref,
+				Hash:                  hashOf("value"),
+				DriftedHash:           deletedDriftedHash,
+			}},
+			expectedEvents: []hiveintv1alpha1.SyncDriftEvent{{
+				SyncSetKind: "SyncSet",
+				SyncSetName: "test-syncset",
+				Resource:    ref,
+			}},
+		},
+		{
+			name:           "deleted resource already reported",
+			driftDetection: hivev1.ReportSyncSetDriftDetection,
+			oldGeneration:  1,
+			oldHash: &hiveintv1alpha1.SyncResourceHash{
+				SyncResourceReference: ref,
+				Hash:                  hashOf("value"),
+				DriftedHash:           deletedDriftedHash,
+			},
+			liveErr:     notFound,
+			expectCheck: true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{
+				SyncResourceReference: ref,
+				Hash:                  hashOf("value"),
+				DriftedHash:           deletedDriftedHash,
+			}},
+		},
+		{
+			name:           "new generation is not checked",
+			driftDetection: hivev1.ReportSyncSetDriftDetection,
+			oldGeneration:  0,
+			oldHash:        &hiveintv1alpha1.SyncResourceHash{SyncResourceReference: ref, Hash: hashOf("value")},
+			liveValue:      "changed",
+			expectApply:    true,
+			expectedHashes: []hiveintv1alpha1.SyncResourceHash{{SyncResourceReference: ref, Hash: hashOf("value")}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			scheme := newScheme()
+			syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+				testsyncset.ForClusterDeployments(testCDName),
+				testsyncset.WithGeneration(1),
+			)
+			// The resources are not round-tripped through a client, so they need to be encoded here.
+			syncSet.Spec.Resources = []runtime.RawExtension{{Raw: configMapRaw}}
+			syncSet.Spec.DriftDetection = tc.driftDetection
+			var oldStatuses []hiveintv1alpha1.SyncStatus
+			if tc.oldHash != nil {
+				oldStatuses = []hiveintv1alpha1.SyncStatus{{
+					Name:               "test-syncset",
+					ObservedGeneration: tc.oldGeneration,
+					Result:             hiveintv1alpha1.SuccessSyncSetResult,
+					LastTransitionTime: timeInThePast,
+					ResourceHashes:     []hiveintv1alpha1.SyncResourceHash{*tc.oldHash},
+				}}
+			}
+			rt := newReconcileTest(t, mockCtrl, scheme)
+			var calls []*gomock.Call
+			if tc.expectCheck {
+				live := liveConfigMap(tc.liveValue)
+				if tc.liveErr != nil {
+					live = nil
+				}
+				calls = append(calls, rt.mockResourceHelper.EXPECT().Get("v1", "ConfigMap", namespace, name).Return(live, tc.liveErr))
+			}
+			if tc.expectApply {
+				calls = append(calls, rt.mockResourceHelper.EXPECT().Apply(gomock.Any()).Return(resource.CreatedApplyResult, nil))
+				if tc.driftDetection != "" {
+					// The resource is read back after it has been applied to record its hash.
+					calls = append(calls, rt.mockResourceHelper.EXPECT().Get("v1", "ConfigMap", namespace, name).Return(liveConfigMap("value"), nil))
+				}
+			}
+			gomock.InOrder(calls...)
+
+			statuses, _, events := rt.r.applySyncSets(
+				cdBuilder(scheme).Build(),
+				"SyncSet",
+				[]CommonSyncSet{(*SyncSetAsCommon)(syncSet)},
+				oldStatuses,
+				true,
+				false,
+				rt.mockResourceHelper,
+				rt.logger,
+			)
+			require.Len(t, statuses, 1, "unexpected number of sync statuses")
+			assert.Equal(t, hiveintv1alpha1.SuccessSyncSetResult, statuses[0].Result, "unexpected result")
+			assert.Equal(t, tc.expectedHashes, statuses[0].ResourceHashes, "unexpected resource hashes")
+			for i := range events {
+				assert.False(t, events[i].Time.IsZero(), "expected drift event time to be set")
+				events[i].Time = metav1.Time{}
+			}
+			assert.Equal(t, tc.expectedEvents, events, "unexpected drift events")
+		})
+	}
+}
+
+func TestAddDriftEvents(t *testing.T) {
+	event := func(name string) hiveintv1alpha1.SyncDriftEvent {
+		return hiveintv1alpha1.SyncDriftEvent{SyncSetName: name}
+	}
+	clusterSync := &hiveintv1alpha1.ClusterSync{}
+	for i := 0; i < maxDriftEvents; i++ {
+		clusterSync.Status.DriftEvents = append(clusterSync.Status.DriftEvents, event("old"))
+	}
+	addDriftEvents(clusterSync, []hiveintv1alpha1.SyncDriftEvent{event("new1"), event("new2")})
+	require.Len(t, clusterSync.Status.DriftEvents, maxDriftEvents, "unexpected number of drift events")
+	assert.Equal(t, event("new1"), clusterSync.Status.DriftEvents[0], "expected newest events first")
+	assert.Equal(t, event("new2"), clusterSync.Status.DriftEvents[1], "expected newest events first")
+	assert.Equal(t, event("old"), clusterSync.Status.DriftEvents[2], "expected older events after newer events")
+}
@@ -0,0 +1,133 @@
+package clustersync
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/remoteclient"
+)
+
+// rolloutBucketCount is the number of buckets that clusters are spread across for SelectorSyncSet rollouts.
+const rolloutBucketCount = 10000
+
+// rolloutBucket returns the bucket of the cluster for SelectorSyncSet rollouts. The bucket depends only on the UID
+// of the ClusterDeployment, so every clustersync replica can tell whether a rollout has been released to a cluster
+// from the status of the SelectorSyncSet alone.
+func rolloutBucket(cd *hivev1.ClusterDeployment) int32 {
+	h := fnv.New32a()
+	h.Write([]byte(cd.UID))
+	return int32(h.Sum32() % rolloutBucketCount)
+}
+
+// isHeldByRollout returns true if the syncset is a SelectorSyncSet with a rollout strategy and the rollout of its
+// current generation has not been released to the cluster.
+func isHeldByRollout(syncSet CommonSyncSet, cd *hivev1.ClusterDeployment) bool {
+	sss, ok := syncSet.(*SelectorSyncSetAsCommon)
+	if !ok || sss.Spec.RolloutStrategy == nil {
+		return false
+	}
+	rollout := sss.Status.Rollout
+	if rollout == nil || rollout.Generation != sss.Generation {
+		// The rollout of this generation has not started yet
+		return true
+	}
+	return rolloutBucket(cd) >= rollout.ReleasedBuckets
+}
+
+// updateRollout advances the rollout of the current generation of a SelectorSyncSet with a rollout strategy. Only
+// clusters to which an earlier generation was applied take part in the rollout. It returns how long to wait before
+// the next batch may be released, or zero if the rollout is waiting for something other than time.
+func updateRollout(sss *hivev1.SelectorSyncSet, clusters []syncSetClusterState, now time.Time, logger log.FieldLogger) time.Duration {
+	strategy := sss.Spec.RolloutStrategy
+	rollout := sss.Status.Rollout
+	if rollout == nil || rollout.Generation != sss.Generation {
+		logger.WithField("generation", sss.Generation).Info("starting rollout")
+		rollout = &hivev1.SelectorSyncSetRolloutStatus{
+			Generation: sss.Generation,
+			State:      hivev1.SelectorSyncSetRolloutProgressing,
+		}
+		sss.Status.Rollout = rollout
+	}
+
+	var synced, failed, unsynced int
+	var held []int32
+	for _, c := range clusters {
+		switch {
+		case c.syncStatus == nil:
+			// No earlier generation to protect, so the cluster is not part of the rollout
+		case c.syncStatus.ObservedGeneration == sss.Generation:
+			synced++
+			if c.syncStatus.Result == hiveintv1alpha1.FailureSyncSetResult {
+				failed++
+			}
+		case rolloutBucket(c.cd) < rollout.ReleasedBuckets:
+			// Released, but not synced yet. Clusters that are not being synced must not stall the rollout.
+			if unreachable, _ := remoteclient.Unreachable(c.cd); !unreachable && !controllerutils.IsClusterPausedOrRelocating(c.cd, logger) {
+				unsynced++
+			}
+		default:
+			held = append(held, rolloutBucket(c.cd))
+		}
+	}
+	rollout.HeldClusters = int32(len(held))
+
+	switch {
+	case rollout.State != hivev1.SelectorSyncSetRolloutProgressing:
+		return 0
+	case strategy.MaxFailurePercentage != nil && failed*100 > int(*strategy.MaxFailurePercentage)*synced:
+		rollout.State = hivev1.SelectorSyncSetRolloutHalted
+		rollout.Message = fmt.Sprintf("%d of %d synced clusters failed to apply generation %d", failed, synced, sss.Generation)
+		logger.WithField("generation", sss.Generation).Warn("halting rollout: " + rollout.Message)
+		return 0
+	case len(held) == 0:
+		rollout.State = hivev1.SelectorSyncSetRolloutComplete
+		rollout.ReleasedBuckets = rolloutBucketCount
+		rollout.Message = fmt.Sprintf("Generation %d has been released to all clusters", sss.Generation)
+		logger.WithField("generation", sss.Generation).Info("rollout complete")
+		return 0
+	case unsynced > 0:
+		rollout.Message = fmt.Sprintf("Waiting for %d clusters to sync", unsynced)
+		return 0
+	}
+	if rollout.LastBatchTime != nil && strategy.PauseBetweenBatches != nil {
+		if next := rollout.LastBatchTime.Add(strategy.PauseBetweenBatches.Duration); now.Before(next) {
+			rollout.Message = fmt.Sprintf("Waiting until %s to release the next batch", next.UTC().Format(time.RFC3339))
+			return next.Sub(now)
+		}
+	}
+
+	batchSize, err := intstr.GetScaledValueFromIntOrPercent(&strategy.BatchSize, len(clusters), true)
+	if err != nil {
+		logger.WithError(err).Error("invalid rollout batch size, releasing one cluster at a time")
+		batchSize = 1
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if batchSize > len(held) {
+		batchSize = len(held)
+	}
+	sort.Slice(held, func(i, j int) bool { return held[i] < held[j] })
+	rollout.ReleasedBuckets = held[batchSize-1] + 1
+	rollout.Batches++
+	lastBatchTime := metav1.NewTime(now)
+	rollout.LastBatchTime = &lastBatchTime
+	released := sort.Search(len(held), func(i int) bool { return held[i] >= rollout.ReleasedBuckets })
+	rollout.HeldClusters = int32(len(held) - released)
+	rollout.Message = fmt.Sprintf("Released batch %d to %d clusters", rollout.Batches, released)
+	logger.WithFields(log.Fields{
+		"generation": sss.Generation,
+		"batch":      rollout.Batches,
+		"clusters":   released,
+	}).Info("released rollout batch")
+	return 0
+}
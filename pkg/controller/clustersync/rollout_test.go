@@ -0,0 +1,375 @@
+package clustersync
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+)
+
+// rolloutTestClusters returns installed, reachable ClusterDeployments ordered by rollout bucket.
+func rolloutTestClusters(n int) []*hivev1.ClusterDeployment {
+	scheme := newScheme()
+	cds := make([]*hivev1.ClusterDeployment, n)
+	for i := range cds {
+		cds[i] = testcd.FullBuilder(testNamespace, fmt.Sprintf("cd%d", i), scheme).
+			GenericOptions(testgeneric.WithUID(fmt.Sprintf("uid-%d", i))).
+			Build(
+				testcd.Installed(),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionFalse,
+				}),
+			)
+	}
+	sort.Slice(cds, func(i, j int) bool { return rolloutBucket(cds[i]) < rolloutBucket(cds[j]) })
+	return cds
+}
+
+func TestUpdateRollout(t *testing.T) {
+	now := time.Now()
+	cds := rolloutTestClusters(10)
+	// releasedTo returns the ReleasedBuckets value for a rollout released to the first n clusters
+	releasedTo := func(n int) int32 {
+		return rolloutBucket(cds[n-1]) + 1
+	}
+	state := func(i int, generation int64, result hiveintv1alpha1.SyncSetResult) syncSetClusterState {
+		return syncSetClusterState{
+			cd: cds[i],
+			syncStatus: &hiveintv1alpha1.SyncStatus{
+				Name:               "test-syncset",
+				ObservedGeneration: generation,
+				Result:             result,
+			},
+		}
+	}
+	// clusters returns the state of all clusters, where the first synced have been synced with generation 2 and
+	// the first failed of those failed to apply it. The rest have generation 1 applied.
+	clusters := func(synced, failed int) []syncSetClusterState {
+		var states []syncSetClusterState
+		for i := range cds {
+			switch {
+			case i < failed:
+				states = append(states, state(i, 2, hiveintv1alpha1.FailureSyncSetResult))
+			case i < synced:
+				states = append(states, state(i, 2, hiveintv1alpha1.SuccessSyncSetResult))
+			default:
+				states = append(states, state(i, 1, hiveintv1alpha1.SuccessSyncSetResult))
+			}
+		}
+		return states
+	}
+	lastBatchTime := func(d time.Duration) *metav1.Time {
+		t := metav1.NewTime(now.Add(-d))
+		return &t
+	}
+
+	cases := []struct {
+		name                 string
+		batchSize            intstr.IntOrString
+		pause                time.Duration
+		maxFailurePercentage *int32
+		rollout              *hivev1.SelectorSyncSetRolloutStatus
+		clusters             []syncSetClusterState
+		expectedRollout      *hivev1.SelectorSyncSetRolloutStatus
+		expectedRequeue      time.Duration
+	}{
+		{
+			name:      "no earlier generation applied",
+			batchSize: intstr.FromInt(1),
+			clusters:  []syncSetClusterState{{cd: cds[0]}, {cd: cds[1]}},
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutComplete,
+				ReleasedBuckets: rolloutBucketCount,
+				Message:         "Generation 2 has been released to all clusters",
+			},
+		},
+		{
+			name:      "first batch",
+			batchSize: intstr.FromInt(3),
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      1,
+				State:           hivev1.SelectorSyncSetRolloutComplete,
+				ReleasedBuckets: rolloutBucketCount,
+			},
+			clusters: clusters(0, 0),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(3),
+				HeldClusters:    7,
+				LastBatchTime:   lastBatchTime(0),
+				Message:         "Released batch 1 to 3 clusters",
+			},
+		},
+		{
+			name:      "percentage batch",
+			batchSize: intstr.FromString("50%"),
+			clusters:  clusters(0, 0),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(5),
+				HeldClusters:    5,
+				LastBatchTime:   lastBatchTime(0),
+				Message:         "Released batch 1 to 5 clusters",
+			},
+		},
+		{
+			name:      "wait for batch to sync",
+			batchSize: intstr.FromInt(3),
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(3),
+				LastBatchTime:   lastBatchTime(time.Minute),
+			},
+			clusters: clusters(2, 0),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(3),
+				HeldClusters:    7,
+				LastBatchTime:   lastBatchTime(time.Minute),
+				Message:         "Waiting for 1 clusters to sync",
+			},
+		},
+		{
+			name:      "unreachable clusters do not stall the rollout",
+			batchSize: intstr.FromInt(3),
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(3),
+				LastBatchTime:   lastBatchTime(time.Minute),
+			},
+			clusters: func() []syncSetClusterState {
+				states := clusters(2, 0)
+				states[2].cd = testcd.Build(testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.UnreachableCondition,
+					Status: corev1.ConditionTrue,
+				}))
+				states[2].cd.UID = cds[2].UID
+				return states
+			}(),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         2,
+				ReleasedBuckets: releasedTo(6),
+				HeldClusters:    4,
+				LastBatchTime:   lastBatchTime(0),
+				Message:         "Released batch 2 to 3 clusters",
+			},
+		},
+		{
+			name:      "pause between batches",
+			batchSize: intstr.FromInt(3),
+			pause:     time.Hour,
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(3),
+				LastBatchTime:   lastBatchTime(time.Minute),
+			},
+			clusters: clusters(3, 0),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(3),
+				HeldClusters:    7,
+				LastBatchTime:   lastBatchTime(time.Minute),
+				Message:         fmt.Sprintf("Waiting until %s to release the next batch", now.Add(59*time.Minute).UTC().Format(time.RFC3339)),
+			},
+			expectedRequeue: 59 * time.Minute,
+		},
+		{
+			name:      "pause elapsed",
+			batchSize: intstr.FromInt(3),
+			pause:     time.Hour,
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(3),
+				LastBatchTime:   lastBatchTime(2 * time.Hour),
+			},
+			clusters: clusters(3, 0),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         2,
+				ReleasedBuckets: releasedTo(6),
+				HeldClusters:    4,
+				LastBatchTime:   lastBatchTime(0),
+				Message:         "Released batch 2 to 3 clusters",
+			},
+		},
+		{
+			name:                 "failures within threshold",
+			batchSize:            intstr.FromInt(5),
+			maxFailurePercentage: pointer.Int32(20),
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(5),
+				LastBatchTime:   lastBatchTime(time.Minute),
+			},
+			clusters: clusters(5, 1),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         2,
+				ReleasedBuckets: releasedTo(10),
+				LastBatchTime:   lastBatchTime(0),
+				Message:         "Released batch 2 to 5 clusters",
+			},
+		},
+		{
+			name:                 "failures exceed threshold",
+			batchSize:            intstr.FromInt(5),
+			maxFailurePercentage: pointer.Int32(20),
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(5),
+				LastBatchTime:   lastBatchTime(time.Minute),
+			},
+			clusters: clusters(5, 2),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutHalted,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(5),
+				HeldClusters:    5,
+				LastBatchTime:   lastBatchTime(time.Minute),
+				Message:         "2 of 5 synced clusters failed to apply generation 2",
+			},
+		},
+		{
+			name:      "halted rollout stays halted",
+			batchSize: intstr.FromInt(5),
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutHalted,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(5),
+				LastBatchTime:   lastBatchTime(time.Minute),
+				Message:         "halted",
+			},
+			clusters: clusters(5, 0),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutHalted,
+				Batches:         1,
+				ReleasedBuckets: releasedTo(5),
+				HeldClusters:    5,
+				LastBatchTime:   lastBatchTime(time.Minute),
+				Message:         "halted",
+			},
+		},
+		{
+			name:      "complete",
+			batchSize: intstr.FromInt(5),
+			rollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutProgressing,
+				Batches:         2,
+				ReleasedBuckets: releasedTo(10),
+				LastBatchTime:   lastBatchTime(time.Minute),
+			},
+			clusters: clusters(10, 0),
+			expectedRollout: &hivev1.SelectorSyncSetRolloutStatus{
+				Generation:      2,
+				State:           hivev1.SelectorSyncSetRolloutComplete,
+				Batches:         2,
+				ReleasedBuckets: rolloutBucketCount,
+				LastBatchTime:   lastBatchTime(time.Minute),
+				Message:         "Generation 2 has been released to all clusters",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sss := testselectorsyncset.FullBuilder("test-syncset", newScheme()).Build(
+				testselectorsyncset.WithGeneration(2),
+			)
+			sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{
+				BatchSize:            tc.batchSize,
+				MaxFailurePercentage: tc.maxFailurePercentage,
+			}
+			if tc.pause != 0 {
+				sss.Spec.RolloutStrategy.PauseBetweenBatches = &metav1.Duration{Duration: tc.pause}
+			}
+			sss.Status.Rollout = tc.rollout
+			requeue := updateRollout(sss, tc.clusters, now, log.New())
+			assert.Equal(t, tc.expectedRequeue, requeue, "unexpected requeue")
+			assert.Equal(t, tc.expectedRollout, sss.Status.Rollout, "unexpected rollout status")
+		})
+	}
+}
+
+func TestApplySyncSetsHeldByRollout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	scheme := newScheme()
+	cd := cdBuilder(scheme).Build()
+	sss := testselectorsyncset.FullBuilder("test-syncset", scheme).Build(
+		testselectorsyncset.WithGeneration(2),
+		testselectorsyncset.WithResources(testConfigMap("dest-namespace", "dest-name")),
+	)
+	sss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{BatchSize: intstr.FromInt(1)}
+	sss.Status.Rollout = &hivev1.SelectorSyncSetRolloutStatus{
+		Generation:      2,
+		State:           hivev1.SelectorSyncSetRolloutProgressing,
+		ReleasedBuckets: rolloutBucket(cd),
+	}
+	oldStatus := hiveintv1alpha1.SyncStatus{
+		Name:               "test-syncset",
+		ObservedGeneration: 1,
+		Result:             hiveintv1alpha1.SuccessSyncSetResult,
+		LastTransitionTime: timeInThePast,
+	}
+	rt := newReconcileTest(t, mockCtrl, scheme)
+	// No calls to the resource helper are expected while the cluster is held
+	statuses, requeue, _ := rt.r.applySyncSets(
+		cd,
+		"SelectorSyncSet",
+		[]CommonSyncSet{(*SelectorSyncSetAsCommon)(sss)},
+		[]hiveintv1alpha1.SyncStatus{oldStatus},
+		true,
+		false,
+		rt.mockResourceHelper,
+		rt.logger,
+	)
+	assert.False(t, requeue, "unexpected requeue")
+	assert.Equal(t, []hiveintv1alpha1.SyncStatus{oldStatus}, statuses, "unexpected sync statuses")
+	assert.True(t, isHeldByRollout((*SelectorSyncSetAsCommon)(sss), cd), "expected cluster to be held")
+
+	sss.Status.Rollout.ReleasedBuckets = rolloutBucket(cd) + 1
+	assert.False(t, isHeldByRollout((*SelectorSyncSetAsCommon)(sss), cd), "expected cluster to be released")
+}
@@ -0,0 +1,91 @@
+package clustersync
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	"github.com/openshift/hive/pkg/resource"
+)
+
+// serverSideApplyFn returns the function with which to apply the resources of a syncset with the ServerSideApply
+// apply behavior.
+func serverSideApplyFn(syncSet CommonSyncSet, resourceHelper resource.Helper) func(obj []byte) (resource.ApplyResult, error) {
+	fieldManager := hivev1.DefaultSyncSetFieldManager
+	force := false
+	if ssa := syncSet.GetSpec().ServerSideApply; ssa != nil {
+		if ssa.FieldManager != "" {
+			fieldManager = ssa.FieldManager
+		}
+		force = ssa.Force
+	}
+	return func(obj []byte) (resource.ApplyResult, error) {
+		return resourceHelper.ServerSideApply(obj, fieldManager, force)
+	}
+}
+
+// applyConflictError is a server-side apply conflict for a resource in a syncset.
+type applyConflictError struct {
+	reference hiveintv1alpha1.SyncResourceReference
+	err       *apierrors.StatusError
+}
+
+func (e *applyConflictError) Error() string {
+	return e.err.Error()
+}
+
+func (e *applyConflictError) Unwrap() error {
+	return e.err
+}
+
+// asApplyConflictError returns err as an applyConflictError for the resource if it is a server-side apply conflict,
+// and err otherwise.
+func asApplyConflictError(err error, reference hiveintv1alpha1.SyncResourceReference) error {
+	var statusErr *apierrors.StatusError
+	if !apierrors.IsConflict(err) || !errors.As(err, &statusErr) || statusErr.ErrStatus.Details == nil {
+		return err
+	}
+	return &applyConflictError{reference: reference, err: statusErr}
+}
+
+// applyConflictConditions returns the ApplyConflict condition for the sync status if err is a server-side apply
+// conflict, keeping the transition time of the condition in oldSyncStatus if the conflicts have not changed.
+func applyConflictConditions(err error, oldSyncStatus hiveintv1alpha1.SyncStatus) []hivev1.SyncCondition {
+	var conflictErr *applyConflictError
+	if !errors.As(err, &conflictErr) {
+		return nil
+	}
+	ref := conflictErr.reference
+	name := ref.Name
+	if ref.Namespace != "" {
+		name = ref.Namespace + "/" + ref.Name
+	}
+	conflicts := []string{fmt.Sprintf("Conflicts applying %s %s:", ref.Kind, name)}
+	for _, cause := range conflictErr.err.ErrStatus.Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+	}
+	if len(conflicts) == 1 {
+		return nil
+	}
+	cond := hivev1.SyncCondition{
+		Type:               hivev1.ApplyConflictSyncCondition,
+		Status:             corev1.ConditionTrue,
+		Reason:             "FieldManagerConflict",
+		Message:            strings.Join(conflicts, "\n"),
+		LastTransitionTime: metav1.Now(),
+	}
+	for _, old := range oldSyncStatus.Conditions {
+		if old.Type == cond.Type && old.Message == cond.Message {
+			cond.LastTransitionTime = old.LastTransitionTime
+		}
+	}
+	return []hivev1.SyncCondition{cond}
+}
@@ -0,0 +1,90 @@
+package clustersync
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+)
+
+func TestApplyConflictConditions(t *testing.T) {
+	ref := testConfigMapRef("dest-namespace", "dest-name")
+	conflict := apierrors.NewApplyConflict(
+		[]metav1.StatusCause{
+			{Type: metav1.CauseTypeFieldManagerConflict, Field: ".data.key", Message: `conflict with "kubectl-edit" using v1`},
+			{Type: metav1.CauseTypeFieldManagerConflict, Field: ".data.other", Message: `conflict with "other-operator" using v1`},
+		},
+		"Apply failed with 2 conflicts",
+	)
+	expectedMessage := "Conflicts applying ConfigMap dest-namespace/dest-name:\n" +
+		`.data.key: conflict with "kubectl-edit" using v1` + "\n" +
+		`.data.other: conflict with "other-operator" using v1`
+	cases := []struct {
+		name              string
+		err               error
+		oldConditions     []hivev1.SyncCondition
+		expectedCondition *hivev1.SyncCondition
+		expectOldTime     bool
+	}{
+		{
+			name: "not a conflict",
+			err:  errors.Wrap(apierrors.NewBadRequest("bad"), "failed to apply resource 0"),
+		},
+		{
+			name: "conflict without resource",
+			err:  errors.Wrap(conflict, "failed to apply resource 0"),
+		},
+		{
+			name: "new conflict",
+			err:  errors.Wrap(asApplyConflictError(conflict, ref), "failed to apply resource 0"),
+			expectedCondition: &hivev1.SyncCondition{
+				Type:    hivev1.ApplyConflictSyncCondition,
+				Status:  corev1.ConditionTrue,
+				Reason:  "FieldManagerConflict",
+				Message: expectedMessage,
+			},
+		},
+		{
+			name: "unchanged conflict",
+			err:  errors.Wrap(asApplyConflictError(conflict, ref), "failed to apply resource 0"),
+			oldConditions: []hivev1.SyncCondition{{
+				Type:               hivev1.ApplyConflictSyncCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             "FieldManagerConflict",
+				Message:            expectedMessage,
+				LastTransitionTime: timeInThePast,
+			}},
+			expectedCondition: &hivev1.SyncCondition{
+				Type:               hivev1.ApplyConflictSyncCondition,
+				Status:             corev1.ConditionTrue,
+				Reason:             "FieldManagerConflict",
+				Message:            expectedMessage,
+				LastTransitionTime: timeInThePast,
+			},
+			expectOldTime: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conditions := applyConflictConditions(tc.err, hiveintv1alpha1.SyncStatus{Conditions: tc.oldConditions})
+			if tc.expectedCondition == nil {
+				assert.Empty(t, conditions, "expected no conditions")
+				return
+			}
+			if assert.Len(t, conditions, 1, "expected a single condition") {
+				if !tc.expectOldTime {
+					assert.False(t, conditions[0].LastTransitionTime.IsZero(), "expected transition time to be set")
+					conditions[0].LastTransitionTime = metav1.Time{}
+				}
+				assert.Equal(t, *tc.expectedCondition, conditions[0], "unexpected condition")
+			}
+			assert.EqualError(t, tc.err, "failed to apply resource 0: Apply failed with 2 conflicts", "error message should be unchanged")
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package clustersync
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// maxFailingClusterNames is the maximum number of failing clusters listed in the status of a
+	// SyncSet or SelectorSyncSet.
+	maxFailingClusterNames = 10
+)
+
+// AddSyncSetStatusToManager adds a controller to mgr that rolls up the results recorded in ClusterSyncs onto the
+// status of each SyncSet and SelectorSyncSet. Only one replica of the clustersync StatefulSet should run it.
+//
+// SelectorSyncSets are cluster scoped, so requests with an empty namespace are for SelectorSyncSets and all
+// other requests are for SyncSets.
+func AddSyncSetStatusToManager(mgr manager.Manager, r *ReconcileSyncSetStatus, concurrentReconciles int) error {
+	c, err := controller.New("syncSetStatus-controller", mgr, controller.Options{
+		Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+		MaxConcurrentReconciles: concurrentReconciles,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to SyncSets
+	if err := c.Watch(&source.Kind{Type: &hivev1.SyncSet{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to SelectorSyncSets
+	if err := c.Watch(&source.Kind{Type: &hivev1.SelectorSyncSet{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterSyncs, which hold the per-cluster results
+	if err := c.Watch(
+		&source.Kind{Type: &hiveintv1alpha1.ClusterSync{}},
+		handler.EnqueueRequestsFromMapFunc(syncSetStatusRequestsForClusterSync)); err != nil {
+		return err
+	}
+
+	// Watch for changes to ClusterDeployments, which can change the set of clusters that a syncset targets
+	if err := c.Watch(
+		&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		handler.EnqueueRequestsFromMapFunc(syncSetStatusRequestsForClusterDeployment(r.Client, r.logger))); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func syncSetStatusRequestsForClusterSync(o client.Object) []reconcile.Request {
+	clusterSync, ok := o.(*hiveintv1alpha1.ClusterSync)
+	if !ok {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, status := range clusterSync.Status.SyncSets {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: clusterSync.Namespace, Name: status.Name}})
+	}
+	for _, status := range clusterSync.Status.SelectorSyncSets {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: status.Name}})
+	}
+	return requests
+}
+
+func syncSetStatusRequestsForClusterDeployment(c client.Client, logger log.FieldLogger) handler.MapFunc {
+	return func(o client.Object) []reconcile.Request {
+		cd, ok := o.(*hivev1.ClusterDeployment)
+		if !ok {
+			return nil
+		}
+		logger := logger.WithField("clusterDeployment", fmt.Sprintf("%s/%s", cd.Namespace, cd.Name))
+		var requests []reconcile.Request
+		syncSets := &hivev1.SyncSetList{}
+		if err := c.List(context.Background(), syncSets, client.InNamespace(cd.Namespace)); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SyncSets")
+		}
+		for i := range syncSets.Items {
+			if doesSyncSetApplyToClusterDeployment(&syncSets.Items[i], cd) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: cd.Namespace, Name: syncSets.Items[i].Name}})
+			}
+		}
+		selectorSyncSets := &hivev1.SelectorSyncSetList{}
+		if err := c.List(context.Background(), selectorSyncSets); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list SelectorSyncSets")
+		}
+		for i := range selectorSyncSets.Items {
+			if doesSelectorSyncSetApplyToClusterDeployment(&selectorSyncSets.Items[i], cd, logger) {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: selectorSyncSets.Items[i].Name}})
+			}
+		}
+		return requests
+	}
+}
+
+var _ reconcile.Reconciler = &ReconcileSyncSetStatus{}
+
+// ReconcileSyncSetStatus reconciles a SyncSet or SelectorSyncSet to summarize the results of applying it to the
+// clusters that it targets
+type ReconcileSyncSetStatus struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// NewSyncSetStatusReconciler returns a new ReconcileSyncSetStatus
+func NewSyncSetStatusReconciler(c client.Client) *ReconcileSyncSetStatus {
+	return &ReconcileSyncSetStatus{
+		Client: c,
+		logger: log.WithField("controller", ControllerName),
+	}
+}
+
+// Reconcile computes the status of a SyncSet or SelectorSyncSet from the ClusterSyncs of its target clusters.
+func (r *ReconcileSyncSetStatus) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	kind := "syncSet"
+	if request.Namespace == "" {
+		kind = "selectorSyncSet"
+	}
+	logger := controllerutils.BuildControllerLogger(ControllerName, kind, request.NamespacedName)
+	logger.Debug("reconciling syncset status")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	var (
+		obj      client.Object
+		status   *hivev1.SyncSetCommonStatus
+		cds      []hivev1.ClusterDeployment
+		statuses func(*hiveintv1alpha1.ClusterSync) []hiveintv1alpha1.SyncStatus
+	)
+	if request.Namespace == "" {
+		sss := &hivev1.SelectorSyncSet{}
+		if err := r.Get(ctx, request.NamespacedName, sss); err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.Debug("SelectorSyncSet not found")
+				return reconcile.Result{}, nil
+			}
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get SelectorSyncSet")
+			return reconcile.Result{}, err
+		}
+		obj, status = sss, &sss.Status.SyncSetCommonStatus
+		statuses = func(cs *hiveintv1alpha1.ClusterSync) []hiveintv1alpha1.SyncStatus { return cs.Status.SelectorSyncSets }
+		selector, err := metav1.LabelSelectorAsSelector(&sss.Spec.ClusterDeploymentSelector)
+		if err != nil {
+			logger.WithError(err).Error("unable to convert selector")
+			// The SelectorSyncSet does not apply to any clusters until its selector is fixed
+			selector = labels.Nothing()
+		}
+		cdList := &hivev1.ClusterDeploymentList{}
+		if err := r.List(ctx, cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "could not list ClusterDeployments")
+			return reconcile.Result{}, err
+		}
+		cds = cdList.Items
+	} else {
+		ss := &hivev1.SyncSet{}
+		if err := r.Get(ctx, request.NamespacedName, ss); err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.Debug("SyncSet not found")
+				return reconcile.Result{}, nil
+			}
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get SyncSet")
+			return reconcile.Result{}, err
+		}
+		obj, status = ss, &ss.Status.SyncSetCommonStatus
+		statuses = func(cs *hiveintv1alpha1.ClusterSync) []hiveintv1alpha1.SyncStatus { return cs.Status.SyncSets }
+		for _, ref := range ss.Spec.ClusterDeploymentRefs {
+			cd := &hivev1.ClusterDeployment{}
+			switch err := r.Get(ctx, types.NamespacedName{Namespace: ss.Namespace, Name: ref.Name}, cd); {
+			case apierrors.IsNotFound(err):
+				continue
+			case err != nil:
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get ClusterDeployment")
+				return reconcile.Result{}, err
+			}
+			cds = append(cds, *cd)
+		}
+	}
+
+	var clusters []syncSetClusterState
+	for i := range cds {
+		cd := &cds[i]
+		if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+			continue
+		}
+		state := syncSetClusterState{cd: cd}
+		clusterSync := &hiveintv1alpha1.ClusterSync{}
+		switch err := r.Get(ctx, types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, clusterSync); {
+		case apierrors.IsNotFound(err):
+			// Not synced yet
+		case err != nil:
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to get ClusterSync")
+			return reconcile.Result{}, err
+		default:
+			for _, syncStatus := range statuses(clusterSync) {
+				if syncStatus.Name == obj.GetName() {
+					syncStatus := syncStatus
+					state.syncStatus = &syncStatus
+					break
+				}
+			}
+		}
+		clusters = append(clusters, state)
+	}
+
+	origObj := obj.DeepCopyObject()
+	*status = summarizeSyncSetStatus(obj.GetGeneration(), clusters)
+	var result reconcile.Result
+	if sss, ok := obj.(*hivev1.SelectorSyncSet); ok {
+		if sss.Spec.RolloutStrategy == nil {
+			sss.Status.Rollout = nil
+		} else {
+			result.RequeueAfter = updateRollout(sss, clusters, time.Now(), logger)
+		}
+	}
+
+	if apiequality.Semantic.DeepEqual(origObj, obj) {
+		logger.Debug("status unchanged")
+		return result, nil
+	}
+	logger.WithFields(log.Fields{
+		"targeted": status.TargetedClusters,
+		"applied":  status.AppliedClusters,
+		"failing":  status.FailingClusters,
+	}).Info("updating status")
+	if err := r.Status().Update(ctx, obj); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to update status")
+		return reconcile.Result{}, err
+	}
+	return result, nil
+}
+
+// syncSetClusterState is the result of syncing a syncset to one of the clusters that it targets.
+type syncSetClusterState struct {
+	cd *hivev1.ClusterDeployment
+	// syncStatus is nil if the syncset has not been synced to the cluster.
+	syncStatus *hiveintv1alpha1.SyncStatus
+}
+
+func summarizeSyncSetStatus(generation int64, clusters []syncSetClusterState) hivev1.SyncSetCommonStatus {
+	status := hivev1.SyncSetCommonStatus{
+		ObservedGeneration: generation,
+		TargetedClusters:   int32(len(clusters)),
+	}
+	var failures []failingCluster
+	for _, c := range clusters {
+		switch {
+		case c.syncStatus == nil:
+		case c.syncStatus.Result == hiveintv1alpha1.FailureSyncSetResult:
+			failures = append(failures, failingCluster{
+				name:    fmt.Sprintf("%s/%s", c.cd.Namespace, c.cd.Name),
+				message: c.syncStatus.FailureMessage,
+			})
+		case c.syncStatus.ObservedGeneration == generation:
+			status.AppliedClusters++
+		}
+	}
+	status.FailingClusters = int32(len(failures))
+	sort.Slice(failures, func(i, j int) bool { return failures[i].name < failures[j].name })
+	for i, f := range failures {
+		if i == maxFailingClusterNames {
+			break
+		}
+		status.FailingClusterNames = append(status.FailingClusterNames, f.name)
+	}
+	if len(failures) > 0 {
+		status.FirstFailureMessage = failures[0].message
+	}
+	return status
+}
+
+type failingCluster struct {
+	name    string
+	message string
+}
@@ -0,0 +1,187 @@
+package clustersync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcs "github.com/openshift/hive/pkg/test/clustersync"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+	testselectorsyncset "github.com/openshift/hive/pkg/test/selectorsyncset"
+	testsyncset "github.com/openshift/hive/pkg/test/syncset"
+)
+
+func TestReconcileSyncSetStatus(t *testing.T) {
+	scheme := newScheme()
+	installedCD := func(name string) *hivev1.ClusterDeployment {
+		return testcd.FullBuilder(testNamespace, name, scheme).Build(
+			testcd.Installed(),
+			testcd.WithLabel("region", "us-east-1"),
+		)
+	}
+	clusterSync := func(name string, opts ...testcs.Option) *hiveintv1alpha1.ClusterSync {
+		return testcs.FullBuilder(testNamespace, name, scheme).Build(opts...)
+	}
+	syncStatus := func(generation int64, result hiveintv1alpha1.SyncSetResult, message string) hiveintv1alpha1.SyncStatus {
+		return hiveintv1alpha1.SyncStatus{
+			Name:               "test-syncset",
+			ObservedGeneration: generation,
+			Result:             result,
+			FailureMessage:     message,
+		}
+	}
+	syncSet := testsyncset.FullBuilder(testNamespace, "test-syncset", scheme).Build(
+		testsyncset.ForClusterDeployments("cd1", "cd2", "cd3", "cd4", "cd5", "missing"),
+		testsyncset.WithGeneration(2),
+	)
+	selectorSyncSet := testselectorsyncset.FullBuilder("test-syncset", scheme).Build(
+		testselectorsyncset.WithLabelSelector("region", "us-east-1"),
+		testselectorsyncset.WithGeneration(2),
+	)
+
+	cases := []struct {
+		name           string
+		selector       bool
+		existing       []runtime.Object
+		expectedStatus hivev1.SyncSetCommonStatus
+	}{
+		{
+			name:     "syncset with no clusters",
+			existing: []runtime.Object{syncSet},
+			expectedStatus: hivev1.SyncSetCommonStatus{
+				ObservedGeneration: 2,
+			},
+		},
+		{
+			name: "syncset",
+			existing: []runtime.Object{
+				syncSet,
+				installedCD("cd1"),
+				installedCD("cd2"),
+				installedCD("cd3"),
+				installedCD("cd4"),
+				testcd.FullBuilder(testNamespace, "cd5", scheme).Build(),
+				clusterSync("cd1", testcs.WithSyncSetStatus(syncStatus(2, hiveintv1alpha1.SuccessSyncSetResult, ""))),
+				clusterSync("cd2", testcs.WithSyncSetStatus(syncStatus(1, hiveintv1alpha1.SuccessSyncSetResult, ""))),
+				clusterSync("cd3", testcs.WithSyncSetStatus(syncStatus(2, hiveintv1alpha1.FailureSyncSetResult, "oops"))),
+			},
+			expectedStatus: hivev1.SyncSetCommonStatus{
+				ObservedGeneration:  2,
+				TargetedClusters:    4,
+				AppliedClusters:     1,
+				FailingClusters:     1,
+				FailingClusterNames: []string{testNamespace + "/cd3"},
+				FirstFailureMessage: "oops",
+			},
+		},
+		{
+			name:     "selectorsyncset",
+			selector: true,
+			existing: []runtime.Object{
+				selectorSyncSet,
+				installedCD("cd1"),
+				installedCD("cd2"),
+				testcd.FullBuilder(testNamespace, "other", scheme).Build(testcd.Installed()),
+				clusterSync("cd1", testcs.WithSelectorSyncSetStatus(syncStatus(2, hiveintv1alpha1.SuccessSyncSetResult, ""))),
+				clusterSync("cd2", testcs.WithSyncSetStatus(syncStatus(2, hiveintv1alpha1.FailureSyncSetResult, "wrong kind"))),
+				clusterSync("other", testcs.WithSelectorSyncSetStatus(syncStatus(2, hiveintv1alpha1.FailureSyncSetResult, "not targeted"))),
+			},
+			expectedStatus: hivev1.SyncSetCommonStatus{
+				ObservedGeneration: 2,
+				TargetedClusters:   2,
+				AppliedClusters:    1,
+			},
+		},
+		{
+			name:     "failing cluster names are bounded",
+			selector: true,
+			existing: func() []runtime.Object {
+				objs := []runtime.Object{selectorSyncSet}
+				for i := 0; i < 12; i++ {
+					name := fmt.Sprintf("cd%02d", i)
+					objs = append(objs,
+						installedCD(name),
+						clusterSync(name, testcs.WithSelectorSyncSetStatus(syncStatus(1, hiveintv1alpha1.FailureSyncSetResult, "failed "+name))),
+					)
+				}
+				return objs
+			}(),
+			expectedStatus: hivev1.SyncSetCommonStatus{
+				ObservedGeneration: 2,
+				TargetedClusters:   12,
+				FailingClusters:    12,
+				FailingClusterNames: []string{
+					testNamespace + "/cd00", testNamespace + "/cd01", testNamespace + "/cd02", testNamespace + "/cd03",
+					testNamespace + "/cd04", testNamespace + "/cd05", testNamespace + "/cd06", testNamespace + "/cd07",
+					testNamespace + "/cd08", testNamespace + "/cd09",
+				},
+				FirstFailureMessage: "failed cd00",
+			},
+		},
+		{
+			name:     "deleted clusters are not targeted",
+			selector: true,
+			existing: []runtime.Object{
+				selectorSyncSet,
+				testcd.FullBuilder(testNamespace, "cd1", scheme).GenericOptions(testgeneric.Deleted()).Build(
+					testcd.Installed(),
+					testcd.WithLabel("region", "us-east-1"),
+				),
+				clusterSync("cd1", testcs.WithSelectorSyncSetStatus(syncStatus(2, hiveintv1alpha1.SuccessSyncSetResult, ""))),
+			},
+			expectedStatus: hivev1.SyncSetCommonStatus{
+				ObservedGeneration: 2,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tc.existing...).Build()
+			r := &ReconcileSyncSetStatus{Client: c, logger: log.New()}
+			key := types.NamespacedName{Namespace: testNamespace, Name: "test-syncset"}
+			if tc.selector {
+				key.Namespace = ""
+			}
+			_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			var status hivev1.SyncSetCommonStatus
+			if tc.selector {
+				sss := &hivev1.SelectorSyncSet{}
+				require.NoError(t, c.Get(context.Background(), key, sss), "could not get SelectorSyncSet")
+				status = sss.Status.SyncSetCommonStatus
+			} else {
+				ss := &hivev1.SyncSet{}
+				require.NoError(t, c.Get(context.Background(), key, ss), "could not get SyncSet")
+				status = ss.Status.SyncSetCommonStatus
+			}
+			assert.Equal(t, tc.expectedStatus, status, "unexpected status")
+		})
+	}
+}
+
+func TestSyncSetStatusRequestsForClusterSync(t *testing.T) {
+	clusterSync := testcs.FullBuilder(testNamespace, testClusterSyncName, newScheme()).Build(
+		testcs.WithSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "ss"}),
+		testcs.WithSelectorSyncSetStatus(hiveintv1alpha1.SyncStatus{Name: "sss"}),
+	)
+	assert.Equal(t,
+		[]reconcile.Request{
+			{NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: "ss"}},
+			{NamespacedName: types.NamespacedName{Name: "sss"}},
+		},
+		syncSetStatusRequestsForClusterSync(clusterSync),
+	)
+}
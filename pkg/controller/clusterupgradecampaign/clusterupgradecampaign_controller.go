@@ -0,0 +1,575 @@
+package clusterupgradecampaign
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	ControllerName = hivev1.ClusterUpgradeCampaignControllerName
+
+	// campaignRequeueInterval is how often an unfinished campaign is reconciled, so that changes in the
+	// ClusterOperator data of its clusters and the end of soak times are observed.
+	campaignRequeueInterval = 5 * time.Minute
+
+	// defaultWaveName is the name of the wave of the clusters that match none of the waves of a campaign.
+	defaultWaveName = "default"
+)
+
+// Add creates a new ClusterUpgradeCampaign controller and adds it to the manager with default RBAC.
+func Add(mgr manager.Manager) error {
+	logger := log.WithField("controller", ControllerName)
+	concurrentReconciles, clientRateLimiter, queueRateLimiter, err := controllerutils.GetControllerConfig(mgr.GetClient(), ControllerName)
+	if err != nil {
+		logger.WithError(err).Error("could not get controller configurations")
+		return err
+	}
+	r := &ReconcileClusterUpgradeCampaign{
+		Client: controllerutils.NewClientWithMetricsOrDie(mgr, ControllerName, &clientRateLimiter),
+		logger: logger,
+	}
+	return AddToManager(mgr, r, concurrentReconciles, queueRateLimiter)
+}
+
+// AddToManager adds a new Controller to mgr with r as the reconcile.Reconciler
+func AddToManager(mgr manager.Manager, r *ReconcileClusterUpgradeCampaign, concurrentReconciles int, rateLimiter workqueue.RateLimiter) error {
+	c, err := controller.New("clusterupgradecampaign-controller", mgr, controller.Options{
+		Reconciler:              controllerutils.NewDelayingReconciler(r, r.logger),
+		MaxConcurrentReconciles: concurrentReconciles,
+		RateLimiter:             rateLimiter,
+	})
+	if err != nil {
+		r.logger.WithError(err).Error("error creating controller")
+		return err
+	}
+
+	// Watch for changes to ClusterUpgradeCampaign
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterUpgradeCampaign{}}, &handler.EnqueueRequestForObject{}); err != nil {
+		r.logger.WithError(err).Error("Error watching ClusterUpgradeCampaign")
+		return err
+	}
+
+	// Watch for changes to the ClusterDeployments and ClusterStates of the clusters selected by campaigns
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterDeployment{}},
+		handler.EnqueueRequestsFromMapFunc(r.clusterDeploymentHandlerFunc)); err != nil {
+		r.logger.WithError(err).Error("Error watching ClusterDeployment")
+		return err
+	}
+	if err := c.Watch(&source.Kind{Type: &hivev1.ClusterState{}},
+		handler.EnqueueRequestsFromMapFunc(r.clusterStateHandlerFunc)); err != nil {
+		r.logger.WithError(err).Error("Error watching ClusterState")
+		return err
+	}
+
+	return nil
+}
+
+// clusterDeploymentHandlerFunc enqueues the campaigns that select the ClusterDeployment.
+func (r *ReconcileClusterUpgradeCampaign) clusterDeploymentHandlerFunc(a client.Object) []reconcile.Request {
+	return r.campaignsForLabels(a.GetLabels())
+}
+
+// clusterStateHandlerFunc enqueues the campaigns that select the ClusterDeployment of the ClusterState.
+func (r *ReconcileClusterUpgradeCampaign) clusterStateHandlerFunc(a client.Object) []reconcile.Request {
+	cd := &hivev1.ClusterDeployment{}
+	if err := r.Get(context.Background(), types.NamespacedName{Namespace: a.GetNamespace(), Name: a.GetName()}, cd); err != nil {
+		if !apierrors.IsNotFound(err) {
+			r.logger.WithError(err).WithField("clusterState", a.GetNamespace()+"/"+a.GetName()).
+				Log(controllerutils.LogLevel(err), "failed to get clusterdeployment for clusterstate")
+		}
+		return nil
+	}
+	return r.campaignsForLabels(cd.Labels)
+}
+
+func (r *ReconcileClusterUpgradeCampaign) campaignsForLabels(cdLabels map[string]string) []reconcile.Request {
+	campaigns := &hivev1.ClusterUpgradeCampaignList{}
+	if err := r.List(context.Background(), campaigns); err != nil {
+		r.logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to list clusterupgradecampaigns")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, campaign := range campaigns.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&campaign.Spec.ClusterDeploymentSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(cdLabels)) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: campaign.Name}})
+		}
+	}
+	return requests
+}
+
+var _ reconcile.Reconciler = &ReconcileClusterUpgradeCampaign{}
+
+// ReconcileClusterUpgradeCampaign reconciles a ClusterUpgradeCampaign object
+type ReconcileClusterUpgradeCampaign struct {
+	client.Client
+	logger log.FieldLogger
+}
+
+// wave is a wave of a campaign with its parsed selector.
+type wave struct {
+	name     string
+	selector labels.Selector
+}
+
+// campaignCluster is a cluster selected by a campaign.
+type campaignCluster struct {
+	cd     *hivev1.ClusterDeployment
+	wave   int
+	status hivev1.ClusterUpgradeCampaignClusterStatus
+}
+
+// Reconcile starts the upgrades of the clusters selected by a ClusterUpgradeCampaign, wave by wave, and reports
+// their progress in the campaign's status.
+func (r *ReconcileClusterUpgradeCampaign) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := controllerutils.BuildControllerLogger(ControllerName, "clusterUpgradeCampaign", request.NamespacedName)
+	logger.Info("reconciling cluster upgrade campaign")
+	recobsrv := hivemetrics.NewReconcileObserver(ControllerName, logger)
+	defer recobsrv.ObserveControllerReconcileTime()
+
+	campaign := &hivev1.ClusterUpgradeCampaign{}
+	if err := r.Get(ctx, request.NamespacedName, campaign); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Debug("cluster upgrade campaign not found")
+			return reconcile.Result{}, nil
+		}
+		logger.WithError(err).Error("error getting cluster upgrade campaign")
+		return reconcile.Result{}, err
+	}
+	if campaign.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	origStatus := campaign.Status.DeepCopy()
+	result, err := r.reconcileCampaign(campaign, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if !reflect.DeepEqual(origStatus, &campaign.Status) {
+		if err := r.Status().Update(ctx, campaign); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster upgrade campaign status")
+			return reconcile.Result{}, err
+		}
+	}
+	return result, nil
+}
+
+func (r *ReconcileClusterUpgradeCampaign) reconcileCampaign(campaign *hivev1.ClusterUpgradeCampaign, logger log.FieldLogger) (reconcile.Result, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&campaign.Spec.ClusterDeploymentSelector)
+	if err != nil {
+		halt(campaign, fmt.Sprintf("invalid clusterDeploymentSelector: %v", err))
+		return reconcile.Result{}, nil
+	}
+	waves, err := campaignWaves(campaign)
+	if err != nil {
+		halt(campaign, err.Error())
+		return reconcile.Result{}, nil
+	}
+	if msg := validateUpgrade(&campaign.Spec.Upgrade); msg != "" {
+		halt(campaign, fmt.Sprintf("invalid upgrade: %s", msg))
+		return reconcile.Result{}, nil
+	}
+	target, err := r.upgradeTarget(campaign)
+	if err != nil {
+		logger.WithError(err).Error("error determining the target of the upgrade")
+		return reconcile.Result{}, err
+	}
+
+	clusters, err := r.campaignClusters(campaign, selector, waves, target, logger)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	campaign.Status.TargetedClusters = int32(len(clusters))
+	campaign.Status.CurrentWave = ""
+	currentWave := -1
+	var failed, completed int32
+	for _, c := range clusters {
+		switch c.status.State {
+		case hivev1.ClusterUpgradeFailed:
+			failed++
+		case hivev1.ClusterUpgradeCompleted:
+			completed++
+		case hivev1.ClusterUpgradePending, hivev1.ClusterUpgradeUpgrading:
+			if currentWave < 0 {
+				currentWave = c.wave
+			}
+		}
+	}
+	if currentWave >= 0 {
+		campaign.Status.CurrentWave = waves[currentWave].name
+	}
+
+	result := reconcile.Result{RequeueAfter: campaignRequeueInterval}
+	switch {
+	case campaign.Status.State == hivev1.ClusterUpgradeCampaignHalted && !campaign.Spec.Paused:
+		// A halted campaign stays halted, even if the failed clusters recover or are no longer selected, until it is
+		// resumed by pausing it.
+	case failed > campaign.Spec.MaxFailures && !campaign.Spec.Paused:
+		campaign.Status.State = hivev1.ClusterUpgradeCampaignHalted
+		campaign.Status.Message = fmt.Sprintf("%d cluster upgrades failed, more than the %d allowed", failed, campaign.Spec.MaxFailures)
+	case currentWave < 0:
+		campaign.Status.State = hivev1.ClusterUpgradeCampaignCompleted
+		campaign.Status.Message = fmt.Sprintf("%d of %d clusters completed their upgrade", completed, len(clusters))
+		result = reconcile.Result{}
+	case campaign.Spec.Paused:
+		campaign.Status.State = hivev1.ClusterUpgradeCampaignPaused
+		campaign.Status.Message = "campaign is paused"
+	default:
+		if soakRemaining := soakRemaining(campaign, clusters, currentWave); soakRemaining > 0 {
+			campaign.Status.State = hivev1.ClusterUpgradeCampaignSoaking
+			campaign.Status.Message = fmt.Sprintf("soaking before starting wave %s", waves[currentWave].name)
+			if soakRemaining < result.RequeueAfter {
+				result.RequeueAfter = soakRemaining
+			}
+			break
+		}
+		campaign.Status.State = hivev1.ClusterUpgradeCampaignProgressing
+		campaign.Status.Message = fmt.Sprintf("upgrading wave %s", waves[currentWave].name)
+		if err := r.startUpgrades(campaign, clusters, currentWave, logger); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	campaign.Status.Clusters = make([]hivev1.ClusterUpgradeCampaignClusterStatus, len(clusters))
+	campaign.Status.UpgradingClusters = 0
+	for i, c := range clusters {
+		campaign.Status.Clusters[i] = c.status
+		if c.status.State == hivev1.ClusterUpgradeUpgrading {
+			campaign.Status.UpgradingClusters++
+		}
+	}
+	campaign.Status.CompletedClusters = completed
+	campaign.Status.FailedClusters = failed
+	return result, nil
+}
+
+// campaignWaves returns the waves of the campaign followed by the wave of the clusters that match none of them.
+func campaignWaves(campaign *hivev1.ClusterUpgradeCampaign) ([]wave, error) {
+	waves := make([]wave, 0, len(campaign.Spec.Waves)+1)
+	for _, w := range campaign.Spec.Waves {
+		selector, err := metav1.LabelSelectorAsSelector(&w.ClusterDeploymentSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid clusterDeploymentSelector of wave %s: %w", w.Name, err)
+		}
+		waves = append(waves, wave{name: w.Name, selector: selector})
+	}
+	return append(waves, wave{name: defaultWaveName, selector: labels.Everything()}), nil
+}
+
+// waveIndex returns the index of the first wave that selects a cluster with the given labels.
+func waveIndex(waves []wave, cdLabels map[string]string) int {
+	for i, w := range waves {
+		if w.selector.Matches(labels.Set(cdLabels)) {
+			return i
+		}
+	}
+	return len(waves) - 1
+}
+
+// validateUpgrade returns why the upgrade of a campaign is invalid, or an empty string if it is valid.
+func validateUpgrade(upgrade *hivev1.ClusterUpgrade) string {
+	switch {
+	case upgrade.Version == "" && upgrade.ImageSetRef == nil:
+		return "must specify either version or imageSetRef"
+	case upgrade.Version != "" && upgrade.ImageSetRef != nil:
+		return "cannot specify both version and imageSetRef"
+	}
+	return ""
+}
+
+// upgradeTarget is the release that a cluster reports in its ClusterDeployment's Status.Upgrade once it has
+// completed the upgrade requested by a campaign.
+type upgradeTarget struct {
+	version string
+	image   string
+}
+
+func (t upgradeTarget) reachedBy(status *hivev1.ClusterUpgradeStatus) bool {
+	if status == nil || (t.image == "" && t.version == "") {
+		return false
+	}
+	if t.image != "" {
+		return status.DesiredImage == t.image
+	}
+	return status.CurrentVersion == t.version
+}
+
+func (r *ReconcileClusterUpgradeCampaign) upgradeTarget(campaign *hivev1.ClusterUpgradeCampaign) (upgradeTarget, error) {
+	ref := campaign.Spec.Upgrade.ImageSetRef
+	if ref == nil {
+		return upgradeTarget{version: campaign.Spec.Upgrade.Version}, nil
+	}
+	imageSet := &hivev1.ClusterImageSet{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: ref.Name}, imageSet); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The clusters report the missing ClusterImageSet as an upgrade failure.
+			return upgradeTarget{}, nil
+		}
+		return upgradeTarget{}, err
+	}
+	return upgradeTarget{image: imageSet.Spec.ReleaseImage}, nil
+}
+
+// campaignClusters returns the installed clusters selected by the campaign, ordered by wave, with the progress of
+// their upgrades.
+func (r *ReconcileClusterUpgradeCampaign) campaignClusters(campaign *hivev1.ClusterUpgradeCampaign, selector labels.Selector, waves []wave, target upgradeTarget, logger log.FieldLogger) ([]*campaignCluster, error) {
+	cdList := &hivev1.ClusterDeploymentList{}
+	if err := r.List(context.TODO(), cdList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "error listing cluster deployments")
+		return nil, err
+	}
+	oldStatuses := make(map[types.NamespacedName]*hivev1.ClusterUpgradeCampaignClusterStatus, len(campaign.Status.Clusters))
+	for i, s := range campaign.Status.Clusters {
+		oldStatuses[types.NamespacedName{Namespace: s.Namespace, Name: s.Name}] = &campaign.Status.Clusters[i]
+	}
+
+	var clusters []*campaignCluster
+	for i := range cdList.Items {
+		cd := &cdList.Items[i]
+		if cd.DeletionTimestamp != nil || !cd.Spec.Installed {
+			continue
+		}
+		c := &campaignCluster{cd: cd, wave: waveIndex(waves, cd.Labels)}
+		status, err := r.clusterStatus(campaign, cd, waves[c.wave].name, target, oldStatuses[types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}])
+		if err != nil {
+			logger.WithField("clusterDeployment", cd.Namespace+"/"+cd.Name).WithError(err).Error("error determining the upgrade status of the cluster")
+			return nil, err
+		}
+		c.status = *status
+		clusters = append(clusters, c)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].wave != clusters[j].wave {
+			return clusters[i].wave < clusters[j].wave
+		}
+		if clusters[i].cd.Namespace != clusters[j].cd.Namespace {
+			return clusters[i].cd.Namespace < clusters[j].cd.Namespace
+		}
+		return clusters[i].cd.Name < clusters[j].cd.Name
+	})
+	return clusters, nil
+}
+
+// clusterStatus returns the progress of the upgrade of a cluster selected by the campaign.
+func (r *ReconcileClusterUpgradeCampaign) clusterStatus(campaign *hivev1.ClusterUpgradeCampaign, cd *hivev1.ClusterDeployment, waveName string, target upgradeTarget, oldStatus *hivev1.ClusterUpgradeCampaignClusterStatus) (*hivev1.ClusterUpgradeCampaignClusterStatus, error) {
+	status := &hivev1.ClusterUpgradeCampaignClusterStatus{
+		Namespace: cd.Namespace,
+		Name:      cd.Name,
+		Wave:      waveName,
+		State:     hivev1.ClusterUpgradePending,
+	}
+	owner := cd.Annotations[constants.UpgradeCampaignAnnotation]
+	if owner != "" && owner != campaign.Name {
+		// A cluster whose upgrade this campaign finished keeps its final state once a later campaign takes it over.
+		if oldStatus != nil && (oldStatus.State == hivev1.ClusterUpgradeCompleted || oldStatus.State == hivev1.ClusterUpgradeFailed) {
+			return oldStatus.DeepCopy(), nil
+		}
+		active, err := r.upgradeInProgress(owner, cd)
+		if err != nil {
+			return nil, err
+		}
+		if active {
+			status.State = hivev1.ClusterUpgradeSkipped
+			status.Message = fmt.Sprintf("upgrade is managed by ClusterUpgradeCampaign %s", owner)
+		}
+		return status, nil
+	}
+	if owner == "" || !reflect.DeepEqual(cd.Spec.Upgrade, &campaign.Spec.Upgrade) {
+		return status, nil
+	}
+
+	if oldStatus != nil {
+		status.StartedTime = oldStatus.StartedTime
+		status.CompletionTime = oldStatus.CompletionTime
+	}
+	upgrading := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ClusterUpgradingCondition)
+	if failed := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ClusterUpgradeFailedCondition); failed != nil && failed.Status == corev1.ConditionTrue {
+		status.State = hivev1.ClusterUpgradeFailed
+		status.Message = failed.Message
+		return status, nil
+	}
+	if upgrading == nil || upgrading.Reason != hivev1.UpgradeCompletedReason || !target.reachedBy(cd.Status.Upgrade) {
+		status.State = hivev1.ClusterUpgradeUpgrading
+		if upgrading != nil {
+			status.Message = upgrading.Message
+		}
+		return status, nil
+	}
+
+	degraded, err := r.degradedOperators(cd)
+	if err != nil {
+		return nil, err
+	}
+	if degraded != "" {
+		status.State = hivev1.ClusterUpgradeFailed
+		status.Message = degraded
+		return status, nil
+	}
+	status.State = hivev1.ClusterUpgradeCompleted
+	status.Message = upgrading.Message
+	if cd.Status.Upgrade.CompletionTime != nil {
+		status.CompletionTime = cd.Status.Upgrade.CompletionTime
+	} else if status.CompletionTime == nil {
+		now := metav1.Now()
+		status.CompletionTime = &now
+	}
+	return status, nil
+}
+
+// upgradeInProgress returns true if the named campaign still exists and has not yet finished the upgrade of the
+// cluster. Once it has, or once it is deleted, other campaigns may upgrade the cluster.
+func (r *ReconcileClusterUpgradeCampaign) upgradeInProgress(campaignName string, cd *hivev1.ClusterDeployment) (bool, error) {
+	owner := &hivev1.ClusterUpgradeCampaign{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Name: campaignName}, owner); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if owner.DeletionTimestamp != nil {
+		return false, nil
+	}
+	for _, s := range owner.Status.Clusters {
+		if s.Namespace != cd.Namespace || s.Name != cd.Name {
+			continue
+		}
+		return s.State == hivev1.ClusterUpgradePending || s.State == hivev1.ClusterUpgradeUpgrading, nil
+	}
+	return false, nil
+}
+
+// degradedOperators describes the ClusterOperators that the ClusterState of the cluster reports as degraded or
+// unavailable, or returns an empty string if there are none.
+func (r *ReconcileClusterUpgradeCampaign) degradedOperators(cd *hivev1.ClusterDeployment) (string, error) {
+	st := &hivev1.ClusterState{}
+	if err := r.Get(context.TODO(), types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}, st); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	var problems []string
+	for _, operator := range st.Status.ClusterOperators {
+		for _, cond := range operator.Conditions {
+			switch {
+			case cond.Type == configv1.OperatorDegraded && cond.Status == configv1.ConditionTrue:
+				problems = append(problems, fmt.Sprintf("ClusterOperator %s is degraded: %s", operator.Name, cond.Message))
+			case cond.Type == configv1.OperatorAvailable && cond.Status == configv1.ConditionFalse:
+				problems = append(problems, fmt.Sprintf("ClusterOperator %s is unavailable: %s", operator.Name, cond.Message))
+			}
+		}
+	}
+	return strings.Join(problems, "; "), nil
+}
+
+// soakRemaining returns how much longer the campaign must wait before starting the current wave.
+func soakRemaining(campaign *hivev1.ClusterUpgradeCampaign, clusters []*campaignCluster, currentWave int) time.Duration {
+	if campaign.Spec.SoakTime == nil {
+		return 0
+	}
+	var lastCompletion *metav1.Time
+	for _, c := range clusters {
+		if c.wave == currentWave && c.status.State != hivev1.ClusterUpgradePending {
+			// The wave has already started.
+			return 0
+		}
+		if c.wave < currentWave && c.status.CompletionTime != nil &&
+			(lastCompletion == nil || lastCompletion.Before(c.status.CompletionTime)) {
+			lastCompletion = c.status.CompletionTime
+		}
+	}
+	if lastCompletion == nil {
+		return 0
+	}
+	return time.Until(lastCompletion.Add(campaign.Spec.SoakTime.Duration))
+}
+
+// startUpgrades starts the upgrades of the pending clusters of the current wave, up to the campaign's
+// MaxUnavailable.
+func (r *ReconcileClusterUpgradeCampaign) startUpgrades(campaign *hivev1.ClusterUpgradeCampaign, clusters []*campaignCluster, currentWave int, logger log.FieldLogger) error {
+	var waveClusters []*campaignCluster
+	upgrading := 0
+	for _, c := range clusters {
+		if c.wave != currentWave {
+			continue
+		}
+		waveClusters = append(waveClusters, c)
+		if c.status.State == hivev1.ClusterUpgradeUpgrading {
+			upgrading++
+		}
+	}
+	maxUnavailable := 1
+	if campaign.Spec.MaxUnavailable != nil {
+		var err error
+		maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(campaign.Spec.MaxUnavailable, len(waveClusters), true)
+		if err != nil {
+			halt(campaign, fmt.Sprintf("invalid maxUnavailable: %v", err))
+			return nil
+		}
+		if maxUnavailable < 1 {
+			maxUnavailable = 1
+		}
+	}
+
+	for _, c := range waveClusters {
+		if upgrading >= maxUnavailable {
+			break
+		}
+		if c.status.State != hivev1.ClusterUpgradePending {
+			continue
+		}
+		cdLog := logger.WithField("clusterDeployment", c.cd.Namespace+"/"+c.cd.Name)
+		c.cd.Spec.Upgrade = campaign.Spec.Upgrade.DeepCopy()
+		if c.cd.Annotations == nil {
+			c.cd.Annotations = map[string]string{}
+		}
+		c.cd.Annotations[constants.UpgradeCampaignAnnotation] = campaign.Name
+		if err := r.Update(context.TODO(), c.cd); err != nil {
+			cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error starting the upgrade of the cluster")
+			return err
+		}
+		cdLog.Info("started the upgrade of the cluster")
+		now := metav1.Now()
+		c.status.State = hivev1.ClusterUpgradeUpgrading
+		c.status.StartedTime = &now
+		c.status.CompletionTime = nil
+		c.status.Message = "upgrade requested"
+		upgrading++
+	}
+	return nil
+}
+
+func halt(campaign *hivev1.ClusterUpgradeCampaign, message string) {
+	campaign.Status.State = hivev1.ClusterUpgradeCampaignHalted
+	campaign.Status.Message = message
+}
@@ -0,0 +1,577 @@
+package clusterupgradecampaign
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcuc "github.com/openshift/hive/pkg/test/clusterupgradecampaign"
+	testgeneric "github.com/openshift/hive/pkg/test/generic"
+)
+
+const (
+	namespace         = "test-namespace"
+	campaignName      = "test-campaign"
+	otherCampaignName = "other-campaign"
+	imageSetName      = "test-image-set"
+
+	fleetLabel = "fleet"
+	fleetValue = "test"
+	waveLabel  = "wave"
+
+	targetVersion = "4.12.10"
+	targetImage   = "registry.example.com/release:4.12.10"
+)
+
+func TestReconcileClusterUpgradeCampaign(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+
+	campaignBuilder := testcuc.FullBuilder(campaignName, scheme).Options(
+		testcuc.WithClusterDeploymentSelector(fleetLabel, fleetValue),
+		testcuc.WithVersion(targetVersion),
+	)
+	cdBuilder := func(name string) testcd.Builder {
+		return testcd.FullBuilder(namespace, name, scheme).
+			GenericOptions(testgeneric.WithLabel(fleetLabel, fleetValue)).
+			Options(testcd.Installed())
+	}
+	upgrading := func(campaign string) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			testcd.WithAnnotation(constants.UpgradeCampaignAnnotation, campaign)(cd)
+			cd.Spec.Upgrade = &hivev1.ClusterUpgrade{Version: targetVersion}
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:    hivev1.ClusterUpgradingCondition,
+				Status:  corev1.ConditionTrue,
+				Reason:  hivev1.UpgradeInProgressReason,
+				Message: "upgrading",
+			})(cd)
+		}
+	}
+	completedAt := func(completionTime time.Time) testcd.Option {
+		return func(cd *hivev1.ClusterDeployment) {
+			upgrading(campaignName)(cd)
+			testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+				Type:    hivev1.ClusterUpgradingCondition,
+				Status:  corev1.ConditionFalse,
+				Reason:  hivev1.UpgradeCompletedReason,
+				Message: "upgrade completed",
+			})(cd)
+			t := metav1.NewTime(completionTime)
+			cd.Status.Upgrade = &hivev1.ClusterUpgradeStatus{
+				CurrentVersion: targetVersion,
+				DesiredVersion: targetVersion,
+				CompletionTime: &t,
+			}
+		}
+	}
+	completed := completedAt(time.Now().Add(-2 * time.Hour))
+	failed := func(cd *hivev1.ClusterDeployment) {
+		upgrading(campaignName)(cd)
+		testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+			Type:    hivev1.ClusterUpgradeFailedCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  hivev1.UpgradeClusterVersionFailingReason,
+			Message: "cluster version is failing",
+		})(cd)
+	}
+	otherCampaign := func(state hivev1.ClusterUpgradeState) *hivev1.ClusterUpgradeCampaign {
+		return testcuc.FullBuilder(otherCampaignName, scheme).Build(func(campaign *hivev1.ClusterUpgradeCampaign) {
+			campaign.Status.Clusters = []hivev1.ClusterUpgradeCampaignClusterStatus{{
+				Namespace: namespace,
+				Name:      "cd1",
+				Wave:      defaultWaveName,
+				State:     state,
+			}}
+		})
+	}
+	clusterState := func(name string, operatorConditions ...configv1.ClusterOperatorStatusCondition) *hivev1.ClusterState {
+		return &hivev1.ClusterState{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Status: hivev1.ClusterStateStatus{
+				ClusterOperators: []hivev1.ClusterOperatorState{{
+					Name:       "test-operator",
+					Conditions: operatorConditions,
+				}},
+			},
+		}
+	}
+
+	cases := []struct {
+		name                 string
+		campaign             *hivev1.ClusterUpgradeCampaign
+		existing             []runtime.Object
+		expectedState        hivev1.ClusterUpgradeCampaignState
+		expectedWave         string
+		expectedClusters     map[string]hivev1.ClusterUpgradeState
+		expectedUpgrading    int32
+		expectedCompleted    int32
+		expectedFailed       int32
+		expectedRequeue      bool
+		expectedStartedByCD  map[string]bool
+		expectedMessageMatch string
+	}{
+		{
+			name:     "start first cluster",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeUpgrading,
+				"cd2": hivev1.ClusterUpgradePending,
+			},
+			expectedUpgrading:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": true, "cd2": false},
+		},
+		{
+			name:     "max unavailable percentage",
+			campaign: campaignBuilder.Build(testcuc.WithMaxUnavailable(intstr.FromString("50%"))),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(),
+				cdBuilder("cd2").Build(),
+				cdBuilder("cd3").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeUpgrading,
+				"cd2": hivev1.ClusterUpgradeUpgrading,
+				"cd3": hivev1.ClusterUpgradePending,
+			},
+			expectedUpgrading:   2,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": true, "cd2": true, "cd3": false},
+		},
+		{
+			name:     "wait for upgrading cluster",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(upgrading(campaignName)),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeUpgrading,
+				"cd2": hivev1.ClusterUpgradePending,
+			},
+			expectedUpgrading:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd2": false},
+		},
+		{
+			name:     "start next cluster",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(completed),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeCompleted,
+				"cd2": hivev1.ClusterUpgradeUpgrading,
+			},
+			expectedUpgrading:   1,
+			expectedCompleted:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd2": true},
+		},
+		{
+			name:     "waves in order",
+			campaign: campaignBuilder.Build(testcuc.WithWave("canary", waveLabel, "canary")),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(),
+				cdBuilder("cd2").GenericOptions(testgeneric.WithLabel(waveLabel, "canary")).Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  "canary",
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradePending,
+				"cd2": hivev1.ClusterUpgradeUpgrading,
+			},
+			expectedUpgrading:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": false, "cd2": true},
+		},
+		{
+			name: "soaking",
+			campaign: campaignBuilder.Build(
+				testcuc.WithWave("canary", waveLabel, "canary"),
+				testcuc.WithSoakTime(time.Hour),
+			),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(),
+				cdBuilder("cd2").GenericOptions(testgeneric.WithLabel(waveLabel, "canary")).
+					Build(completedAt(time.Now().Add(-10 * time.Minute))),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignSoaking,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradePending,
+				"cd2": hivev1.ClusterUpgradeCompleted,
+			},
+			expectedCompleted:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": false},
+		},
+		{
+			name: "start next wave after soak",
+			campaign: campaignBuilder.Build(
+				testcuc.WithWave("canary", waveLabel, "canary"),
+				testcuc.WithSoakTime(time.Hour),
+			),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(),
+				cdBuilder("cd2").GenericOptions(testgeneric.WithLabel(waveLabel, "canary")).Build(completed),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeUpgrading,
+				"cd2": hivev1.ClusterUpgradeCompleted,
+			},
+			expectedUpgrading:   1,
+			expectedCompleted:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": true},
+		},
+		{
+			name:     "halt on failed upgrade",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(failed),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignHalted,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeFailed,
+				"cd2": hivev1.ClusterUpgradePending,
+			},
+			expectedFailed:      1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd2": false},
+		},
+		{
+			name:     "halt on degraded operator",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(completed),
+				cdBuilder("cd2").Build(),
+				clusterState("cd1", configv1.ClusterOperatorStatusCondition{
+					Type:    configv1.OperatorDegraded,
+					Status:  configv1.ConditionTrue,
+					Message: "operator is degraded",
+				}),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignHalted,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeFailed,
+				"cd2": hivev1.ClusterUpgradePending,
+			},
+			expectedFailed:      1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd2": false},
+		},
+		{
+			name: "stay halted after failed cluster recovers",
+			campaign: campaignBuilder.Build(func(campaign *hivev1.ClusterUpgradeCampaign) {
+				campaign.Status.State = hivev1.ClusterUpgradeCampaignHalted
+				campaign.Status.Message = "1 cluster upgrades failed, more than the 0 allowed"
+			}),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(completed),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignHalted,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeCompleted,
+				"cd2": hivev1.ClusterUpgradePending,
+			},
+			expectedCompleted:    1,
+			expectedRequeue:      true,
+			expectedStartedByCD:  map[string]bool{"cd2": false},
+			expectedMessageMatch: "1 cluster upgrades failed",
+		},
+		{
+			name: "pause halted campaign",
+			campaign: campaignBuilder.Build(testcuc.Paused(), func(campaign *hivev1.ClusterUpgradeCampaign) {
+				campaign.Status.State = hivev1.ClusterUpgradeCampaignHalted
+			}),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(failed),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignPaused,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeFailed,
+				"cd2": hivev1.ClusterUpgradePending,
+			},
+			expectedFailed:      1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd2": false},
+		},
+		{
+			name: "resume campaign paused after halting",
+			campaign: campaignBuilder.Build(func(campaign *hivev1.ClusterUpgradeCampaign) {
+				campaign.Status.State = hivev1.ClusterUpgradeCampaignPaused
+			}),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(completed),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeCompleted,
+				"cd2": hivev1.ClusterUpgradeUpgrading,
+			},
+			expectedUpgrading:   1,
+			expectedCompleted:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd2": true},
+		},
+		{
+			name:     "healthy operators",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(completed),
+				clusterState("cd1",
+					configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorDegraded, Status: configv1.ConditionFalse},
+					configv1.ClusterOperatorStatusCondition{Type: configv1.OperatorAvailable, Status: configv1.ConditionTrue},
+				),
+			},
+			expectedState:     hivev1.ClusterUpgradeCampaignCompleted,
+			expectedClusters:  map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradeCompleted},
+			expectedCompleted: 1,
+		},
+		{
+			name:     "failures within max failures",
+			campaign: campaignBuilder.Build(testcuc.WithMaxFailures(1)),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(failed),
+				cdBuilder("cd2").Build(),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:  defaultWaveName,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeFailed,
+				"cd2": hivev1.ClusterUpgradeUpgrading,
+			},
+			expectedUpgrading:   1,
+			expectedFailed:      1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd2": true},
+		},
+		{
+			name:     "paused",
+			campaign: campaignBuilder.Build(testcuc.Paused()),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(),
+			},
+			expectedState:       hivev1.ClusterUpgradeCampaignPaused,
+			expectedWave:        defaultWaveName,
+			expectedClusters:    map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradePending},
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": false},
+		},
+		{
+			name:     "skip cluster managed by another campaign",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				otherCampaign(hivev1.ClusterUpgradeUpgrading),
+				cdBuilder("cd1").Build(upgrading(otherCampaignName)),
+			},
+			expectedState:    hivev1.ClusterUpgradeCampaignCompleted,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradeSkipped},
+		},
+		{
+			name:     "upgrade cluster another campaign finished with",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				otherCampaign(hivev1.ClusterUpgradeCompleted),
+				cdBuilder("cd1").Build(upgrading(otherCampaignName)),
+			},
+			expectedState:       hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:        defaultWaveName,
+			expectedClusters:    map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradeUpgrading},
+			expectedUpgrading:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": true},
+		},
+		{
+			name:     "upgrade cluster of deleted campaign",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(upgrading(otherCampaignName)),
+			},
+			expectedState:       hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:        defaultWaveName,
+			expectedClusters:    map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradeUpgrading},
+			expectedUpgrading:   1,
+			expectedRequeue:     true,
+			expectedStartedByCD: map[string]bool{"cd1": true},
+		},
+		{
+			name: "keep completed cluster taken over by another campaign",
+			campaign: campaignBuilder.Build(func(campaign *hivev1.ClusterUpgradeCampaign) {
+				campaign.Status.Clusters = []hivev1.ClusterUpgradeCampaignClusterStatus{{
+					Namespace: namespace,
+					Name:      "cd1",
+					Wave:      defaultWaveName,
+					State:     hivev1.ClusterUpgradeCompleted,
+				}}
+			}),
+			existing: []runtime.Object{
+				otherCampaign(hivev1.ClusterUpgradeUpgrading),
+				cdBuilder("cd1").Build(upgrading(otherCampaignName)),
+			},
+			expectedState:     hivev1.ClusterUpgradeCampaignCompleted,
+			expectedClusters:  map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradeCompleted},
+			expectedCompleted: 1,
+		},
+		{
+			name:     "completed",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(completed),
+				cdBuilder("cd2").Build(completed),
+			},
+			expectedState: hivev1.ClusterUpgradeCampaignCompleted,
+			expectedClusters: map[string]hivev1.ClusterUpgradeState{
+				"cd1": hivev1.ClusterUpgradeCompleted,
+				"cd2": hivev1.ClusterUpgradeCompleted,
+			},
+			expectedCompleted: 2,
+		},
+		{
+			name:     "stale completed condition",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(completed, func(cd *hivev1.ClusterDeployment) {
+					cd.Status.Upgrade.CurrentVersion = "4.12.9"
+				}),
+			},
+			expectedState:     hivev1.ClusterUpgradeCampaignProgressing,
+			expectedWave:      defaultWaveName,
+			expectedClusters:  map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradeUpgrading},
+			expectedUpgrading: 1,
+			expectedRequeue:   true,
+		},
+		{
+			name:     "completed with image set",
+			campaign: campaignBuilder.Build(testcuc.WithImageSet(imageSetName)),
+			existing: []runtime.Object{
+				&hivev1.ClusterImageSet{
+					ObjectMeta: metav1.ObjectMeta{Name: imageSetName},
+					Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: targetImage},
+				},
+				cdBuilder("cd1").Build(completed, func(cd *hivev1.ClusterDeployment) {
+					cd.Spec.Upgrade = &hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{Name: imageSetName}}
+					cd.Status.Upgrade.DesiredImage = targetImage
+				}),
+			},
+			expectedState:     hivev1.ClusterUpgradeCampaignCompleted,
+			expectedClusters:  map[string]hivev1.ClusterUpgradeState{"cd1": hivev1.ClusterUpgradeCompleted},
+			expectedCompleted: 1,
+		},
+		{
+			name:     "ignore uninstalled and unselected clusters",
+			campaign: campaignBuilder.Build(),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+				testcd.FullBuilder(namespace, "cd2", scheme).Build(testcd.Installed()),
+			},
+			expectedState:       hivev1.ClusterUpgradeCampaignCompleted,
+			expectedClusters:    map[string]hivev1.ClusterUpgradeState{},
+			expectedStartedByCD: map[string]bool{"cd1": false, "cd2": false},
+		},
+		{
+			name: "invalid upgrade",
+			campaign: campaignBuilder.Build(func(campaign *hivev1.ClusterUpgradeCampaign) {
+				campaign.Spec.Upgrade = hivev1.ClusterUpgrade{}
+			}),
+			existing: []runtime.Object{
+				cdBuilder("cd1").Build(),
+			},
+			expectedState:        hivev1.ClusterUpgradeCampaignHalted,
+			expectedStartedByCD:  map[string]bool{"cd1": false},
+			expectedMessageMatch: "invalid upgrade",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(append(tc.existing, tc.campaign)...).Build()
+			reconciler := &ReconcileClusterUpgradeCampaign{
+				Client: c,
+				logger: logger,
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: campaignName},
+			})
+			require.NoError(t, err, "unexpected error from Reconcile")
+			assert.Equal(t, tc.expectedRequeue, result.RequeueAfter > 0, "unexpected requeue")
+
+			campaign := &hivev1.ClusterUpgradeCampaign{}
+			require.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: campaignName}, campaign), "could not get campaign")
+			assert.Equal(t, tc.expectedState, campaign.Status.State, "unexpected campaign state")
+			assert.Equal(t, tc.expectedWave, campaign.Status.CurrentWave, "unexpected current wave")
+			assert.Contains(t, campaign.Status.Message, tc.expectedMessageMatch, "unexpected campaign message")
+			if tc.expectedClusters != nil {
+				clusters := map[string]hivev1.ClusterUpgradeState{}
+				for _, s := range campaign.Status.Clusters {
+					clusters[s.Name] = s.State
+				}
+				assert.Equal(t, tc.expectedClusters, clusters, "unexpected cluster states")
+				assert.Equal(t, int32(len(tc.expectedClusters)), campaign.Status.TargetedClusters, "unexpected targeted clusters")
+			}
+			assert.Equal(t, tc.expectedUpgrading, campaign.Status.UpgradingClusters, "unexpected upgrading clusters")
+			assert.Equal(t, tc.expectedCompleted, campaign.Status.CompletedClusters, "unexpected completed clusters")
+			assert.Equal(t, tc.expectedFailed, campaign.Status.FailedClusters, "unexpected failed clusters")
+
+			for name, started := range tc.expectedStartedByCD {
+				cd := &hivev1.ClusterDeployment{}
+				require.NoError(t, c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: name}, cd), "could not get cluster deployment")
+				if started {
+					if assert.NotNil(t, cd.Spec.Upgrade, "expected upgrade to be requested for %s", name) {
+						assert.Equal(t, campaign.Spec.Upgrade, *cd.Spec.Upgrade, "unexpected upgrade for %s", name)
+					}
+					assert.Equal(t, campaignName, cd.Annotations[constants.UpgradeCampaignAnnotation], "unexpected campaign annotation for %s", name)
+				} else {
+					assert.Nil(t, cd.Spec.Upgrade, "expected no upgrade to be requested for %s", name)
+					assert.Empty(t, cd.Annotations[constants.UpgradeCampaignAnnotation], "unexpected campaign annotation for %s", name)
+				}
+			}
+		})
+	}
+}
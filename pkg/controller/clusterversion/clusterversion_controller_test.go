@@ -25,6 +25,7 @@ import (
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
 	"github.com/openshift/hive/pkg/constants"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 )
@@ -37,6 +38,9 @@ const (
 	pullSecretSecret                = "pull-secret"
 	testRemoteClusterCurrentVersion = "4.0.0"
 	remoteClusterVersionObjectName  = "version"
+	testUpgradeVersion              = "4.0.1"
+	testUpgradeImage                = "quay.io/openshift-release-dev/ocp-release:4.0.1-x86_64"
+	testChannel                     = "stable-4.0"
 )
 
 func init() {
@@ -48,11 +52,14 @@ func TestClusterVersionReconcile(t *testing.T) {
 	configv1.Install(scheme.Scheme)
 
 	tests := []struct {
-		name         string
-		existing     []runtime.Object
-		noRemoteCall bool
-		expectError  bool
-		validate     func(*testing.T, *hivev1.ClusterDeployment)
+		name                 string
+		existing             []runtime.Object
+		configureRemote      func(*configv1.ClusterVersion)
+		noRemoteCall         bool
+		expectError          bool
+		expectedRequeueAfter time.Duration
+		validate             func(*testing.T, *hivev1.ClusterDeployment)
+		validateRemote       func(*testing.T, *configv1.ClusterVersion)
 	}{
 		{
 			// no cluster deployment, no error expected
@@ -76,6 +83,218 @@ func TestClusterVersionReconcile(t *testing.T) {
 				assert.Equal(t, "2", cd.Labels[constants.VersionMajorLabel], "unexpected version major label")
 				assert.Equal(t, "2.3", cd.Labels[constants.VersionMajorMinorLabel], "unexpected version major-minor label")
 				assert.Equal(t, "2.3.4", cd.Labels[constants.VersionMajorMinorPatchLabel], "unexpected version major-minor-patch label")
+				assert.Nil(t, cd.Status.Upgrade, "unexpected upgrade status")
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade to available version",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion, Channel: testChannel}),
+				testKubeconfigSecret(),
+			},
+			configureRemote: func(cv *configv1.ClusterVersion) {
+				cv.Status.AvailableUpdates = []configv1.Release{{Version: testUpgradeVersion, Image: testUpgradeImage}}
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionTrue, hivev1.UpgradeRequestedReason)
+				assertCondition(t, cd, hivev1.ClusterUpgradeFailedCondition, corev1.ConditionFalse, hivev1.UpgradeNotFailingReason)
+				if assert.NotNil(t, cd.Status.Upgrade, "expected upgrade status") {
+					assert.Equal(t, testRemoteClusterCurrentVersion, cd.Status.Upgrade.CurrentVersion, "unexpected current version")
+					assert.Equal(t, testChannel, cd.Status.Upgrade.Channel, "unexpected channel")
+					assert.Nil(t, cd.Status.Upgrade.StartedTime, "unexpected started time")
+				}
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Equal(t, &configv1.Update{Version: testUpgradeVersion}, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade to unavailable version",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion}),
+				testKubeconfigSecret(),
+			},
+			configureRemote: func(cv *configv1.ClusterVersion) {
+				cv.Status.AvailableUpdates = []configv1.Release{{Version: "4.0.2"}}
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionFalse, hivev1.UpgradeNotRequestedReason)
+				cond := assertCondition(t, cd, hivev1.ClusterUpgradeFailedCondition, corev1.ConditionTrue, hivev1.UpgradeVersionNotAvailableReason)
+				if cond != nil {
+					assert.Equal(t, `version 4.0.1 is not an available update of channel "stable-4.0"; available updates: 4.0.2`, cond.Message, "unexpected message")
+				}
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "forced upgrade to unavailable version",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion, Force: true}),
+				testKubeconfigSecret(),
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionTrue, hivev1.UpgradeRequestedReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Equal(t, &configv1.Update{Version: testUpgradeVersion, Force: true}, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade changes channel",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{Version: "4.1.0", Channel: "stable-4.1"}),
+				testKubeconfigSecret(),
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionTrue, hivev1.UpgradeRequestedReason)
+				assertCondition(t, cd, hivev1.ClusterUpgradeFailedCondition, corev1.ConditionFalse, hivev1.UpgradeNotFailingReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Equal(t, "stable-4.1", cv.Spec.Channel, "unexpected channel")
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade to ClusterImageSet",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{Name: "test-imageset"}}),
+				testKubeconfigSecret(),
+				&hivev1.ClusterImageSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-imageset"},
+					Spec:       hivev1.ClusterImageSetSpec{ReleaseImage: testUpgradeImage},
+				},
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionTrue, hivev1.UpgradeRequestedReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Equal(t, &configv1.Update{Image: testUpgradeImage}, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade to missing ClusterImageSet",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{Name: "test-imageset"}}),
+				testKubeconfigSecret(),
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assertCondition(t, cd, hivev1.ClusterUpgradeFailedCondition, corev1.ConditionTrue, hivev1.UpgradeClusterImageSetNotFoundReason)
+			},
+			validateRemote: func(t *testing.T, cv *configv1.ClusterVersion) {
+				assert.Nil(t, cv.Spec.DesiredUpdate, "unexpected desired update")
+			},
+		},
+		{
+			name: "upgrade in progress",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion}),
+				testKubeconfigSecret(),
+			},
+			configureRemote: func(cv *configv1.ClusterVersion) {
+				startUpgrade(cv)
+				cv.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+					Type:    configv1.OperatorProgressing,
+					Status:  configv1.ConditionTrue,
+					Message: "Working towards 4.0.1: 10% complete",
+				}}
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionTrue, hivev1.UpgradeInProgressReason)
+				if cond != nil {
+					assert.Equal(t, "Working towards 4.0.1: 10% complete", cond.Message, "unexpected message")
+				}
+				if assert.NotNil(t, cd.Status.Upgrade, "expected upgrade status") {
+					assert.Equal(t, testRemoteClusterCurrentVersion, cd.Status.Upgrade.CurrentVersion, "unexpected current version")
+					assert.Equal(t, testUpgradeVersion, cd.Status.Upgrade.DesiredVersion, "unexpected desired version")
+					assert.NotNil(t, cd.Status.Upgrade.StartedTime, "expected started time")
+					assert.Nil(t, cd.Status.Upgrade.CompletionTime, "unexpected completion time")
+				}
+			},
+		},
+		{
+			name: "upgrade failing",
+			existing: []runtime.Object{
+				testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion}),
+				testKubeconfigSecret(),
+			},
+			configureRemote: func(cv *configv1.ClusterVersion) {
+				startUpgrade(cv)
+				cv.Status.Conditions = []configv1.ClusterOperatorStatusCondition{{
+					Type:    clusterVersionFailing,
+					Status:  configv1.ConditionTrue,
+					Message: "Cluster operator kube-apiserver is degraded",
+				}}
+			},
+			expectedRequeueAfter: upgradeRequeueInterval,
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionTrue, hivev1.UpgradeInProgressReason)
+				cond := assertCondition(t, cd, hivev1.ClusterUpgradeFailedCondition, corev1.ConditionTrue, hivev1.UpgradeClusterVersionFailingReason)
+				if cond != nil {
+					assert.Equal(t, "Cluster operator kube-apiserver is degraded", cond.Message, "unexpected message")
+				}
+			},
+		},
+		{
+			name: "upgrade completed",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testUpgradeClusterDeployment(&hivev1.ClusterUpgrade{Version: testUpgradeVersion})
+					cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ClusterUpgradingCondition,
+						Status: corev1.ConditionTrue,
+						Reason: hivev1.UpgradeInProgressReason,
+					})
+					return cd
+				}(),
+				testKubeconfigSecret(),
+			},
+			configureRemote: func(cv *configv1.ClusterVersion) {
+				startUpgrade(cv)
+				completionTime := metav1.NewTime(time.Unix(3600, 0))
+				cv.Status.History[0].State = configv1.CompletedUpdate
+				cv.Status.History[0].CompletionTime = &completionTime
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond := assertCondition(t, cd, hivev1.ClusterUpgradingCondition, corev1.ConditionFalse, hivev1.UpgradeCompletedReason)
+				if cond != nil {
+					assert.Equal(t, "cluster is running version 4.0.1", cond.Message, "unexpected message")
+				}
+				if assert.NotNil(t, cd.Status.Upgrade, "expected upgrade status") {
+					assert.Equal(t, testUpgradeVersion, cd.Status.Upgrade.CurrentVersion, "unexpected current version")
+					assert.NotNil(t, cd.Status.Upgrade.CompletionTime, "expected completion time")
+				}
+			},
+		},
+		{
+			name: "upgrade removed",
+			existing: []runtime.Object{
+				func() *hivev1.ClusterDeployment {
+					cd := testClusterDeployment()
+					cd.Status.Upgrade = &hivev1.ClusterUpgradeStatus{CurrentVersion: testRemoteClusterCurrentVersion}
+					cd.Status.Conditions = append(cd.Status.Conditions, hivev1.ClusterDeploymentCondition{
+						Type:   hivev1.ClusterUpgradeFailedCondition,
+						Status: corev1.ConditionTrue,
+						Reason: hivev1.UpgradeVersionNotAvailableReason,
+					})
+					return cd
+				}(),
+				testKubeconfigSecret(),
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				assert.Nil(t, cd.Status.Upgrade, "unexpected upgrade status")
+				assertCondition(t, cd, hivev1.ClusterUpgradeFailedCondition, corev1.ConditionFalse, hivev1.UpgradeNotRequestedReason)
 			},
 		},
 	}
@@ -85,8 +304,9 @@ func TestClusterVersionReconcile(t *testing.T) {
 			fakeClient := fake.NewClientBuilder().WithRuntimeObjects(test.existing...).Build()
 			mockCtrl := gomock.NewController(t)
 			mockRemoteClientBuilder := remoteclientmock.NewMockBuilder(mockCtrl)
+			remoteClient := testRemoteClusterAPIClient(test.configureRemote)
 			if !test.noRemoteCall {
-				mockRemoteClientBuilder.EXPECT().Build().Return(testRemoteClusterAPIClient(), nil)
+				mockRemoteClientBuilder.EXPECT().Build().Return(remoteClient, nil)
 			}
 			rcd := &ReconcileClusterVersion{
 				Client:                        fakeClient,
@@ -99,7 +319,8 @@ func TestClusterVersionReconcile(t *testing.T) {
 				Namespace: testNamespace,
 			}
 
-			_, err := rcd.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName})
+			result, err := rcd.Reconcile(context.TODO(), reconcile.Request{NamespacedName: namespacedName})
+			assert.Equal(t, test.expectedRequeueAfter, result.RequeueAfter, "unexpected requeue after")
 
 			if test.validate != nil {
 				cd := &hivev1.ClusterDeployment{}
@@ -111,6 +332,15 @@ func TestClusterVersionReconcile(t *testing.T) {
 				test.validate(t, cd)
 			}
 
+			if test.validateRemote != nil {
+				cv := &configv1.ClusterVersion{}
+				if err := remoteClient.Get(context.TODO(), types.NamespacedName{Name: remoteClusterVersionObjectName}, cv); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				test.validateRemote(t, cv)
+			}
+
 			if err != nil && !test.expectError {
 				t.Errorf("Unexpected error: %v", err)
 			}
@@ -160,6 +390,12 @@ func testClusterDeployment() *hivev1.ClusterDeployment {
 	return cd
 }
 
+func testUpgradeClusterDeployment(upgrade *hivev1.ClusterUpgrade) *hivev1.ClusterDeployment {
+	cd := testClusterDeployment()
+	cd.Spec.Upgrade = upgrade
+	return cd
+}
+
 func testDeletedClusterDeployment() *hivev1.ClusterDeployment {
 	cd := testClusterDeployment()
 	now := metav1.Now()
@@ -184,13 +420,19 @@ func testSecret(name, key, value string) *corev1.Secret {
 	return s
 }
 
-func testRemoteClusterAPIClient() client.Client {
+func testRemoteClusterAPIClient(configure func(*configv1.ClusterVersion)) client.Client {
 	remoteClusterVersion := &configv1.ClusterVersion{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: remoteClusterVersionObjectName,
 		},
+		Spec: configv1.ClusterVersionSpec{
+			Channel: testChannel,
+		},
 	}
 	remoteClusterVersion.Status = *testRemoteClusterVersionStatus()
+	if configure != nil {
+		configure(remoteClusterVersion)
+	}
 
 	return fake.NewClientBuilder().WithRuntimeObjects(remoteClusterVersion).Build()
 }
@@ -213,3 +455,27 @@ func testRemoteClusterVersionStatus() *configv1.ClusterVersionStatus {
 		VersionHash:        "TESTVERSIONHASH",
 	}
 }
+
+// startUpgrade updates the status of the remote ClusterVersion as if the cluster had started upgrading to the test
+// upgrade version.
+func startUpgrade(cv *configv1.ClusterVersion) {
+	startedTime := metav1.NewTime(time.Unix(60, 0))
+	cv.Spec.DesiredUpdate = &configv1.Update{Version: testUpgradeVersion}
+	cv.Status.Desired = configv1.Release{Version: testUpgradeVersion, Image: testUpgradeImage}
+	cv.Status.History = append([]configv1.UpdateHistory{{
+		State:       configv1.PartialUpdate,
+		Version:     testUpgradeVersion,
+		Image:       testUpgradeImage,
+		StartedTime: startedTime,
+	}}, cv.Status.History...)
+}
+
+func assertCondition(t *testing.T, cd *hivev1.ClusterDeployment, condType hivev1.ClusterDeploymentConditionType, status corev1.ConditionStatus, reason string) *hivev1.ClusterDeploymentCondition {
+	cond := controllerutils.FindCondition(cd.Status.Conditions, condType)
+	if !assert.NotNil(t, cond, "missing %s condition", condType) {
+		return nil
+	}
+	assert.Equal(t, status, cond.Status, "unexpected %s condition status", condType)
+	assert.Equal(t, reason, cond.Reason, "unexpected %s condition reason", condType)
+	return cond
+}
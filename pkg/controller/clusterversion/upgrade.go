@@ -0,0 +1,319 @@
+package clusterversion
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	openshiftapiv1 "github.com/openshift/api/config/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivemetrics "github.com/openshift/hive/pkg/controller/metrics"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+const (
+	// upgradeRequeueInterval is how often the ClusterVersion of a cluster is checked while it is upgrading.
+	upgradeRequeueInterval = time.Minute
+
+	// clusterVersionFailing is the ClusterVersion condition reporting that the cluster is failing to apply its
+	// desired release.
+	clusterVersionFailing openshiftapiv1.ClusterStatusConditionType = "Failing"
+)
+
+var (
+	metricUpgradesRequested = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cluster_deployment_upgrades_requested_total",
+		Help: "Counter incremented every time Hive requests an upgrade of a cluster's ClusterVersion.",
+	}, []string{"cluster_type"})
+	metricUpgradeFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hive_cluster_deployment_upgrade_failures_total",
+		Help: "Counter incremented every time the upgrade of a cluster starts failing.",
+	}, []string{"cluster_type", "reason"})
+	metricUpgradeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "hive_cluster_deployment_upgrade_duration_seconds",
+		Help:    "Length of time for a cluster to complete an upgrade requested by Hive.",
+		Buckets: []float64{600, 1200, 1800, 2700, 3600, 5400, 7200, 10800},
+	}, []string{"cluster_type"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricUpgradesRequested)
+	metrics.Registry.MustRegister(metricUpgradeFailures)
+	metrics.Registry.MustRegister(metricUpgradeDuration)
+}
+
+// upgradeFailure is a problem with the upgrade requested in a ClusterDeployment that is reported in the
+// ClusterUpgradeFailed condition rather than retried.
+type upgradeFailure struct {
+	reason  string
+	message string
+}
+
+// reconcileUpgrade requests the upgrade in the ClusterDeployment's Spec.Upgrade from the remote cluster's
+// ClusterVersion, and reports the progress of the upgrade on the ClusterDeployment.
+func (r *ReconcileClusterVersion) reconcileUpgrade(cd *hivev1.ClusterDeployment, remoteClient client.Client, clusterVersion *openshiftapiv1.ClusterVersion, cdLog log.FieldLogger) (reconcile.Result, error) {
+	if cd.Spec.Upgrade == nil {
+		return reconcile.Result{}, r.clearUpgradeStatus(cd, cdLog)
+	}
+	wasUpgrading := isConditionTrue(cd.Status.Conditions, hivev1.ClusterUpgradingCondition)
+	wasFailing := isConditionTrue(cd.Status.Conditions, hivev1.ClusterUpgradeFailedCondition)
+
+	desiredUpdate, failure, err := r.desiredUpdate(cd, clusterVersion)
+	if err != nil {
+		cdLog.WithError(err).Error("error determining the desired update")
+		return reconcile.Result{}, err
+	}
+	if err := requestUpgrade(cd, remoteClient, clusterVersion, desiredUpdate, cdLog); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	completed := upgradeCompleted(clusterVersion, desiredUpdate)
+	if failure == nil {
+		if cond := findClusterVersionCondition(clusterVersion, clusterVersionFailing); cond != nil && cond.Status == openshiftapiv1.ConditionTrue {
+			failure = &upgradeFailure{reason: hivev1.UpgradeClusterVersionFailingReason, message: cond.Message}
+		}
+	}
+
+	conditions, upgradingChanged := setUpgradingCondition(cd, clusterVersion, desiredUpdate, failure, completed)
+	conditions, failedChanged := setUpgradeFailedCondition(conditions, failure)
+	status := upgradeStatus(clusterVersion, desiredUpdate)
+	if !upgradingChanged && !failedChanged && reflect.DeepEqual(status, cd.Status.Upgrade) {
+		return upgradeResult(completed), nil
+	}
+
+	cd.Status.Conditions = conditions
+	cd.Status.Upgrade = status
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating cluster deployment upgrade status")
+		return reconcile.Result{}, err
+	}
+
+	clusterType := hivemetrics.GetLabelValue(cd, hivev1.HiveClusterTypeLabel)
+	if failure != nil && !wasFailing {
+		cdLog.WithField("reason", failure.reason).WithField("message", failure.message).Warn("upgrade is failing")
+		metricUpgradeFailures.WithLabelValues(clusterType, failure.reason).Inc()
+	}
+	if completed && wasUpgrading {
+		cdLog.WithField("version", status.DesiredVersion).Info("upgrade completed")
+		if status.StartedTime != nil && status.CompletionTime != nil {
+			metricUpgradeDuration.WithLabelValues(clusterType).Observe(status.CompletionTime.Sub(status.StartedTime.Time).Seconds())
+		}
+	}
+	return upgradeResult(completed), nil
+}
+
+// desiredUpdate returns the update of the remote ClusterVersion for the upgrade in the ClusterDeployment, or the
+// reason that the upgrade cannot be requested. No update is returned for a version while the cluster is being moved
+// to a new channel, as the available updates of the new channel are not yet known.
+func (r *ReconcileClusterVersion) desiredUpdate(cd *hivev1.ClusterDeployment, clusterVersion *openshiftapiv1.ClusterVersion) (*openshiftapiv1.Update, *upgradeFailure, error) {
+	upgrade := cd.Spec.Upgrade
+	if ref := upgrade.ImageSetRef; ref != nil {
+		imageSet := &hivev1.ClusterImageSet{}
+		if err := r.Get(context.TODO(), types.NamespacedName{Name: ref.Name}, imageSet); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, &upgradeFailure{
+					reason:  hivev1.UpgradeClusterImageSetNotFoundReason,
+					message: fmt.Sprintf("ClusterImageSet %s does not exist", ref.Name),
+				}, nil
+			}
+			return nil, nil, err
+		}
+		return &openshiftapiv1.Update{Image: imageSet.Spec.ReleaseImage, Force: upgrade.Force}, nil, nil
+	}
+
+	if upgrade.Force || clusterVersion.Status.Desired.Version == upgrade.Version {
+		return &openshiftapiv1.Update{Version: upgrade.Version, Force: upgrade.Force}, nil, nil
+	}
+	if upgrade.Channel != "" && upgrade.Channel != clusterVersion.Spec.Channel {
+		return nil, nil, nil
+	}
+	available := make([]string, len(clusterVersion.Status.AvailableUpdates))
+	for i, release := range clusterVersion.Status.AvailableUpdates {
+		if release.Version == upgrade.Version {
+			return &openshiftapiv1.Update{Version: upgrade.Version}, nil, nil
+		}
+		available[i] = release.Version
+	}
+	message := fmt.Sprintf("version %s is not an available update of channel %q", upgrade.Version, clusterVersion.Spec.Channel)
+	if len(available) > 0 {
+		message = fmt.Sprintf("%s; available updates: %s", message, strings.Join(available, ", "))
+	}
+	return nil, &upgradeFailure{reason: hivev1.UpgradeVersionNotAvailableReason, message: message}, nil
+}
+
+// requestUpgrade updates the desired update, if any, and channel of the remote ClusterVersion if they do not match
+// the upgrade in the ClusterDeployment.
+func requestUpgrade(cd *hivev1.ClusterDeployment, remoteClient client.Client, clusterVersion *openshiftapiv1.ClusterVersion, desiredUpdate *openshiftapiv1.Update, cdLog log.FieldLogger) error {
+	updateChanged := desiredUpdate != nil && !updateMatches(clusterVersion.Spec.DesiredUpdate, desiredUpdate)
+	channelChanged := cd.Spec.Upgrade.Channel != "" && cd.Spec.Upgrade.Channel != clusterVersion.Spec.Channel
+	if !updateChanged && !channelChanged {
+		return nil
+	}
+	if updateChanged {
+		if old := clusterVersion.Spec.DesiredUpdate; old != nil {
+			desiredUpdate.Architecture = old.Architecture
+		}
+		clusterVersion.Spec.DesiredUpdate = desiredUpdate
+	}
+	if channelChanged {
+		clusterVersion.Spec.Channel = cd.Spec.Upgrade.Channel
+	}
+	if err := remoteClient.Update(context.TODO(), clusterVersion); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error updating remote clusterversion object")
+		return err
+	}
+	cdLog.WithField("channel", clusterVersion.Spec.Channel).Info("requested upgrade of remote cluster")
+	if updateChanged {
+		metricUpgradesRequested.WithLabelValues(hivemetrics.GetLabelValue(cd, hivev1.HiveClusterTypeLabel)).Inc()
+	}
+	return nil
+}
+
+// updateMatches returns true if the update requested in a ClusterVersion is the desired update.
+func updateMatches(requested, desired *openshiftapiv1.Update) bool {
+	return requested != nil &&
+		requested.Version == desired.Version &&
+		requested.Image == desired.Image &&
+		requested.Force == desired.Force
+}
+
+// releaseMatches returns true if the release is the one requested by the desired update.
+func releaseMatches(version, image string, desired *openshiftapiv1.Update) bool {
+	if desired.Image != "" {
+		return image == desired.Image
+	}
+	return version == desired.Version
+}
+
+// upgradeCompleted returns true if the cluster has completed updating to the desired update.
+func upgradeCompleted(clusterVersion *openshiftapiv1.ClusterVersion, desiredUpdate *openshiftapiv1.Update) bool {
+	if desiredUpdate == nil || len(clusterVersion.Status.History) == 0 {
+		return false
+	}
+	latest := clusterVersion.Status.History[0]
+	return latest.State == openshiftapiv1.CompletedUpdate && releaseMatches(latest.Version, latest.Image, desiredUpdate)
+}
+
+func setUpgradingCondition(cd *hivev1.ClusterDeployment, clusterVersion *openshiftapiv1.ClusterVersion, desiredUpdate *openshiftapiv1.Update, failure *upgradeFailure, completed bool) ([]hivev1.ClusterDeploymentCondition, bool) {
+	status, reason, message := corev1.ConditionTrue, hivev1.UpgradeRequestedReason, "waiting for the cluster to start the upgrade"
+	switch {
+	case desiredUpdate == nil && failure != nil:
+		status, reason, message = corev1.ConditionFalse, hivev1.UpgradeNotRequestedReason, "the upgrade cannot be requested"
+	case desiredUpdate == nil:
+		message = fmt.Sprintf("waiting for the cluster to retrieve the available updates of channel %q", clusterVersion.Spec.Channel)
+	case completed:
+		status, reason, message = corev1.ConditionFalse, hivev1.UpgradeCompletedReason,
+			fmt.Sprintf("cluster is running version %s", clusterVersion.Status.History[0].Version)
+	case releaseMatches(clusterVersion.Status.Desired.Version, clusterVersion.Status.Desired.Image, desiredUpdate):
+		reason, message = hivev1.UpgradeInProgressReason, fmt.Sprintf("cluster is upgrading to version %s", clusterVersion.Status.Desired.Version)
+		if cond := findClusterVersionCondition(clusterVersion, openshiftapiv1.OperatorProgressing); cond != nil && cond.Message != "" {
+			message = cond.Message
+		}
+	}
+	return controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		cd.Status.Conditions,
+		hivev1.ClusterUpgradingCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+func setUpgradeFailedCondition(conditions []hivev1.ClusterDeploymentCondition, failure *upgradeFailure) ([]hivev1.ClusterDeploymentCondition, bool) {
+	status, reason, message := corev1.ConditionFalse, hivev1.UpgradeNotFailingReason, "upgrade is not failing"
+	if failure != nil {
+		status, reason, message = corev1.ConditionTrue, failure.reason, failure.message
+	}
+	return controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+		conditions,
+		hivev1.ClusterUpgradeFailedCondition,
+		status,
+		reason,
+		message,
+		controllerutils.UpdateConditionIfReasonOrMessageChange,
+	)
+}
+
+// upgradeStatus returns the upgrade status of the cluster as reported by its ClusterVersion.
+func upgradeStatus(clusterVersion *openshiftapiv1.ClusterVersion, desiredUpdate *openshiftapiv1.Update) *hivev1.ClusterUpgradeStatus {
+	status := &hivev1.ClusterUpgradeStatus{
+		DesiredVersion: clusterVersion.Status.Desired.Version,
+		DesiredImage:   clusterVersion.Status.Desired.Image,
+		Channel:        clusterVersion.Spec.Channel,
+	}
+	for i, entry := range clusterVersion.Status.History {
+		if i == 0 && desiredUpdate != nil && releaseMatches(entry.Version, entry.Image, desiredUpdate) {
+			status.StartedTime = entry.StartedTime.DeepCopy()
+			status.CompletionTime = entry.CompletionTime.DeepCopy()
+		}
+		if entry.State == openshiftapiv1.CompletedUpdate {
+			status.CurrentVersion = entry.Version
+			break
+		}
+	}
+	return status
+}
+
+// clearUpgradeStatus removes the upgrade status and marks the upgrade conditions as not requested when the
+// ClusterDeployment no longer requests an upgrade.
+func (r *ReconcileClusterVersion) clearUpgradeStatus(cd *hivev1.ClusterDeployment, cdLog log.FieldLogger) error {
+	changed := cd.Status.Upgrade != nil
+	cd.Status.Upgrade = nil
+	for _, condType := range []hivev1.ClusterDeploymentConditionType{hivev1.ClusterUpgradingCondition, hivev1.ClusterUpgradeFailedCondition} {
+		if controllerutils.FindCondition(cd.Status.Conditions, condType) == nil {
+			continue
+		}
+		var condChanged bool
+		cd.Status.Conditions, condChanged = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
+			cd.Status.Conditions,
+			condType,
+			corev1.ConditionFalse,
+			hivev1.UpgradeNotRequestedReason,
+			"no upgrade is requested",
+			controllerutils.UpdateConditionIfReasonOrMessageChange,
+		)
+		changed = changed || condChanged
+	}
+	if !changed {
+		return nil
+	}
+	if err := r.Status().Update(context.TODO(), cd); err != nil {
+		cdLog.WithError(err).Log(controllerutils.LogLevel(err), "error clearing cluster deployment upgrade status")
+		return err
+	}
+	return nil
+}
+
+func upgradeResult(completed bool) reconcile.Result {
+	if completed {
+		return reconcile.Result{}
+	}
+	return reconcile.Result{RequeueAfter: upgradeRequeueInterval}
+}
+
+func findClusterVersionCondition(clusterVersion *openshiftapiv1.ClusterVersion, condType openshiftapiv1.ClusterStatusConditionType) *openshiftapiv1.ClusterOperatorStatusCondition {
+	for i, cond := range clusterVersion.Status.Conditions {
+		if cond.Type == condType {
+			return &clusterVersion.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+func isConditionTrue(conditions []hivev1.ClusterDeploymentCondition, condType hivev1.ClusterDeploymentConditionType) bool {
+	cond := controllerutils.FindCondition(conditions, condType)
+	return cond != nil && cond.Status == corev1.ConditionTrue
+}
@@ -13,6 +13,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -163,6 +164,7 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
 			cdLog.Info("cluster deployment Not Found")
+			clearHibernationScheduleMetric(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
@@ -176,9 +178,11 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, nil
 	}
 
-	// If cluster is already deleted, skip any processing
-	if !cd.DeletionTimestamp.IsZero() {
+	// If cluster is already deleted, skip any processing. Clusters waiting out their deprovision grace period are
+	// still hibernated.
+	if !cd.DeletionTimestamp.IsZero() && !controllerutils.IsDeprovisionPending(cd) {
 		msg := "ClusterDeployment has been marked for deletion"
+		clearHibernationScheduleMetric(cd.Namespace, cd.Name)
 		return r.setStatusStatesUnknown(cd, hivev1.HibernatingReasonClusterDeploymentDeleted, hivev1.ReadyReasonClusterDeploymentDeleted, msg, cdLog)
 	}
 
@@ -245,6 +249,41 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		return reconcile.Result{}, r.updateClusterDeploymentStatus(cd, cdLog)
 	}
 
+	// Apply the HibernationSchedule, if any. This may change Spec.PowerState, in which case the update will
+	// trigger another reconcile. Clusters waiting out their deprovision grace period stay hibernated regardless.
+	if cd.Spec.HibernationSchedule != nil && !isUnclaimedPoolCluster(cd) && !controllerutils.IsDeprovisionPending(cd) {
+		nextTransition, specUpdated, err := r.applyHibernationSchedule(cd, cdLog)
+		if err != nil || specUpdated {
+			return reconcile.Result{}, err
+		}
+		if nextTransition != nil {
+			// Make sure we come back around when the schedule next wants to change the power state, even if
+			// nothing else needs us to.
+			defer func() {
+				if returnErr != nil || (result.Requeue && result.RequeueAfter <= 0) {
+					return
+				}
+				requeueAfter := time.Until(*nextTransition)
+				if result.RequeueAfter <= 0 || requeueAfter < result.RequeueAfter {
+					cdLog.Debugf("cluster will reconcile due to hibernation schedule in: %v", requeueAfter)
+					result.RequeueAfter = requeueAfter
+				}
+			}()
+		}
+	} else {
+		clearHibernationScheduleMetric(cd.Namespace, cd.Name)
+		changed := r.setHibernationScheduleValid(cd, cdLog)
+		if cd.Status.HibernationSchedule != nil {
+			cd.Status.HibernationSchedule = nil
+			changed = true
+		}
+		if changed {
+			if err := r.updateClusterDeploymentStatus(cd, cdLog); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
 	shouldHibernate := cd.Spec.PowerState == hivev1.ClusterPowerStateHibernating
 	// set readyToHibernate if hibernate after is ready to kick in hibernation
 	var readyToHibernate bool
@@ -257,13 +296,13 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 		// - The last time the cluster resumed (status.conditions[Hibernating].lastTransitionTime if not hibernating (but see TODO))
 		// BUT pool clusters wait until they're claimed for HibernateAfter to have effect.
 		poolRef := cd.Spec.ClusterPoolRef
-		isUnclaimedPoolCluster := poolRef != nil && poolRef.PoolName != "" &&
+		isUnclaimed := isUnclaimedPoolCluster(cd) || (poolRef != nil && poolRef.PoolName != "" &&
 			// Upgrade note: If we hit this code path on a CD that was claimed before upgrading to
 			// where we introduced ClaimedTimestamp, then that CD was Hibernating when it was claimed
 			// (because that's the same time we introduced ClusterPool.RunningCount) so it's safe to
 			// just use installed/last-resumed as the baseline for hibernateAfter.
-			(poolRef.ClaimName == "" || poolRef.ClaimedTimestamp == nil)
-		if !isUnclaimedPoolCluster {
+			poolRef.ClaimedTimestamp == nil)
+		if !isUnclaimed {
 			hibernateAfterDur := cd.Spec.HibernateAfter.Duration
 			hibLog := cdLog.WithField("hibernateAfter", hibernateAfterDur)
 
@@ -386,6 +425,115 @@ func (r *hibernationReconciler) Reconcile(ctx context.Context, request reconcile
 	return r.checkClusterRunning(cd, syncSetsApplied, cdLog, readyCondition)
 }
 
+// isUnclaimedPoolCluster returns true if the ClusterDeployment belongs to a ClusterPool and has not been assigned
+// to a ClusterClaim. The clusterpool controller manages the power state of such clusters.
+func isUnclaimedPoolCluster(cd *hivev1.ClusterDeployment) bool {
+	poolRef := cd.Spec.ClusterPoolRef
+	return poolRef != nil && poolRef.PoolName != "" && poolRef.ClaimName == ""
+}
+
+// applyHibernationSchedule sets Spec.PowerState according to the ClusterDeployment's HibernationSchedule if a
+// schedule boundary has passed since we last applied it, and records the upcoming transition in status. It
+// returns the time of the next transition (nil if there is none) and whether the spec was updated.
+func (r *hibernationReconciler) applyHibernationSchedule(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (*time.Time, bool, error) {
+	schedule := cd.Spec.HibernationSchedule
+	loc, err := controllerutils.LoadScheduleLocation(schedule.TimeZone)
+	if err != nil {
+		err = fmt.Errorf("invalid time zone %q: %w", schedule.TimeZone, err)
+		return nil, false, r.setHibernationScheduleInvalid(cd, err, logger)
+	}
+	state, err := controllerutils.EvaluateScheduleWindows(schedule.RunWindows, loc, time.Now())
+	if err != nil {
+		return nil, false, r.setHibernationScheduleInvalid(cd, err, logger)
+	}
+
+	desiredPowerState, nextPowerState := hivev1.ClusterPowerStateHibernating, hivev1.ClusterPowerStateRunning
+	if state.Active {
+		desiredPowerState, nextPowerState = hivev1.ClusterPowerStateRunning, hivev1.ClusterPowerStateHibernating
+	}
+	logger = logger.WithFields(log.Fields{
+		"scheduledPowerState": desiredPowerState,
+		"lastTransition":      state.LastTransition,
+		"nextTransition":      state.NextTransition,
+	})
+
+	oldStatus := cd.Status.HibernationSchedule
+	newStatus := &hivev1.HibernationScheduleStatus{}
+	if oldStatus != nil {
+		newStatus.LastTransitionTime = oldStatus.LastTransitionTime
+	}
+	if !state.NextTransition.IsZero() {
+		newStatus.NextTransitionTime = &metav1.Time{Time: state.NextTransition}
+		newStatus.NextPowerState = nextPowerState
+	}
+
+	// Only act if a boundary has passed since we last did. This allows PowerState to be overridden manually
+	// between boundaries.
+	specUpdated := false
+	if newStatus.LastTransitionTime == nil || newStatus.LastTransitionTime.Time.Before(state.LastTransition) {
+		if cd.Spec.PowerState != desiredPowerState &&
+			// An empty PowerState means Running
+			!(cd.Spec.PowerState == "" && desiredPowerState == hivev1.ClusterPowerStateRunning) {
+			logger.Info("setting power state according to hibernation schedule")
+			cd.Spec.PowerState = desiredPowerState
+			if err := r.Update(context.TODO(), cd); err != nil {
+				logger.WithError(err).Log(controllerutils.LogLevel(err), "error applying hibernation schedule")
+				return nil, false, err
+			}
+			specUpdated = true
+		}
+		if state.LastTransition.IsZero() {
+			// The windows cover all of time; record when we first applied them.
+			now := metav1.Now().Rfc3339Copy()
+			newStatus.LastTransitionTime = &now
+		} else {
+			newStatus.LastTransitionTime = &metav1.Time{Time: state.LastTransition}
+		}
+	}
+
+	setHibernationScheduleMetric(cd, newStatus)
+	conditionChanged := r.setHibernationScheduleValid(cd, logger)
+	if conditionChanged || !equality.Semantic.DeepEqual(oldStatus, newStatus) {
+		cd.Status.HibernationSchedule = newStatus
+		if err := r.updateClusterDeploymentStatus(cd, logger); err != nil {
+			return nil, specUpdated, err
+		}
+	}
+	if newStatus.NextTransitionTime == nil {
+		return nil, specUpdated, nil
+	}
+	return &newStatus.NextTransitionTime.Time, specUpdated, nil
+}
+
+// setHibernationScheduleInvalid reports a HibernationSchedule that cannot be evaluated. Since the schedule will
+// not act, any previously reported schedule status and metric are cleared.
+func (r *hibernationReconciler) setHibernationScheduleInvalid(cd *hivev1.ClusterDeployment, scheduleErr error, logger log.FieldLogger) error {
+	// Admission should prevent this, but e.g. a time zone may have been removed from the tz database.
+	logger.WithError(scheduleErr).Error("invalid hibernation schedule")
+	clearHibernationScheduleMetric(cd.Namespace, cd.Name)
+	changed := r.setCDCondition(cd, hivev1.HibernationScheduleInvalidCondition, hivev1.HibernationScheduleReasonInvalid,
+		scheduleErr.Error(), corev1.ConditionTrue, logger)
+	if cd.Status.HibernationSchedule != nil {
+		cd.Status.HibernationSchedule = nil
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return r.updateClusterDeploymentStatus(cd, logger)
+}
+
+// setHibernationScheduleValid clears the HibernationScheduleInvalid condition, if present. It returns true if the
+// condition changed.
+func (r *hibernationReconciler) setHibernationScheduleValid(cd *hivev1.ClusterDeployment, logger log.FieldLogger) bool {
+	cond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.HibernationScheduleInvalidCondition)
+	if cond == nil || cond.Status == corev1.ConditionFalse {
+		return false
+	}
+	return r.setCDCondition(cd, hivev1.HibernationScheduleInvalidCondition, hivev1.HibernationScheduleReasonValid,
+		"Hibernation schedule is valid or unset", corev1.ConditionFalse, logger)
+}
+
 func (r *hibernationReconciler) startMachines(cd *hivev1.ClusterDeployment, logger log.FieldLogger) (reconcile.Result, error) {
 	actuator := r.getActuator(cd)
 	if actuator == nil {
@@ -683,6 +831,9 @@ func logCumulativeMetric(metric *prometheus.HistogramVec, cd *hivev1.ClusterDepl
 
 func (r *hibernationReconciler) setCDCondition(cd *hivev1.ClusterDeployment, cond hivev1.ClusterDeploymentConditionType,
 	reason, message string, status corev1.ConditionStatus, logger log.FieldLogger) bool {
+	if cond == hivev1.ClusterHibernatingCondition && status != corev1.ConditionTrue {
+		recordHibernatedDuration(cd)
+	}
 	changed := false
 	cd.Status.Conditions, changed = controllerutils.SetClusterDeploymentConditionWithChangeCheck(
 		cd.Status.Conditions,
@@ -698,6 +849,21 @@ func (r *hibernationReconciler) setCDCondition(cd *hivev1.ClusterDeployment, con
 	return changed
 }
 
+// recordHibernatedDuration adds the time since the cluster started hibernating to its total hibernated duration if the
+// Hibernating condition is true, and is called before that condition is changed to any other status.
+func recordHibernatedDuration(cd *hivev1.ClusterDeployment) {
+	hibernatingCondition := controllerutils.FindCondition(cd.Status.Conditions, hivev1.ClusterHibernatingCondition)
+	if hibernatingCondition == nil || hibernatingCondition.Status != corev1.ConditionTrue ||
+		hibernatingCondition.LastTransitionTime.IsZero() {
+		return
+	}
+	hibernated := time.Since(hibernatingCondition.LastTransitionTime.Time)
+	if cd.Status.HibernatedDuration != nil {
+		hibernated += cd.Status.HibernatedDuration.Duration
+	}
+	cd.Status.HibernatedDuration = &metav1.Duration{Duration: hibernated}
+}
+
 func (r *hibernationReconciler) updateClusterDeploymentStatus(cd *hivev1.ClusterDeployment, logger log.FieldLogger) error {
 	err := r.Status().Update(context.TODO(), cd)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ import (
 	hiveintv1alpha1 "github.com/openshift/hive/apis/hiveinternal/v1alpha1"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/hibernation/mock"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/remoteclient"
 	remoteclientmock "github.com/openshift/hive/pkg/remoteclient/mock"
 	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
@@ -120,6 +122,40 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.ClusterPowerStateUnknown, string(cd.Status.PowerState))
 			},
 		},
+		{
+			name: "cluster deleted, deprovision pending, start hibernating",
+			cd: cdBuilder.GenericOptions(testgeneric.Deleted(), testgeneric.WithFinalizer(finalizer)).Options(o.shouldHibernate,
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ProvisionedCondition,
+					Status: corev1.ConditionFalse,
+					Reason: hivev1.ProvisionedReasonDeprovisionPending,
+				})).Build(),
+			cs: csBuilder.Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StopMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond, _ := getHibernatingAndRunningConditions(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.HibernatingReasonStopping, cond.Reason)
+				assert.Equal(t, hivev1.ClusterPowerStateStopping, cd.Status.PowerState)
+			},
+		},
+		{
+			name: "cluster deleted, deprovisioning",
+			cd: cdBuilder.GenericOptions(testgeneric.Deleted(), testgeneric.WithFinalizer(finalizer)).Options(o.shouldHibernate,
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ProvisionedCondition,
+					Status: corev1.ConditionFalse,
+					Reason: hivev1.ProvisionedReasonDeprovisioning,
+				})).Build(),
+			cs: csBuilder.Build(),
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond, _ := getHibernatingAndRunningConditions(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, hivev1.HibernatingReasonClusterDeploymentDeleted, cond.Reason)
+			},
+		},
 		{
 			name: "hibernation and running condition initialized",
 			cd:   cdBuilder.Options(o.notInstalled, o.shouldHibernate).Build(),
@@ -590,6 +626,25 @@ func TestReconcile(t *testing.T) {
 				assert.Equal(t, hivev1.ClusterPowerStateStartingMachines, cd.Status.PowerState)
 			},
 		},
+		{
+			name: "start resuming adds to hibernated duration",
+			cd: cdBuilder.Options(o.shouldRun).Build(
+				testcd.WithCondition(hibernatingCondition(corev1.ConditionTrue, hivev1.HibernatingReasonHibernating, 2*time.Hour)),
+				func(cd *hivev1.ClusterDeployment) {
+					cd.Status.HibernatedDuration = &metav1.Duration{Duration: 3 * time.Hour}
+				}),
+			cs: csBuilder.Build(),
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().StartMachines(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+			},
+			validate: func(t *testing.T, cd *hivev1.ClusterDeployment) {
+				cond, _ := getHibernatingAndRunningConditions(cd)
+				require.NotNil(t, cond)
+				assert.Equal(t, corev1.ConditionFalse, cond.Status)
+				require.NotNil(t, cd.Status.HibernatedDuration)
+				assert.InDelta(t, (5 * time.Hour).Seconds(), cd.Status.HibernatedDuration.Seconds(), time.Minute.Seconds())
+			},
+		},
 		{
 			name: "resuming machines failed to start",
 			cd:   cdBuilder.Options(o.hibernating).Build(),
@@ -1271,6 +1326,236 @@ func TestHibernateAfter(t *testing.T) {
 	}
 }
 
+func TestHibernationSchedule(t *testing.T) {
+	logger := log.New()
+	logger.SetLevel(log.DebugLevel)
+
+	scheme := runtime.NewScheme()
+	corev1.AddToScheme(scheme)
+	hivev1.AddToScheme(scheme)
+	hiveintv1alpha1.AddToScheme(scheme)
+
+	cdBuilder := testcd.FullBuilder(namespace, cdName, scheme).Options(
+		testcd.Installed(),
+		testcd.WithClusterVersion("4.4.9"),
+		testcd.InstalledTimestamp(time.Now().Add(-48*time.Hour)),
+	)
+	o := clusterDeploymentOptions{}
+	csBuilder := testcs.FullBuilder(namespace, cdName, scheme).Options(
+		testcs.WithFirstSuccessTime(time.Now().Add(-10 * time.Hour)),
+	)
+
+	// Windows are expressed relative to the current time, in UTC, at minute granularity.
+	now := time.Now().UTC()
+	clock := func(t time.Time) string { return t.Format("15:04") }
+	openedAt := now.Add(-1 * time.Hour).Truncate(time.Minute)
+	closesAt := now.Add(1 * time.Hour).Truncate(time.Minute)
+	opensAt := now.Add(2 * time.Hour).Truncate(time.Minute)
+	openWindow := hivev1.ScheduleWindow{Start: clock(openedAt), End: clock(closesAt)}
+	closedWindow := hivev1.ScheduleWindow{Start: clock(opensAt), End: clock(now.Add(3 * time.Hour))}
+	alwaysOpenWindow := hivev1.ScheduleWindow{Start: "00:00", End: "00:00"}
+	staleStatus := &hivev1.HibernationScheduleStatus{
+		LastTransitionTime: &metav1.Time{Time: openedAt},
+		NextTransitionTime: &metav1.Time{Time: closesAt},
+		NextPowerState:     hivev1.ClusterPowerStateHibernating,
+	}
+
+	tests := []struct {
+		name          string
+		setupActuator func(actuator *mock.MockHibernationActuator)
+		cd            *hivev1.ClusterDeployment
+
+		expectedPowerState     hivev1.ClusterPowerState
+		expectScheduleStatus   bool
+		expectedNextPowerState hivev1.ClusterPowerState
+		expectedNextTransition time.Time
+		expectRequeueAfter     time.Duration
+		expectInvalidCondition bool
+	}{
+		{
+			name: "window opened, resume hibernating cluster",
+			cd: cdBuilder.Build(
+				o.shouldHibernate, o.hibernating,
+				testcd.WithHibernationSchedule("", openWindow)),
+			expectedPowerState:     hivev1.ClusterPowerStateRunning,
+			expectScheduleStatus:   true,
+			expectedNextPowerState: hivev1.ClusterPowerStateHibernating,
+			expectedNextTransition: closesAt,
+		},
+		{
+			name: "window closed, hibernate running cluster",
+			cd: cdBuilder.Build(
+				testcd.WithCondition(readyCondition(corev1.ConditionTrue, hivev1.ReadyReasonRunning, 6*time.Hour)),
+				testcd.WithHibernationSchedule("", closedWindow)),
+			expectedPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectScheduleStatus:   true,
+			expectedNextPowerState: hivev1.ClusterPowerStateRunning,
+			expectedNextTransition: opensAt,
+		},
+		{
+			name: "window covers all time, resume hibernating cluster",
+			cd: cdBuilder.Build(
+				o.shouldHibernate, o.hibernating,
+				testcd.WithHibernationSchedule("", alwaysOpenWindow)),
+			expectedPowerState:   hivev1.ClusterPowerStateRunning,
+			expectScheduleStatus: true,
+		},
+		{
+			name: "window already applied, manual override respected",
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil, nil)
+			},
+			cd: cdBuilder.Build(
+				o.shouldHibernate, o.hibernating,
+				testcd.WithHibernationSchedule("", openWindow),
+				testcd.WithHibernationScheduleStatus(&hivev1.HibernationScheduleStatus{
+					LastTransitionTime: &metav1.Time{Time: openedAt},
+				})),
+			expectedPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectScheduleStatus:   true,
+			expectedNextPowerState: hivev1.ClusterPowerStateHibernating,
+			expectedNextTransition: closesAt,
+			expectRequeueAfter:     time.Until(closesAt),
+		},
+		{
+			name: "schedule removed, status cleared",
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil, nil)
+			},
+			cd: cdBuilder.Build(
+				o.shouldHibernate, o.hibernating,
+				testcd.WithHibernationScheduleStatus(staleStatus)),
+			expectedPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "invalid time zone, status cleared",
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil, nil)
+			},
+			cd: cdBuilder.Build(
+				o.shouldHibernate, o.hibernating,
+				testcd.WithHibernationSchedule("Mars/Olympus_Mons", openWindow),
+				testcd.WithHibernationScheduleStatus(staleStatus)),
+			expectedPowerState:     hivev1.ClusterPowerStateHibernating,
+			expectInvalidCondition: true,
+		},
+		{
+			name: "schedule fixed, invalid condition cleared",
+			cd: cdBuilder.Build(
+				o.shouldHibernate, o.hibernating,
+				testcd.WithHibernationSchedule("", openWindow),
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.HibernationScheduleInvalidCondition,
+					Status: corev1.ConditionTrue,
+					Reason: hivev1.HibernationScheduleReasonInvalid,
+				})),
+			expectedPowerState:     hivev1.ClusterPowerStateRunning,
+			expectScheduleStatus:   true,
+			expectedNextPowerState: hivev1.ClusterPowerStateHibernating,
+			expectedNextTransition: closesAt,
+		},
+		{
+			name: "unclaimed pool cluster ignores schedule",
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil, nil)
+			},
+			cd: cdBuilder.Build(
+				o.shouldHibernate, o.hibernating,
+				testcd.WithClusterPoolReference(namespace, "pool", ""),
+				testcd.WithHibernationSchedule("", openWindow)),
+			expectedPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+		{
+			name: "deprovision pending ignores schedule",
+			setupActuator: func(actuator *mock.MockHibernationActuator) {
+				actuator.EXPECT().MachinesStopped(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(true, nil, nil)
+			},
+			cd: cdBuilder.Build(
+				testcd.Generic(testgeneric.Deleted()), testcd.Generic(testgeneric.WithFinalizer(finalizer)),
+				o.shouldHibernate, o.hibernating,
+				testcd.WithCondition(hivev1.ClusterDeploymentCondition{
+					Type:   hivev1.ProvisionedCondition,
+					Status: corev1.ConditionFalse,
+					Reason: hivev1.ProvisionedReasonDeprovisionPending,
+				}),
+				testcd.WithHibernationSchedule("", openWindow)),
+			expectedPowerState: hivev1.ClusterPowerStateHibernating,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockActuator := mock.NewMockHibernationActuator(ctrl)
+			mockActuator.EXPECT().CanHandle(gomock.Any()).AnyTimes().Return(true)
+			if test.setupActuator != nil {
+				test.setupActuator(mockActuator)
+			}
+			actuators = []HibernationActuator{mockActuator}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.cd, csBuilder.Build()).Build()
+			setHibernationScheduleMetric(test.cd, test.cd.Status.HibernationSchedule)
+			defer clearHibernationScheduleMetric(namespace, cdName)
+
+			reconciler := hibernationReconciler{
+				Client: c,
+				logger: log.WithField("controller", "hibernation"),
+				remoteClientBuilder: func(cd *hivev1.ClusterDeployment) remoteclient.Builder {
+					return remoteclientmock.NewMockBuilder(ctrl)
+				},
+				csrUtil: mock.NewMockcsrHelper(ctrl),
+			}
+			result, err := reconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: namespace, Name: cdName},
+			})
+			require.NoError(t, err, "unexpected error from reconcile")
+
+			if test.expectRequeueAfter == 0 {
+				assert.Zero(t, result.RequeueAfter)
+			} else {
+				assert.InDelta(t, test.expectRequeueAfter.Seconds(), result.RequeueAfter.Seconds(), 10, "unexpected requeue after")
+			}
+
+			cd := &hivev1.ClusterDeployment{}
+			err = c.Get(context.TODO(), client.ObjectKey{Namespace: namespace, Name: cdName}, cd)
+			require.NoError(t, err, "error looking up ClusterDeployment")
+			assert.Equal(t, test.expectedPowerState, cd.Spec.PowerState, "unexpected PowerState")
+
+			invalidCond := controllerutils.FindCondition(cd.Status.Conditions, hivev1.HibernationScheduleInvalidCondition)
+			if test.expectInvalidCondition {
+				if assert.NotNil(t, invalidCond, "expected HibernationScheduleInvalid condition") {
+					assert.Equal(t, corev1.ConditionTrue, invalidCond.Status, "unexpected HibernationScheduleInvalid status")
+				}
+			} else if invalidCond != nil {
+				assert.Equal(t, corev1.ConditionFalse, invalidCond.Status, "unexpected HibernationScheduleInvalid status")
+			}
+
+			expectedMetrics := 0
+			if test.expectedNextPowerState != "" {
+				expectedMetrics = 1
+			}
+			assert.Equal(t, expectedMetrics, testutil.CollectAndCount(metricHibernationScheduleNextTransition),
+				"unexpected number of next transition metrics")
+
+			if !test.expectScheduleStatus {
+				assert.Nil(t, cd.Status.HibernationSchedule, "expected no hibernation schedule status")
+				return
+			}
+			if assert.NotNil(t, cd.Status.HibernationSchedule, "expected hibernation schedule status") {
+				assert.NotNil(t, cd.Status.HibernationSchedule.LastTransitionTime, "expected last transition time")
+				if test.expectedNextPowerState == "" {
+					assert.Nil(t, cd.Status.HibernationSchedule.NextTransitionTime, "expected no next transition time")
+					return
+				}
+				assert.Equal(t, test.expectedNextPowerState, cd.Status.HibernationSchedule.NextPowerState, "unexpected next power state")
+				if assert.NotNil(t, cd.Status.HibernationSchedule.NextTransitionTime, "expected next transition time") {
+					assert.True(t, test.expectedNextTransition.Equal(cd.Status.HibernationSchedule.NextTransitionTime.Time),
+						"unexpected next transition time %v", cd.Status.HibernationSchedule.NextTransitionTime)
+				}
+			}
+		})
+	}
+}
+
 func hibernatingCondition(status corev1.ConditionStatus, reason string, lastTransitionAgo time.Duration) hivev1.ClusterDeploymentCondition {
 	return hivev1.ClusterDeploymentCondition{
 		Type:               hivev1.ClusterHibernatingCondition,
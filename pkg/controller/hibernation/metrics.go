@@ -0,0 +1,38 @@
+package hibernation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+var (
+	// metricHibernationScheduleNextTransition reports, for each ClusterDeployment with a HibernationSchedule, the
+	// unix time at which the schedule will next change its power state.
+	metricHibernationScheduleNextTransition = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "hive_cluster_deployment_hibernation_schedule_next_transition_timestamp_seconds",
+		Help: "Unix time at which the ClusterDeployment's hibernation schedule will next transition it to the given power state.",
+	}, []string{"cluster_deployment", "namespace", "power_state"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(metricHibernationScheduleNextTransition)
+}
+
+func setHibernationScheduleMetric(cd *hivev1.ClusterDeployment, status *hivev1.HibernationScheduleStatus) {
+	clearHibernationScheduleMetric(cd.Namespace, cd.Name)
+	if status == nil || status.NextTransitionTime == nil {
+		return
+	}
+	metricHibernationScheduleNextTransition.WithLabelValues(cd.Name, cd.Namespace, string(status.NextPowerState)).
+		Set(float64(status.NextTransitionTime.Unix()))
+}
+
+func clearHibernationScheduleMetric(namespace, name string) {
+	metricHibernationScheduleNextTransition.DeletePartialMatch(prometheus.Labels{
+		"cluster_deployment": name,
+		"namespace":          namespace,
+	})
+}
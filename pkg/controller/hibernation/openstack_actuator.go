@@ -0,0 +1,183 @@
+package hibernation
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/openstackclient"
+)
+
+var (
+	// OpenStack servers keep their status (e.g. ACTIVE) while a power operation is in progress, so the task
+	// state is used in place of the status when there is one. See openstackServerState.
+	openstackRunningStates           = sets.NewString("ACTIVE")
+	openstackStoppedStates           = sets.NewString("SHUTOFF")
+	openstackPendingStates           = sets.NewString("BUILD", "REBOOT", "HARD_REBOOT", "powering-on", "rebooting", "reboot_started")
+	openstackStoppingStates          = sets.NewString("powering-off")
+	openstackRunningOrPendingStates  = openstackRunningStates.Union(openstackPendingStates)
+	openstackStoppedOrStoppingStates = openstackStoppedStates.Union(openstackStoppingStates)
+	openstackNotRunningStates        = openstackStoppedOrStoppingStates.Union(openstackPendingStates)
+	openstackNotStoppedStates        = openstackRunningOrPendingStates.Union(openstackStoppingStates)
+)
+
+func init() {
+	RegisterActuator(&openstackActuator{openstackClientFn: getOpenStackClient})
+}
+
+type openstackActuator struct {
+	// openstackClientFn is the function to build an OpenStack client, here for testing
+	openstackClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *openstackActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.OpenStack != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *openstackActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "openstack")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	servers, err := getOpenStackClusterServers(cd, openstackClient, openstackRunningStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		logger.Info("No servers were found to stop")
+		return nil
+	}
+	for _, server := range servers {
+		logger.WithField("server", server.Name).Info("Stopping server")
+		if err := openstackClient.StopServer(context.TODO(), server.ID); err != nil {
+			logger.WithError(err).WithField("server", server.Name).Error("failed to stop server")
+			return err
+		}
+	}
+	return nil
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *openstackActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "openstack")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	servers, err := getOpenStackClusterServers(cd, openstackClient, openstackStoppedStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(servers) == 0 {
+		logger.Info("No servers were found to start")
+		return nil
+	}
+	for _, server := range servers {
+		logger.WithField("server", server.Name).Info("Starting server")
+		if err := openstackClient.StartServer(context.TODO(), server.ID); err != nil {
+			logger.WithError(err).WithField("server", server.Name).Error("failed to start server")
+			return err
+		}
+	}
+	return nil
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *openstackActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "openstack")
+	logger.Infof("checking whether machines are running")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	servers, err := getOpenStackClusterServers(cd, openstackClient, openstackNotRunningStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(servers) == 0, openstackServerNames(servers), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *openstackActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "openstack")
+	logger.Infof("checking whether machines are stopped")
+	openstackClient, err := a.openstackClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	servers, err := getOpenStackClusterServers(cd, openstackClient, openstackNotStoppedStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(servers) == 0, openstackServerNames(servers), nil
+}
+
+func getOpenStackClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (openstackclient.API, error) {
+	if cd.Spec.Platform.OpenStack == nil {
+		return nil, errors.New("OpenStack platform is not set in ClusterDeployment")
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.OpenStack.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch OpenStack credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch OpenStack credentials secret")
+	}
+	buf := &bytes.Buffer{}
+	if ref := cd.Spec.Platform.OpenStack.CertificatesSecretRef; ref != nil && ref.Name != "" {
+		if err := controllerutils.TrustBundleFromSecretToWriter(c, cd.Namespace, ref.Name, buf); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to load trust bundle from CertificatesSecretRef")
+			return nil, errors.Wrap(err, "failed to load trust bundle from CertificatesSecretRef")
+		}
+	}
+	return openstackclient.NewClientFromSecret(secret, cd.Spec.Platform.OpenStack.Cloud, buf.Bytes())
+}
+
+func getOpenStackClusterServers(cd *hivev1.ClusterDeployment, c openstackclient.API, states sets.String, logger log.FieldLogger) ([]openstackclient.Server, error) {
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	logger = logger.WithField("infraID", infraID)
+	logger.Debug("listing cluster servers")
+	servers, err := c.ListServers(context.TODO(), infraID)
+	if err != nil {
+		logger.WithError(err).Error("failed to list servers")
+		return nil, err
+	}
+	var result []openstackclient.Server
+	for _, server := range servers {
+		if states.Has(openstackServerState(server)) {
+			result = append(result, server)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing servers")
+	return result, nil
+}
+
+// openstackServerState returns the server's task state if it has one, and its status otherwise.
+func openstackServerState(server openstackclient.Server) string {
+	if server.TaskState != "" {
+		return server.TaskState
+	}
+	return server.Status
+}
+
+func openstackServerNames(servers []openstackclient.Server) []string {
+	names := make([]string, len(servers))
+	for i, server := range servers {
+		names[i] = server.Name
+	}
+	return names
+}
@@ -0,0 +1,239 @@
+package hibernation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	"github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	"github.com/openshift/hive/pkg/openstackclient"
+	mockopenstackclient "github.com/openshift/hive/pkg/openstackclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestOpenStackCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.OpenStack = &hivev1openstack.Platform{}
+	}).Build()
+	actuator := openstackActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestOpenStackStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name          string
+		testFunc      string
+		servers       map[string]int
+		expectedCalls []string
+		setupClient   func(*testing.T, *mockopenstackclient.MockAPI)
+		expectErr     bool
+	}{
+		{
+			name:     "stop no running servers",
+			testFunc: "StopMachines",
+			servers:  map[string]int{"SHUTOFF": 2, "powering-off": 2, "BUILD": 1},
+		},
+		{
+			name:          "stop running servers",
+			testFunc:      "StopMachines",
+			servers:       map[string]int{"SHUTOFF": 5, "ACTIVE": 2, "powering-off": 1},
+			expectedCalls: []string{"ACTIVE-0", "ACTIVE-1"},
+		},
+		{
+			name:     "start no stopped servers",
+			testFunc: "StartMachines",
+			servers:  map[string]int{"BUILD": 4, "ACTIVE": 3, "powering-on": 1},
+		},
+		{
+			name:          "start stopped servers",
+			testFunc:      "StartMachines",
+			servers:       map[string]int{"SHUTOFF": 3, "ACTIVE": 4},
+			expectedCalls: []string{"SHUTOFF-0", "SHUTOFF-1", "SHUTOFF-2"},
+		},
+		{
+			name:     "unable to list servers",
+			testFunc: "StopMachines",
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockAPI) {
+				c.EXPECT().ListServers(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("cannot list servers"))
+			},
+			expectErr: true,
+		},
+		{
+			name:     "unable to stop server",
+			testFunc: "StopMachines",
+			setupClient: func(t *testing.T, c *mockopenstackclient.MockAPI) {
+				setupOpenStackClientServers(c, map[string]int{"ACTIVE": 1})
+				c.EXPECT().StopServer(gomock.Any(), "ACTIVE-0").Times(1).Return(errors.New("cannot stop server"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openstackClient := mockopenstackclient.NewMockAPI(ctrl)
+			if test.servers != nil {
+				setupOpenStackClientServers(openstackClient, test.servers)
+			}
+			if test.setupClient != nil {
+				test.setupClient(t, openstackClient)
+			}
+			for _, id := range test.expectedCalls {
+				switch test.testFunc {
+				case "StopMachines":
+					openstackClient.EXPECT().StopServer(gomock.Any(), id).Times(1).Return(nil)
+				case "StartMachines":
+					openstackClient.EXPECT().StartServer(gomock.Any(), id).Times(1).Return(nil)
+				}
+			}
+			actuator := testOpenStackActuator(openstackClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testOpenStackClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testOpenStackClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			if test.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenStackMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name              string
+		testFunc          string
+		expectedRemaining []string
+		expectedResult    bool
+		servers           map[string]int
+	}{
+		{
+			name:           "Stopped - All machines stopped",
+			testFunc:       "MachinesStopped",
+			expectedResult: true,
+			servers:        map[string]int{"SHUTOFF": 3},
+		},
+		{
+			name:              "Stopped - Some machines powering off",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"powering-off-0", "powering-off-1"},
+			servers:           map[string]int{"SHUTOFF": 3, "powering-off": 2},
+		},
+		{
+			name:              "Stopped - machines running",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"ACTIVE-0", "ACTIVE-1", "ACTIVE-2"},
+			servers:           map[string]int{"ACTIVE": 3, "SHUTOFF": 2},
+		},
+		{
+			name:           "Running - All machines running",
+			testFunc:       "MachinesRunning",
+			expectedResult: true,
+			servers:        map[string]int{"ACTIVE": 3},
+		},
+		{
+			name:              "Running - Some machines powering on",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"powering-on-0"},
+			servers:           map[string]int{"ACTIVE": 3, "powering-on": 1},
+		},
+		{
+			name:              "Running - Some machines stopped or building",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"SHUTOFF-0", "SHUTOFF-1", "BUILD-0"},
+			servers:           map[string]int{"ACTIVE": 3, "SHUTOFF": 2, "BUILD": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			openstackClient := mockopenstackclient.NewMockAPI(ctrl)
+			setupOpenStackClientServers(openstackClient, test.servers)
+			actuator := testOpenStackActuator(openstackClient)
+			var err error
+			var result bool
+			var remaining []string
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, remaining, err = actuator.MachinesStopped(testOpenStackClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, remaining, err = actuator.MachinesRunning(testOpenStackClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			if len(test.expectedRemaining) > 0 {
+				sort.Strings(test.expectedRemaining)
+				sort.Strings(remaining)
+				assert.Equal(t, test.expectedRemaining, remaining)
+			}
+		})
+	}
+}
+
+func testOpenStackActuator(openstackClient openstackclient.API) *openstackActuator {
+	return &openstackActuator{
+		openstackClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (openstackclient.API, error) {
+			return openstackClient, nil
+		},
+	}
+}
+
+// setupOpenStackClientServers expects a call to list servers, returning servers in the given states. A state is
+// either a server status (e.g. ACTIVE) or a task state (e.g. powering-off).
+func setupOpenStackClientServers(openstackClient *mockopenstackclient.MockAPI, states map[string]int) {
+	taskStatuses := map[string]string{
+		"powering-off": "ACTIVE",
+		"powering-on":  "SHUTOFF",
+	}
+	servers := []openstackclient.Server{}
+	for state, count := range states {
+		for i := 0; i < count; i++ {
+			server := openstackclient.Server{
+				ID:     fmt.Sprintf("%s-%d", state, i),
+				Name:   fmt.Sprintf("%s-%d", state, i),
+				Status: state,
+			}
+			if status, ok := taskStatuses[state]; ok {
+				server.Status = status
+				server.TaskState = state
+			}
+			servers = append(servers, server)
+		}
+	}
+	openstackClient.EXPECT().ListServers(gomock.Any(), "testopenstackcluster-foobarbaz").Times(1).Return(servers, nil)
+}
+
+func testOpenStackClusterDeployment() *hivev1.ClusterDeployment {
+	cdBuilder := testcd.FullBuilder("testns", "testopenstackcluster", scheme.Scheme)
+	return cdBuilder.Build(
+		testcd.WithOpenStackPlatform(&hivev1openstack.Platform{Cloud: "openstack"}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "testopenstackcluster-foobarbaz"}),
+	)
+}
@@ -0,0 +1,189 @@
+package hibernation
+
+import (
+	"bytes"
+	"context"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/ovirtclient"
+)
+
+var (
+	ovirtRunningStates           = sets.NewString(string(ovirtsdk.VMSTATUS_UP))
+	ovirtStoppedStates           = sets.NewString(string(ovirtsdk.VMSTATUS_DOWN))
+	ovirtPendingStates           = sets.NewString(string(ovirtsdk.VMSTATUS_WAIT_FOR_LAUNCH), string(ovirtsdk.VMSTATUS_POWERING_UP), string(ovirtsdk.VMSTATUS_REBOOT_IN_PROGRESS))
+	ovirtStoppingStates          = sets.NewString(string(ovirtsdk.VMSTATUS_POWERING_DOWN))
+	ovirtRunningOrPendingStates  = ovirtRunningStates.Union(ovirtPendingStates)
+	ovirtStoppedOrStoppingStates = ovirtStoppedStates.Union(ovirtStoppingStates)
+	ovirtNotRunningStates        = ovirtStoppedOrStoppingStates.Union(ovirtPendingStates)
+	ovirtNotStoppedStates        = ovirtRunningOrPendingStates.Union(ovirtStoppingStates)
+)
+
+func init() {
+	RegisterActuator(&ovirtActuator{ovirtClientFn: getOvirtClient})
+}
+
+type ovirtActuator struct {
+	// ovirtClientFn is the function to build an oVirt client, here for testing
+	ovirtClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (ovirtclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *ovirtActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.Ovirt != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *ovirtActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "ovirt")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer ovirtClose(ovirtClient, logger)
+
+	vms, err := getOvirtClusterVMs(cd, ovirtClient, ovirtRunningStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to stop")
+		return nil
+	}
+	for _, vm := range vms {
+		logger.WithField("vm", vm.MustName()).Info("Shutting down VM")
+		if err := ovirtClient.ShutdownVM(vm.MustId()); err != nil {
+			logger.WithError(err).WithField("vm", vm.MustName()).Error("failed to shut down VM")
+			return err
+		}
+	}
+	return nil
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *ovirtActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "ovirt")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer ovirtClose(ovirtClient, logger)
+
+	vms, err := getOvirtClusterVMs(cd, ovirtClient, ovirtStoppedStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to start")
+		return nil
+	}
+	for _, vm := range vms {
+		logger.WithField("vm", vm.MustName()).Info("Starting VM")
+		if err := ovirtClient.StartVM(vm.MustId()); err != nil {
+			logger.WithError(err).WithField("vm", vm.MustName()).Error("failed to start VM")
+			return err
+		}
+	}
+	return nil
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *ovirtActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "ovirt")
+	logger.Infof("checking whether machines are running")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer ovirtClose(ovirtClient, logger)
+
+	vms, err := getOvirtClusterVMs(cd, ovirtClient, ovirtNotRunningStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, ovirtVMNames(vms), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *ovirtActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "ovirt")
+	logger.Infof("checking whether machines are stopped")
+	ovirtClient, err := a.ovirtClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer ovirtClose(ovirtClient, logger)
+
+	vms, err := getOvirtClusterVMs(cd, ovirtClient, ovirtNotStoppedStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, ovirtVMNames(vms), nil
+}
+
+func getOvirtClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (ovirtclient.API, error) {
+	if cd.Spec.Platform.Ovirt == nil {
+		return nil, errors.New("oVirt platform is not set in ClusterDeployment")
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.Ovirt.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch oVirt credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch oVirt credentials secret")
+	}
+	buf := &bytes.Buffer{}
+	if name := cd.Spec.Platform.Ovirt.CertificatesSecretRef.Name; name != "" {
+		if err := controllerutils.TrustBundleFromSecretToWriter(c, cd.Namespace, name, buf); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to load trust bundle from CertificatesSecretRef")
+			return nil, errors.Wrap(err, "failed to load trust bundle from CertificatesSecretRef")
+		}
+	}
+	return ovirtclient.NewClientFromSecret(secret, buf.Bytes())
+}
+
+func ovirtClose(c ovirtclient.API, logger log.FieldLogger) {
+	if err := c.Close(); err != nil {
+		logger.WithError(err).Warn("failed to close oVirt connection")
+	}
+}
+
+func getOvirtClusterVMs(cd *hivev1.ClusterDeployment, c ovirtclient.API, states sets.String, logger log.FieldLogger) ([]*ovirtsdk.Vm, error) {
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	logger = logger.WithField("infraID", infraID)
+	logger.Debug("listing cluster VMs")
+	vms, err := c.ListVMs(infraID)
+	if err != nil {
+		logger.WithError(err).Error("failed to list VMs")
+		return nil, err
+	}
+	var result []*ovirtsdk.Vm
+	for _, vm := range vms {
+		status, _ := vm.Status()
+		if states.Has(string(status)) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing VMs")
+	return result, nil
+}
+
+func ovirtVMNames(vms []*ovirtsdk.Vm) []string {
+	names := make([]string, len(vms))
+	for i, vm := range vms {
+		names[i] = vm.MustName()
+	}
+	return names
+}
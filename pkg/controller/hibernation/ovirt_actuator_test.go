@@ -0,0 +1,232 @@
+package hibernation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	"github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	"github.com/openshift/hive/pkg/ovirtclient"
+	mockovirtclient "github.com/openshift/hive/pkg/ovirtclient/mock"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+)
+
+func TestOvirtCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.Ovirt = &hivev1ovirt.Platform{}
+	}).Build()
+	actuator := ovirtActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestOvirtStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name          string
+		testFunc      string
+		vms           map[string]int
+		expectedCalls []string
+		setupClient   func(*testing.T, *mockovirtclient.MockAPI)
+		expectErr     bool
+	}{
+		{
+			name:     "stop no running VMs",
+			testFunc: "StopMachines",
+			vms:      map[string]int{"down": 2, "powering_down": 2, "wait_for_launch": 1},
+		},
+		{
+			name:          "stop running VMs",
+			testFunc:      "StopMachines",
+			vms:           map[string]int{"down": 5, "up": 2, "powering_down": 1},
+			expectedCalls: []string{"up-0", "up-1"},
+		},
+		{
+			name:     "start no stopped VMs",
+			testFunc: "StartMachines",
+			vms:      map[string]int{"wait_for_launch": 4, "up": 3, "powering_up": 1},
+		},
+		{
+			name:          "start stopped VMs",
+			testFunc:      "StartMachines",
+			vms:           map[string]int{"down": 3, "up": 4},
+			expectedCalls: []string{"down-0", "down-1", "down-2"},
+		},
+		{
+			name:     "unable to list VMs",
+			testFunc: "StopMachines",
+			setupClient: func(t *testing.T, c *mockovirtclient.MockAPI) {
+				c.EXPECT().ListVMs(gomock.Any()).Times(1).Return(nil, errors.New("cannot list VMs"))
+			},
+			expectErr: true,
+		},
+		{
+			name:     "unable to shut down VM",
+			testFunc: "StopMachines",
+			setupClient: func(t *testing.T, c *mockovirtclient.MockAPI) {
+				setupOvirtClientVMs(c, map[string]int{"up": 1})
+				c.EXPECT().ShutdownVM("up-0").Times(1).Return(errors.New("cannot shut down VM"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ovirtClient := mockovirtclient.NewMockAPI(ctrl)
+			ovirtClient.EXPECT().Close().AnyTimes().Return(nil)
+			if test.vms != nil {
+				setupOvirtClientVMs(ovirtClient, test.vms)
+			}
+			if test.setupClient != nil {
+				test.setupClient(t, ovirtClient)
+			}
+			for _, id := range test.expectedCalls {
+				switch test.testFunc {
+				case "StopMachines":
+					ovirtClient.EXPECT().ShutdownVM(id).Times(1).Return(nil)
+				case "StartMachines":
+					ovirtClient.EXPECT().StartVM(id).Times(1).Return(nil)
+				}
+			}
+			actuator := testOvirtActuator(ovirtClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testOvirtClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testOvirtClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			if test.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestOvirtMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name              string
+		testFunc          string
+		expectedRemaining []string
+		expectedResult    bool
+		vms               map[string]int
+	}{
+		{
+			name:           "Stopped - All machines stopped",
+			testFunc:       "MachinesStopped",
+			expectedResult: true,
+			vms:            map[string]int{"down": 3},
+		},
+		{
+			name:              "Stopped - Some machines powering off",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"powering_down-0", "powering_down-1"},
+			vms:               map[string]int{"down": 3, "powering_down": 2},
+		},
+		{
+			name:              "Stopped - machines running",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"up-0", "up-1", "up-2"},
+			vms:               map[string]int{"up": 3, "down": 2},
+		},
+		{
+			name:           "Running - All machines running",
+			testFunc:       "MachinesRunning",
+			expectedResult: true,
+			vms:            map[string]int{"up": 3},
+		},
+		{
+			name:              "Running - Some machines powering on",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"powering_up-0"},
+			vms:               map[string]int{"up": 3, "powering_up": 1},
+		},
+		{
+			name:              "Running - Some machines stopped or waiting for launch",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"down-0", "down-1", "wait_for_launch-0"},
+			vms:               map[string]int{"up": 3, "down": 2, "wait_for_launch": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ovirtClient := mockovirtclient.NewMockAPI(ctrl)
+			ovirtClient.EXPECT().Close().AnyTimes().Return(nil)
+			setupOvirtClientVMs(ovirtClient, test.vms)
+			actuator := testOvirtActuator(ovirtClient)
+			var err error
+			var result bool
+			var remaining []string
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, remaining, err = actuator.MachinesStopped(testOvirtClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, remaining, err = actuator.MachinesRunning(testOvirtClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			if len(test.expectedRemaining) > 0 {
+				sort.Strings(test.expectedRemaining)
+				sort.Strings(remaining)
+				assert.Equal(t, test.expectedRemaining, remaining)
+			}
+		})
+	}
+}
+
+func testOvirtActuator(ovirtClient ovirtclient.API) *ovirtActuator {
+	return &ovirtActuator{
+		ovirtClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (ovirtclient.API, error) {
+			return ovirtClient, nil
+		},
+	}
+}
+
+// setupOvirtClientVMs expects a call to list VMs, returning VMs with the given statuses.
+func setupOvirtClientVMs(ovirtClient *mockovirtclient.MockAPI, statuses map[string]int) {
+	vms := []*ovirtsdk.Vm{}
+	for status, count := range statuses {
+		for i := 0; i < count; i++ {
+			vms = append(vms, ovirtsdk.NewVmBuilder().
+				Id(fmt.Sprintf("%s-%d", status, i)).
+				Name(fmt.Sprintf("%s-%d", status, i)).
+				Status(ovirtsdk.VmStatus(status)).
+				MustBuild())
+		}
+	}
+	ovirtClient.EXPECT().ListVMs("testovirtcluster-foobarbaz").Times(1).Return(vms, nil)
+}
+
+func testOvirtClusterDeployment() *hivev1.ClusterDeployment {
+	cdBuilder := testcd.FullBuilder("testns", "testovirtcluster", scheme.Scheme)
+	return cdBuilder.Build(
+		testcd.WithOvirtPlatform(&hivev1ovirt.Platform{ClusterID: "testovirtclusterid"}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "testovirtcluster-foobarbaz"}),
+	)
+}
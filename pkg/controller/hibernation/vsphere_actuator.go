@@ -0,0 +1,208 @@
+package hibernation
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+	"github.com/openshift/hive/pkg/vsphereclient"
+)
+
+const (
+	vsphereGuestStateShuttingDown = "shuttingDown"
+	vsphereGuestStateResetting    = "resetting"
+)
+
+var (
+	// vSphere VMs remain poweredOn while the guest OS is shutting down, so the guest state is used in place of
+	// the power state during those transitions. See vsphereVMState.
+	vsphereRunningStates           = sets.NewString(string(types.VirtualMachinePowerStatePoweredOn))
+	vsphereStoppedStates           = sets.NewString(string(types.VirtualMachinePowerStatePoweredOff), string(types.VirtualMachinePowerStateSuspended))
+	vspherePendingStates           = sets.NewString(vsphereGuestStateResetting)
+	vsphereStoppingStates          = sets.NewString(vsphereGuestStateShuttingDown)
+	vsphereRunningOrPendingStates  = vsphereRunningStates.Union(vspherePendingStates)
+	vsphereStoppedOrStoppingStates = vsphereStoppedStates.Union(vsphereStoppingStates)
+	vsphereNotRunningStates        = vsphereStoppedOrStoppingStates.Union(vspherePendingStates)
+	vsphereNotStoppedStates        = vsphereRunningOrPendingStates.Union(vsphereStoppingStates)
+)
+
+func init() {
+	RegisterActuator(&vsphereActuator{vsphereClientFn: getVSphereClient})
+}
+
+type vsphereActuator struct {
+	// vsphereClientFn is the function to build a vSphere client, here for testing
+	vsphereClientFn func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.API, error)
+}
+
+// CanHandle returns true if the actuator can handle a particular ClusterDeployment
+func (a *vsphereActuator) CanHandle(cd *hivev1.ClusterDeployment) bool {
+	return cd.Spec.Platform.VSphere != nil
+}
+
+// StopMachines will stop machines belonging to the given ClusterDeployment
+func (a *vsphereActuator) StopMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vsphere")
+	vsphereClient, err := a.vsphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+
+	vms, err := getVSphereClusterVMs(cd, vsphereClient, vsphereRunningStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to stop")
+		return nil
+	}
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Shutting down VM")
+		if err := vsphereClient.ShutdownVirtualMachine(context.TODO(), vm); err != nil {
+			logger.WithError(err).WithField("vm", vm.Name).Error("failed to shut down VM")
+			return err
+		}
+	}
+	return nil
+}
+
+// StartMachines will start machines belonging to the given ClusterDeployment
+func (a *vsphereActuator) StartMachines(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) error {
+	logger = logger.WithField("cloud", "vsphere")
+	vsphereClient, err := a.vsphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+
+	vms, err := getVSphereClusterVMs(cd, vsphereClient, vsphereStoppedStates, logger)
+	if err != nil {
+		return err
+	}
+	if len(vms) == 0 {
+		logger.Info("No VMs were found to start")
+		return nil
+	}
+	for _, vm := range vms {
+		logger.WithField("vm", vm.Name).Info("Powering on VM")
+		if err := vsphereClient.PowerOnVirtualMachine(context.TODO(), vm); err != nil {
+			logger.WithError(err).WithField("vm", vm.Name).Error("failed to power on VM")
+			return err
+		}
+	}
+	return nil
+}
+
+// MachinesRunning will return true if the machines associated with the given
+// ClusterDeployment are in a running state. It also returns a list of machines that
+// are not running.
+func (a *vsphereActuator) MachinesRunning(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "vsphere")
+	logger.Infof("checking whether machines are running")
+	vsphereClient, err := a.vsphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+
+	vms, err := getVSphereClusterVMs(cd, vsphereClient, vsphereNotRunningStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, vsphereVMNames(vms), nil
+}
+
+// MachinesStopped will return true if the machines associated with the given
+// ClusterDeployment are in a stopped state. It also returns a list of machines
+// that have not stopped.
+func (a *vsphereActuator) MachinesStopped(cd *hivev1.ClusterDeployment, hiveClient client.Client, logger log.FieldLogger) (bool, []string, error) {
+	logger = logger.WithField("cloud", "vsphere")
+	logger.Infof("checking whether machines are stopped")
+	vsphereClient, err := a.vsphereClientFn(cd, hiveClient, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	defer vsphereLogout(vsphereClient, logger)
+
+	vms, err := getVSphereClusterVMs(cd, vsphereClient, vsphereNotStoppedStates, logger)
+	if err != nil {
+		return false, nil, err
+	}
+	return len(vms) == 0, vsphereVMNames(vms), nil
+}
+
+func getVSphereClient(cd *hivev1.ClusterDeployment, c client.Client, logger log.FieldLogger) (vsphereclient.API, error) {
+	if cd.Spec.Platform.VSphere == nil {
+		return nil, errors.New("vSphere platform is not set in ClusterDeployment")
+	}
+	secret := &corev1.Secret{}
+	err := c.Get(context.TODO(), client.ObjectKey{Name: cd.Spec.Platform.VSphere.CredentialsSecretRef.Name, Namespace: cd.Namespace}, secret)
+	if err != nil {
+		logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to fetch vSphere credentials secret")
+		return nil, errors.Wrap(err, "failed to fetch vSphere credentials secret")
+	}
+	buf := &bytes.Buffer{}
+	if name := cd.Spec.Platform.VSphere.CertificatesSecretRef.Name; name != "" {
+		if err := controllerutils.TrustBundleFromSecretToWriter(c, cd.Namespace, name, buf); err != nil {
+			logger.WithError(err).Log(controllerutils.LogLevel(err), "failed to load trust bundle from CertificatesSecretRef")
+			return nil, errors.Wrap(err, "failed to load trust bundle from CertificatesSecretRef")
+		}
+	}
+	return vsphereclient.NewClientFromSecret(context.TODO(), secret, cd.Spec.Platform.VSphere.VCenter, buf.Bytes())
+}
+
+func vsphereLogout(c vsphereclient.API, logger log.FieldLogger) {
+	if err := c.Logout(context.TODO()); err != nil {
+		logger.WithError(err).Warn("failed to log out of vCenter")
+	}
+}
+
+func getVSphereClusterVMs(cd *hivev1.ClusterDeployment, c vsphereclient.API, states sets.String, logger log.FieldLogger) ([]mo.VirtualMachine, error) {
+	infraID := cd.Spec.ClusterMetadata.InfraID
+	logger = logger.WithField("infraID", infraID)
+	logger.Debug("listing cluster VMs")
+	vms, err := c.ListVirtualMachines(context.TODO(), infraID)
+	if err != nil {
+		logger.WithError(err).Error("failed to list VMs")
+		return nil, err
+	}
+	var result []mo.VirtualMachine
+	for _, vm := range vms {
+		if states.Has(vsphereVMState(vm)) {
+			result = append(result, vm)
+		}
+	}
+	logger.WithField("count", len(result)).WithField("states", states).Debug("result of listing VMs")
+	return result, nil
+}
+
+// vsphereVMState returns the VM's guest state if it is powered on and the guest is shutting down or resetting,
+// and its power state otherwise.
+func vsphereVMState(vm mo.VirtualMachine) string {
+	if vm.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn && vm.Guest != nil {
+		switch vm.Guest.GuestState {
+		case vsphereGuestStateShuttingDown, vsphereGuestStateResetting:
+			return vm.Guest.GuestState
+		}
+	}
+	return string(vm.Runtime.PowerState)
+}
+
+func vsphereVMNames(vms []mo.VirtualMachine) []string {
+	names := make([]string, len(vms))
+	for i, vm := range vms {
+		names[i] = vm.Name
+	}
+	return names
+}
@@ -0,0 +1,256 @@
+package hibernation
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
+	"github.com/openshift/hive/pkg/client/clientset/versioned/scheme"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	"github.com/openshift/hive/pkg/vsphereclient"
+	mockvsphereclient "github.com/openshift/hive/pkg/vsphereclient/mock"
+)
+
+func TestVSphereCanHandle(t *testing.T) {
+	cd := testcd.BasicBuilder().Options(func(cd *hivev1.ClusterDeployment) {
+		cd.Spec.Platform.VSphere = &hivev1vsphere.Platform{}
+	}).Build()
+	actuator := vsphereActuator{}
+	assert.True(t, actuator.CanHandle(cd))
+
+	cd = testcd.BasicBuilder().Build()
+	assert.False(t, actuator.CanHandle(cd))
+}
+
+func TestVSphereStopAndStartMachines(t *testing.T) {
+	tests := []struct {
+		name          string
+		testFunc      string
+		vms           map[string]int
+		expectedCalls []string
+		setupClient   func(*testing.T, *mockvsphereclient.MockAPI)
+		expectErr     bool
+	}{
+		{
+			name:     "stop no running VMs",
+			testFunc: "StopMachines",
+			vms:      map[string]int{"poweredOff": 2, "shuttingDown": 2, "resetting": 1},
+		},
+		{
+			name:          "stop running VMs",
+			testFunc:      "StopMachines",
+			vms:           map[string]int{"poweredOff": 5, "poweredOn": 2, "shuttingDown": 1},
+			expectedCalls: []string{"poweredOn-0", "poweredOn-1"},
+		},
+		{
+			name:     "start no stopped VMs",
+			testFunc: "StartMachines",
+			vms:      map[string]int{"shuttingDown": 4, "poweredOn": 3, "resetting": 1},
+		},
+		{
+			name:          "start stopped and suspended VMs",
+			testFunc:      "StartMachines",
+			vms:           map[string]int{"poweredOff": 3, "suspended": 1, "poweredOn": 4},
+			expectedCalls: []string{"poweredOff-0", "poweredOff-1", "poweredOff-2", "suspended-0"},
+		},
+		{
+			name:     "unable to list VMs",
+			testFunc: "StopMachines",
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockAPI) {
+				c.EXPECT().ListVirtualMachines(gomock.Any(), gomock.Any()).Times(1).Return(nil, errors.New("cannot list VMs"))
+			},
+			expectErr: true,
+		},
+		{
+			name:     "unable to shut down VM",
+			testFunc: "StopMachines",
+			setupClient: func(t *testing.T, c *mockvsphereclient.MockAPI) {
+				setupVSphereClientVMs(c, map[string]int{"poweredOn": 1})
+				c.EXPECT().ShutdownVirtualMachine(gomock.Any(), vsphereVMNamed("poweredOn-0")).Times(1).Return(errors.New("cannot shut down VM"))
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vsphereClient := mockvsphereclient.NewMockAPI(ctrl)
+			vsphereClient.EXPECT().Logout(gomock.Any()).AnyTimes().Return(nil)
+			if test.vms != nil {
+				setupVSphereClientVMs(vsphereClient, test.vms)
+			}
+			if test.setupClient != nil {
+				test.setupClient(t, vsphereClient)
+			}
+			for _, id := range test.expectedCalls {
+				switch test.testFunc {
+				case "StopMachines":
+					vsphereClient.EXPECT().ShutdownVirtualMachine(gomock.Any(), vsphereVMNamed(id)).Times(1).Return(nil)
+				case "StartMachines":
+					vsphereClient.EXPECT().PowerOnVirtualMachine(gomock.Any(), vsphereVMNamed(id)).Times(1).Return(nil)
+				}
+			}
+			actuator := testVSphereActuator(vsphereClient)
+			var err error
+			switch test.testFunc {
+			case "StopMachines":
+				err = actuator.StopMachines(testVSphereClusterDeployment(), nil, log.New())
+			case "StartMachines":
+				err = actuator.StartMachines(testVSphereClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			if test.expectErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestVSphereMachinesStoppedAndRunning(t *testing.T) {
+	tests := []struct {
+		name              string
+		testFunc          string
+		expectedRemaining []string
+		expectedResult    bool
+		vms               map[string]int
+	}{
+		{
+			name:           "Stopped - All machines stopped",
+			testFunc:       "MachinesStopped",
+			expectedResult: true,
+			vms:            map[string]int{"poweredOff": 3},
+		},
+		{
+			name:              "Stopped - Some machines shutting down",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"shuttingDown-0", "shuttingDown-1"},
+			vms:               map[string]int{"poweredOff": 3, "shuttingDown": 2},
+		},
+		{
+			name:              "Stopped - machines running",
+			testFunc:          "MachinesStopped",
+			expectedResult:    false,
+			expectedRemaining: []string{"poweredOn-0", "poweredOn-1", "poweredOn-2"},
+			vms:               map[string]int{"poweredOn": 3, "poweredOff": 2},
+		},
+		{
+			name:           "Running - All machines running",
+			testFunc:       "MachinesRunning",
+			expectedResult: true,
+			vms:            map[string]int{"poweredOn": 3},
+		},
+		{
+			name:              "Running - Some machines resetting",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"resetting-0"},
+			vms:               map[string]int{"poweredOn": 3, "resetting": 1},
+		},
+		{
+			name:              "Running - Some machines stopped or suspended",
+			testFunc:          "MachinesRunning",
+			expectedResult:    false,
+			expectedRemaining: []string{"poweredOff-0", "poweredOff-1", "suspended-0"},
+			vms:               map[string]int{"poweredOn": 3, "poweredOff": 2, "suspended": 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			vsphereClient := mockvsphereclient.NewMockAPI(ctrl)
+			vsphereClient.EXPECT().Logout(gomock.Any()).AnyTimes().Return(nil)
+			setupVSphereClientVMs(vsphereClient, test.vms)
+			actuator := testVSphereActuator(vsphereClient)
+			var err error
+			var result bool
+			var remaining []string
+			switch test.testFunc {
+			case "MachinesStopped":
+				result, remaining, err = actuator.MachinesStopped(testVSphereClusterDeployment(), nil, log.New())
+			case "MachinesRunning":
+				result, remaining, err = actuator.MachinesRunning(testVSphereClusterDeployment(), nil, log.New())
+			default:
+				t.Fatal("Invalid function to test")
+			}
+			require.Nil(t, err)
+			assert.Equal(t, test.expectedResult, result)
+			if len(test.expectedRemaining) > 0 {
+				sort.Strings(test.expectedRemaining)
+				sort.Strings(remaining)
+				assert.Equal(t, test.expectedRemaining, remaining)
+			}
+		})
+	}
+}
+
+func testVSphereActuator(vsphereClient vsphereclient.API) *vsphereActuator {
+	return &vsphereActuator{
+		vsphereClientFn: func(*hivev1.ClusterDeployment, client.Client, log.FieldLogger) (vsphereclient.API, error) {
+			return vsphereClient, nil
+		},
+	}
+}
+
+// setupVSphereClientVMs expects a call to list VMs, returning VMs in the given states. A state is either a power
+// state (e.g. poweredOn) or the guest state of a powered on VM (e.g. shuttingDown).
+func setupVSphereClientVMs(vsphereClient *mockvsphereclient.MockAPI, states map[string]int) {
+	guestStates := sets.NewString("shuttingDown", "resetting")
+	vms := []mo.VirtualMachine{}
+	for state, count := range states {
+		for i := 0; i < count; i++ {
+			vm := mo.VirtualMachine{}
+			vm.Name = fmt.Sprintf("%s-%d", state, i)
+			vm.Runtime.PowerState = types.VirtualMachinePowerState(state)
+			if guestStates.Has(state) {
+				vm.Runtime.PowerState = types.VirtualMachinePowerStatePoweredOn
+				vm.Guest = &types.GuestInfo{GuestState: state}
+			}
+			vms = append(vms, vm)
+		}
+	}
+	vsphereClient.EXPECT().ListVirtualMachines(gomock.Any(), "testvspherecluster-foobarbaz").Times(1).Return(vms, nil)
+}
+
+// vsphereVMNamed matches a VM with the given name.
+func vsphereVMNamed(name string) gomock.Matcher {
+	return vsphereVMNameMatcher(name)
+}
+
+type vsphereVMNameMatcher string
+
+func (m vsphereVMNameMatcher) Matches(x interface{}) bool {
+	vm, ok := x.(mo.VirtualMachine)
+	return ok && vm.Name == string(m)
+}
+
+func (m vsphereVMNameMatcher) String() string {
+	return fmt.Sprintf("is VM named %s", string(m))
+}
+
+func testVSphereClusterDeployment() *hivev1.ClusterDeployment {
+	cdBuilder := testcd.FullBuilder("testns", "testvspherecluster", scheme.Scheme)
+	return cdBuilder.Build(
+		testcd.WithVSpherePlatform(&hivev1vsphere.Platform{VCenter: "vcenter.example.com"}),
+		testcd.WithClusterMetadata(&hivev1.ClusterMetadata{InfraID: "testvspherecluster-foobarbaz"}),
+	)
+}
@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/clustercost"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
+)
+
+// accruedCost is the cost accrued by a ClusterDeployment or ClusterPool since the collector first saw it.
+type accruedCost struct {
+	total       float64
+	lastAccrued time.Time
+}
+
+// accrue adds the cost of the time since the cost was last accrued at the hourly cost.
+func (a *accruedCost) accrue(hourlyCost float64, now time.Time) float64 {
+	cost := hourlyCost * now.Sub(a.lastAccrued).Hours()
+	a.total += cost
+	a.lastAccrued = now
+	return cost
+}
+
+// cluster cost metrics collected through a custom prometheus collector
+type clusterCostCollector struct {
+	client client.Client
+
+	cdLabels   *dynamicLabels
+	poolLabels *dynamicLabels
+	// cdLabelList and poolLabelList are the labels of the metrics, in the order of the metric descriptions.
+	cdLabelList   []string
+	poolLabelList []string
+
+	// metricClusterDeploymentHourlyCost is a prometheus metric for the estimated hourly cost of an installed cluster.
+	metricClusterDeploymentHourlyCost *prometheus.Desc
+	// metricClusterDeploymentCostTotal is a prometheus metric for the estimated cost accrued by an installed cluster.
+	metricClusterDeploymentCostTotal *prometheus.Desc
+	// metricClusterPoolHourlyCost is a prometheus metric for the estimated hourly cost of the unclaimed clusters of a
+	// cluster pool.
+	metricClusterPoolHourlyCost *prometheus.Desc
+	// metricClusterPoolCostTotal is a prometheus metric for the estimated cost accrued by the unclaimed clusters of a
+	// cluster pool.
+	metricClusterPoolCostTotal *prometheus.Desc
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+
+	// lock guards the accrued costs, since scrapes may be concurrent.
+	lock      sync.Mutex
+	cdCosts   map[types.NamespacedName]*accruedCost
+	poolCosts map[types.NamespacedName]*accruedCost
+}
+
+// collects the metrics for clusterCostCollector
+func (cc *clusterCostCollector) Collect(ch chan<- prometheus.Metric) {
+	ccLog := log.WithField("controller", "metrics")
+
+	prices, found, err := clustercost.Load(cc.client, controllerutils.GetHiveNamespace())
+	if err != nil {
+		ccLog.WithError(err).Error("error loading the cluster cost price table")
+		return
+	}
+	if !found {
+		// Cost estimation is disabled without a price table
+		return
+	}
+	ccLog.Info("calculating cost metrics across all ClusterDeployments")
+
+	clusterDeployments := &hivev1.ClusterDeploymentList{}
+	if err := cc.client.List(context.Background(), clusterDeployments); err != nil {
+		ccLog.WithError(err).Error("error listing cluster deployments")
+		return
+	}
+	machinePools := &hivev1.MachinePoolList{}
+	if err := cc.client.List(context.Background(), machinePools); err != nil {
+		ccLog.WithError(err).Error("error listing machine pools")
+		return
+	}
+	clusterPools := &hivev1.ClusterPoolList{}
+	if err := cc.client.List(context.Background(), clusterPools); err != nil {
+		ccLog.WithError(err).Error("error listing cluster pools")
+		return
+	}
+	machinePoolsByCD := clustercost.MachinePoolsByClusterDeployment(machinePools.Items)
+
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+	now := cc.now()
+
+	// Costs of ClusterDeployments and ClusterPools that are gone are dropped along with their metrics.
+	cdCosts := make(map[types.NamespacedName]*accruedCost, len(clusterDeployments.Items))
+	poolCosts := make(map[types.NamespacedName]*accruedCost, len(clusterPools.Items))
+	poolHourlyCosts := make(map[types.NamespacedName]float64, len(clusterPools.Items))
+	for _, pool := range clusterPools.Items {
+		key := types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}
+		if cost, ok := cc.poolCosts[key]; ok {
+			poolCosts[key] = cost
+		} else {
+			poolCosts[key] = &accruedCost{lastAccrued: now}
+		}
+	}
+
+	for i := range clusterDeployments.Items {
+		cd := &clusterDeployments.Items[i]
+		if !cd.Spec.Installed {
+			continue
+		}
+		key := types.NamespacedName{Namespace: cd.Namespace, Name: cd.Name}
+		hourlyCost, ok := prices.HourlyCost(cd, machinePoolsByCD[key])
+		if !ok {
+			continue
+		}
+		cost, ok := cc.cdCosts[key]
+		if !ok {
+			cost = &accruedCost{lastAccrued: now}
+		}
+		accrued := cost.accrue(hourlyCost, now)
+		cdCosts[key] = cost
+
+		// Unclaimed clusters are charged to their pool
+		if ref := cd.Spec.ClusterPoolRef; ref != nil && ref.ClaimName == "" {
+			poolKey := types.NamespacedName{Namespace: ref.Namespace, Name: ref.PoolName}
+			if poolCost, ok := poolCosts[poolKey]; ok {
+				poolCost.total += accrued
+				poolHourlyCosts[poolKey] += hourlyCost
+			}
+		}
+
+		labelValues := cc.labelValues(cc.cdLabels, cc.cdLabelList, map[string]string{
+			"cluster_deployment": cd.Name,
+			"namespace":          cd.Namespace,
+			"platform":           cd.Labels[hivev1.HiveClusterPlatformLabel],
+			"region":             cd.Labels[hivev1.HiveClusterRegionLabel],
+		}, cd)
+		ch <- prometheus.MustNewConstMetric(cc.metricClusterDeploymentHourlyCost, prometheus.GaugeValue, hourlyCost, labelValues...)
+		ch <- prometheus.MustNewConstMetric(cc.metricClusterDeploymentCostTotal, prometheus.CounterValue, cost.total, labelValues...)
+	}
+
+	for i := range clusterPools.Items {
+		pool := &clusterPools.Items[i]
+		key := types.NamespacedName{Namespace: pool.Namespace, Name: pool.Name}
+		labelValues := cc.labelValues(cc.poolLabels, cc.poolLabelList, map[string]string{
+			"clusterpool_namespace": pool.Namespace,
+			"clusterpool_name":      pool.Name,
+		}, pool)
+		ch <- prometheus.MustNewConstMetric(cc.metricClusterPoolHourlyCost, prometheus.GaugeValue, poolHourlyCosts[key], labelValues...)
+		ch <- prometheus.MustNewConstMetric(cc.metricClusterPoolCostTotal, prometheus.CounterValue, poolCosts[key].total, labelValues...)
+	}
+
+	cc.cdCosts = cdCosts
+	cc.poolCosts = poolCosts
+}
+
+func (cc *clusterCostCollector) Describe(ch chan<- *prometheus.Desc) {
+	// Describing by collecting would accrue costs, so the descriptions are sent directly.
+	ch <- cc.metricClusterDeploymentHourlyCost
+	ch <- cc.metricClusterDeploymentCostTotal
+	ch <- cc.metricClusterPoolHourlyCost
+	ch <- cc.metricClusterPoolCostTotal
+}
+
+// labelValues returns the values of the labels of a metric in the order of its description.
+func (cc *clusterCostCollector) labelValues(d *dynamicLabels, labelList []string, fixedLabels map[string]string, obj metav1.Object) []string {
+	labels := d.buildLabels(fixedLabels, obj)
+	values := make([]string, len(labelList))
+	for i, label := range labelList {
+		values[i] = labels[label]
+	}
+	return values
+}
+
+func newClusterCostCollector(client client.Client, optionalLabels map[string]string) prometheus.Collector {
+	cdLabels := &dynamicLabels{
+		fixedLabels:    []string{"cluster_deployment", "namespace", "platform", "region"},
+		optionalLabels: optionalLabels,
+	}
+	poolLabels := &dynamicLabels{
+		fixedLabels:    []string{"clusterpool_namespace", "clusterpool_name"},
+		optionalLabels: optionalLabels,
+	}
+	for _, d := range []*dynamicLabels{cdLabels, poolLabels} {
+		if repeatedLabels := d.getRepeatedLabels(); repeatedLabels != nil {
+			panic(fmt.Sprintf("Label(s) %v in HiveConfig.Spec.AdditionalClusterDeploymentLabels conflict with fixed label(s) for the cluster cost metrics. Please rename your label.", repeatedLabels))
+		}
+	}
+	cdLabelList := cdLabels.getLabelList()
+	poolLabelList := poolLabels.getLabelList()
+	return &clusterCostCollector{
+		client:        client,
+		cdLabels:      cdLabels,
+		poolLabels:    poolLabels,
+		cdLabelList:   cdLabelList,
+		poolLabelList: poolLabelList,
+		metricClusterDeploymentHourlyCost: prometheus.NewDesc(
+			"hive_cluster_deployment_hourly_cost",
+			"Estimated hourly cost of an installed cluster, from the cluster cost price table.",
+			cdLabelList,
+			nil,
+		),
+		metricClusterDeploymentCostTotal: prometheus.NewDesc(
+			"hive_cluster_deployment_cost_total",
+			"Estimated cost accrued by an installed cluster since the controller started observing it.",
+			cdLabelList,
+			nil,
+		),
+		metricClusterPoolHourlyCost: prometheus.NewDesc(
+			"hive_clusterpool_hourly_cost",
+			"Estimated hourly cost of the unclaimed clusters of a cluster pool, from the cluster cost price table.",
+			poolLabelList,
+			nil,
+		),
+		metricClusterPoolCostTotal: prometheus.NewDesc(
+			"hive_clusterpool_cost_total",
+			"Estimated cost accrued by the unclaimed clusters of a cluster pool since the controller started observing it.",
+			poolLabelList,
+			nil,
+		),
+		now:       time.Now,
+		cdCosts:   map[types.NamespacedName]*accruedCost{},
+		poolCosts: map[types.NamespacedName]*accruedCost{},
+	}
+}
@@ -0,0 +1,233 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
+	"github.com/openshift/hive/pkg/constants"
+	testcd "github.com/openshift/hive/pkg/test/clusterdeployment"
+	testcp "github.com/openshift/hive/pkg/test/clusterpool"
+	testcm "github.com/openshift/hive/pkg/test/configmap"
+	testmp "github.com/openshift/hive/pkg/test/machinepool"
+)
+
+const testPriceTable = `
+- platform: aws
+  hourlyCost: 2
+  hibernatingHourlyCost: 0.5
+- platform: aws
+  region: us-west-2
+  hourlyCost: 3
+  hibernatingHourlyCost: 0.5
+- platform: aws
+  instanceType: m5.xlarge
+  hourlyCost: 0.25
+`
+
+func TestClusterCostCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	cdBuilder := func(name string) testcd.Builder {
+		return testcd.FullBuilder(name, name, scheme).Options(
+			testcd.Installed(),
+			testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+			testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-east-1"),
+		)
+	}
+	workers := func(cdName, instanceType string, replicas int64) *hivev1.MachinePool {
+		return testmp.FullBuilder(cdName, "worker", cdName, scheme).Build(func(mp *hivev1.MachinePool) {
+			mp.Spec.Platform.AWS = &hivev1aws.MachinePoolPlatform{InstanceType: instanceType}
+			mp.Spec.Replicas = pointer.Int64(replicas)
+		})
+	}
+	prices := func(table string) *corev1.ConfigMap {
+		return testcm.FullBuilder(constants.DefaultHiveNamespace, constants.ClusterCostPricesConfigMapName, scheme).
+			Build(testcm.WithDataKeyValue(constants.ClusterCostPricesDataKey, table))
+	}
+
+	cases := []struct {
+		name string
+
+		existing       []runtime.Object
+		optionalLabels map[string]string
+		elapsed        time.Duration
+
+		expected []string
+	}{{
+		name: "no price table",
+		existing: []runtime.Object{
+			cdBuilder("cd-1").Build(),
+		},
+	}, {
+		name: "invalid price table",
+		existing: []runtime.Object{
+			prices("- hourlyCost: 2"),
+			cdBuilder("cd-1").Build(),
+		},
+	}, {
+		name: "cluster and machine pool costs",
+		existing: []runtime.Object{
+			prices(testPriceTable),
+			cdBuilder("cd-1").Build(),
+			workers("cd-1", "m5.xlarge", 4),
+		},
+		elapsed: 2 * time.Hour,
+		expected: []string{
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 6",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 3",
+		},
+	}, {
+		name: "region specific cost",
+		existing: []runtime.Object{
+			prices(testPriceTable),
+			cdBuilder("cd-1").Build(testcd.WithLabel(hivev1.HiveClusterRegionLabel, "us-west-2")),
+		},
+		elapsed: time.Hour,
+		expected: []string{
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-west-2 3",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-west-2 3",
+		},
+	}, {
+		name: "hibernating cluster",
+		existing: []runtime.Object{
+			prices(testPriceTable),
+			cdBuilder("cd-1").Build(testcd.WithStatusPowerState(hivev1.ClusterPowerStateHibernating)),
+			workers("cd-1", "m5.xlarge", 4),
+		},
+		elapsed: 4 * time.Hour,
+		expected: []string{
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 2",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 0.5",
+		},
+	}, {
+		name: "unpriced instance type",
+		existing: []runtime.Object{
+			prices(testPriceTable),
+			cdBuilder("cd-1").Build(),
+			workers("cd-1", "m5.4xlarge", 4),
+		},
+		expected: []string{
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 0",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 2",
+		},
+	}, {
+		name: "unpriced platform and uninstalled cluster",
+		existing: []runtime.Object{
+			prices(testPriceTable),
+			cdBuilder("cd-1").Build(testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "gcp")),
+			cdBuilder("cd-2").Build(func(cd *hivev1.ClusterDeployment) { cd.Spec.Installed = false }),
+		},
+	}, {
+		name: "unclaimed clusters are charged to their pool",
+		existing: []runtime.Object{
+			prices(testPriceTable),
+			testcp.FullBuilder("pools", "pool-1", scheme).Build(),
+			testcp.FullBuilder("pools", "pool-2", scheme).Build(),
+			cdBuilder("cd-1").Build(testcd.WithUnclaimedClusterPoolReference("pools", "pool-1")),
+			cdBuilder("cd-2").Build(testcd.WithUnclaimedClusterPoolReference("pools", "pool-1")),
+			cdBuilder("cd-3").Build(testcd.WithClusterPoolReference("pools", "pool-1", "claim")),
+		},
+		elapsed: time.Hour,
+		expected: []string{
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 2",
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-2 cluster_type = unspecified namespace = cd-2 platform = aws region = us-east-1 2",
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-3 cluster_type = unspecified namespace = cd-3 platform = aws region = us-east-1 2",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-1 cluster_type = unspecified namespace = cd-1 platform = aws region = us-east-1 2",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-2 cluster_type = unspecified namespace = cd-2 platform = aws region = us-east-1 2",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-3 cluster_type = unspecified namespace = cd-3 platform = aws region = us-east-1 2",
+			"hive_clusterpool_cost_total cluster_type = unspecified clusterpool_name = pool-1 clusterpool_namespace = pools 4",
+			"hive_clusterpool_cost_total cluster_type = unspecified clusterpool_name = pool-2 clusterpool_namespace = pools 0",
+			"hive_clusterpool_hourly_cost cluster_type = unspecified clusterpool_name = pool-1 clusterpool_namespace = pools 4",
+			"hive_clusterpool_hourly_cost cluster_type = unspecified clusterpool_name = pool-2 clusterpool_namespace = pools 0",
+		},
+	}, {
+		name: "additional cluster deployment labels",
+		existing: []runtime.Object{
+			prices(testPriceTable),
+			cdBuilder("cd-1").Build(testcd.WithLabel("example.com/team", "payments")),
+		},
+		optionalLabels: map[string]string{"team": "example.com/team"},
+		expected: []string{
+			"hive_cluster_deployment_cost_total cluster_deployment = cd-1 namespace = cd-1 platform = aws region = us-east-1 team = payments 0",
+			"hive_cluster_deployment_hourly_cost cluster_deployment = cd-1 namespace = cd-1 platform = aws region = us-east-1 team = payments 2",
+		},
+	}}
+	for _, test := range cases {
+		t.Run(test.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(test.existing...).Build()
+			optionalLabels := test.optionalLabels
+			if optionalLabels == nil {
+				optionalLabels = map[string]string{"cluster_type": hivev1.HiveClusterTypeLabel}
+			}
+			collector := newClusterCostCollector(c, optionalLabels).(*clusterCostCollector)
+			now := time.Now()
+			collector.now = func() time.Time { return now }
+			registry := prometheus.NewRegistry()
+			require.NoError(t, registry.Register(collector))
+
+			// The first collection starts accruing costs, the second one accrues the elapsed time.
+			_, err := registry.Gather()
+			require.NoError(t, err)
+			now = now.Add(test.elapsed)
+			families, err := registry.Gather()
+			require.NoError(t, err)
+
+			var got []string
+			for _, family := range families {
+				for _, m := range family.Metric {
+					value := m.GetGauge().GetValue()
+					if m.Counter != nil {
+						value = m.GetCounter().GetValue()
+					}
+					got = append(got, fmt.Sprintf("%s %s %g", family.GetName(), metricPretty(*m), value))
+				}
+			}
+			sort.Strings(got)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestClusterCostCollectorDropsDeletedClusters(t *testing.T) {
+	scheme := runtime.NewScheme()
+	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
+
+	cd := testcd.FullBuilder("cd-1", "cd-1", scheme).Build(
+		testcd.Installed(),
+		testcd.WithLabel(hivev1.HiveClusterPlatformLabel, "aws"),
+	)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(
+		testcm.FullBuilder(constants.DefaultHiveNamespace, constants.ClusterCostPricesConfigMapName, scheme).
+			Build(testcm.WithDataKeyValue(constants.ClusterCostPricesDataKey, testPriceTable)),
+		cd,
+	).Build()
+	collector := newClusterCostCollector(c, nil).(*clusterCostCollector)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	_, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Len(t, collector.cdCosts, 1, "expected the cost of the cluster to be accrued")
+
+	require.NoError(t, c.Delete(context.TODO(), cd))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	assert.Empty(t, families, "expected no metrics for the deleted cluster")
+	assert.Empty(t, collector.cdCosts, "expected the cost of the deleted cluster to be dropped")
+}
@@ -228,6 +228,8 @@ func (mc *Calculator) Start(ctx context.Context) error {
 	// Register optional metrics and update them in their corresponding maps, so controllers logging them can access
 	// the information
 	mc.registerOptionalMetrics(mConfig)
+	// The cost metrics are only reported once the cluster cost price table ConfigMap is created
+	metrics.Registry.MustRegister(newClusterCostCollector(mc.Client, GetOptionalClusterTypeLabels(mConfig)))
 
 	// Run forever, sleep at the end:
 	wait.UntilWithContext(ctx, func(ctx context.Context) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -19,6 +20,41 @@ func IsDeleteProtected(cd *hivev1.ClusterDeployment) bool {
 	return protectedDelete && err == nil
 }
 
+// IsDeprovisionPending returns true if the ClusterDeployment is deleted and its deprovision is held off by its
+// deprovision grace period.
+func IsDeprovisionPending(cd *hivev1.ClusterDeployment) bool {
+	if cd.DeletionTimestamp == nil {
+		return false
+	}
+	provisioned := FindCondition(cd.Status.Conditions, hivev1.ProvisionedCondition)
+	return provisioned != nil && provisioned.Reason == hivev1.ProvisionedReasonDeprovisionPending
+}
+
+// DeprovisionGracePeriod returns how long the deprovision of the ClusterDeployment is held off once it is deleted,
+// during which it can be cancelled. The deprovision-grace-period annotation takes precedence over defaultGracePeriod.
+// Clusters that are not installed, that are preserved on delete, that belong to a ClusterPool or that are being
+// relocated have no grace period. ClusterPool clusters are deleted by Hive itself, such as when their claim is deleted.
+func DeprovisionGracePeriod(cd *hivev1.ClusterDeployment, defaultGracePeriod time.Duration, logger log.FieldLogger) time.Duration {
+	if !cd.Spec.Installed || cd.Spec.PreserveOnDelete {
+		return 0
+	}
+	if cd.Spec.ClusterPoolRef != nil {
+		return 0
+	}
+	if _, relocating := cd.Annotations[constants.RelocateAnnotation]; relocating {
+		return 0
+	}
+	if value, ok := cd.Annotations[constants.DeprovisionGracePeriodAnnotation]; ok {
+		gracePeriod, err := time.ParseDuration(value)
+		if err == nil {
+			return gracePeriod
+		}
+		logger.WithError(err).WithField("annotation", constants.DeprovisionGracePeriodAnnotation).
+			Warn("could not parse deprovision grace period, using the default")
+	}
+	return defaultGracePeriod
+}
+
 func IsFakeCluster(cd *hivev1.ClusterDeployment) bool {
 	fakeCluster, err := strconv.ParseBool(cd.Annotations[constants.HiveFakeClusterAnnotation])
 	return fakeCluster && err == nil
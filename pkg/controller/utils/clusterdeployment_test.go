@@ -2,6 +2,7 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -60,6 +61,67 @@ func TestIsDeleteProtected(t *testing.T) {
 	}
 }
 
+func TestDeprovisionGracePeriod(t *testing.T) {
+	cases := []struct {
+		name     string
+		cd       *hivev1.ClusterDeployment
+		expected time.Duration
+	}{
+		{
+			name:     "default",
+			cd:       clusterdeployment.Build(clusterdeployment.Installed()),
+			expected: time.Hour,
+		},
+		{
+			name: "annotation",
+			cd: clusterdeployment.Build(clusterdeployment.Installed(),
+				clusterdeployment.WithAnnotation(constants.DeprovisionGracePeriodAnnotation, "2h")),
+			expected: 2 * time.Hour,
+		},
+		{
+			name: "annotation disables",
+			cd: clusterdeployment.Build(clusterdeployment.Installed(),
+				clusterdeployment.WithAnnotation(constants.DeprovisionGracePeriodAnnotation, "0s")),
+		},
+		{
+			name: "unparseable annotation",
+			cd: clusterdeployment.Build(clusterdeployment.Installed(),
+				clusterdeployment.WithAnnotation(constants.DeprovisionGracePeriodAnnotation, "a day")),
+			expected: time.Hour,
+		},
+		{
+			name: "not installed",
+			cd:   clusterdeployment.Build(),
+		},
+		{
+			name: "preserved on delete",
+			cd:   clusterdeployment.Build(clusterdeployment.Installed(), clusterdeployment.PreserveOnDelete()),
+		},
+		{
+			name: "unclaimed pool cluster",
+			cd: clusterdeployment.Build(clusterdeployment.Installed(),
+				clusterdeployment.WithUnclaimedClusterPoolReference("pool-namespace", "pool")),
+		},
+		{
+			name: "claimed pool cluster",
+			cd: clusterdeployment.Build(clusterdeployment.Installed(),
+				clusterdeployment.WithClusterPoolReference("pool-namespace", "pool", "claim"),
+				clusterdeployment.WithAnnotation(constants.DeprovisionGracePeriodAnnotation, "2h")),
+		},
+		{
+			name: "relocating",
+			cd: clusterdeployment.Build(clusterdeployment.Installed(),
+				clusterdeployment.WithAnnotation(constants.RelocateAnnotation, "relocate/outgoing")),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := DeprovisionGracePeriod(tc.cd, time.Hour, logrus.New())
+			assert.Equal(t, tc.expected, actual, "unexpected grace period")
+		})
+	}
+}
+
 func TestIsClusterPausedOrRelocating(t *testing.T) {
 	cases := []struct {
 		name     string
@@ -1,8 +1,11 @@
 package utils
 
 import (
+	"fmt"
 	"strconv"
 
+	"github.com/davegardnerisme/deephash"
+
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/openshift/hive/pkg/constants"
 )
@@ -36,3 +39,26 @@ func MarkClusterForRemoval(cd *hivev1.ClusterDeployment) {
 	}
 	cd.Annotations[constants.RemovePoolClusterAnnotation] = "true"
 }
+
+// CalculatePoolVersion computes a hash of the important (to ClusterDeployments) fields of the
+// ClusterPool.Spec. This is annotated on ClusterDeployments when the pool creates them, which
+// subsequently allows us to tell whether all unclaimed CDs are up to date and set a condition
+// accordingly.
+// NOTE: If we change this algorithm, we're guaranteed to think that all CDs are stale at the
+// moment that code update rolls out. We may wish to consider a way to support detecting the old
+// value as "current" in that case.
+func CalculatePoolVersion(clp *hivev1.ClusterPool) string {
+	ba := []byte{}
+	ba = append(ba, deephash.Hash(clp.Spec.Platform)...)
+	ba = append(ba, deephash.Hash(clp.Spec.BaseDomain)...)
+	ba = append(ba, deephash.Hash(clp.Spec.ImageSetRef)...)
+	ba = append(ba, deephash.Hash(clp.Spec.InstallConfigSecretTemplateRef)...)
+	// Inventory changes the behavior of cluster pool, thus it needs to be in the pool version.
+	// But to avoid redployment of clusters if inventory changes, a fixed string is added to pool version.
+	// https://github.com/openshift/hive/blob/master/docs/enhancements/clusterpool-inventory.md#pool-version
+	if clp.Spec.Inventory != nil {
+		ba = append(ba, []byte("hasInventory")...)
+	}
+	// Hash of hashes to ensure fixed length
+	return fmt.Sprintf("%x", deephash.Hash(ba))
+}
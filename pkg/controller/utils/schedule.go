@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	// Embed the IANA time zone database so schedules can be evaluated regardless of what is installed in the image.
+	_ "time/tzdata"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// scheduleHorizonDays is how far (in days) on either side of "now" we expand weekly windows when evaluating a
+// schedule. A week plus a day ensures we always see the previous and next boundary of any weekly window,
+// including those that wrap past midnight.
+const scheduleHorizonDays = 8
+
+// scheduleClockRE matches the same "HH:MM" format as the ScheduleWindow CRD validation.
+var scheduleClockRE = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+
+var scheduleWeekdays = map[hivev1.ScheduleWeekday]time.Weekday{
+	hivev1.ScheduleSunday:    time.Sunday,
+	hivev1.ScheduleMonday:    time.Monday,
+	hivev1.ScheduleTuesday:   time.Tuesday,
+	hivev1.ScheduleWednesday: time.Wednesday,
+	hivev1.ScheduleThursday:  time.Thursday,
+	hivev1.ScheduleFriday:    time.Friday,
+	hivev1.ScheduleSaturday:  time.Saturday,
+}
+
+// ScheduleState describes where a point in time falls relative to a set of ScheduleWindows.
+type ScheduleState struct {
+	// Active is true if the point in time is within one of the windows.
+	Active bool
+	// LastTransition is the most recent time (at or before the point in time) at which a window opened (if Active)
+	// or closed (if !Active). Zero if there is no such boundary, e.g. because the windows cover all of time.
+	LastTransition time.Time
+	// NextTransition is the next time (after the point in time) at which a window closes (if Active) or opens
+	// (if !Active). Zero if there is no such boundary.
+	NextTransition time.Time
+}
+
+type scheduleInterval struct {
+	start, end time.Time
+}
+
+// LoadScheduleLocation returns the time.Location for the given IANA time zone name, defaulting to UTC.
+func LoadScheduleLocation(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(timeZone)
+}
+
+// ValidateScheduleWindows checks that the given windows are well formed.
+func ValidateScheduleWindows(windows []hivev1.ScheduleWindow) error {
+	for i, w := range windows {
+		if _, _, err := parseScheduleClock(w.Start); err != nil {
+			return fmt.Errorf("window %d: invalid start: %w", i, err)
+		}
+		if _, _, err := parseScheduleClock(w.End); err != nil {
+			return fmt.Errorf("window %d: invalid end: %w", i, err)
+		}
+		for _, d := range w.Days {
+			if _, ok := scheduleWeekdays[d]; !ok {
+				return fmt.Errorf("window %d: invalid day %q", i, d)
+			}
+		}
+	}
+	return nil
+}
+
+// EvaluateScheduleWindows determines whether now falls within any of the windows, evaluated in loc, and when
+// the surrounding transitions occur.
+func EvaluateScheduleWindows(windows []hivev1.ScheduleWindow, loc *time.Location, now time.Time) (ScheduleState, error) {
+	if err := ValidateScheduleWindows(windows); err != nil {
+		return ScheduleState{}, err
+	}
+	now = now.In(loc)
+	horizonStart := time.Date(now.Year(), now.Month(), now.Day()-scheduleHorizonDays, 0, 0, 0, 0, loc)
+	horizonEnd := time.Date(now.Year(), now.Month(), now.Day()+scheduleHorizonDays+1, 0, 0, 0, 0, loc)
+
+	var intervals []scheduleInterval
+	for _, w := range windows {
+		// Errors were checked by ValidateScheduleWindows above
+		startHour, startMinute, _ := parseScheduleClock(w.Start)
+		endHour, endMinute, _ := parseScheduleClock(w.End)
+		endDayOffset := 0
+		if endHour*60+endMinute <= startHour*60+startMinute {
+			endDayOffset = 1
+		}
+		for d := -scheduleHorizonDays; d <= scheduleHorizonDays; d++ {
+			day := time.Date(now.Year(), now.Month(), now.Day()+d, 0, 0, 0, 0, loc)
+			if !scheduleWindowOpensOn(w, day.Weekday()) {
+				continue
+			}
+			intervals = append(intervals, scheduleInterval{
+				start: time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, loc),
+				end:   time.Date(day.Year(), day.Month(), day.Day()+endDayOffset, endHour, endMinute, 0, 0, loc),
+			})
+		}
+	}
+
+	state := ScheduleState{}
+	lastClose := time.Time{}
+	for _, iv := range mergeScheduleIntervals(intervals) {
+		if iv.end.After(now) && !iv.start.After(now) {
+			state.Active = true
+			if iv.start.After(horizonStart) {
+				state.LastTransition = iv.start
+			}
+			if iv.end.Before(horizonEnd) {
+				state.NextTransition = iv.end
+			}
+			return state, nil
+		}
+		if iv.start.After(now) {
+			state.LastTransition = lastClose
+			state.NextTransition = iv.start
+			return state, nil
+		}
+		lastClose = iv.end
+	}
+	state.LastTransition = lastClose
+	return state, nil
+}
+
+func scheduleWindowOpensOn(w hivev1.ScheduleWindow, weekday time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if scheduleWeekdays[d] == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeScheduleIntervals returns the given intervals sorted and with overlapping or adjacent intervals combined.
+func mergeScheduleIntervals(intervals []scheduleInterval) []scheduleInterval {
+	sort.Slice(intervals, func(i, j int) bool {
+		return intervals[i].start.Before(intervals[j].start)
+	})
+	var merged []scheduleInterval
+	for _, iv := range intervals {
+		if n := len(merged); n > 0 && !iv.start.After(merged[n-1].end) {
+			if iv.end.After(merged[n-1].end) {
+				merged[n-1].end = iv.end
+			}
+			continue
+		}
+		merged = append(merged, iv)
+	}
+	return merged
+}
+
+func parseScheduleClock(clock string) (hour, minute int, err error) {
+	if !scheduleClockRE.MatchString(clock) {
+		return 0, 0, fmt.Errorf("%q is not in 24-hour HH:MM format", clock)
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, 0, err
+	}
+	return t.Hour(), t.Minute(), nil
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestEvaluateScheduleWindows(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err, "failed to load time zone")
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2023, month, day, hour, minute, 0, 0, berlin)
+	}
+	weekdays := []hivev1.ScheduleWeekday{
+		hivev1.ScheduleMonday, hivev1.ScheduleTuesday, hivev1.ScheduleWednesday, hivev1.ScheduleThursday, hivev1.ScheduleFriday,
+	}
+	businessHours := []hivev1.ScheduleWindow{{Days: weekdays, Start: "08:00", End: "19:00"}}
+
+	tests := []struct {
+		name          string
+		windows       []hivev1.ScheduleWindow
+		now           time.Time
+		expectActive  bool
+		expectLast    time.Time
+		expectNext    time.Time
+		expectedError bool
+	}{
+		{
+			name:         "within weekday window",
+			windows:      businessHours,
+			now:          at(time.June, 7, 10, 0), // Wednesday
+			expectActive: true,
+			expectLast:   at(time.June, 7, 8, 0),
+			expectNext:   at(time.June, 7, 19, 0),
+		},
+		{
+			name:       "weekday evening",
+			windows:    businessHours,
+			now:        at(time.June, 7, 20, 0),
+			expectLast: at(time.June, 7, 19, 0),
+			expectNext: at(time.June, 8, 8, 0),
+		},
+		{
+			name:       "weekend",
+			windows:    businessHours,
+			now:        at(time.June, 10, 12, 0), // Saturday
+			expectLast: at(time.June, 9, 19, 0),
+			expectNext: at(time.June, 12, 8, 0),
+		},
+		{
+			name:         "exactly at window start",
+			windows:      businessHours,
+			now:          at(time.June, 7, 8, 0),
+			expectActive: true,
+			expectLast:   at(time.June, 7, 8, 0),
+			expectNext:   at(time.June, 7, 19, 0),
+		},
+		{
+			name:       "exactly at window end",
+			windows:    businessHours,
+			now:        at(time.June, 7, 19, 0),
+			expectLast: at(time.June, 7, 19, 0),
+			expectNext: at(time.June, 8, 8, 0),
+		},
+		{
+			name:         "overnight window",
+			windows:      []hivev1.ScheduleWindow{{Start: "22:00", End: "06:00"}},
+			now:          at(time.June, 7, 2, 0),
+			expectActive: true,
+			expectLast:   at(time.June, 6, 22, 0),
+			expectNext:   at(time.June, 7, 6, 0),
+		},
+		{
+			name: "overlapping windows are merged",
+			windows: []hivev1.ScheduleWindow{
+				{Start: "08:00", End: "12:00"},
+				{Start: "11:00", End: "14:00"},
+			},
+			now:          at(time.June, 7, 9, 0),
+			expectActive: true,
+			expectLast:   at(time.June, 7, 8, 0),
+			expectNext:   at(time.June, 7, 14, 0),
+		},
+		{
+			name:         "always open",
+			windows:      []hivev1.ScheduleWindow{{Start: "00:00", End: "00:00"}},
+			now:          at(time.June, 7, 9, 0),
+			expectActive: true,
+		},
+		{
+			name:         "across daylight saving change",
+			windows:      []hivev1.ScheduleWindow{{Start: "01:00", End: "04:00"}},
+			now:          at(time.March, 26, 3, 30), // clocks jump from 02:00 to 03:00
+			expectActive: true,
+			expectLast:   at(time.March, 26, 1, 0),
+			expectNext:   at(time.March, 26, 4, 0),
+		},
+		{
+			name:          "invalid start",
+			windows:       []hivev1.ScheduleWindow{{Start: "8am", End: "19:00"}},
+			now:           at(time.June, 7, 9, 0),
+			expectedError: true,
+		},
+		{
+			name:          "single-digit hour",
+			windows:       []hivev1.ScheduleWindow{{Start: "8:00", End: "19:00"}},
+			now:           at(time.June, 7, 9, 0),
+			expectedError: true,
+		},
+		{
+			name:          "invalid day",
+			windows:       []hivev1.ScheduleWindow{{Days: []hivev1.ScheduleWeekday{"Caturday"}, Start: "08:00", End: "19:00"}},
+			now:           at(time.June, 7, 9, 0),
+			expectedError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state, err := EvaluateScheduleWindows(test.windows, berlin, test.now)
+			if test.expectedError {
+				assert.Error(t, err, "expected error")
+				return
+			}
+			require.NoError(t, err, "unexpected error")
+			assert.Equal(t, test.expectActive, state.Active, "unexpected active state")
+			assert.True(t, test.expectLast.Equal(state.LastTransition), "unexpected last transition %v", state.LastTransition)
+			assert.True(t, test.expectNext.Equal(state.NextTransition), "unexpected next transition %v", state.NextTransition)
+		})
+	}
+}
@@ -47,6 +47,18 @@ type Client interface {
 
 	ListComputeInstances(ListComputeInstancesOptions, func(*compute.InstanceAggregatedList) error) error
 
+	ListComputeDisks(ListComputeDisksOptions, func(*compute.DiskAggregatedList) error) error
+
+	ListComputeForwardingRules(ListComputeForwardingRulesOptions, func(*compute.ForwardingRuleAggregatedList) error) error
+
+	ListComputeTargetPools(ListComputeTargetPoolsOptions, func(*compute.TargetPoolAggregatedList) error) error
+
+	ListComputeBackendServices(ListComputeBackendServicesOptions, func(*compute.BackendServiceAggregatedList) error) error
+
+	ListComputeHealthChecks(ListComputeHealthChecksOptions, func(*compute.HealthChecksAggregatedList) error) error
+
+	ListComputeHTTPHealthChecks(ListComputeHTTPHealthChecksOptions, func(*compute.HttpHealthCheckList) error) error
+
 	StopInstance(*compute.Instance) error
 
 	StartInstance(*compute.Instance) error
@@ -72,6 +84,42 @@ type ListComputeInstancesOptions struct {
 	Fields string
 }
 
+// ListComputeDisksOptions are the options for listing compute disks.
+type ListComputeDisksOptions struct {
+	Filter string
+	Fields string
+}
+
+// ListComputeForwardingRulesOptions are the options for listing compute forwarding rules.
+type ListComputeForwardingRulesOptions struct {
+	Filter string
+	Fields string
+}
+
+// ListComputeTargetPoolsOptions are the options for listing compute target pools.
+type ListComputeTargetPoolsOptions struct {
+	Filter string
+	Fields string
+}
+
+// ListComputeBackendServicesOptions are the options for listing compute backend services.
+type ListComputeBackendServicesOptions struct {
+	Filter string
+	Fields string
+}
+
+// ListComputeHealthChecksOptions are the options for listing compute health checks.
+type ListComputeHealthChecksOptions struct {
+	Filter string
+	Fields string
+}
+
+// ListComputeHTTPHealthChecksOptions are the options for listing legacy compute HTTP health checks.
+type ListComputeHTTPHealthChecksOptions struct {
+	Filter string
+	Fields string
+}
+
 type gcpClient struct {
 	projectName                string
 	creds                      *google.Credentials
@@ -247,6 +295,96 @@ func (c *gcpClient) ListComputeInstances(opts ListComputeInstancesOptions, pages
 	return nil
 }
 
+func (c *gcpClient) ListComputeDisks(opts ListComputeDisksOptions, pagesFn func(*compute.DiskAggregatedList) error) error {
+	req := c.computeClient.Disks.AggregatedList(c.projectName)
+	if len(opts.Fields) > 0 {
+		req.Fields(googleapi.Field(opts.Fields))
+	}
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute disks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeForwardingRules(opts ListComputeForwardingRulesOptions, pagesFn func(*compute.ForwardingRuleAggregatedList) error) error {
+	req := c.computeClient.ForwardingRules.AggregatedList(c.projectName)
+	if len(opts.Fields) > 0 {
+		req.Fields(googleapi.Field(opts.Fields))
+	}
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute forwarding rules")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeTargetPools(opts ListComputeTargetPoolsOptions, pagesFn func(*compute.TargetPoolAggregatedList) error) error {
+	req := c.computeClient.TargetPools.AggregatedList(c.projectName)
+	if len(opts.Fields) > 0 {
+		req.Fields(googleapi.Field(opts.Fields))
+	}
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute target pools")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeBackendServices(opts ListComputeBackendServicesOptions, pagesFn func(*compute.BackendServiceAggregatedList) error) error {
+	req := c.computeClient.BackendServices.AggregatedList(c.projectName)
+	if len(opts.Fields) > 0 {
+		req.Fields(googleapi.Field(opts.Fields))
+	}
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute backend services")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeHealthChecks(opts ListComputeHealthChecksOptions, pagesFn func(*compute.HealthChecksAggregatedList) error) error {
+	req := c.computeClient.HealthChecks.AggregatedList(c.projectName)
+	if len(opts.Fields) > 0 {
+		req.Fields(googleapi.Field(opts.Fields))
+	}
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute health checks")
+	}
+	return nil
+}
+
+func (c *gcpClient) ListComputeHTTPHealthChecks(opts ListComputeHTTPHealthChecksOptions, pagesFn func(*compute.HttpHealthCheckList) error) error {
+	req := c.computeClient.HttpHealthChecks.List(c.projectName)
+	if len(opts.Fields) > 0 {
+		req.Fields(googleapi.Field(opts.Fields))
+	}
+	if len(opts.Filter) > 0 {
+		req = req.Filter(opts.Filter)
+	}
+	err := req.Pages(context.TODO(), pagesFn)
+	if err != nil {
+		return errors.Wrapf(err, "failed to fetch compute HTTP health checks")
+	}
+	return nil
+}
+
 func (c *gcpClient) StopInstance(instance *compute.Instance) error {
 	zone := instanceZone(instance)
 	_, err := c.computeClient.Instances.Stop(c.projectName, zone, instance.Name).Do()
@@ -122,6 +122,76 @@ func (mr *MockClientMockRecorder) GetManagedZone(managedZone interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetManagedZone", reflect.TypeOf((*MockClient)(nil).GetManagedZone), managedZone)
 }
 
+// ListComputeBackendServices mocks base method.
+func (m *MockClient) ListComputeBackendServices(arg0 gcpclient.ListComputeBackendServicesOptions, arg1 func(*compute.BackendServiceAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeBackendServices", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeBackendServices indicates an expected call of ListComputeBackendServices.
+func (mr *MockClientMockRecorder) ListComputeBackendServices(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeBackendServices", reflect.TypeOf((*MockClient)(nil).ListComputeBackendServices), arg0, arg1)
+}
+
+// ListComputeDisks mocks base method.
+func (m *MockClient) ListComputeDisks(arg0 gcpclient.ListComputeDisksOptions, arg1 func(*compute.DiskAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeDisks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeDisks indicates an expected call of ListComputeDisks.
+func (mr *MockClientMockRecorder) ListComputeDisks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeDisks", reflect.TypeOf((*MockClient)(nil).ListComputeDisks), arg0, arg1)
+}
+
+// ListComputeForwardingRules mocks base method.
+func (m *MockClient) ListComputeForwardingRules(arg0 gcpclient.ListComputeForwardingRulesOptions, arg1 func(*compute.ForwardingRuleAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeForwardingRules", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeForwardingRules indicates an expected call of ListComputeForwardingRules.
+func (mr *MockClientMockRecorder) ListComputeForwardingRules(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeForwardingRules", reflect.TypeOf((*MockClient)(nil).ListComputeForwardingRules), arg0, arg1)
+}
+
+// ListComputeHTTPHealthChecks mocks base method.
+func (m *MockClient) ListComputeHTTPHealthChecks(arg0 gcpclient.ListComputeHTTPHealthChecksOptions, arg1 func(*compute.HttpHealthCheckList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeHTTPHealthChecks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeHTTPHealthChecks indicates an expected call of ListComputeHTTPHealthChecks.
+func (mr *MockClientMockRecorder) ListComputeHTTPHealthChecks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeHTTPHealthChecks", reflect.TypeOf((*MockClient)(nil).ListComputeHTTPHealthChecks), arg0, arg1)
+}
+
+// ListComputeHealthChecks mocks base method.
+func (m *MockClient) ListComputeHealthChecks(arg0 gcpclient.ListComputeHealthChecksOptions, arg1 func(*compute.HealthChecksAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeHealthChecks", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeHealthChecks indicates an expected call of ListComputeHealthChecks.
+func (mr *MockClientMockRecorder) ListComputeHealthChecks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeHealthChecks", reflect.TypeOf((*MockClient)(nil).ListComputeHealthChecks), arg0, arg1)
+}
+
 // ListComputeImages mocks base method.
 func (m *MockClient) ListComputeImages(arg0 gcpclient.ListComputeImagesOptions) (*compute.ImageList, error) {
 	m.ctrl.T.Helper()
@@ -151,6 +221,20 @@ func (mr *MockClientMockRecorder) ListComputeInstances(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeInstances", reflect.TypeOf((*MockClient)(nil).ListComputeInstances), arg0, arg1)
 }
 
+// ListComputeTargetPools mocks base method.
+func (m *MockClient) ListComputeTargetPools(arg0 gcpclient.ListComputeTargetPoolsOptions, arg1 func(*compute.TargetPoolAggregatedList) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListComputeTargetPools", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListComputeTargetPools indicates an expected call of ListComputeTargetPools.
+func (mr *MockClientMockRecorder) ListComputeTargetPools(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListComputeTargetPools", reflect.TypeOf((*MockClient)(nil).ListComputeTargetPools), arg0, arg1)
+}
+
 // ListComputeZones mocks base method.
 func (m *MockClient) ListComputeZones(arg0 gcpclient.ListComputeZonesOptions) (*compute.ZoneList, error) {
 	m.ctrl.T.Helper()
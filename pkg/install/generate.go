@@ -284,6 +284,25 @@ func InstallerPodSpec(
 		})
 	}
 
+	// Mount the claim to copy the logs of a failed install to, if there is one
+	for _, envVar := range extraEnvVars {
+		if envVar.Name != constants.InstallLogsPVCClaimNameEnvVar {
+			continue
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: "install-logs",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: envVar.Value,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "install-logs",
+			MountPath: constants.InstallLogsPVCMountDir,
+		})
+	}
+
 	// Signal to fake an installation:
 	if controllerutils.IsFakeCluster(cd) {
 		env = append(env, corev1.EnvVar{
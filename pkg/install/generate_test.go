@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
 	hiveassert "github.com/openshift/hive/pkg/test/assert"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -103,6 +104,48 @@ func TestInstallerPodSpec(t *testing.T) {
 				assert.NoError(t, actualError)
 			},
 		},
+		{
+			name: "Test Provision Pod Install Logs Claim",
+			clusterDeployment: &hivev1.ClusterDeployment{
+				Spec: hivev1.ClusterDeploymentSpec{
+					Provisioning: &hivev1.Provisioning{
+						InstallConfigSecretRef: &corev1.LocalObjectReference{Name: "foo"},
+					},
+				},
+				Status: hivev1.ClusterDeploymentStatus{
+					InstallerImage: &installerImage,
+					CLIImage:       &cliImage,
+				},
+			},
+			provisionName: "testprovision",
+			extraEnvVars: []corev1.EnvVar{
+				{
+					Name:  constants.InstallLogsUploadProviderEnvVar,
+					Value: constants.InstallLogsUploadProviderPVC,
+				},
+				{
+					Name:  constants.InstallLogsPVCClaimNameEnvVar,
+					Value: "install-logs-claim",
+				},
+			},
+			validate: func(t *testing.T, actualPodSpec *corev1.PodSpec, actualError error) {
+				if !assert.NoError(t, actualError) {
+					return
+				}
+				assert.Contains(t, actualPodSpec.Volumes, corev1.Volume{
+					Name: "install-logs",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "install-logs-claim"},
+					},
+				}, "expected install logs claim volume")
+				for _, container := range actualPodSpec.Containers {
+					assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+						Name:      "install-logs",
+						MountPath: constants.InstallLogsPVCMountDir,
+					}, "expected install logs claim to be mounted")
+				}
+			},
+		},
 	}
 
 	for _, test := range tests {
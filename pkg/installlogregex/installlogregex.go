@@ -0,0 +1,197 @@
+package installlogregex
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	// ConfigMapName is the name of the ConfigMap in the hive namespace with the regexes shipped with Hive.
+	ConfigMapName = "install-log-regexes"
+	// AdditionalConfigMapName is the name of the optional ConfigMap in the hive namespace with regexes added by the
+	// administrator, which are searched for after the regexes shipped with Hive.
+	AdditionalConfigMapName = "additional-install-log-regexes"
+	// DataEntryName is the data entry of the ConfigMaps that holds the regexes.
+	DataEntryName = "regexes"
+)
+
+// InstallLogRegex is a struct that represents all the data we use to scan for certain
+// search strings in install logs. These structs are serialized as yaml and stored/read from
+// the install-log-regexes ConfigMap.
+type InstallLogRegex struct {
+	// Name is the name of the regex.
+	Name string `json:"name"`
+
+	// SearchRegexStrings are the regex strings we will search for.
+	SearchRegexStrings []string `json:"searchRegexStrings"`
+
+	// InstallFailingReason is the single word CamelCase reason we report for this failure in conditions, metrics and logs.
+	InstallFailingReason string `json:"installFailingReason"`
+
+	// InstallFailingMessage is the user friendly sentence we report for this failure and conditions, metrics and logs.
+	InstallFailingMessage string `json:"installFailingMessage"`
+
+	// Category is the category of this failure. Defaults to Unknown.
+	Category hivev1.InstallFailureCategory `json:"category,omitempty"`
+
+	// Severity decides which failure is reported when several are found in an install log. Defaults to Error.
+	Severity hivev1.InstallFailureSeverity `json:"severity,omitempty"`
+
+	// Retryable is whether retrying the install may succeed despite this failure. Defaults to true.
+	Retryable *bool `json:"retryable,omitempty"`
+}
+
+// severityRanks orders the severities of install failures, most severe first.
+var severityRanks = map[hivev1.InstallFailureSeverity]int{
+	hivev1.InstallFailureSeverityCritical: 0,
+	hivev1.InstallFailureSeverityError:    1,
+	hivev1.InstallFailureSeverityWarning:  2,
+}
+
+var knownCategories = map[hivev1.InstallFailureCategory]bool{
+	hivev1.InstallFailureCategoryQuota:         true,
+	hivev1.InstallFailureCategoryPermissions:   true,
+	hivev1.InstallFailureCategoryDNS:           true,
+	hivev1.InstallFailureCategoryCloudOutage:   true,
+	hivev1.InstallFailureCategoryInstallerBug:  true,
+	hivev1.InstallFailureCategoryConfiguration: true,
+	hivev1.InstallFailureCategoryUnknown:       true,
+}
+
+// Failure returns the failure reported when the regex matches an install log, with defaults applied.
+func (ilr InstallLogRegex) Failure() hivev1.InstallFailureMatch {
+	m := hivev1.InstallFailureMatch{
+		Name:      ilr.Name,
+		Reason:    ilr.InstallFailingReason,
+		Message:   ilr.InstallFailingMessage,
+		Category:  ilr.Category,
+		Severity:  ilr.Severity,
+		Retryable: ilr.Retryable == nil || *ilr.Retryable,
+	}
+	if !knownCategories[m.Category] {
+		m.Category = hivev1.InstallFailureCategoryUnknown
+	}
+	if _, ok := severityRanks[m.Severity]; !ok {
+		m.Severity = hivev1.InstallFailureSeverityError
+	}
+	return m
+}
+
+// searchRegexp compiles a search string. Search strings are case insensitive.
+func searchRegexp(ss string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + ss)
+}
+
+// Parse unmarshals the regexes data entry of an install log regexes ConfigMap.
+func Parse(raw string) ([]InstallLogRegex, error) {
+	regexes := []InstallLogRegex{}
+	if err := yaml.Unmarshal([]byte(raw), &regexes); err != nil {
+		return nil, err
+	}
+	return regexes, nil
+}
+
+// Validate returns an error for each search string of the regexes that does not compile. Such search strings never
+// match an install log.
+func Validate(regexes []InstallLogRegex, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ilr := range regexes {
+		for j, ss := range ilr.SearchRegexStrings {
+			if _, err := searchRegexp(ss); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Index(i).Child("searchRegexStrings").Index(j), ss, err.Error()))
+			}
+		}
+	}
+	return allErrs
+}
+
+// Shadowed returns the regexes that are never reported because another regex matches every install log they match
+// and takes precedence over them, i.e. it is more severe, or it is as severe and listed first. The returned map is
+// keyed by the index of the shadowed regex, with the index of the regex that shadows it as value.
+func Shadowed(regexes []InstallLogRegex) map[int]int {
+	shadowed := map[int]int{}
+	for i, ilr := range regexes {
+		for j, other := range regexes {
+			if i == j || !precedes(other, j, ilr, i) || !searchesFor(other, ilr.SearchRegexStrings) {
+				continue
+			}
+			shadowed[i] = j
+			break
+		}
+	}
+	return shadowed
+}
+
+// precedes returns whether regex a, at index ai, is reported rather than regex b, at index bi, when both match.
+func precedes(a InstallLogRegex, ai int, b InstallLogRegex, bi int) bool {
+	ar, br := severityRanks[a.Failure().Severity], severityRanks[b.Failure().Severity]
+	return ar < br || (ar == br && ai < bi)
+}
+
+// searchesFor returns whether the regex has all the given search strings.
+func searchesFor(ilr InstallLogRegex, searchStrings []string) bool {
+	if len(searchStrings) == 0 {
+		return false
+	}
+	for _, ss := range searchStrings {
+		found := false
+		for _, own := range ilr.SearchRegexStrings {
+			if strings.EqualFold(own, ss) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Match is a regex that matched an install log.
+type Match struct {
+	InstallLogRegex
+	// SearchString is the first search string of the regex that matched.
+	SearchString string
+	// Text is the text of the install log matched by the search string.
+	Text string
+}
+
+// Find returns the regexes that match the install log, in the order in which their failures are reported: most
+// severe first, and in the order of the regexes for the same severity. Search strings that do not compile are skipped.
+func Find(installLog string, regexes []InstallLogRegex, logger log.FieldLogger) []Match {
+	var matches []Match
+	for _, ilr := range regexes {
+		ilrLog := logger.WithField("regexName", ilr.Name)
+		ilrLog.Debug("parsing regex entry")
+		for _, ss := range ilr.SearchRegexStrings {
+			ssLog := ilrLog.WithField("searchString", ss)
+			ssLog.Debug("matching search string")
+			re, err := searchRegexp(ss)
+			if err != nil {
+				ssLog.WithError(err).Error("unable to compile regex")
+				continue
+			}
+			if loc := re.FindStringIndex(installLog); loc != nil {
+				matches = append(matches, Match{
+					InstallLogRegex: ilr,
+					SearchString:    ss,
+					Text:            installLog[loc[0]:loc[1]],
+				})
+				break
+			}
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return severityRanks[matches[i].Failure().Severity] < severityRanks[matches[j].Failure().Severity]
+	})
+	return matches
+}
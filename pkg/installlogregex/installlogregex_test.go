@@ -0,0 +1,148 @@
+package installlogregex
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const testRegexes = `
+- name: KubeAPIWaitTimeout
+  searchRegexStrings:
+  - "waiting for Kubernetes API: context deadline exceeded"
+  installFailingReason: KubeAPIWaitTimeout
+  installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+  severity: Warning
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "Tried to create resource record set.*but it already exists"
+  - "DNS record already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+  category: DNS
+`
+
+func TestParse(t *testing.T) {
+	regexes, err := Parse(testRegexes)
+	require.NoError(t, err, "unexpected error parsing regexes")
+	if assert.Len(t, regexes, 2, "unexpected number of regexes") {
+		assert.Equal(t, "KubeAPIWaitTimeout", regexes[0].Name, "unexpected name")
+		assert.Equal(t, []string{"Tried to create resource record set.*but it already exists", "DNS record already exists"}, regexes[1].SearchRegexStrings, "unexpected search strings")
+	}
+
+	_, err = Parse("malformed")
+	assert.Error(t, err, "expected error parsing malformed regexes")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		regexes        []InstallLogRegex
+		expectedFields []string
+	}{
+		{
+			name: "valid",
+			regexes: []InstallLogRegex{
+				{Name: "a", SearchRegexStrings: []string{"foo.*bar", `failed to load asset \"Install Config\"`}},
+			},
+		},
+		{
+			name: "invalid search strings",
+			regexes: []InstallLogRegex{
+				{Name: "a", SearchRegexStrings: []string{"foo"}},
+				{Name: "b", SearchRegexStrings: []string{"*", "bar", "(unclosed"}},
+			},
+			expectedFields: []string{
+				"regexes[1].searchRegexStrings[0]",
+				"regexes[1].searchRegexStrings[2]",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := Validate(test.regexes, field.NewPath("regexes"))
+			var fields []string
+			for _, err := range errs {
+				fields = append(fields, err.Field)
+			}
+			assert.Equal(t, test.expectedFields, fields, "unexpected invalid fields")
+		})
+	}
+}
+
+func TestShadowed(t *testing.T) {
+	tests := []struct {
+		name     string
+		regexes  []InstallLogRegex
+		expected map[int]int
+	}{
+		{
+			name: "distinct search strings",
+			regexes: []InstallLogRegex{
+				{Name: "a", SearchRegexStrings: []string{"foo"}},
+				{Name: "b", SearchRegexStrings: []string{"bar"}},
+			},
+			expected: map[int]int{},
+		},
+		{
+			name: "same search strings listed later",
+			regexes: []InstallLogRegex{
+				{Name: "a", SearchRegexStrings: []string{"foo", "bar"}},
+				{Name: "b", SearchRegexStrings: []string{"FOO"}},
+			},
+			expected: map[int]int{1: 0},
+		},
+		{
+			name: "subset of search strings does not shadow",
+			regexes: []InstallLogRegex{
+				{Name: "a", SearchRegexStrings: []string{"foo"}},
+				{Name: "b", SearchRegexStrings: []string{"foo", "bar"}},
+			},
+			expected: map[int]int{},
+		},
+		{
+			name: "more severe regex listed later",
+			regexes: []InstallLogRegex{
+				{Name: "a", SearchRegexStrings: []string{"foo"}},
+				{Name: "b", SearchRegexStrings: []string{"foo"}, Severity: "Critical"},
+			},
+			expected: map[int]int{0: 1},
+		},
+		{
+			name: "less severe regex listed first",
+			regexes: []InstallLogRegex{
+				{Name: "a", SearchRegexStrings: []string{"foo"}, Severity: "Warning"},
+				{Name: "b", SearchRegexStrings: []string{"foo"}},
+			},
+			expected: map[int]int{0: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Shadowed(test.regexes), "unexpected shadowed regexes")
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	regexes, err := Parse(testRegexes)
+	require.NoError(t, err, "unexpected error parsing regexes")
+	regexes = append(regexes, InstallLogRegex{Name: "Bad", SearchRegexStrings: []string{"*"}})
+
+	installLog := "level=fatal msg=\"waiting for Kubernetes API: context deadline exceeded\"\n" +
+		"Error building changeset: InvalidChangeBatch: [Tried to create resource record set [name='api.example.com.'type='A'] but it already exists]"
+	matches := Find(installLog, regexes, log.WithField("test", t.Name()))
+	if assert.Len(t, matches, 2, "unexpected number of matches") {
+		assert.Equal(t, "DNSAlreadyExists", matches[0].Name, "expected the most severe regex first")
+		assert.Equal(t, "Tried to create resource record set.*but it already exists", matches[0].SearchString, "unexpected search string")
+		assert.Equal(t, "Tried to create resource record set [name='api.example.com.'type='A'] but it already exists", matches[0].Text, "unexpected matched text")
+		assert.Equal(t, "KubeAPIWaitTimeout", matches[1].Name, "expected the less severe regex last")
+		assert.Equal(t, "waiting for Kubernetes API: context deadline exceeded", matches[1].Text, "unexpected matched text")
+	}
+
+	assert.Empty(t, Find("nothing to see here", regexes, log.WithField("test", t.Name())), "expected no matches")
+}
@@ -0,0 +1,176 @@
+package installmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// azureBlobAPIVersion is the version of the Blob Storage REST API used to upload blobs. OAuth authorization requires
+// 2017-11-09 or later.
+const azureBlobAPIVersion = "2020-10-02"
+
+// Ensure azureLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &azureLogUploaderActuator{}
+
+// azureLogUploaderActuator uploads installer logs to an Azure Blob Storage container.
+type azureLogUploaderActuator struct {
+	// blobClientFn is the function to build a Blob Storage client, here for lazy loading the client.
+	blobClientFn func(c client.Client, secretName, namespace, storageAccount, cloudName string, logger log.FieldLogger) (*azureBlobClient, error)
+}
+
+// azureBlobClient uploads blobs to a storage account. The vendored Azure SDK has no Blob Storage client, so it uses
+// the REST API directly.
+type azureBlobClient struct {
+	// serviceURL is the blob service endpoint of the storage account.
+	serviceURL string
+	authorizer autorest.Authorizer
+	sender     autorest.Sender
+}
+
+// IsConfigured returns true if the install logs upload provider is set to Azure Blob Storage
+func (a *azureLogUploaderActuator) IsConfigured() bool {
+	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+
+	return provider == constants.InstallLogsUploadProviderAzure
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *azureLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	storageAccount, foundStorageAccountEnvVar := os.LookupEnv(constants.InstallLogsAzureStorageAccountEnvVar)
+	if !foundStorageAccountEnvVar {
+		return errors.New("couldn't find storage account in environment variable. Skipping upload")
+	}
+
+	container, foundContainerEnvVar := os.LookupEnv(constants.InstallLogsAzureContainerEnvVar)
+	if !foundContainerEnvVar {
+		return errors.New("couldn't find container in environment variable. Skipping upload")
+	}
+
+	cloudName := os.Getenv(constants.InstallLogsAzureCloudNameEnvVar)
+
+	blobClient, err := a.blobClientFn(c, secretName, clusterprovision.Namespace, storageAccount, cloudName, log)
+	if err != nil {
+		return err
+	}
+
+	retvalErrs := []error{}
+
+	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+
+	log.Infof("Uploading log(s) to Azure Blob Storage: %v/%v/%v/", blobClient.serviceURL, container, folder)
+
+	for _, filename := range filenames {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed reading log file: %v", filename))
+			continue
+		}
+
+		stat, err := os.Stat(filename)
+		if err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed stat on log file: %v", filename))
+			continue
+		}
+
+		logkey := fmt.Sprintf("%v/%v-%v", folder, clusterprovision.Name, stat.Name())
+
+		if err := blobClient.upload(context.TODO(), container, logkey, content); err != nil {
+			retvalErrs = append(retvalErrs, errors.Wrapf(err, "Failed uploading log file: %v", filename))
+		}
+	}
+
+	return utilerrors.NewAggregate(retvalErrs)
+}
+
+// upload creates or replaces the block blob with the given name in the container.
+func (c *azureBlobClient) upload(ctx context.Context, container, name string, content []byte) error {
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx),
+		autorest.AsPut(),
+		autorest.WithBaseURL(c.serviceURL),
+		// Blob names are made of cluster and provision names, which need no escaping.
+		autorest.WithPath(path.Join(container, name)),
+		autorest.WithHeader("x-ms-blob-type", "BlockBlob"),
+		autorest.WithHeader("x-ms-version", azureBlobAPIVersion),
+		autorest.WithBytes(&content),
+		c.authorizer.WithAuthorization(),
+	)
+	if err != nil {
+		return err
+	}
+	resp, err := autorest.SendWithSender(c.sender, req)
+	if err != nil {
+		return err
+	}
+	return autorest.Respond(resp,
+		azure.WithErrorUnlessStatusCode(http.StatusCreated),
+		autorest.ByClosing(),
+	)
+}
+
+func getAzureBlobClient(c client.Client, secretName, namespace, storageAccount, cloudName string, logger log.FieldLogger) (*azureBlobClient, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		logger.WithError(err).Error("failed to get Azure credentials secret")
+		return nil, err
+	}
+	authJSON, ok := secret.Data[constants.AzureCredentialsName]
+	if !ok {
+		return nil, fmt.Errorf("creds secret does not contain %q data", constants.AzureCredentialsName)
+	}
+	var creds struct {
+		ClientID     string `json:"clientId"`
+		ClientSecret string `json:"clientSecret"`
+		TenantID     string `json:"tenantId"`
+	}
+	if err := json.Unmarshal(authJSON, &creds); err != nil {
+		return nil, errors.Wrap(err, "could not parse Azure credentials")
+	}
+
+	if cloudName == "" {
+		cloudName = azure.PublicCloud.Name
+	}
+	env, err := azure.EnvironmentFromName(cloudName)
+	if err != nil {
+		return nil, err
+	}
+
+	config := auth.NewClientCredentialsConfig(creds.ClientID, creds.ClientSecret, creds.TenantID)
+	config.Resource = env.ResourceIdentifiers.Storage
+	config.AADEndpoint = env.ActiveDirectoryEndpoint
+	authorizer, err := config.Authorizer()
+	if err != nil {
+		logger.WithError(err).Error("failed to get Azure authorizer")
+		return nil, err
+	}
+	return &azureBlobClient{
+		serviceURL: fmt.Sprintf("https://%s.blob.%s", storageAccount, env.StorageEndpointSuffix),
+		authorizer: authorizer,
+		sender:     &http.Client{},
+	}, nil
+}
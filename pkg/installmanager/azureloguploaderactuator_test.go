@@ -0,0 +1,90 @@
+package installmanager
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestAzureUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		setupEnvVars            bool
+		statusCode              int
+		expectedUploadLogsError bool
+	}{
+		{
+			name:                    "missing env vars",
+			expectedUploadLogsError: true,
+		},
+		{
+			name:         "successfully upload blobs",
+			setupEnvVars: true,
+			statusCode:   http.StatusCreated,
+		},
+		{
+			name:                    "upload denied",
+			setupEnvVars:            true,
+			statusCode:              http.StatusForbidden,
+			expectedUploadLogsError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			var uploads []*http.Request
+			var uploaded []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				uploads = append(uploads, r)
+				uploaded, _ = io.ReadAll(r.Body)
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			mocks := setupDefaultMocks(t)
+
+			if test.setupEnvVars {
+				t.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderAzure)
+				t.Setenv(constants.InstallLogsCredentialsSecretRefEnvVar, "notarealsecret")
+				t.Setenv(constants.InstallLogsAzureStorageAccountEnvVar, "account1")
+				t.Setenv(constants.InstallLogsAzureContainerEnvVar, "container1")
+			}
+
+			actuator := &azureLogUploaderActuator{blobClientFn: func(client.Client, string, string, string, string, log.FieldLogger) (*azureBlobClient, error) {
+				return &azureBlobClient{
+					serviceURL: server.URL,
+					authorizer: autorest.NullAuthorizer{},
+					sender:     server.Client(),
+				}, nil
+			}}
+			provision := testClusterProvision()
+
+			// Act
+			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			// Assert
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+				return
+			}
+			require.NoError(t, err, "Function errored unexpectedly")
+			require.Len(t, uploads, 1, "expected a single upload")
+			assert.Equal(t, http.MethodPut, uploads[0].Method, "unexpected upload method")
+			assert.Equal(t, "/container1/notarealcluster-test-namespace/test-provision-issue", uploads[0].URL.Path, "unexpected blob path")
+			assert.Equal(t, "BlockBlob", uploads[0].Header.Get("x-ms-blob-type"), "unexpected blob type")
+			expected, err := os.ReadFile("/etc/issue")
+			require.NoError(t, err)
+			assert.Equal(t, expected, uploaded, "unexpected uploaded content")
+		})
+	}
+}
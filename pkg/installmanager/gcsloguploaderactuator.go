@@ -0,0 +1,112 @@
+package installmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Ensure gcsLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &gcsLogUploaderActuator{}
+
+// gcsLogUploaderActuator uploads installer logs to a Google Cloud Storage bucket.
+type gcsLogUploaderActuator struct {
+	// gcsServiceFn is the function to build a Cloud Storage client, here for lazy loading the client.
+	gcsServiceFn func(c client.Client, secretName, namespace string, logger log.FieldLogger) (*storage.Service, error)
+}
+
+// IsConfigured returns true if the install logs upload provider is set to GCS
+func (a *gcsLogUploaderActuator) IsConfigured() bool {
+	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+
+	return provider == constants.InstallLogsUploadProviderGCP
+}
+
+// UploadLogs uploads installer logs to the provider's storage mechanism.
+func (a *gcsLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	secretName, foundSecretName := os.LookupEnv(constants.InstallLogsCredentialsSecretRefEnvVar)
+	if !foundSecretName {
+		return errors.New("couldn't find secret name in environment variable. Skipping upload")
+	}
+
+	bucket, foundBucketEnvVar := os.LookupEnv(constants.InstallLogsGCPBucketEnvVar)
+	if !foundBucketEnvVar {
+		return errors.New("couldn't find bucket in environment variable. Skipping upload")
+	}
+
+	svc, err := a.gcsServiceFn(c, secretName, clusterprovision.Namespace, log)
+	if err != nil {
+		return err
+	}
+
+	retvalErrs := []error{}
+
+	folder := fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace)
+
+	log.Infof("Uploading log(s) to GCS: gs://%v/%v/", bucket, folder)
+
+	for _, filename := range filenames {
+		if err := uploadGCSLogFile(svc, bucket, folder, clusterprovision.Name, filename); err != nil {
+			retvalErrs = append(retvalErrs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(retvalErrs)
+}
+
+func uploadGCSLogFile(svc *storage.Service, bucket, folder, provisionName, filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return errors.Wrapf(err, "Failed opening log file: %v", filename)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return errors.Wrapf(err, "Failed stat on log file: %v", filename)
+	}
+
+	logkey := fmt.Sprintf("%v/%v-%v", folder, provisionName, stat.Name())
+
+	_, err = svc.Objects.Insert(bucket, &storage.Object{Name: logkey}).Media(file).Do()
+	return errors.Wrapf(err, "Failed uploading log file: %v", filename)
+}
+
+func getGCSService(c client.Client, secretName, namespace string, logger log.FieldLogger) (*storage.Service, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: secretName, Namespace: namespace}, secret); err != nil {
+		logger.WithError(err).Error("failed to get GCP credentials secret")
+		return nil, err
+	}
+	authJSON, ok := secret.Data[constants.GCPCredentialsName]
+	if !ok {
+		return nil, fmt.Errorf("creds secret does not contain %q data", constants.GCPCredentialsName)
+	}
+	creds, err := google.CredentialsFromJSON(context.TODO(), authJSON, storage.DevstorageReadWriteScope)
+	if err != nil {
+		logger.WithError(err).Error("failed to load GCP credentials")
+		return nil, err
+	}
+	svc, err := storage.NewService(context.TODO(), option.WithCredentials(creds), option.WithUserAgent("openshift.io hive/v1"))
+	if err != nil {
+		logger.WithError(err).Error("failed to get GCS client")
+	}
+	return svc, err
+}
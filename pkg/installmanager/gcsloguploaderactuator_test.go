@@ -0,0 +1,92 @@
+package installmanager
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestGCSUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		setupEnvVars            bool
+		statusCode              int
+		expectedUploadLogsError bool
+	}{
+		{
+			name:                    "missing env vars",
+			expectedUploadLogsError: true,
+		},
+		{
+			name:         "successfully upload objects",
+			setupEnvVars: true,
+			statusCode:   http.StatusOK,
+		},
+		{
+			name:                    "upload denied",
+			setupEnvVars:            true,
+			statusCode:              http.StatusForbidden,
+			expectedUploadLogsError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			var uploads []*http.Request
+			var uploaded []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				uploads = append(uploads, r)
+				uploaded, _ = io.ReadAll(r.Body)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(test.statusCode)
+				w.Write([]byte("{}"))
+			}))
+			defer server.Close()
+
+			mocks := setupDefaultMocks(t)
+
+			if test.setupEnvVars {
+				t.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderGCP)
+				t.Setenv(constants.InstallLogsCredentialsSecretRefEnvVar, "notarealsecret")
+				t.Setenv(constants.InstallLogsGCPBucketEnvVar, "bucket1")
+			}
+
+			actuator := &gcsLogUploaderActuator{gcsServiceFn: func(client.Client, string, string, log.FieldLogger) (*storage.Service, error) {
+				return storage.NewService(context.Background(),
+					option.WithEndpoint(server.URL+"/storage/v1/"),
+					option.WithHTTPClient(server.Client()),
+				)
+			}}
+			provision := testClusterProvision()
+
+			// Act
+			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			// Assert
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+				return
+			}
+			require.NoError(t, err, "Function errored unexpectedly")
+			require.Len(t, uploads, 1, "expected a single upload")
+			assert.Equal(t, http.MethodPost, uploads[0].Method, "unexpected upload method")
+			assert.Equal(t, "/upload/storage/v1/b/bucket1/o", uploads[0].URL.Path, "unexpected upload path")
+			assert.Contains(t, string(uploaded), `"name":"notarealcluster-test-namespace/test-provision-issue"`, "unexpected object name")
+			expected, err := os.ReadFile("/etc/issue")
+			require.NoError(t, err)
+			assert.Contains(t, string(uploaded), string(expected), "unexpected uploaded content")
+		})
+	}
+}
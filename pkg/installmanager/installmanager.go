@@ -128,6 +128,7 @@ type InstallManager struct {
 	loadSecrets                      func(*InstallManager, *hivev1.ClusterDeployment)
 	cleanupFailedProvision           func(dynamicClient client.Client, cd *hivev1.ClusterDeployment, infraID string, logger log.FieldLogger) error
 	updateClusterProvision           func(*InstallManager, provisionMutation) error
+	recordInstallPhase               func(*InstallManager, hivev1.InstallPhase, time.Time) error
 	readClusterMetadata              func(*InstallManager) ([]byte, *installertypes.ClusterMetadata, error)
 	uploadAdminKubeconfig            func(*InstallManager) (*corev1.Secret, error)
 	uploadAdminPassword              func(*InstallManager) (*corev1.Secret, error)
@@ -213,6 +214,7 @@ func (m *InstallManager) Complete(args []string) error {
 	// Connect up structure's function pointers
 	m.loadSecrets = loadSecrets
 	m.updateClusterProvision = updateClusterProvisionWithRetries
+	m.recordInstallPhase = recordInstallPhase
 	m.readClusterMetadata = readClusterMetadata
 	m.uploadAdminKubeconfig = uploadAdminKubeconfig
 	m.uploadAdminPassword = uploadAdminPassword
@@ -345,6 +347,11 @@ func (m *InstallManager) Run() error {
 		m.log.WithError(err).Error("error reading install-config.yaml")
 		return err
 	}
+	icData, err = applyInstallConfigRemediations(icData, m.ClusterProvision.Spec.InstallConfigRemediations, m.log)
+	if err != nil {
+		m.log.WithError(err).Error("error applying remediations to install-config.yaml")
+		return err
+	}
 	icData, err = pasteInPullSecret(icData, m.PullSecretMountPath)
 	if err != nil {
 		m.log.WithError(err).Error("error adding pull secret to install-config.yaml")
@@ -586,6 +593,9 @@ func getActuator() LogUploaderActuator {
 	// As we add more LogUploaderActuators, add them here
 	actuators := []LogUploaderActuator{
 		&s3LogUploaderActuator{awsClientFn: getAWSClient},
+		&gcsLogUploaderActuator{gcsServiceFn: getGCSService},
+		&azureLogUploaderActuator{blobClientFn: getAzureBlobClient},
+		&pvcLogUploaderActuator{dir: constants.InstallLogsPVCMountDir},
 	}
 
 	for _, a := range actuators {
@@ -1060,7 +1070,8 @@ func (m *InstallManager) runOpenShiftInstallCommand(args ...string) error {
 }
 
 // tailFullInstallLog streams the full install log to standard out so that
-// the log can be seen from the pods logs.
+// the log can be seen from the pods logs. It also records the install phases
+// reported by the installer in the status of the ClusterProvision.
 func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 	logfileName := filepath.Join(m.WorkDir, installerFullLogFile)
 	m.waitForFiles([]string{logfileName})
@@ -1116,6 +1127,12 @@ func (m *InstallManager) tailFullInstallLog(scrubInstallLog bool) {
 			continue
 		}
 
+		if phase, at, ok := parseInstallPhase(fullLine); ok {
+			if err := m.recordInstallPhase(m, phase, at); err != nil {
+				m.log.WithError(err).WithField("phase", phase).Warn("could not record install phase")
+			}
+		}
+
 		if scrubInstallLog {
 			cleanLine := cleanupLogOutput(fullLine)
 			fmt.Println(cleanLine + suffix)
@@ -1759,6 +1776,30 @@ func pasteInPullSecret(icData []byte, pullSecretFile string) ([]byte, error) {
 	return yaml.Marshal(icRaw)
 }
 
+// applyInstallConfigRemediations applies the patches of the remediations of a provision attempt to the install-config,
+// in order.
+func applyInstallConfigRemediations(icData []byte, remediations []hivev1.InstallConfigRemediation, logger log.FieldLogger) ([]byte, error) {
+	for _, remediation := range remediations {
+		patch := yamlpatch.Patch{}
+		for _, p := range remediation.InstallConfigPatches {
+			var value interface{} = p.Value
+			patch = append(patch, yamlpatch.Operation{
+				Op:    yamlpatch.Op(p.Op),
+				Path:  yamlpatch.OpPath(p.Path),
+				From:  yamlpatch.OpPath(p.From),
+				Value: yamlpatch.NewNode(&value),
+			})
+		}
+		patched, err := patch.Apply(icData)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not apply remediation %s", remediation.Name)
+		}
+		logger.WithField("remediation", remediation.Name).Info("applied remediation to install-config.yaml")
+		icData = patched
+	}
+	return icData, nil
+}
+
 func getHomeDir() string {
 	home := os.Getenv("HOME")
 	if home != "" {
@@ -24,6 +24,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	machineapi "github.com/openshift/api/machine/v1beta1"
 	installertypes "github.com/openshift/installer/pkg/types"
@@ -252,7 +253,7 @@ func TestInstallManager(t *testing.T) {
 			im.cleanupFailedProvision = alwaysSucceedCleanupFailedProvision
 
 			// Save the list of actuators so that it can be restored at the end of this test
-			im.actuator = &s3LogUploaderActuator{awsClientFn: func(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
+			im.actuator = &s3LogUploaderActuator{awsClientFn: func(c client.Client, secretName, namespace, region, endpoint string, logger log.FieldLogger) (awsclient.Client, error) {
 				return mocks.mockAWSClient, nil
 			}}
 
@@ -724,6 +725,73 @@ func Test_pasteInPullSecret(t *testing.T) {
 	}
 }
 
+func Test_applyInstallConfigRemediations(t *testing.T) {
+	cases := []struct {
+		name                 string
+		remediations         []hivev1.InstallConfigRemediation
+		expectErr            bool
+		expectedInstanceType string
+		expectedRootVolume   bool
+	}{
+		{
+			name:                 "no remediations",
+			expectedInstanceType: "m5.xlarge",
+			expectedRootVolume:   true,
+		},
+		{
+			name: "remediations applied in order",
+			remediations: []hivev1.InstallConfigRemediation{
+				{
+					Name:    "larger-instances",
+					Reasons: []string{"AWSInsufficientCapacity"},
+					InstallConfigPatches: []hivev1.PatchEntity{
+						{Op: "replace", Path: "/compute/0/platform/aws/type", Value: "m5.2xlarge"},
+					},
+				},
+				{
+					Name:    "even-larger-instances",
+					Reasons: []string{"AWSInsufficientCapacity"},
+					InstallConfigPatches: []hivev1.PatchEntity{
+						{Op: "replace", Path: "/compute/0/platform/aws/type", Value: "m5.4xlarge"},
+						{Op: "remove", Path: "/compute/0/platform/aws/rootVolume"},
+					},
+				},
+			},
+			expectedInstanceType: "m5.4xlarge",
+		},
+		{
+			name: "remediation does not apply",
+			remediations: []hivev1.InstallConfigRemediation{
+				{
+					Name:    "other-zones",
+					Reasons: []string{"AWSInsufficientCapacity"},
+					InstallConfigPatches: []hivev1.PatchEntity{
+						{Op: "remove", Path: "/compute/0/platform/aws/zones"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			icData, err := os.ReadFile(filepath.Join("testdata", "install-config.yaml"))
+			require.NoError(t, err, "unexpected error reading install-config.yaml")
+			actual, err := applyInstallConfigRemediations(icData, tc.remediations, log.WithField("test", tc.name))
+			if tc.expectErr {
+				assert.Error(t, err, "expected error applying remediations")
+				return
+			}
+			require.NoError(t, err, "unexpected error applying remediations")
+			ic := &installertypes.InstallConfig{}
+			require.NoError(t, yaml.Unmarshal(actual, ic), "unexpected error unmarshalling remediated install-config")
+			require.Len(t, ic.Compute, 1, "unexpected compute pools")
+			assert.Equal(t, tc.expectedInstanceType, ic.Compute[0].Platform.AWS.InstanceType, "unexpected instance type")
+			assert.Equal(t, tc.expectedRootVolume, ic.Compute[0].Platform.AWS.EC2RootVolume.Size != 0, "unexpected root volume")
+		})
+	}
+}
+
 func TestPatchWorkerMachineSet(t *testing.T) {
 	machineSetYAMLBase := `---
 apiVersion: machine.openshift.io/v1beta1
@@ -0,0 +1,81 @@
+package installmanager
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+var (
+	// installPhaseMessages are the messages of the installer log that mark the start of each install phase.
+	installPhaseMessages = []struct {
+		phase   hivev1.InstallPhase
+		message *regexp.Regexp
+	}{
+		{hivev1.InstallPhaseInfrastructureCreation, regexp.MustCompile(`Creating infrastructure resources`)},
+		{hivev1.InstallPhaseBootstrap, regexp.MustCompile(`Waiting up to \S+ (\(until [^)]*\) )?for the Kubernetes API`)},
+		{hivev1.InstallPhaseBootstrapComplete, regexp.MustCompile(`Destroying the bootstrap resources|It is now safe to remove the bootstrap resources`)},
+		{hivev1.InstallPhaseClusterOperators, regexp.MustCompile(`Waiting up to \S+ (\(until [^)]*\) )?for the cluster at \S+ to initialize`)},
+		{hivev1.InstallPhaseInstallComplete, regexp.MustCompile(`Install complete!`)},
+	}
+
+	// installLogTime extracts the timestamp of a line of the installer log.
+	installLogTime = regexp.MustCompile(`^time="([^"]+)"`)
+)
+
+// parseInstallPhase returns the install phase whose start is marked by a line of the installer log, and the time at
+// which the line was logged. The current time is used if the line has no timestamp.
+func parseInstallPhase(line string) (hivev1.InstallPhase, time.Time, bool) {
+	for _, p := range installPhaseMessages {
+		if !p.message.MatchString(line) {
+			continue
+		}
+		if m := installLogTime.FindStringSubmatch(line); m != nil {
+			if t, err := time.Parse(time.RFC3339, m[1]); err == nil {
+				return p.phase, t, true
+			}
+		}
+		return p.phase, time.Now(), true
+	}
+	return "", time.Time{}, false
+}
+
+// setInstallPhase records that the install reached the phase at the given time, completing the phase it was in.
+// It returns false if the phase was already recorded.
+func setInstallPhase(status *hivev1.ClusterProvisionStatus, phase hivev1.InstallPhase, at time.Time) bool {
+	for _, p := range status.InstallPhases {
+		if p.Name == phase {
+			return false
+		}
+	}
+	start := metav1.NewTime(at)
+	if n := len(status.InstallPhases); n > 0 && status.InstallPhases[n-1].CompletionTime == nil {
+		status.InstallPhases[n-1].CompletionTime = &start
+	}
+	status.InstallPhases = append(status.InstallPhases, hivev1.ClusterProvisionInstallPhase{
+		Name:      phase,
+		StartTime: start,
+	})
+	return true
+}
+
+// recordInstallPhase records that the install reached the phase in the status of the ClusterProvision. It uses its
+// own copy of the ClusterProvision since it is called while the install is running.
+func recordInstallPhase(m *InstallManager, phase hivev1.InstallPhase, at time.Time) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		provision := &hivev1.ClusterProvision{}
+		if err := m.DynamicClient.Get(context.TODO(), types.NamespacedName{Namespace: m.Namespace, Name: m.ClusterProvisionName}, provision); err != nil {
+			return err
+		}
+		if !setInstallPhase(&provision.Status, phase, at) {
+			return nil
+		}
+		return m.DynamicClient.Status().Update(context.TODO(), provision)
+	})
+}
@@ -0,0 +1,147 @@
+package installmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestParseInstallPhase(t *testing.T) {
+	tests := []struct {
+		name          string
+		line          string
+		expectedPhase hivev1.InstallPhase
+		expectedTime  string
+	}{
+		{
+			name:          "infrastructure creation",
+			line:          `time="2021-03-04T10:00:00Z" level=info msg="Creating infrastructure resources..."`,
+			expectedPhase: hivev1.InstallPhaseInfrastructureCreation,
+			expectedTime:  "2021-03-04T10:00:00Z",
+		},
+		{
+			name:          "bootstrap",
+			line:          `time="2021-03-04T10:05:00Z" level=info msg="Waiting up to 20m0s for the Kubernetes API at https://api.test.example.com:6443..."`,
+			expectedPhase: hivev1.InstallPhaseBootstrap,
+			expectedTime:  "2021-03-04T10:05:00Z",
+		},
+		{
+			name:          "bootstrap with deadline",
+			line:          `time="2021-03-04T10:05:00Z" level=info msg="Waiting up to 20m0s (until 10:25AM) for the Kubernetes API at https://api.test.example.com:6443..."`,
+			expectedPhase: hivev1.InstallPhaseBootstrap,
+			expectedTime:  "2021-03-04T10:05:00Z",
+		},
+		{
+			name:          "bootstrap complete",
+			line:          `time="2021-03-04T10:20:00Z" level=info msg="Destroying the bootstrap resources..."`,
+			expectedPhase: hivev1.InstallPhaseBootstrapComplete,
+			expectedTime:  "2021-03-04T10:20:00Z",
+		},
+		{
+			name:          "cluster operators",
+			line:          `time="2021-03-04T10:22:00Z" level=info msg="Waiting up to 40m0s for the cluster at https://api.test.example.com:6443 to initialize..."`,
+			expectedPhase: hivev1.InstallPhaseClusterOperators,
+			expectedTime:  "2021-03-04T10:22:00Z",
+		},
+		{
+			name:          "install complete",
+			line:          `time="2021-03-04T10:45:00Z" level=info msg="Install complete!"`,
+			expectedPhase: hivev1.InstallPhaseInstallComplete,
+			expectedTime:  "2021-03-04T10:45:00Z",
+		},
+		{
+			name:          "no timestamp",
+			line:          `Install complete!`,
+			expectedPhase: hivev1.InstallPhaseInstallComplete,
+		},
+		{
+			name: "not a phase",
+			line: `time="2021-03-04T10:06:00Z" level=debug msg="Still waiting for the Kubernetes API: Get https://api.test.example.com:6443/version: dial tcp: i/o timeout"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := time.Now()
+			phase, at, ok := parseInstallPhase(test.line)
+			if test.expectedPhase == "" {
+				assert.False(t, ok, "expected no install phase")
+				return
+			}
+			if assert.True(t, ok, "expected an install phase") {
+				assert.Equal(t, test.expectedPhase, phase, "unexpected install phase")
+				if test.expectedTime != "" {
+					expectedTime, _ := time.Parse(time.RFC3339, test.expectedTime)
+					assert.True(t, expectedTime.Equal(at), "unexpected time %v", at)
+				} else {
+					assert.False(t, at.Before(before), "expected the current time")
+				}
+			}
+		})
+	}
+}
+
+func TestSetInstallPhase(t *testing.T) {
+	start := time.Date(2021, 3, 4, 10, 0, 0, 0, time.UTC)
+	bootstrap := start.Add(5 * time.Minute)
+	tests := []struct {
+		name            string
+		existing        []hivev1.ClusterProvisionInstallPhase
+		phase           hivev1.InstallPhase
+		at              time.Time
+		expectedChanged bool
+		expected        []hivev1.ClusterProvisionInstallPhase
+	}{
+		{
+			name:            "first phase",
+			phase:           hivev1.InstallPhaseInfrastructureCreation,
+			at:              start,
+			expectedChanged: true,
+			expected: []hivev1.ClusterProvisionInstallPhase{
+				{Name: hivev1.InstallPhaseInfrastructureCreation, StartTime: metav1.NewTime(start)},
+			},
+		},
+		{
+			name: "completes previous phase",
+			existing: []hivev1.ClusterProvisionInstallPhase{
+				{Name: hivev1.InstallPhaseInfrastructureCreation, StartTime: metav1.NewTime(start)},
+			},
+			phase:           hivev1.InstallPhaseBootstrap,
+			at:              bootstrap,
+			expectedChanged: true,
+			expected: []hivev1.ClusterProvisionInstallPhase{
+				{Name: hivev1.InstallPhaseInfrastructureCreation, StartTime: metav1.NewTime(start), CompletionTime: timePtr(bootstrap)},
+				{Name: hivev1.InstallPhaseBootstrap, StartTime: metav1.NewTime(bootstrap)},
+			},
+		},
+		{
+			name: "phase already recorded",
+			existing: []hivev1.ClusterProvisionInstallPhase{
+				{Name: hivev1.InstallPhaseInfrastructureCreation, StartTime: metav1.NewTime(start)},
+			},
+			phase:           hivev1.InstallPhaseInfrastructureCreation,
+			at:              bootstrap,
+			expectedChanged: false,
+			expected: []hivev1.ClusterProvisionInstallPhase{
+				{Name: hivev1.InstallPhaseInfrastructureCreation, StartTime: metav1.NewTime(start)},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status := &hivev1.ClusterProvisionStatus{InstallPhases: test.existing}
+			changed := setInstallPhase(status, test.phase, test.at)
+			assert.Equal(t, test.expectedChanged, changed, "unexpected changed")
+			assert.Equal(t, test.expected, status.InstallPhases, "unexpected install phases")
+		})
+	}
+}
+
+func timePtr(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
@@ -0,0 +1,77 @@
+package installmanager
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/constants"
+)
+
+// Ensure pvcLogUploaderActuator implements the Actuator interface. This will fail at compile time when false.
+var _ LogUploaderActuator = &pvcLogUploaderActuator{}
+
+// pvcLogUploaderActuator copies installer logs to a PersistentVolumeClaim mounted in the install pod.
+type pvcLogUploaderActuator struct {
+	// dir is the directory where the claim is mounted.
+	dir string
+}
+
+// IsConfigured returns true if the install logs upload provider is set to a PersistentVolumeClaim
+func (a *pvcLogUploaderActuator) IsConfigured() bool {
+	provider, foundProviderEnvVar := os.LookupEnv(constants.InstallLogsUploadProviderEnvVar)
+	if !foundProviderEnvVar {
+		log.Debug("Couldn't find install logs provider environment variable. Skipping.")
+		return false
+	}
+
+	return provider == constants.InstallLogsUploadProviderPVC
+}
+
+// UploadLogs copies installer logs to the mounted claim.
+func (a *pvcLogUploaderActuator) UploadLogs(clusterName string, clusterprovision *hivev1.ClusterProvision, c client.Client, log log.FieldLogger, filenames ...string) error {
+	if _, err := os.Stat(a.dir); err != nil {
+		return errors.Wrap(err, "install logs claim is not mounted. Skipping upload")
+	}
+
+	folder := filepath.Join(a.dir, fmt.Sprintf("%v-%v", clusterName, clusterprovision.Namespace))
+	if err := os.MkdirAll(folder, 0755); err != nil {
+		return errors.Wrapf(err, "Failed creating log directory: %v", folder)
+	}
+
+	log.Infof("Copying log(s) to claim: %v/", folder)
+
+	retvalErrs := []error{}
+	for _, filename := range filenames {
+		if err := copyLogFile(filename, folder, clusterprovision.Name); err != nil {
+			retvalErrs = append(retvalErrs, err)
+		}
+	}
+
+	return utilerrors.NewAggregate(retvalErrs)
+}
+
+func copyLogFile(filename, folder, provisionName string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return errors.Wrapf(err, "Failed opening log file: %v", filename)
+	}
+	defer file.Close()
+
+	dest, err := os.Create(filepath.Join(folder, fmt.Sprintf("%v-%v", provisionName, filepath.Base(filename))))
+	if err != nil {
+		return errors.Wrapf(err, "Failed creating copy of log file: %v", filename)
+	}
+	if _, err := io.Copy(dest, file); err != nil {
+		dest.Close()
+		return errors.Wrapf(err, "Failed copying log file: %v", filename)
+	}
+	return errors.Wrapf(dest.Close(), "Failed copying log file: %v", filename)
+}
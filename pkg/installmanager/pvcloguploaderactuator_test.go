@@ -0,0 +1,120 @@
+package installmanager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+func TestPVCIsConfigured(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		expected bool
+	}{
+		{
+			name: "missing env var",
+		},
+		{
+			name:     "other provider",
+			provider: constants.InstallLogsUploadProviderAWS,
+		},
+		{
+			name:     "pvc provider",
+			provider: constants.InstallLogsUploadProviderPVC,
+			expected: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.provider != "" {
+				t.Setenv(constants.InstallLogsUploadProviderEnvVar, test.provider)
+			}
+			actuator := &pvcLogUploaderActuator{dir: t.TempDir()}
+			assert.Equal(t, test.expected, actuator.IsConfigured())
+		})
+	}
+}
+
+func TestPVCUploadLogs(t *testing.T) {
+	tests := []struct {
+		name                    string
+		unmounted               bool
+		filenames               []string
+		expectedUploadLogsError bool
+		expectedFiles           []string
+	}{
+		{
+			name:          "successfully copy logs",
+			filenames:     []string{"install.log", "installer-gather.tar.gz"},
+			expectedFiles: []string{"test-provision-install.log", "test-provision-installer-gather.tar.gz"},
+		},
+		{
+			name:                    "claim not mounted",
+			unmounted:               true,
+			filenames:               []string{"install.log"},
+			expectedUploadLogsError: true,
+		},
+		{
+			name:                    "missing log file",
+			filenames:               []string{"install.log", "missing.log"},
+			expectedUploadLogsError: true,
+			expectedFiles:           []string{"test-provision-install.log"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			mocks := setupDefaultMocks(t)
+			srcDir := t.TempDir()
+			var filenames []string
+			for _, name := range test.filenames {
+				filename := filepath.Join(srcDir, name)
+				if name != "missing.log" {
+					require.NoError(t, os.WriteFile(filename, []byte("contents of "+name), 0644))
+				}
+				filenames = append(filenames, filename)
+			}
+			claimDir := t.TempDir()
+			if test.unmounted {
+				claimDir = filepath.Join(claimDir, "unmounted")
+			}
+			actuator := &pvcLogUploaderActuator{dir: claimDir}
+			provision := testClusterProvision()
+
+			// Act
+			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), filenames...)
+
+			// Assert
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+			} else {
+				assert.NoError(t, err, "Function errored unexpectedly")
+			}
+			if test.unmounted {
+				_, err := os.Stat(claimDir)
+				assert.True(t, os.IsNotExist(err), "expected no directory to be created for an unmounted claim")
+				return
+			}
+			folder := filepath.Join(claimDir, "notarealcluster-test-namespace")
+			entries, err := os.ReadDir(folder)
+			require.NoError(t, err, "could not read log directory")
+			var actualFiles []string
+			for _, entry := range entries {
+				actualFiles = append(actualFiles, entry.Name())
+			}
+			assert.Equal(t, test.expectedFiles, actualFiles, "unexpected log files")
+			for i, name := range test.expectedFiles {
+				contents, err := os.ReadFile(filepath.Join(folder, name))
+				require.NoError(t, err, "could not read copied log file")
+				assert.Equal(t, "contents of "+test.filenames[i], string(contents), "unexpected contents of copied log file")
+			}
+		})
+	}
+}
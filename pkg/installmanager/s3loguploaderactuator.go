@@ -23,7 +23,7 @@ var _ LogUploaderActuator = &s3LogUploaderActuator{}
 // s3LogUploaderActuator manages getting the desired state, getting the current state and reconciling the two.
 type s3LogUploaderActuator struct {
 	// awsClientFn is the function to build an AWS client, here for lazy loading the client.
-	awsClientFn func(client.Client, string, string, string, log.FieldLogger) (awsclient.Client, error)
+	awsClientFn func(c client.Client, secretName, namespace, region, endpoint string, logger log.FieldLogger) (awsclient.Client, error)
 }
 
 // IsConfigured returns true if the actuator can handle a particular ClusterDeprovision
@@ -54,7 +54,10 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 		return errors.New("couldn't find bucket in environment variable. Skipping upload")
 	}
 
-	awsc, err := a.awsClientFn(c, secretName, clusterprovision.Namespace, region, log)
+	// An empty endpoint selects AWS S3.
+	endpoint := os.Getenv(constants.InstallLogsAWSServiceEndpointEnvVar)
+
+	awsc, err := a.awsClientFn(c, secretName, clusterprovision.Namespace, region, endpoint, log)
 	if err != nil {
 		return err
 	}
@@ -95,8 +98,14 @@ func (a *s3LogUploaderActuator) UploadLogs(clusterName string, clusterprovision
 	return utilerrors.NewAggregate(retvalErrs)
 }
 
-func getAWSClient(c client.Client, secretName, namespace, region string, logger log.FieldLogger) (awsclient.Client, error) {
-	awsClient, err := awsclient.NewClient(c, secretName, namespace, region)
+func getAWSClient(c client.Client, secretName, namespace, region, endpoint string, logger log.FieldLogger) (awsclient.Client, error) {
+	var awsClient awsclient.Client
+	var err error
+	if endpoint != "" {
+		awsClient, err = awsclient.NewClientWithS3Endpoint(c, secretName, namespace, region, endpoint)
+	} else {
+		awsClient, err = awsclient.NewClient(c, secretName, namespace, region)
+	}
 	if err != nil {
 		logger.WithError(err).Error("failed to get AWS client")
 	}
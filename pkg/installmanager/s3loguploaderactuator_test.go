@@ -1,6 +1,9 @@
 package installmanager
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
@@ -9,7 +12,10 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 
@@ -64,7 +70,7 @@ func TestUploadLogs(t *testing.T) {
 					Return(nil, test.putObjectError)
 			}
 
-			actuator := &s3LogUploaderActuator{awsClientFn: func(client.Client, string, string, string, log.FieldLogger) (awsclient.Client, error) {
+			actuator := &s3LogUploaderActuator{awsClientFn: func(client.Client, string, string, string, string, log.FieldLogger) (awsclient.Client, error) {
 				return mocks.mockAWSClient, nil
 			}}
 			provision := testClusterProvision()
@@ -88,3 +94,69 @@ func TestUploadLogs(t *testing.T) {
 		})
 	}
 }
+
+func TestUploadLogsToS3CompatibleEndpoint(t *testing.T) {
+	apis.AddToScheme(scheme.Scheme)
+	tests := []struct {
+		name                    string
+		statusCode              int
+		expectedUploadLogsError bool
+	}{
+		{
+			name:       "successfully upload objects",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:                    "upload denied",
+			statusCode:              http.StatusForbidden,
+			expectedUploadLogsError: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Arrange
+			var uploads []*http.Request
+			var uploaded []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				uploads = append(uploads, r)
+				uploaded, _ = io.ReadAll(r.Body)
+				w.WriteHeader(test.statusCode)
+			}))
+			defer server.Close()
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "minio-creds", Namespace: testNamespace},
+				Data: map[string][]byte{
+					"aws_access_key_id":     []byte("minio"),
+					"aws_secret_access_key": []byte("minio123"),
+				},
+			}
+			mocks := setupDefaultMocks(t, secret)
+
+			t.Setenv(constants.InstallLogsUploadProviderEnvVar, constants.InstallLogsUploadProviderAWS)
+			t.Setenv(constants.InstallLogsCredentialsSecretRefEnvVar, secret.Name)
+			t.Setenv(constants.InstallLogsAWSRegionEnvVar, "region1")
+			t.Setenv(constants.InstallLogsAWSServiceEndpointEnvVar, server.URL)
+			t.Setenv(constants.InstallLogsAWSS3BucketEnvVar, "bucket1")
+
+			actuator := &s3LogUploaderActuator{awsClientFn: getAWSClient}
+			provision := testClusterProvision()
+
+			// Act
+			err := actuator.UploadLogs("notarealcluster", provision, mocks.fakeKubeClient, log.New(), "/etc/issue")
+
+			// Assert
+			if test.expectedUploadLogsError {
+				assert.Error(t, err, "Function didn't error as expected")
+				return
+			}
+			require.NoError(t, err, "Function errored unexpectedly")
+			require.Len(t, uploads, 1, "expected a single upload")
+			assert.Equal(t, http.MethodPut, uploads[0].Method, "unexpected upload method")
+			assert.Equal(t, "/bucket1/notarealcluster-test-namespace/test-provision-issue", uploads[0].URL.Path, "bucket not addressed by path")
+			expected, err := os.ReadFile("/etc/issue")
+			require.NoError(t, err)
+			assert.Equal(t, expected, uploaded, "unexpected uploaded content")
+		})
+	}
+}
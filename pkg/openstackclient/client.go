@@ -0,0 +1,129 @@
+package openstackclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// API represents the calls made to the OpenStack API.
+type API interface {
+	ListServers(ctx context.Context, infraID string) ([]Server, error)
+	StartServer(ctx context.Context, id string) error
+	StopServer(ctx context.Context, id string) error
+}
+
+// Server is the subset of an OpenStack compute server that hive needs.
+type Server struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	// Status is the server's status, e.g. ACTIVE or SHUTOFF.
+	Status string `json:"status"`
+
+	// TaskState is the task the server is currently performing, e.g. powering-off. Empty if there is none.
+	TaskState string `json:"OS-EXT-STS:task_state"`
+}
+
+// openstackClient is not safe for concurrent use, since each call sets the context of the compute client's requests.
+type openstackClient struct {
+	computeClient *gophercloud.ServiceClient
+}
+
+// NewClientFromSecret creates a client for the given cloud in the clouds.yaml stored in the given credentials secret.
+// If trustBundle is not empty it is used as the CA bundle when connecting to the cloud.
+func NewClientFromSecret(secret *corev1.Secret, cloud string, trustBundle []byte) (API, error) {
+	cloudsYAML, ok := secret.Data[constants.OpenStackCredentialsName]
+	if !ok {
+		return nil, errors.New("creds secret does not contain \"" + constants.OpenStackCredentialsName + "\" data")
+	}
+	return NewClient(cloudsYAML, cloud, trustBundle)
+}
+
+// NewClient creates a client for the given cloud in the given clouds.yaml content.
+func NewClient(cloudsYAML []byte, cloud string, trustBundle []byte) (API, error) {
+	var clouds clientconfig.Clouds
+	if err := yaml.Unmarshal(cloudsYAML, &clouds); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal clouds.yaml")
+	}
+	cloudConfig, ok := clouds.Clouds[cloud]
+	if !ok {
+		return nil, errors.Errorf("no cloud %s found in clouds.yaml", cloud)
+	}
+	if len(trustBundle) > 0 {
+		// clientconfig accepts either a path or the contents of the CA bundle
+		cloudConfig.CACertFile = string(trustBundle)
+	}
+
+	computeClient, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{
+		Cloud:    cloud,
+		YAMLOpts: &yamlOpts{clouds: map[string]clientconfig.Cloud{cloud: cloudConfig}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create OpenStack compute client")
+	}
+	return &openstackClient{computeClient: computeClient}, nil
+}
+
+// ListServers returns the servers whose names start with the given infraID.
+func (c *openstackClient) ListServers(ctx context.Context, infraID string) ([]Server, error) {
+	c.computeClient.Context = ctx
+	// The name filter is a regular expression evaluated by the compute service.
+	pages, err := servers.List(c.computeClient, servers.ListOpts{Name: fmt.Sprintf("^%s-", infraID)}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	var result []Server
+	if err := servers.ExtractServersInto(pages, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StartServer starts the server with the given ID.
+func (c *openstackClient) StartServer(ctx context.Context, id string) error {
+	return c.serverAction(ctx, id, "os-start")
+}
+
+// StopServer stops the server with the given ID.
+func (c *openstackClient) StopServer(ctx context.Context, id string) error {
+	return c.serverAction(ctx, id, "os-stop")
+}
+
+func (c *openstackClient) serverAction(ctx context.Context, id, action string) error {
+	c.computeClient.Context = ctx
+	_, err := c.computeClient.Post(c.computeClient.ServiceURL("servers", id, "action"), map[string]interface{}{action: nil}, nil, &gophercloud.RequestOpts{
+		OkCodes: []int{http.StatusAccepted},
+	})
+	return err
+}
+
+// yamlOpts provides clientconfig with clouds that have already been loaded rather than reading them from disk.
+type yamlOpts struct {
+	clouds map[string]clientconfig.Cloud
+}
+
+func (o *yamlOpts) LoadCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return o.clouds, nil
+}
+
+func (o *yamlOpts) LoadSecureCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	// secure.yaml is optional so just pretend it doesn't exist
+	return nil, nil
+}
+
+func (o *yamlOpts) LoadPublicCloudsYAML() (map[string]clientconfig.Cloud, error) {
+	return nil, errors.New("LoadPublicCloudsYAML() not implemented")
+}
@@ -6,10 +6,12 @@
 // config/hiveadmission/clusterdeployment-webhook.yaml
 // config/hiveadmission/clusterimageset-webhook.yaml
 // config/hiveadmission/clusterprovision-webhook.yaml
+// config/hiveadmission/clusterupgradecampaign-webhook.yaml
 // config/hiveadmission/deployment.yaml
 // config/hiveadmission/dnszones-webhook.yaml
 // config/hiveadmission/hiveadmission_rbac_role.yaml
 // config/hiveadmission/hiveadmission_rbac_role_binding.yaml
+// config/hiveadmission/installlogregexes-webhook.yaml
 // config/hiveadmission/machinepool-webhook.yaml
 // config/hiveadmission/sa-token-secret.yaml
 // config/hiveadmission/selectorsyncset-webhook.yaml
@@ -392,6 +394,52 @@ func configHiveadmissionClusterprovisionWebhookYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionClusterupgradecampaignWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  annotations:
+    service.beta.openshift.io/inject-cabundle: "true"
+  name: clusterupgradecampaignvalidators.admission.hive.openshift.io
+webhooks:
+- name: clusterupgradecampaignvalidators.admission.hive.openshift.io
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/clusterupgradecampaignvalidators
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - hive.openshift.io
+    apiVersions:
+    - v1
+    resources:
+    - clusterupgradecampaigns
+  failurePolicy: Fail
+  sideEffects: None
+`)
+
+func configHiveadmissionClusterupgradecampaignWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionClusterupgradecampaignWebhookYaml, nil
+}
+
+func configHiveadmissionClusterupgradecampaignWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionClusterupgradecampaignWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/clusterupgradecampaign-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionDeploymentYaml = []byte(`---
 # to create the namespace-reservation-server
 apiVersion: apps/v1
@@ -613,6 +661,57 @@ func configHiveadmissionHiveadmission_rbac_role_bindingYaml() (*asset, error) {
 	return a, nil
 }
 
+var _configHiveadmissionInstalllogregexesWebhookYaml = []byte(`---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  annotations:
+    service.beta.openshift.io/inject-cabundle: "true"
+  name: installlogregexesvalidators.admission.hive.openshift.io
+webhooks:
+- name: installlogregexesvalidators.admission.hive.openshift.io
+  admissionReviewVersions:
+  - v1beta1
+  clientConfig:
+    service:
+      # reach the webhook via the registered aggregated API
+      namespace: default
+      name: kubernetes
+      path: /apis/admission.hive.openshift.io/v1/installlogregexesvalidators
+  # The operator restricts the webhook to the hive namespace.
+  namespaceSelector:
+    matchLabels:
+      kubernetes.io/metadata.name: hive
+  rules:
+  - operations:
+    - CREATE
+    - UPDATE
+    apiGroups:
+    - ""
+    apiVersions:
+    - v1
+    resources:
+    - configmaps
+  # Hive applies the install-log-regexes ConfigMap before hiveadmission is running.
+  failurePolicy: Ignore
+  sideEffects: None
+`)
+
+func configHiveadmissionInstalllogregexesWebhookYamlBytes() ([]byte, error) {
+	return _configHiveadmissionInstalllogregexesWebhookYaml, nil
+}
+
+func configHiveadmissionInstalllogregexesWebhookYaml() (*asset, error) {
+	bytes, err := configHiveadmissionInstalllogregexesWebhookYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "config/hiveadmission/installlogregexes-webhook.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _configHiveadmissionMachinepoolWebhookYaml = []byte(`---
 apiVersion: admissionregistration.k8s.io/v1
 kind: ValidatingWebhookConfiguration
@@ -1635,102 +1734,130 @@ data:
       - "Error: .*InsufficientInstanceCapacity.* Our system will be working on provisioning additional capacity"
       installFailingReason: AWSInsufficientCapacity
       installFailingMessage: AWS currently does not have sufficient capacity to provision the requested EC2 instances in the specified Availability Zone. Please try again later or in a different Availability Zone.
+      category: CloudOutage
     - name: AWSEC2QuotaExceeded
       searchRegexStrings:
       - "failed to generate asset.*Platform Quota Check.*MissingQuota.*ec2"
       installFailingReason: AWSEC2QuotaExceeded
       installFailingMessage: AWS EC2 Quota Exceeded
+      category: Quota
     - name: AWSNATGatewayLimitExceeded
       searchRegexStrings:
       - "NatGatewayLimitExceeded"
       installFailingReason: AWSNATGatewayLimitExceeded
       installFailingMessage: AWS NAT gateway limit exceeded
+      category: Quota
     - name: AWSVPCLimitExceeded
       searchRegexStrings:
       - "VpcLimitExceeded"
       installFailingReason: AWSVPCLimitExceeded
       installFailingMessage: AWS VPC limit exceeded
+      category: Quota
     - name: S3BucketsLimitExceeded
       searchRegexStrings:
        - "TooManyBuckets"
       installFailingReason: S3BucketsLimitExceeded
       installFailingMessage: S3 Buckets Limit Exceeded
+      category: Quota
     - name: LoadBalancerLimitExceeded
       searchRegexStrings:
       - "TooManyLoadBalancers: Exceeded quota of account"
       installFailingReason: LoadBalancerLimitExceeded
       installFailingMessage: AWS Load Balancer Limit Exceeded
+      category: Quota
     - name: EIPAddressLimitExceeded
       searchRegexStrings:
       - "EIP: AddressLimitExceeded"
       installFailingReason: EIPAddressLimitExceeded
       installFailingMessage: EIP Address limit exceeded
+      category: Quota
     - name: MissingPublicSubnetForZone
       searchRegexStrings:
       - "No public subnet provided for zone"
       installFailingReason: MissingPublicSubnetForZone
       installFailingMessage: No public subnet provided for at least one zone
+      category: Configuration
+      retryable: false
     - name: PrivateSubnetInMultipleZones
       searchRegexStrings:
       - "private subnet .* is also in zone"
       installFailingReason: PrivateSubnetInMultipleZones
       installFailingMessage: Same private subnet used in multiple zones
+      category: Configuration
+      retryable: false
     - name: InvalidInstallConfigSubnet
       searchRegexStrings:
       - "CIDR range start.*is outside of the specified machine networks"
       installFailingReason: InvalidInstallConfigSubnet
       installFailingMessage: Invalid subnet in install config. Subnet's CIDR range start is outside of the specified machine networks
+      category: Configuration
+      retryable: false
     # https://bugzilla.redhat.com/show_bug.cgi?id=1844320
     - name: AWSUnableToFindMatchingRouteTable
       searchRegexStrings:
       - "Error: Unable to find matching route for Route Table"
       installFailingReason: AWSUnableToFindMatchingRouteTable
       installFailingMessage: Unable to find matching route for route table
+      category: Configuration
+      retryable: false
     - name: DNSAlreadyExists
       searchRegexStrings:
       - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
       installFailingReason: DNSAlreadyExists
       installFailingMessage: DNS record already exists
+      category: DNS
     - name: PendingVerification
       searchRegexStrings:
       - "PendingVerification: Your request for accessing resources in this region is being validated"
       installFailingReason: PendingVerification
       installFailingMessage: Account pending verification for region
+      category: CloudOutage
     - name: NoMatchingRoute53Zone
       searchRegexStrings:
       - "data.aws_route53_zone.public: no matching Route53Zone found"
       installFailingReason: NoMatchingRoute53Zone
       installFailingMessage: No matching Route53Zone found
+      category: DNS
+      retryable: false
     - name: TooManyRoute53Zones
       searchRegexStrings:
       - "error creating Route53 Hosted Zone: TooManyHostedZones: Limits Exceeded"
       installFailingReason: TooManyRoute53Zones
       installFailingMessage: Route53 hosted zone limit exceeded
+      category: Quota
     - name: MultipleRoute53ZonesFound
       searchRegexStrings:
         - "Error: multiple Route53Zone found"
       installFailingReason: MultipleRoute53ZonesFound
       installFailingMessage: Multiple Route53 zones found
+      category: DNS
+      retryable: false
     - name: DefaultEbsKmsKeyInsufficientPermissions
       searchRegexStrings:
         - "Client.InternalError: Client error on launch"
       installFailingReason: DefaultEbsKmsKeyInsufficientPermissions
       installFailingMessage: Default KMS key for EBS encryption has insufficient permissions to launch EC2 instances
+      category: Permissions
+      retryable: false
     - name: SimulatorThrottling
       searchRegexStrings:
       - "validate AWS credentials: checking install permissions: error simulating policy: Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded while simulating policy
+      category: CloudOutage
     - name: S3AccessControlListNotSupported
       searchRegexStrings:
       - "error creating S3 bucket ACL for.*AccessControlListNotSupported: The bucket does not allow ACLs"
       installFailingReason: S3AccessControlListNotSupported
       installFailingMessage: S3AccessControlListNotSupported
+      category: Configuration
+      retryable: false
     - name: GeneralThrottling
       searchRegexStrings:
       - "Throttling: Rate exceeded"
       installFailingReason: AWSAPIRateLimitExceeded
       installFailingMessage: AWS API rate limit exceeded
+      category: CloudOutage
     # This issue is caused by AWS throttling the CreateHostedZone request. The terraform provider is not properly
     # handling the throttling response and gets stuck in a state where it does not retry the request. Eventually,
     # the terraform provider times out claiming that it is waiting for the hosted zone to be INSYNC.
@@ -1739,26 +1866,35 @@ data:
       - "error waiting for Route53 Hosted Zone .* creation: timeout while waiting for state to become 'INSYNC'"
       installFailingReason: AWSRoute53Timeout
       installFailingMessage: AWS Route53 timeout while waiting for INSYNC. This is usually caused by Route53 rate limiting.
+      category: DNS
     - name: InvalidCredentials
       searchRegexStrings:
       - "InvalidClientTokenId: The security token included in the request is invalid."
       installFailingReason: InvalidCredentials
       installFailingMessage: Credentials are invalid
+      category: Permissions
+      severity: Critical
+      retryable: false
     - name: InvalidAWSTags
       searchRegexStrings:
       - "platform\\.aws\\.userTags.*: Invalid value:.*value contains invalid characters"
       installFailingReason: InvalidAWSTags
       installFailingMessage: You have specified an invalid AWS tag value. Verify that your tags meet AWS requirements and try again.
+      category: Configuration
+      retryable: false
     - name: ErrorDeletingIAMRole
       searchRegexStrings:
         - "Error deleting IAM Role .* DeleteConflict: Cannot delete entity, must detach all policies first."
       installFailingReason: ErrorDeletingIAMRole
       installFailingMessage: The cluster installer was not able to delete the roles it used during the installation. Ensure that no policies are added to new roles by default and try again.
+      category: Permissions
     - name: AWSSubnetDoesNotExist
       searchRegexStrings:
       - "The subnet ID .* does not exist"
       installFailingReason: AWSSubnetDoesNotExist
       installFailingMessage: AWS Subnet Does Not Exist
+      category: Configuration
+      retryable: false
     # iam:CreateServiceLinkedRole is a super powerful permission that we don't give to STS clusters. We require it's done as a one-time prereq.
     # This is the error we see when the prereq step was missed.
     - name: NATGatewayFailed
@@ -1766,69 +1902,90 @@ data:
       - "Error waiting for NAT Gateway (.*) to become available"
       installFailingReason: NATGatewayFailed
       installFailingMessage: Error waiting for NAT Gateway to become available.
+      category: CloudOutage
     - name: AWSAccessDeniedSLR
       searchRegexStrings:
       - "Error creating network Load Balancer: AccessDenied.*iam:CreateServiceLinkedRole"
       installFailingReason: AWSAccessDeniedSLR
       installFailingMessage: Missing prerequisite service role for load balancer
+      category: Permissions
+      retryable: false
     - name: AWSInsufficientPermissions
       searchRegexStrings:
       - "current credentials insufficient for performing cluster installation"
       - "UnauthorizedOperation: You are not authorized to perform this operation. Encoded authorization failure message"
       installFailingReason: AWSInsufficientPermissions
       installFailingMessage: AWS credentials are insufficient for performing cluster installation
+      category: Permissions
+      severity: Critical
+      retryable: false
     - name: AWSDeniedBySCP
       searchRegexStrings:
       - "AccessDenied: .* with an explicit deny in a service control policy"
       installFailingReason: AWSDeniedBySCP
       installFailingMessage: "A service control policy (SCP) is too restrictive for performing cluster installation"
+      category: Permissions
+      severity: Critical
+      retryable: false
     - name: VcpuLimitExceeded
       searchRegexStrings:
       - "VcpuLimitExceeded"
       installFailingReason: VcpuLimitExceeded
       installFailingMessage: The install requires more vCPU capacity than your current vCPU limit
+      category: Quota
     - name: Gp3VolumeLimitExceeded
       searchRegexStrings:
       - "VolumeLimitExceeded: You have exceeded your maximum gp3 storage limit"
       installFailingReason: Gp3VolumeLimitExceeded
       installFailingMessage: "The installation failed due to insufficient gp3 storage quota in the region (QuotaCode L-7A658B76)"
+      category: Quota
     - name: UserInitiatedShutdown
       searchRegexStrings:
       - "Error waiting for instance .* to become ready .* User initiated shutdown"
       installFailingReason: UserInitiatedShutdown
       installFailingMessage: User initiated shutdown of instances as the install was running
+      category: CloudOutage
     # openshift-installer intermittent failure on AWS with Error: Provider produced inconsistent result after apply
     - name: InconsistentTerraformResult
       searchRegexStrings:
       - "Error: Provider produced inconsistent result after apply"
       installFailingReason: InconsistentTerraformResult
       installFailingMessage: Inconsistent result after Terraform apply
+      category: InstallerBug
     - name: AWSVPCDoesNotExist
       searchRegexStrings:
       - "The vpc ID .* does not exist"
       installFailingReason: AWSVPCDoesNotExist
       installFailingMessage: The AWS VPC does not exist
+      category: Configuration
+      retryable: false
     - name: TargetGroupNotFound
     # https://bugzilla.redhat.com/show_bug.cgi?id=1898265
       searchRegexStrings:
       - "TargetGroupNotFound"
       installFailingReason: TargetGroupNotFound
       installFailingMessage: Target Group cannot be found
+      category: InstallerBug
     - name: ErrorCreatingNetworkLoadBalancer
       searchRegexStrings:
       - "Error creating network Load Balancer: InternalFailure: "
       installFailingReason: ErrorCreatingNetworkLoadBalancer
       installFailingMessage: AWS network load balancer creation encountered an error during cluster installation
+      category: InstallerBug
     - name: TerraformFailedToDeleteResources
       searchRegexStrings:
         - "terraform destroy: failed to destroy using Terraform"
       installFailingReason: InstallerFailedToDestroyResources
       installFailingMessage: The installer failed to destroy installation resources
+      category: InstallerBug
     - name: AWSAccountBlocked
       searchRegexStrings:
         - "Blocked: This account is currently blocked and not recognized as a valid account."
       installFailingReason: AWSAccountIsBlocked
       installFailingMessage: "AWS account is currently blocked and not recognized as a valid account. Please contact aws-verification@amazon.com if you have questions."
+      category: Permissions
+      severity: Critical
+      retryable: false
 
 
     # GCP Specific
@@ -1837,31 +1994,39 @@ data:
       - "platform.gcp.project.* invalid project ID"
       installFailingReason: GCPInvalidProjectID
       installFailingMessage: Invalid GCP project ID
+      category: Configuration
+      retryable: false
     - name: GCPInstanceTypeNotFound
       searchRegexStrings:
       - "platform.gcp.type: Invalid value:.* instance type.* not found]"
       installFailingReason: GCPInstanceTypeNotFound
       installFailingMessage: GCP instance type not found
+      category: Configuration
+      retryable: false
     - name: GCPPreconditionFailed
       searchRegexStrings:
       - "googleapi: Error 412"
       installFailingReason: GCPPreconditionFailed
       installFailingMessage: GCP Precondition Failed
+      category: CloudOutage
     - name: GCPQuotaSSDTotalGBExceeded
       searchRegexStrings:
       - "Quota \'SSD_TOTAL_GB\' exceeded"
       installFailingReason: GCPQuotaSSDTotalGBExceeded
       installFailingMessage: GCP quota SSD_TOTAL_GB exceeded
+      category: Quota
     - name: GCPComputeQuota
       searchRegexStrings:
       - "compute\\.googleapis\\.com/cpus is not available in [a-z0-9-]* because the required number of resources \\([0-9]*\\) is more than"
       installFailingReason: GCPComputeQuotaExceeded
       installFailingMessage: GCP CPUs quota exceeded
+      category: Quota
     - name: GCPServiceAccountQuota
       searchRegexStrings:
       - "iam\\.googleapis\\.com/quota/service-account-count is not available in global because the required number of resources \\([0-9]*\\) is more than remaining quota"
       installFailingReason: GCPServiceAccountQuotaExceeded
       installFailingMessage: GCP Service Account quota exceeded
+      category: Quota
 
 
     # Bare Metal
@@ -1870,11 +2035,14 @@ data:
       - "platform.baremetal.libvirtURI: Internal error: could not connect to libvirt: virError.Code=38, Domain=7, Message=.Cannot recv data: Permission denied"
       installFailingReason: LibvirtSSHKeyPermissionDenied
       installFailingMessage: "Permission denied connecting to libvirt host, check SSH key configuration and pass phrase"
+      category: Permissions
+      retryable: false
     - name: LibvirtConnectionFailed
       searchRegexStrings:
       - "could not connect to libvirt"
       installFailingReason: LibvirtConnectionFailed
       installFailingMessage: "Could not connect to libvirt host"
+      category: CloudOutage
 
 
     # Proxy-enabled clusters
@@ -1885,11 +2053,14 @@ data:
       - "error pinging docker registry .+ proxyconnect tcp: dial tcp [^ ]+: connect: no route to host"
       installFailingReason: ProxyTimeout
       installFailingMessage: The cluster is installing via a proxy, however the proxy server is refusing or timing out connections. Verify that the proxy is running and would be accessible from the cluster's private subnet(s).
+      category: Configuration
     - name: ProxyInvalidCABundle
       searchRegexStrings:
       - "error pinging docker registry .+ proxyconnect tcp: x509: certificate signed by unknown authority"
       installFailingReason: ProxyInvalidCABundle
       installFailingMessage: The cluster is installing via a proxy, but does not trust the signing certificate the proxy is presenting. Verify that the Certificate Authority certificate(s) to verify proxy communications have been supplied at installation time.
+      category: Configuration
+      retryable: false
 
 
     # Generic OpenShift Install
@@ -1899,46 +2070,55 @@ data:
       - "waiting for Kubernetes API: context deadline exceeded"
       installFailingReason: KubeAPIWaitTimeout
       installFailingMessage: Timeout waiting for the Kubernetes API to begin responding
+      category: InstallerBug
     - name: KubeAPIWaitFailed
       searchRegexStrings:
       - "Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane"
       installFailingReason: KubeAPIWaitFailed
       installFailingMessage: Failed waiting for Kubernetes API. This error usually happens when there is a problem on the bootstrap host that prevents creating a temporary control plane
+      category: InstallerBug
     - name: BootstrapFailed
       searchRegexStrings:
       - "Failed to wait for bootstrapping to complete. This error usually happens when there is a problem with control plane hosts that prevents the control plane operators from creating the control plane."
       installFailingReason: BootstrapFailed
       installFailingMessage: Failed to wait for bootstrapping to complete. This error usually happens when there is a problem with control plane hosts that prevents the control plane operators from creating the control plane. Verify the networking configuration and account permissions and try again.
+      category: InstallerBug
     - name: GenericBootstrapFailed
       searchRegexStrings:
       - "Bootstrap failed to complete"
       installFailingReason: GenericBootstrapFailed
       installFailingMessage: Installation Bootstrap failed to complete. Verify the networking configuration and account permissions and try again.
+      category: InstallerBug
     - name: MonitoringOperatorStillUpdating
       searchRegexStrings:
       - "failed to initialize the cluster: Cluster operator monitoring is still updating"
       installFailingReason: MonitoringOperatorStillUpdating
       installFailingMessage: Timeout waiting for the monitoring operator to become ready
+      category: InstallerBug
     - name: NoWorkerNodesReady
       searchRegexStrings:
       - "Got 0 worker nodes, \\d+ master nodes.*none are schedulable or ready for ingress pods"
       installFailingReason: NoWorkerNodesReady
       installFailingMessage: 0 worker nodes have joined the cluster
+      category: InstallerBug
     - name: AuthenticationOperatorDegraded
       searchRegexStrings:
       - "Cluster operator authentication Degraded is True"
       installFailingReason: AuthenticationOperatorDegraded
       installFailingMessage: Timeout waiting for the authentication operator to become ready
+      category: InstallerBug
     - name: GeneralOperatorDegraded
       searchRegexStrings:
       - "Cluster operator.*Degraded is True"
       installFailingReason: GeneralOperatorDegraded
       installFailingMessage: Timeout waiting for an operator to become ready
+      category: InstallerBug
     - name: GeneralClusterOperatorsStillUpdating
       searchRegexStrings:
       - "failed to initialize the cluster: Some cluster operators are still updating:"
       installFailingReason: GeneralClusterOperatorsStillUpdating
       installFailingMessage: Timeout waiting for all cluster operators to become ready
+      category: InstallerBug
 
     # Keep these at the bottom so that they're only hit if nothing above matches.
     # We don't want to show these to users unless it's a last resort. It's barely better than "unknown error".
@@ -1948,21 +2128,30 @@ data:
       - "Quota '[A-Z_]*' exceeded"
       installFailingReason: FallbackQuotaExceeded
       installFailingMessage: Unknown quota exceeded - couldn't parse a specific resource type
+      category: Quota
+      severity: Warning
     - name: FallbackResourceLimitExceeded
       searchRegexStrings:
       - "LimitExceeded"
       installFailingReason: FallbackResourceLimitExceeded
       installFailingMessage: Unknown resource limit exceeded - couldn't parse a specific resource type
+      category: Quota
+      severity: Warning
     - name: FallbackInvalidInstallConfig
       searchRegexStrings:
       - "failed to load asset \\\"Install Config\\\""
       installFailingReason: FallbackInvalidInstallConfig
       installFailingMessage: Unknown error - installer failed to load install config
+      category: Configuration
+      severity: Warning
+      retryable: false
     - name: FallbackInstancesFailedToBecomeReady
       searchRegexStrings:
       - "Error waiting for instance .* to become ready"
       installFailingReason: FallbackInstancesFailedToBecomeReady
       installFailingMessage: Unknown error - instances failed to become ready
+      category: CloudOutage
+      severity: Warning
 `)
 
 func configConfigmapsInstallLogRegexesConfigmapYamlBytes() ([]byte, error) {
@@ -2038,10 +2227,12 @@ var _bindata = map[string]func() (*asset, error){
 	"config/hiveadmission/clusterdeployment-webhook.yaml":       configHiveadmissionClusterdeploymentWebhookYaml,
 	"config/hiveadmission/clusterimageset-webhook.yaml":         configHiveadmissionClusterimagesetWebhookYaml,
 	"config/hiveadmission/clusterprovision-webhook.yaml":        configHiveadmissionClusterprovisionWebhookYaml,
+	"config/hiveadmission/clusterupgradecampaign-webhook.yaml":  configHiveadmissionClusterupgradecampaignWebhookYaml,
 	"config/hiveadmission/deployment.yaml":                      configHiveadmissionDeploymentYaml,
 	"config/hiveadmission/dnszones-webhook.yaml":                configHiveadmissionDnszonesWebhookYaml,
 	"config/hiveadmission/hiveadmission_rbac_role.yaml":         configHiveadmissionHiveadmission_rbac_roleYaml,
 	"config/hiveadmission/hiveadmission_rbac_role_binding.yaml": configHiveadmissionHiveadmission_rbac_role_bindingYaml,
+	"config/hiveadmission/installlogregexes-webhook.yaml":       configHiveadmissionInstalllogregexesWebhookYaml,
 	"config/hiveadmission/machinepool-webhook.yaml":             configHiveadmissionMachinepoolWebhookYaml,
 	"config/hiveadmission/sa-token-secret.yaml":                 configHiveadmissionSaTokenSecretYaml,
 	"config/hiveadmission/selectorsyncset-webhook.yaml":         configHiveadmissionSelectorsyncsetWebhookYaml,
@@ -2127,10 +2318,12 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"clusterdeployment-webhook.yaml":       {configHiveadmissionClusterdeploymentWebhookYaml, map[string]*bintree{}},
 			"clusterimageset-webhook.yaml":         {configHiveadmissionClusterimagesetWebhookYaml, map[string]*bintree{}},
 			"clusterprovision-webhook.yaml":        {configHiveadmissionClusterprovisionWebhookYaml, map[string]*bintree{}},
+			"clusterupgradecampaign-webhook.yaml":  {configHiveadmissionClusterupgradecampaignWebhookYaml, map[string]*bintree{}},
 			"deployment.yaml":                      {configHiveadmissionDeploymentYaml, map[string]*bintree{}},
 			"dnszones-webhook.yaml":                {configHiveadmissionDnszonesWebhookYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role.yaml":         {configHiveadmissionHiveadmission_rbac_roleYaml, map[string]*bintree{}},
 			"hiveadmission_rbac_role_binding.yaml": {configHiveadmissionHiveadmission_rbac_role_bindingYaml, map[string]*bintree{}},
+			"installlogregexes-webhook.yaml":       {configHiveadmissionInstalllogregexesWebhookYaml, map[string]*bintree{}},
 			"machinepool-webhook.yaml":             {configHiveadmissionMachinepoolWebhookYaml, map[string]*bintree{}},
 			"sa-token-secret.yaml":                 {configHiveadmissionSaTokenSecretYaml, map[string]*bintree{}},
 			"selectorsyncset-webhook.yaml":         {configHiveadmissionSelectorsyncsetWebhookYaml, map[string]*bintree{}},
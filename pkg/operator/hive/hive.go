@@ -146,10 +146,19 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 	// It would be neat if it did that purely based on the FailedProvisionConfig ConfigMap, to
 	// which it does have access, but that code path is shared by other things that need the
 	// same copied secret.
-	if awsSpec := instance.Spec.FailedProvisionConfig.AWS; awsSpec != nil {
+	var installLogsSecretName string
+	switch fpConfig := instance.Spec.FailedProvisionConfig; {
+	case fpConfig.AWS != nil:
+		installLogsSecretName = fpConfig.AWS.CredentialsSecretRef.Name
+	case fpConfig.GCP != nil:
+		installLogsSecretName = fpConfig.GCP.CredentialsSecretRef.Name
+	case fpConfig.Azure != nil:
+		installLogsSecretName = fpConfig.Azure.CredentialsSecretRef.Name
+	}
+	if installLogsSecretName != "" {
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
 			Name:  constants.InstallLogsCredentialsSecretRefEnvVar,
-			Value: awsSpec.CredentialsSecretRef.Name,
+			Value: installLogsSecretName,
 		})
 	}
 
@@ -215,6 +224,15 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
 	}
 
+	if instance.Spec.VerifyDeprovisions != nil && *instance.Spec.VerifyDeprovisions {
+		hLog.Info("deprovision verification enabled in hiveconfig")
+		tmpEnvVar := corev1.EnvVar{
+			Name:  constants.VerifyDeprovisionsEnvVar,
+			Value: "true",
+		}
+		hiveContainer.Env = append(hiveContainer.Env, tmpEnvVar)
+	}
+
 	if instance.Spec.Backup.MinBackupPeriodSeconds != nil {
 		hLog.Infof("MinBackupPeriodSeconds specified.")
 		tmpEnvVar := corev1.EnvVar{
@@ -232,6 +250,14 @@ func (r *ReconcileHiveConfig) deployHive(hLog log.FieldLogger, h resource.Helper
 		})
 	}
 
+	if instance.Spec.DeprovisionGracePeriod != nil {
+		hLog.WithField("gracePeriod", instance.Spec.DeprovisionGracePeriod.Duration).Info("deprovision grace period set in hiveconfig")
+		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
+			Name:  constants.DeprovisionGracePeriodEnvVar,
+			Value: instance.Spec.DeprovisionGracePeriod.Duration.String(),
+		})
+	}
+
 	if instance.Spec.ReleaseImageVerificationConfigMapRef != nil {
 		hLog.Info("Release Image verification enabled")
 		hiveContainer.Env = append(hiveContainer.Env, corev1.EnvVar{
@@ -19,6 +19,7 @@ import (
 
 	admregv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -47,8 +48,12 @@ var webhookAssets = []string{
 	"config/hiveadmission/machinepool-webhook.yaml",
 	"config/hiveadmission/syncset-webhook.yaml",
 	"config/hiveadmission/selectorsyncset-webhook.yaml",
+	"config/hiveadmission/installlogregexes-webhook.yaml",
+	"config/hiveadmission/clusterupgradecampaign-webhook.yaml",
 }
 
+const installLogRegexesWebhookName = "installlogregexesvalidators.admission.hive.openshift.io"
+
 func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resource.Helper, instance *hivev1.HiveConfig, namespacesToClean []string, additionalHashes ...string) error {
 	deploymentAsset := "config/hiveadmission/deployment.yaml"
 	namespacedAssets := []string{
@@ -149,6 +154,14 @@ func (r *ReconcileHiveConfig) deployHiveAdmission(hLog log.FieldLogger, h resour
 	for i, yaml := range webhookAssets {
 		asset = assets.MustAsset(yaml)
 		wh := util.ReadValidatingWebhookConfigurationV1OrDie(asset, scheme.Scheme)
+		if wh.Name == installLogRegexesWebhookName {
+			// Only the install log regexes ConfigMaps in the hive namespace are validated.
+			for j := range wh.Webhooks {
+				wh.Webhooks[j].NamespaceSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{corev1.LabelMetadataName: hiveNSName},
+				}
+			}
+		}
 		validatingWebhooks[i] = wh
 	}
 
@@ -0,0 +1,98 @@
+package ovirtclient
+
+import (
+	"fmt"
+
+	ovirtsdk "github.com/ovirt/go-ovirt"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// API represents the calls made to the oVirt API.
+type API interface {
+	ListVMs(infraID string) ([]*ovirtsdk.Vm, error)
+	StartVM(id string) error
+	ShutdownVM(id string) error
+	Close() error
+}
+
+// Config holds the oVirt API access details stored in the ovirt-config.yaml credentials file.
+type Config struct {
+	URL      string `yaml:"ovirt_url"`
+	Username string `yaml:"ovirt_username"`
+	Password string `yaml:"ovirt_password"`
+	Insecure bool   `yaml:"ovirt_insecure,omitempty"`
+	CABundle string `yaml:"ovirt_ca_bundle,omitempty"`
+}
+
+type ovirtClient struct {
+	connection *ovirtsdk.Connection
+}
+
+// NewClientFromSecret creates a client using the ovirt-config.yaml stored in the given credentials secret.
+// If trustBundle is not empty it is appended to the CA bundle from the credentials.
+func NewClientFromSecret(secret *corev1.Secret, trustBundle []byte) (API, error) {
+	configYAML, ok := secret.Data[constants.OvirtCredentialsName]
+	if !ok {
+		return nil, errors.New("creds secret does not contain \"" + constants.OvirtCredentialsName + "\" data")
+	}
+	config := Config{}
+	if err := yaml.Unmarshal(configYAML, &config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal ovirt-config.yaml")
+	}
+	if len(trustBundle) > 0 {
+		config.CABundle = config.CABundle + "\n" + string(trustBundle)
+	}
+	return NewClient(config)
+}
+
+// NewClient creates a client using the given config.
+func NewClient(config Config) (API, error) {
+	connection, err := ovirtsdk.NewConnectionBuilder().
+		URL(config.URL).
+		Username(config.Username).
+		Password(config.Password).
+		CACert([]byte(config.CABundle)).
+		Insecure(config.Insecure).
+		Build()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to oVirt engine")
+	}
+	return &ovirtClient{connection: connection}, nil
+}
+
+// ListVMs returns the VMs whose names start with the given infraID.
+func (c *ovirtClient) ListVMs(infraID string) ([]*ovirtsdk.Vm, error) {
+	resp, err := c.connection.SystemService().VmsService().List().Search(fmt.Sprintf("name=%s-*", infraID)).Send()
+	if err != nil {
+		return nil, err
+	}
+	vms, ok := resp.Vms()
+	if !ok {
+		return nil, nil
+	}
+	return vms.Slice(), nil
+}
+
+// StartVM starts the VM with the given ID.
+func (c *ovirtClient) StartVM(id string) error {
+	_, err := c.connection.SystemService().VmsService().VmService(id).Start().Send()
+	return err
+}
+
+// ShutdownVM asks the guest OS of the VM with the given ID to shut down.
+func (c *ovirtClient) ShutdownVM(id string) error {
+	_, err := c.connection.SystemService().VmsService().VmService(id).Shutdown().Send()
+	return err
+}
+
+// Close closes the client's connection to the oVirt engine.
+func (c *ovirtClient) Close() error {
+	return c.connection.Close()
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
@@ -30,11 +31,16 @@ type Helper interface {
 	CreateOrUpdateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
 	Create(obj []byte) (ApplyResult, error)
 	CreateRuntimeObject(obj runtime.Object, scheme *runtime.Scheme) (ApplyResult, error)
+	// ServerSideApply applies the given resource bytes to the target cluster using server-side apply with the given
+	// field manager, taking ownership of fields owned by other field managers if force is true.
+	ServerSideApply(obj []byte, fieldManager string, force bool) (ApplyResult, error)
 	// Info determines the name/namespace and type of the passed in resource bytes
 	Info(obj []byte) (*Info, error)
 	// Patch invokes the kubectl patch command with the given resource, patch and patch type
 	Patch(name types.NamespacedName, kind, apiVersion string, patch []byte, patchType string) error
 	Delete(apiVersion, kind, namespace, name string) error
+	// Get fetches the given resource from the target cluster
+	Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
 }
 
 // helper contains configuration for apply and patch operations
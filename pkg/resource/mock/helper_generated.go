@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	resource "github.com/openshift/hive/pkg/resource"
+	unstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
 )
@@ -140,6 +141,21 @@ func (mr *MockHelperMockRecorder) Delete(apiVersion, kind, namespace, name inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHelper)(nil).Delete), apiVersion, kind, namespace, name)
 }
 
+// Get mocks base method.
+func (m *MockHelper) Get(apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", apiVersion, kind, namespace, name)
+	ret0, _ := ret[0].(*unstructured.Unstructured)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockHelperMockRecorder) Get(apiVersion, kind, namespace, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockHelper)(nil).Get), apiVersion, kind, namespace, name)
+}
+
 // Info mocks base method.
 func (m *MockHelper) Info(obj []byte) (*resource.Info, error) {
 	m.ctrl.T.Helper()
@@ -168,3 +184,18 @@ func (mr *MockHelperMockRecorder) Patch(name, kind, apiVersion, patch, patchType
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockHelper)(nil).Patch), name, kind, apiVersion, patch, patchType)
 }
+
+// ServerSideApply mocks base method.
+func (m *MockHelper) ServerSideApply(obj []byte, fieldManager string, force bool) (resource.ApplyResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ServerSideApply", obj, fieldManager, force)
+	ret0, _ := ret[0].(resource.ApplyResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ServerSideApply indicates an expected call of ServerSideApply.
+func (mr *MockHelperMockRecorder) ServerSideApply(obj, fieldManager, force interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ServerSideApply", reflect.TypeOf((*MockHelper)(nil).ServerSideApply), obj, fieldManager, force)
+}
@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ServerSideApply applies the given resource bytes to the target cluster using server-side apply, so that only the
+// fields in the resource are owned by fieldManager. When force is false, fields owned by other field managers are not
+// changed and a Conflict error is returned whose status causes list the conflicting fields. The error is returned
+// unwrapped so that callers can check for it.
+func (r *helper) ServerSideApply(obj []byte, fieldManager string, force bool) (ApplyResult, error) {
+	factory, err := r.getFactory("")
+	if err != nil {
+		r.logger.WithError(err).Error("failed to obtain factory for server-side apply")
+		return "", err
+	}
+	info, err := r.getResourceInternalInfo(factory, obj)
+	if err != nil {
+		return "", err
+	}
+	c, err := factory.DynamicClient()
+	if err != nil {
+		return "", err
+	}
+	existed := true
+	if err := info.Get(); err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+		existed = false
+	}
+	applied, err := c.Resource(info.ResourceMapping().Resource).Namespace(info.Namespace).Patch(
+		context.TODO(),
+		info.Name,
+		types.ApplyPatchType,
+		obj,
+		metav1.PatchOptions{FieldManager: fieldManager, Force: &force},
+	)
+	if err != nil {
+		r.logger.WithError(err).Warn("server-side apply failed")
+		return "", err
+	}
+	switch {
+	case !existed:
+		return CreatedApplyResult, nil
+	case applied.GetResourceVersion() == info.ResourceVersion:
+		return UnchangedApplyResult, nil
+	default:
+		return ConfiguredApplyResult, nil
+	}
+}
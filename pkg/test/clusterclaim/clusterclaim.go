@@ -111,3 +111,15 @@ func WithLifetime(lifetime time.Duration) Option {
 		clusterClaim.Spec.Lifetime = &metav1.Duration{Duration: lifetime}
 	}
 }
+
+func WithSelector(selector *metav1.LabelSelector) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.Selector = selector
+	}
+}
+
+func WithMinVersion(minVersion string) Option {
+	return func(clusterClaim *hivev1.ClusterClaim) {
+		clusterClaim.Spec.MinVersion = minVersion
+	}
+}
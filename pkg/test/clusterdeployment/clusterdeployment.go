@@ -13,6 +13,9 @@ import (
 	hivev1azure "github.com/openshift/hive/apis/hive/v1/azure"
 	hivev1gcp "github.com/openshift/hive/apis/hive/v1/gcp"
 	hivev1ibmcloud "github.com/openshift/hive/apis/hive/v1/ibmcloud"
+	hivev1openstack "github.com/openshift/hive/apis/hive/v1/openstack"
+	hivev1ovirt "github.com/openshift/hive/apis/hive/v1/ovirt"
+	hivev1vsphere "github.com/openshift/hive/apis/hive/v1/vsphere"
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/test/generic"
 )
@@ -204,6 +207,21 @@ func WithHibernateAfter(dur time.Duration) Option {
 	}
 }
 
+func WithHibernationSchedule(timeZone string, windows ...hivev1.ScheduleWindow) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.HibernationSchedule = &hivev1.HibernationSchedule{
+			TimeZone:   timeZone,
+			RunWindows: windows,
+		}
+	}
+}
+
+func WithHibernationScheduleStatus(status *hivev1.HibernationScheduleStatus) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Status.HibernationSchedule = status
+	}
+}
+
 // WithAWSPlatform sets the specified aws platform on the supplied object.
 func WithAWSPlatform(platform *hivev1aws.Platform) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
@@ -239,6 +257,27 @@ func WithAlibabaCloudPlatform(platform *hivev1alibabacloud.Platform) Option {
 	}
 }
 
+// WithOpenStackPlatform sets the specified OpenStack platform on the cd.
+func WithOpenStackPlatform(platform *hivev1openstack.Platform) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Platform.OpenStack = platform
+	}
+}
+
+// WithVSpherePlatform sets the specified vSphere platform on the cd.
+func WithVSpherePlatform(platform *hivev1vsphere.Platform) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Platform.VSphere = platform
+	}
+}
+
+// WithOvirtPlatform sets the specified oVirt platform on the cd.
+func WithOvirtPlatform(platform *hivev1ovirt.Platform) Option {
+	return func(clusterDeployment *hivev1.ClusterDeployment) {
+		clusterDeployment.Spec.Platform.Ovirt = platform
+	}
+}
+
 // WithClusterMetadata sets the specified cluster metadata on the cd.
 func WithClusterMetadata(clusterMetadata *hivev1.ClusterMetadata) Option {
 	return func(clusterDeployment *hivev1.ClusterDeployment) {
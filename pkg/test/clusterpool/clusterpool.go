@@ -189,6 +189,24 @@ func WithCondition(cond hivev1.ClusterPoolCondition) Option {
 	}
 }
 
+func WithHibernationSchedule(schedule *hivev1.HibernationSchedule) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.HibernationSchedule = schedule
+	}
+}
+
+func WithSizingSchedule(entries ...hivev1.ClusterPoolSizingScheduleEntry) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.SizingSchedule = entries
+	}
+}
+
+func WithAutoscaling(minSize, maxSize int32) Option {
+	return func(clusterPool *hivev1.ClusterPool) {
+		clusterPool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MinSize: minSize, MaxSize: maxSize}
+	}
+}
+
 func WithRunningCount(size int) Option {
 	return func(clusterPool *hivev1.ClusterPool) {
 		clusterPool.Spec.RunningCount = int32(size)
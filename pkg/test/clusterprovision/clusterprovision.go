@@ -147,6 +147,27 @@ func WithFailureReason(reason string) Option {
 	}
 }
 
+func WithFailureClassification(category hivev1.InstallFailureCategory, retryable bool) Option {
+	return func(clusterProvision *hivev1.ClusterProvision) {
+		clusterProvision.Status.FailureClassification = &hivev1.InstallFailureClassification{
+			Category:  category,
+			Retryable: retryable,
+		}
+	}
+}
+
+func WithInstallConfigRemediations(remediations ...hivev1.InstallConfigRemediation) Option {
+	return func(clusterProvision *hivev1.ClusterProvision) {
+		clusterProvision.Spec.InstallConfigRemediations = remediations
+	}
+}
+
+func WithInstallPhases(phases ...hivev1.ClusterProvisionInstallPhase) Option {
+	return func(clusterProvision *hivev1.ClusterProvision) {
+		clusterProvision.Status.InstallPhases = phases
+	}
+}
+
 func WithCreationTimestamp(time time.Time) Option {
 	return Generic(generic.WithCreationTimestamp(time))
 }
@@ -87,3 +87,21 @@ func WithClusterDeploymentSelector(key, value string) Option {
 		}
 	}
 }
+
+func WithDryRun() Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Spec.DryRun = true
+	}
+}
+
+// WithClusterDeploymentStatus adds the relocation of a ClusterDeployment to the status of the ClusterRelocate.
+func WithClusterDeploymentStatus(namespace, name string, state hivev1.ClusterRelocateState) Option {
+	return func(clusterRelocate *hivev1.ClusterRelocate) {
+		clusterRelocate.Status.ClusterDeployments = append(clusterRelocate.Status.ClusterDeployments,
+			hivev1.ClusterRelocateClusterDeploymentStatus{
+				Namespace: namespace,
+				Name:      name,
+				State:     state,
+			})
+	}
+}
@@ -0,0 +1,138 @@
+package clusterupgradecampaign
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/test/generic"
+)
+
+// Option defines a function signature for any function that wants to be passed into Build
+type Option func(*hivev1.ClusterUpgradeCampaign)
+
+// Build runs each of the functions passed in to generate the object.
+func Build(opts ...Option) *hivev1.ClusterUpgradeCampaign {
+	retval := &hivev1.ClusterUpgradeCampaign{}
+	for _, o := range opts {
+		o(retval)
+	}
+
+	return retval
+}
+
+type Builder interface {
+	Build(opts ...Option) *hivev1.ClusterUpgradeCampaign
+
+	Options(opts ...Option) Builder
+
+	GenericOptions(opts ...generic.Option) Builder
+}
+
+func BasicBuilder() Builder {
+	return &builder{}
+}
+
+func FullBuilder(name string, typer runtime.ObjectTyper) Builder {
+	b := &builder{}
+	return b.GenericOptions(
+		generic.WithTypeMeta(typer),
+		generic.WithResourceVersion("1"),
+		generic.WithName(name),
+	)
+}
+
+type builder struct {
+	options []Option
+}
+
+func (b *builder) Build(opts ...Option) *hivev1.ClusterUpgradeCampaign {
+	return Build(append(b.options, opts...)...)
+}
+
+func (b *builder) Options(opts ...Option) Builder {
+	return &builder{
+		options: append(b.options, opts...),
+	}
+}
+
+func (b *builder) GenericOptions(opts ...generic.Option) Builder {
+	options := make([]Option, len(opts))
+	for i, o := range opts {
+		options[i] = Generic(o)
+	}
+	return b.Options(options...)
+}
+
+// Generic allows common functions applicable to all objects to be used as Options to Build
+func Generic(opt generic.Option) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		opt(campaign)
+	}
+}
+
+func WithClusterDeploymentSelector(key, value string) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.ClusterDeploymentSelector = metav1.LabelSelector{
+			MatchLabels: map[string]string{key: value},
+		}
+	}
+}
+
+func WithVersion(version string) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.Upgrade = hivev1.ClusterUpgrade{Version: version}
+	}
+}
+
+func WithImageSet(name string) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.Upgrade = hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{Name: name}}
+	}
+}
+
+// WithWave adds a wave selecting the clusters with the given label.
+func WithWave(name, key, value string) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.Waves = append(campaign.Spec.Waves, hivev1.ClusterUpgradeWave{
+			Name: name,
+			ClusterDeploymentSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{key: value},
+			},
+		})
+	}
+}
+
+func WithMaxUnavailable(maxUnavailable intstr.IntOrString) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.MaxUnavailable = &maxUnavailable
+	}
+}
+
+func WithSoakTime(soakTime time.Duration) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.SoakTime = &metav1.Duration{Duration: soakTime}
+	}
+}
+
+func WithMaxFailures(maxFailures int32) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.MaxFailures = maxFailures
+	}
+}
+
+func Paused() Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Spec.Paused = true
+	}
+}
+
+// WithClusterStatus adds the progress of the upgrade of a cluster to the status of the campaign.
+func WithClusterStatus(status hivev1.ClusterUpgradeCampaignClusterStatus) Option {
+	return func(campaign *hivev1.ClusterUpgradeCampaign) {
+		campaign.Status.Clusters = append(campaign.Status.Clusters, status)
+	}
+}
@@ -0,0 +1,125 @@
+// Package syncsettemplate renders the resources and patches of SyncSets and SelectorSyncSets that have resource
+// templates enabled.
+package syncsettemplate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+// Data is the data available to resource templates.
+type Data struct {
+	// ClusterDeployment is the ClusterDeployment of the cluster to which the syncset is being applied.
+	ClusterDeployment *hivev1.ClusterDeployment
+	// ClusterMetadata is the metadata of the installed cluster, e.g. {{ .ClusterMetadata.InfraID }}.
+	ClusterMetadata *hivev1.ClusterMetadata
+	// Labels are the labels of the ClusterDeployment, e.g. {{ index .Labels "hive.openshift.io/cluster-region" }}.
+	Labels map[string]string
+	// Annotations are the annotations of the ClusterDeployment.
+	Annotations map[string]string
+}
+
+// DataForClusterDeployment returns the template data for the cluster of the ClusterDeployment.
+func DataForClusterDeployment(cd *hivev1.ClusterDeployment) *Data {
+	return &Data{
+		ClusterDeployment: cd,
+		ClusterMetadata:   cd.Spec.ClusterMetadata,
+		Labels:            cd.Labels,
+		Annotations:       cd.Annotations,
+	}
+}
+
+// validationData is rendered in place of a real cluster when validating templates, so that references to fields
+// that do not exist are caught while references to labels and annotations that a cluster may not have are not.
+var validationData = &Data{
+	ClusterDeployment: &hivev1.ClusterDeployment{
+		Spec: hivev1.ClusterDeploymentSpec{ClusterMetadata: &hivev1.ClusterMetadata{}},
+	},
+	ClusterMetadata: &hivev1.ClusterMetadata{},
+	Labels:          map[string]string{},
+	Annotations:     map[string]string{},
+}
+
+// RenderString renders s as a template. Referencing a label or annotation that the cluster does not have as a
+// field, e.g. {{ .Labels.region }}, is an error.
+func RenderString(s string, data *Data) (string, error) {
+	return render(s, data, "missingkey=error")
+}
+
+// RenderObject renders, in place, every string value in obj as a template.
+func RenderObject(obj map[string]interface{}, data *Data) error {
+	return renderValues("", obj, func(s string) (string, error) { return RenderString(s, data) })
+}
+
+// ValidateString returns an error if s is not a valid template.
+func ValidateString(s string) error {
+	_, err := render(s, validationData, "missingkey=zero")
+	// Optional fields, such as the platform of the cluster, are nil in the validation data but may be set for real
+	// clusters, so they cannot be rejected here.
+	if err != nil && strings.Contains(err.Error(), "nil pointer evaluating") {
+		return nil
+	}
+	return err
+}
+
+// ValidateObject returns an error if any string value in obj is not a valid template.
+func ValidateObject(obj map[string]interface{}) error {
+	return renderValues("", obj, func(s string) (string, error) { return s, ValidateString(s) })
+}
+
+func render(s string, data *Data, missingKeyOption string) (string, error) {
+	if !strings.Contains(s, "{{") {
+		return s, nil
+	}
+	t, err := template.New("resource").Option(missingKeyOption).Parse(s)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func renderValues(path string, value interface{}, renderFn func(string) (string, error)) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			itemPath := key
+			if path != "" {
+				itemPath = path + "." + key
+			}
+			rendered, err := renderValue(itemPath, item, renderFn)
+			if err != nil {
+				return err
+			}
+			v[key] = rendered
+		}
+	case []interface{}:
+		for i, item := range v {
+			rendered, err := renderValue(fmt.Sprintf("%s[%d]", path, i), item, renderFn)
+			if err != nil {
+				return err
+			}
+			v[i] = rendered
+		}
+	}
+	return nil
+}
+
+func renderValue(path string, value interface{}, renderFn func(string) (string, error)) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, renderValues(path, value, renderFn)
+	}
+	rendered, err := renderFn(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return rendered, nil
+}
@@ -0,0 +1,116 @@
+package syncsettemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestRenderObject(t *testing.T) {
+	cd := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster",
+			Labels:      map[string]string{"hive.openshift.io/cluster-region": "us-east-1"},
+			Annotations: map[string]string{"owner": "team-a"},
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			ClusterName:     "cluster-name",
+			ClusterMetadata: &hivev1.ClusterMetadata{InfraID: "cluster-abcde"},
+		},
+	}
+	cases := []struct {
+		name          string
+		obj           map[string]interface{}
+		expectedObj   map[string]interface{}
+		expectedError string
+	}{
+		{
+			name: "rendered",
+			obj: map[string]interface{}{
+				"name":     "{{ .ClusterDeployment.Spec.ClusterName }}-config",
+				"replicas": int64(3),
+				"data": map[string]interface{}{
+					"infraID": "{{ .ClusterMetadata.InfraID }}",
+					"list":    []interface{}{"{{ index .Labels \"hive.openshift.io/cluster-region\" }}", "{{ .Annotations.owner }}"},
+				},
+			},
+			expectedObj: map[string]interface{}{
+				"name":     "cluster-name-config",
+				"replicas": int64(3),
+				"data": map[string]interface{}{
+					"infraID": "cluster-abcde",
+					"list":    []interface{}{"us-east-1", "team-a"},
+				},
+			},
+		},
+		{
+			name: "missing annotation",
+			obj: map[string]interface{}{
+				"data": map[string]interface{}{
+					"list": []interface{}{"{{ .Annotations.missing }}"},
+				},
+			},
+			expectedError: `data.list[0]: template: resource:1:15: executing "resource" at <.Annotations.missing>: map has no entry for key "missing"`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := RenderObject(tc.obj, DataForClusterDeployment(cd))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError, "unexpected error")
+				return
+			}
+			assert.NoError(t, err, "unexpected error")
+			assert.Equal(t, tc.expectedObj, tc.obj, "unexpected rendered object")
+		})
+	}
+}
+
+func TestValidateString(t *testing.T) {
+	cases := []struct {
+		name        string
+		template    string
+		expectValid bool
+	}{
+		{
+			name:        "no template",
+			template:    "plain",
+			expectValid: true,
+		},
+		{
+			name:        "missing label",
+			template:    "{{ .Labels.region }}",
+			expectValid: true,
+		},
+		{
+			name:        "unset optional field",
+			template:    "{{ .ClusterDeployment.Spec.Platform.AWS.Region }}",
+			expectValid: true,
+		},
+		{
+			name:     "syntax error",
+			template: "{{ .ClusterDeployment.Name",
+		},
+		{
+			name:     "unknown field",
+			template: "{{ .ClusterDeployment.Spec.Region }}",
+		},
+		{
+			name:     "unknown function",
+			template: "{{ upper .ClusterDeployment.Name }}",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateString(tc.template)
+			if tc.expectValid {
+				assert.NoError(t, err, "expected template to be valid")
+			} else {
+				assert.Error(t, err, "expected template to be invalid")
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	log "github.com/sirupsen/logrus"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/openshift/hive/pkg/constants"
 	"github.com/openshift/hive/pkg/controller/awsprivatelink"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 	"github.com/openshift/hive/pkg/manageddns"
 	"github.com/openshift/hive/pkg/util/contracts"
 )
@@ -45,7 +47,7 @@ const (
 )
 
 var (
-	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "InstallAttemptsLimit", "Platform.AgentBareMetal.AgentSelector", "Platform.AWS.PrivateLink.AdditionalAllowedPrincipals"}
+	mutableFields = []string{"CertificateBundles", "ClusterMetadata", "ControlPlaneConfig", "Ingress", "Installed", "PreserveOnDelete", "ClusterPoolRef", "PowerState", "HibernateAfter", "HibernationSchedule", "InstallAttemptsLimit", "Upgrade", "Platform.AgentBareMetal.AgentSelector", "Platform.AWS.PrivateLink.AdditionalAllowedPrincipals"}
 )
 
 // ClusterDeploymentValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
@@ -292,6 +294,8 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateCreate(admissionSpec
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath.Child("platform"), cd.Spec.Platform)...)
 	allErrs = append(allErrs, validateCanManageDNSForClusterPlatform(specPath, cd.Spec)...)
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateClusterUpgrade(specPath.Child("upgrade"), cd.Spec.Upgrade)...)
 
 	if cd.Spec.Platform.AWS != nil {
 		allErrs = append(allErrs, validateAWSPrivateLink(specPath.Child("platform", "aws"), cd.Spec.Platform.AWS, a.awsPrivateLinkConfig)...)
@@ -695,6 +699,9 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 		}
 	}
 
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), cd.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateClusterUpgrade(specPath.Child("upgrade"), cd.Spec.Upgrade)...)
+
 	// Validate the ClusterPoolRef:
 	switch oldPoolRef, newPoolRef := oldObject.Spec.ClusterPoolRef, cd.Spec.ClusterPoolRef; {
 	case oldPoolRef != nil && newPoolRef != nil:
@@ -725,6 +732,45 @@ func (a *ClusterDeploymentValidatingAdmissionHook) validateUpdate(admissionSpec
 	}
 }
 
+// validateHibernationSchedule ensures the time zone and windows of a HibernationSchedule can be evaluated.
+func validateHibernationSchedule(path *field.Path, schedule *hivev1.HibernationSchedule) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if schedule == nil {
+		return allErrs
+	}
+	if _, err := controllerutils.LoadScheduleLocation(schedule.TimeZone); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), schedule.TimeZone, err.Error()))
+	}
+	if len(schedule.RunWindows) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("runWindows"), "must specify at least one run window"))
+	}
+	if err := controllerutils.ValidateScheduleWindows(schedule.RunWindows); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("runWindows"), schedule.RunWindows, err.Error()))
+	}
+	return allErrs
+}
+
+// validateClusterUpgrade ensures a ClusterUpgrade requests exactly one release.
+func validateClusterUpgrade(path *field.Path, upgrade *hivev1.ClusterUpgrade) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if upgrade == nil {
+		return allErrs
+	}
+	switch {
+	case upgrade.Version == "" && upgrade.ImageSetRef == nil:
+		allErrs = append(allErrs, field.Required(path, "must specify either version or imageSetRef"))
+	case upgrade.Version != "" && upgrade.ImageSetRef != nil:
+		allErrs = append(allErrs, field.Invalid(path.Child("imageSetRef"), upgrade.ImageSetRef, "cannot specify both version and imageSetRef"))
+	case upgrade.Version != "":
+		if _, err := semver.Parse(upgrade.Version); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("version"), upgrade.Version, err.Error()))
+		}
+	case upgrade.ImageSetRef.Name == "":
+		allErrs = append(allErrs, field.Required(path.Child("imageSetRef", "name"), "must specify the name of a ClusterImageSet"))
+	}
+	return allErrs
+}
+
 // validateDelete specifically validates delete operations for ClusterDeployment objects.
 func (a *ClusterDeploymentValidatingAdmissionHook) validateDelete(request *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
 	logger := log.WithFields(log.Fields{
@@ -179,6 +179,17 @@ func validAgentBareMetalClusterDeployment() *hivev1.ClusterDeployment {
 }
 
 // Meant to be used to compare new and old as the same values.
+func validHibernationSchedule() *hivev1.HibernationSchedule {
+	return &hivev1.HibernationSchedule{
+		TimeZone: "Europe/Berlin",
+		RunWindows: []hivev1.ScheduleWindow{{
+			Days:  []hivev1.ScheduleWeekday{hivev1.ScheduleMonday, hivev1.ScheduleFriday},
+			Start: "08:00",
+			End:   "19:00",
+		}},
+	}
+}
+
 func validClusterDeploymentSameValues() *hivev1.ClusterDeployment {
 	return validAWSClusterDeployment()
 }
@@ -343,6 +354,175 @@ func TestClusterDeploymentValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: false,
 		},
+		{
+			name: "Test create with valid HibernationSchedule",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = validHibernationSchedule()
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with HibernationSchedule in unknown time zone",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.TimeZone = "Mars/Olympus_Mons"
+					return s
+				}()
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with HibernationSchedule without run windows",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = &hivev1.HibernationSchedule{TimeZone: "Europe/Berlin"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with HibernationSchedule with invalid start time",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.RunWindows[0].Start = "8:00"
+					return s
+				}()
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with HibernationSchedule with invalid end time",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.RunWindows[0].End = "24:00"
+					return s
+				}()
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with HibernationSchedule with invalid day",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.RunWindows[0].Days = []hivev1.ScheduleWeekday{"Caturday"}
+					return s
+				}()
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adding valid HibernationSchedule",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = validHibernationSchedule()
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update adding HibernationSchedule in unknown time zone",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.TimeZone = "Mars/Olympus_Mons"
+					return s
+				}()
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adding upgrade to version",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{Version: "4.12.3", Channel: "stable-4.12"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update adding upgrade to ClusterImageSet",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{Name: "openshift-v4.12.3"}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update adding upgrade without release",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{Channel: "stable-4.12"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adding upgrade to version and ClusterImageSet",
+			oldObject: validAWSClusterDeployment(),
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{
+					Version:     "4.12.3",
+					ImageSetRef: &hivev1.ClusterImageSetReference{Name: "openshift-v4.12.3"},
+				}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with upgrade to invalid version",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{Version: "4.12"}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with upgrade to unnamed ClusterImageSet",
+			newObject: func() *hivev1.ClusterDeployment {
+				cd := validAWSClusterDeployment()
+				cd.Spec.Upgrade = &hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{}}
+				return cd
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test update with claimed ClusterPoolReference",
 			oldObject:       validAWSClusterDeploymentFromPool("pool-ns", "mypool", ""),
@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	controllerutils "github.com/openshift/hive/pkg/controller/utils"
 )
 
 const (
@@ -178,6 +179,9 @@ func (a *ClusterPoolValidatingAdmissionHook) validateCreate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), newObject.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateSizingSchedule(specPath.Child("sizingSchedule"), newObject.Spec.SizingSchedule)...)
+	allErrs = append(allErrs, validateAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
 
 	if len(allErrs) > 0 {
 		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
@@ -238,6 +242,9 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 	specPath := field.NewPath("spec")
 
 	allErrs = append(allErrs, validateClusterPlatform(specPath, newObject.Spec.Platform)...)
+	allErrs = append(allErrs, validateHibernationSchedule(specPath.Child("hibernationSchedule"), newObject.Spec.HibernationSchedule)...)
+	allErrs = append(allErrs, validateSizingSchedule(specPath.Child("sizingSchedule"), newObject.Spec.SizingSchedule)...)
+	allErrs = append(allErrs, validateAutoscaling(specPath.Child("autoscaling"), newObject.Spec.Autoscaling)...)
 
 	if len(allErrs) > 0 {
 		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
@@ -254,3 +261,33 @@ func (a *ClusterPoolValidatingAdmissionHook) validateUpdate(admissionSpec *admis
 		Allowed: true,
 	}
 }
+
+// validateSizingSchedule ensures the time zone and windows of each SizingSchedule entry can be evaluated.
+func validateSizingSchedule(path *field.Path, schedule []hivev1.ClusterPoolSizingScheduleEntry) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, entry := range schedule {
+		entryPath := path.Index(i)
+		if _, err := controllerutils.LoadScheduleLocation(entry.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(entryPath.Child("timeZone"), entry.TimeZone, err.Error()))
+		}
+		if len(entry.Windows) == 0 {
+			allErrs = append(allErrs, field.Required(entryPath.Child("windows"), "must specify at least one window"))
+		}
+		if err := controllerutils.ValidateScheduleWindows(entry.Windows); err != nil {
+			allErrs = append(allErrs, field.Invalid(entryPath.Child("windows"), entry.Windows, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateAutoscaling ensures the bounds of Autoscaling are consistent.
+func validateAutoscaling(path *field.Path, autoscaling *hivev1.ClusterPoolAutoscaling) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if autoscaling == nil {
+		return allErrs
+	}
+	if autoscaling.MaxSize < autoscaling.MinSize {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxSize"), autoscaling.MaxSize, "must not be less than minSize"))
+	}
+	return allErrs
+}
@@ -10,6 +10,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	hivev1aws "github.com/openshift/hive/apis/hive/v1/aws"
@@ -102,6 +103,167 @@ func TestClusterPoolValidate(t *testing.T) {
 			operation:       admissionv1beta1.Update,
 			expectedAllowed: true,
 		},
+		{
+			name: "Test create with valid HibernationSchedule",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationSchedule = validHibernationSchedule()
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with HibernationSchedule in unknown time zone",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.TimeZone = "Mars/Olympus_Mons"
+					return s
+				}()
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with HibernationSchedule without run windows",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationSchedule = &hivev1.HibernationSchedule{TimeZone: "Europe/Berlin"}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with HibernationSchedule with invalid start time",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.RunWindows[0].Start = "8:00"
+					return s
+				}()
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with HibernationSchedule with invalid day",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.RunWindows[0].Days = []hivev1.ScheduleWeekday{"Caturday"}
+					return s
+				}()
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adding valid HibernationSchedule",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationSchedule = validHibernationSchedule()
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update adding HibernationSchedule with invalid end time",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.HibernationSchedule = func() *hivev1.HibernationSchedule {
+					s := validHibernationSchedule()
+					s.RunWindows[0].End = "19:60"
+					return s
+				}()
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with valid SizingSchedule",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.SizingSchedule = []hivev1.ClusterPoolSizingScheduleEntry{{
+					TimeZone: "Europe/Berlin",
+					Windows:  validHibernationSchedule().RunWindows,
+					Size:     pointer.Int32(10),
+				}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "Test create with SizingSchedule in unknown time zone",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.SizingSchedule = []hivev1.ClusterPoolSizingScheduleEntry{{
+					TimeZone: "Mars/Olympus_Mons",
+					Windows:  validHibernationSchedule().RunWindows,
+					Size:     pointer.Int32(10),
+				}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with SizingSchedule without windows",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.SizingSchedule = []hivev1.ClusterPoolSizingScheduleEntry{{Size: pointer.Int32(10)}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test update adding SizingSchedule with invalid start time",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.SizingSchedule = []hivev1.ClusterPoolSizingScheduleEntry{{
+					Windows:      []hivev1.ScheduleWindow{{Start: "8:00", End: "19:00"}},
+					RunningCount: pointer.Int32(2),
+				}}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "Test create with valid Autoscaling",
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MinSize: 1, MaxSize: 10}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test update adding Autoscaling with maxSize less than minSize",
+			oldObject: validAWSClusterPool(),
+			newObject: func() *hivev1.ClusterPool {
+				pool := validAWSClusterPool()
+				pool.Spec.Autoscaling = &hivev1.ClusterPoolAutoscaling{MinSize: 5, MaxSize: 2}
+				return pool
+			}(),
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test unable to marshal new object during create",
 			newObjectRaw:    []byte{0},
@@ -0,0 +1,219 @@
+package v1
+
+import (
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+const (
+	clusterUpgradeCampaignGroup    = "hive.openshift.io"
+	clusterUpgradeCampaignVersion  = "v1"
+	clusterUpgradeCampaignResource = "clusterupgradecampaigns"
+)
+
+// ClusterUpgradeCampaignValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
+type ClusterUpgradeCampaignValidatingAdmissionHook struct {
+	decoder *admission.Decoder
+}
+
+// NewClusterUpgradeCampaignValidatingAdmissionHook constructs a new ClusterUpgradeCampaignValidatingAdmissionHook
+func NewClusterUpgradeCampaignValidatingAdmissionHook(decoder *admission.Decoder) *ClusterUpgradeCampaignValidatingAdmissionHook {
+	return &ClusterUpgradeCampaignValidatingAdmissionHook{decoder: decoder}
+}
+
+// ValidatingResource is called by generic-admission-server on startup to register the returned REST resource through which the
+// webhook is accessed by the kube apiserver.
+// For example, generic-admission-server uses the data below to register the webhook on the REST resource "/apis/admission.hive.openshift.io/v1/clusterupgradecampaignvalidators".
+// When the kube apiserver calls this registered REST resource, the generic-admission-server calls the Validate() method below.
+func (a *ClusterUpgradeCampaignValidatingAdmissionHook) ValidatingResource() (plural schema.GroupVersionResource, singular string) {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterupgradecampaignvalidator",
+	}).Info("Registering validation REST resource")
+	// NOTE: This GVR is meant to be different than the ClusterUpgradeCampaign CRD GVR which has group "hive.openshift.io".
+	return schema.GroupVersionResource{
+			Group:    "admission.hive.openshift.io",
+			Version:  "v1",
+			Resource: "clusterupgradecampaignvalidators",
+		},
+		"clusterupgradecampaignvalidator"
+}
+
+// Initialize is called by generic-admission-server on startup to setup any special initialization that your webhook needs.
+func (a *ClusterUpgradeCampaignValidatingAdmissionHook) Initialize(kubeClientConfig *rest.Config, stopCh <-chan struct{}) error {
+	log.WithFields(log.Fields{
+		"group":    "admission.hive.openshift.io",
+		"version":  "v1",
+		"resource": "clusterupgradecampaignvalidator",
+	}).Info("Initializing validation REST resource")
+	return nil // No initialization needed right now.
+}
+
+// Validate is called by generic-admission-server when the registered REST resource above is called with an admission request.
+// Usually it's the kube apiserver that is making the admission validation request.
+func (a *ClusterUpgradeCampaignValidatingAdmissionHook) Validate(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "Validate",
+	})
+
+	if !a.shouldValidate(admissionSpec) {
+		contextLogger.Info("Skipping validation for request")
+		// The request object isn't something that this validator should validate.
+		// Therefore, we say that it's allowed.
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: true,
+		}
+	}
+
+	contextLogger.Info("Validating request")
+
+	// Creates and updates are validated alike: the campaign may be changed freely while it runs.
+	if admissionSpec.Operation == admissionv1beta1.Create || admissionSpec.Operation == admissionv1beta1.Update {
+		return a.validateSpec(admissionSpec)
+	}
+
+	// We're only validating creates and updates at this time, so all other operations are explicitly allowed.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// shouldValidate explicitly checks if the request should validated. For example, this webhook may have accidentally been registered to check
+// the validity of some other type of object with a different GVR.
+func (a *ClusterUpgradeCampaignValidatingAdmissionHook) shouldValidate(admissionSpec *admissionv1beta1.AdmissionRequest) bool {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "shouldValidate",
+	})
+
+	if admissionSpec.Resource.Group != clusterUpgradeCampaignGroup {
+		contextLogger.Debug("Returning False, not our group")
+		return false
+	}
+
+	if admissionSpec.Resource.Version != clusterUpgradeCampaignVersion {
+		contextLogger.Debug("Returning False, it's our group, but not the right version")
+		return false
+	}
+
+	if admissionSpec.Resource.Resource != clusterUpgradeCampaignResource {
+		contextLogger.Debug("Returning False, it's our group and version, but not the right resource")
+		return false
+	}
+
+	// If we get here, then we're supposed to validate the object.
+	contextLogger.Debug("Returning True, passed all prerequisites.")
+	return true
+}
+
+// validateSpec validates the spec of a created or updated ClusterUpgradeCampaign.
+func (a *ClusterUpgradeCampaignValidatingAdmissionHook) validateSpec(admissionSpec *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	contextLogger := log.WithFields(log.Fields{
+		"operation": admissionSpec.Operation,
+		"group":     admissionSpec.Resource.Group,
+		"version":   admissionSpec.Resource.Version,
+		"resource":  admissionSpec.Resource.Resource,
+		"method":    "validateSpec",
+	})
+
+	newObject := &hivev1.ClusterUpgradeCampaign{}
+	if err := a.decoder.DecodeRaw(admissionSpec.Object, newObject); err != nil {
+		contextLogger.Errorf("Failed unmarshaling Object: %v", err.Error())
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status: metav1.StatusFailure, Code: http.StatusBadRequest, Reason: metav1.StatusReasonBadRequest,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	// Add the new data to the contextLogger
+	contextLogger.Data["object.Name"] = newObject.Name
+
+	allErrs := validateClusterUpgradeCampaignSpec(field.NewPath("spec"), &newObject.Spec)
+
+	if len(allErrs) > 0 {
+		contextLogger.WithError(allErrs.ToAggregate()).Info("failed validation")
+		status := errors.NewInvalid(schemaGVK(admissionSpec.Kind).GroupKind(), admissionSpec.Name, allErrs).Status()
+		return &admissionv1beta1.AdmissionResponse{
+			Allowed: false,
+			Result:  &status,
+		}
+	}
+
+	// If we get here, then all checks passed, so the object is valid.
+	contextLogger.Info("Successful validation")
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: true,
+	}
+}
+
+// validateClusterUpgradeCampaignSpec ensures the selectors of a campaign parse, that it requests exactly one release,
+// and that its limits are not negative.
+func validateClusterUpgradeCampaignSpec(path *field.Path, spec *hivev1.ClusterUpgradeCampaignSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&spec.ClusterDeploymentSelector, metav1validation.LabelSelectorValidationOptions{}, path.Child("clusterDeploymentSelector"))...)
+	allErrs = append(allErrs, validateClusterUpgrade(path.Child("upgrade"), &spec.Upgrade)...)
+
+	waveNames := sets.NewString()
+	for i, wave := range spec.Waves {
+		wavePath := path.Child("waves").Index(i)
+		switch {
+		case wave.Name == "":
+			allErrs = append(allErrs, field.Required(wavePath.Child("name"), "must specify a name for the wave"))
+		case waveNames.Has(wave.Name):
+			allErrs = append(allErrs, field.Duplicate(wavePath.Child("name"), wave.Name))
+		}
+		waveNames.Insert(wave.Name)
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&wave.ClusterDeploymentSelector, metav1validation.LabelSelectorValidationOptions{}, wavePath.Child("clusterDeploymentSelector"))...)
+	}
+
+	if maxUnavailable := spec.MaxUnavailable; maxUnavailable != nil {
+		maxUnavailablePath := path.Child("maxUnavailable")
+		switch {
+		case maxUnavailable.Type == intstr.String && !strings.HasSuffix(maxUnavailable.StrVal, "%"):
+			allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable.StrVal, "must be an integer or a percentage"))
+		case maxUnavailable.Type == intstr.String:
+			// 100% scaled to 100 is the percentage itself
+			if percent, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, 100, true); err != nil {
+				allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable.StrVal, err.Error()))
+			} else if percent < 0 {
+				allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable.StrVal, "must not be negative"))
+			}
+		case maxUnavailable.IntVal < 0:
+			allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable.IntVal, "must not be negative"))
+		}
+	}
+	if spec.SoakTime != nil && spec.SoakTime.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("soakTime"), spec.SoakTime.Duration.String(), "must not be negative"))
+	}
+	if spec.MaxFailures < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxFailures"), spec.MaxFailures, "must not be negative"))
+	}
+	return allErrs
+}
@@ -0,0 +1,239 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+)
+
+func TestClusterUpgradeCampaignValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewClusterUpgradeCampaignValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "clusterupgradecampaignvalidators",
+	}
+	expectedSingular := "clusterupgradecampaignvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestClusterUpgradeCampaignInitialize(t *testing.T) {
+	// Arrange
+	data := NewClusterUpgradeCampaignValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(nil, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestClusterUpgradeCampaignValidate(t *testing.T) {
+	validSpec := func() hivev1.ClusterUpgradeCampaignSpec {
+		return hivev1.ClusterUpgradeCampaignSpec{
+			ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			Upgrade:                   hivev1.ClusterUpgrade{Version: "4.12.3"},
+		}
+	}
+	invalidSelector := metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "env", Operator: "Bogus"}},
+	}
+	cases := []struct {
+		name            string
+		spec            func(spec *hivev1.ClusterUpgradeCampaignSpec)
+		operation       admissionv1beta1.Operation
+		gvr             *metav1.GroupVersionResource
+		expectedAllowed bool
+	}{
+		{
+			name:            "valid campaign",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name: "valid campaign with waves and limits",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.Upgrade = hivev1.ClusterUpgrade{ImageSetRef: &hivev1.ClusterImageSetReference{Name: "openshift-v4.12.3"}}
+				spec.Waves = []hivev1.ClusterUpgradeWave{
+					{Name: "canary", ClusterDeploymentSelector: metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}},
+					{Name: "rest"},
+				}
+				spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.String, StrVal: "10%"}
+				spec.SoakTime = &metav1.Duration{Duration: time.Hour}
+				spec.MaxFailures = 2
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name: "invalid clusterDeploymentSelector",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.ClusterDeploymentSelector = invalidSelector
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "invalid wave selector",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.Waves = []hivev1.ClusterUpgradeWave{{Name: "canary", ClusterDeploymentSelector: invalidSelector}}
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "wave without name",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.Waves = []hivev1.ClusterUpgradeWave{{}}
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "duplicate wave names",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.Waves = []hivev1.ClusterUpgradeWave{{Name: "canary"}, {Name: "canary"}}
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "no upgrade target",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.Upgrade = hivev1.ClusterUpgrade{Channel: "stable-4.12"}
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "both version and imageSetRef",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.Upgrade.ImageSetRef = &hivev1.ClusterImageSetReference{Name: "openshift-v4.12.3"}
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "negative maxUnavailable",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.Int, IntVal: -1}
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "negative maxUnavailable percentage",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.String, StrVal: "-10%"}
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "maxUnavailable is not a percentage",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.MaxUnavailable = &intstr.IntOrString{Type: intstr.String, StrVal: "ten"}
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "negative maxFailures",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.MaxFailures = -1
+			},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name: "negative soakTime",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.SoakTime = &metav1.Duration{Duration: -time.Minute}
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name: "delete is allowed",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.MaxFailures = -1
+			},
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "other resources are not validated",
+			spec: func(spec *hivev1.ClusterUpgradeCampaignSpec) {
+				spec.MaxFailures = -1
+			},
+			operation: admissionv1beta1.Create,
+			gvr: &metav1.GroupVersionResource{
+				Group:    "hive.openshift.io",
+				Version:  "v1",
+				Resource: "clusterdeployments",
+			},
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewClusterUpgradeCampaignValidatingAdmissionHook(createDecoder(t))
+			newObject := &hivev1.ClusterUpgradeCampaign{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-campaign"},
+				Spec:       validSpec(),
+			}
+			if tc.spec != nil {
+				tc.spec(&newObject.Spec)
+			}
+			newObjectRaw, _ := json.Marshal(newObject)
+			oldObjectRaw, _ := json.Marshal(&hivev1.ClusterUpgradeCampaign{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-campaign"},
+				Spec:       validSpec(),
+			})
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "hive.openshift.io",
+					Version:  "v1",
+					Resource: "clusterupgradecampaigns",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Object: runtime.RawExtension{
+					Raw: newObjectRaw,
+				},
+				OldObject: runtime.RawExtension{
+					Raw: oldObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -14,6 +15,7 @@ import (
 func createDecoder(t *testing.T) *admission.Decoder {
 	scheme := runtime.NewScheme()
 	hivev1.AddToScheme(scheme)
+	corev1.AddToScheme(scheme)
 	decoder, err := admission.NewDecoder(scheme)
 	require.NoError(t, err, "unexpected error creating decoder")
 	return decoder
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const validInstallLogRegexes = `
+- name: DNSAlreadyExists
+  searchRegexStrings:
+  - "aws_route53_record.*Error building changeset:.*Tried to create resource record set.*but it already exists"
+  installFailingReason: DNSAlreadyExists
+  installFailingMessage: DNS record already exists
+`
+
+const uncompilableInstallLogRegexes = `
+- name: BadEntry
+  searchRegexStrings:
+  - "*"
+  installFailingReason: BadEntry
+  installFailingMessage: Bad entry
+`
+
+func TestInstallLogRegexesValidatingResource(t *testing.T) {
+	// Arrange
+	data := NewInstallLogRegexesValidatingAdmissionHook(createDecoder(t))
+	expectedPlural := schema.GroupVersionResource{
+		Group:    "admission.hive.openshift.io",
+		Version:  "v1",
+		Resource: "installlogregexesvalidators",
+	}
+	expectedSingular := "installlogregexesvalidator"
+
+	// Act
+	plural, singular := data.ValidatingResource()
+
+	// Assert
+	assert.Equal(t, expectedPlural, plural)
+	assert.Equal(t, expectedSingular, singular)
+}
+
+func TestInstallLogRegexesInitialize(t *testing.T) {
+	// Arrange
+	data := NewInstallLogRegexesValidatingAdmissionHook(createDecoder(t))
+
+	// Act
+	err := data.Initialize(nil, nil)
+
+	// Assert
+	assert.Nil(t, err)
+}
+
+func TestInstallLogRegexesValidate(t *testing.T) {
+	cases := []struct {
+		name            string
+		configMapName   string
+		data            map[string]string
+		newObjectRaw    []byte
+		operation       admissionv1beta1.Operation
+		expectedAllowed bool
+		gvr             *metav1.GroupVersionResource
+	}{
+		{
+			name:            "valid regexes",
+			data:            map[string]string{"regexes": validInstallLogRegexes},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "valid additional regexes",
+			configMapName:   "additional-install-log-regexes",
+			data:            map[string]string{"regexes": validInstallLogRegexes},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "no regexes",
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+		{
+			name:            "uncompilable regex on create",
+			data:            map[string]string{"regexes": uncompilableInstallLogRegexes},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "uncompilable regex on update",
+			configMapName:   "additional-install-log-regexes",
+			data:            map[string]string{"regexes": validInstallLogRegexes + uncompilableInstallLogRegexes},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "malformed regexes",
+			data:            map[string]string{"regexes": "malformed"},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: false,
+		},
+		{
+			name:            "other configmap",
+			configMapName:   "some-other-configmap",
+			data:            map[string]string{"regexes": uncompilableInstallLogRegexes},
+			operation:       admissionv1beta1.Update,
+			expectedAllowed: true,
+		},
+		{
+			name:            "unable to marshal new object",
+			newObjectRaw:    []byte{0},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: false,
+		},
+		{
+			name:            "deletes are not validated",
+			data:            map[string]string{"regexes": uncompilableInstallLogRegexes},
+			operation:       admissionv1beta1.Delete,
+			expectedAllowed: true,
+		},
+		{
+			name: "wrong resource",
+			data: map[string]string{"regexes": uncompilableInstallLogRegexes},
+			gvr: &metav1.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "secrets",
+			},
+			operation:       admissionv1beta1.Create,
+			expectedAllowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			data := NewInstallLogRegexesValidatingAdmissionHook(createDecoder(t))
+			if tc.configMapName == "" {
+				tc.configMapName = "install-log-regexes"
+			}
+			newObject := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tc.configMapName,
+					Namespace: "hive",
+				},
+				Data: tc.data,
+			}
+
+			if tc.newObjectRaw == nil {
+				tc.newObjectRaw, _ = json.Marshal(newObject)
+			}
+
+			if tc.gvr == nil {
+				tc.gvr = &metav1.GroupVersionResource{
+					Group:    "",
+					Version:  "v1",
+					Resource: "configmaps",
+				}
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				Operation: tc.operation,
+				Resource:  *tc.gvr,
+				Name:      tc.configMapName,
+				Object: runtime.RawExtension{
+					Raw: tc.newObjectRaw,
+				},
+			}
+
+			// Act
+			response := data.Validate(request)
+
+			// Assert
+			assert.Equal(t, tc.expectedAllowed, response.Allowed)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"net/http"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
@@ -9,6 +10,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -159,6 +161,10 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateCreate(admissionSpec *a
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec").Child("patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateDriftDetection(newObject.Spec.DriftDetection, field.NewPath("spec", "driftDetection"))...)
+	allErrs = append(allErrs, validateResourceTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateServerSideApply(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateRolloutStrategy(newObject.Spec.RolloutStrategy, field.NewPath("spec", "rolloutStrategy"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -206,6 +212,10 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 	allErrs = append(allErrs, validatePatches(newObject.Spec.Patches, field.NewPath("spec", "patches"))...)
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateDriftDetection(newObject.Spec.DriftDetection, field.NewPath("spec", "driftDetection"))...)
+	allErrs = append(allErrs, validateResourceTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateServerSideApply(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateRolloutStrategy(newObject.Spec.RolloutStrategy, field.NewPath("spec", "rolloutStrategy"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -222,3 +232,31 @@ func (a *SelectorSyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *a
 		Allowed: true,
 	}
 }
+
+func validateRolloutStrategy(strategy *hivev1.SelectorSyncSetRolloutStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if strategy == nil {
+		return allErrs
+	}
+	batchSizePath := fldPath.Child("batchSize")
+	switch batchSize := strategy.BatchSize; {
+	case batchSize.Type == intstr.String && !strings.HasSuffix(batchSize.StrVal, "%"):
+		allErrs = append(allErrs, field.Invalid(batchSizePath, batchSize.StrVal, "must be an integer or a percentage"))
+	case batchSize.Type == intstr.String:
+		// 100% scaled to 100 is the percentage itself
+		if percent, err := intstr.GetScaledValueFromIntOrPercent(&batchSize, 100, false); err != nil {
+			allErrs = append(allErrs, field.Invalid(batchSizePath, batchSize.StrVal, err.Error()))
+		} else if percent < 1 || percent > 100 {
+			allErrs = append(allErrs, field.Invalid(batchSizePath, batchSize.StrVal, "must be between 1% and 100%"))
+		}
+	case batchSize.IntVal < 1:
+		allErrs = append(allErrs, field.Invalid(batchSizePath, batchSize.IntVal, "must be at least 1"))
+	}
+	if strategy.PauseBetweenBatches != nil && strategy.PauseBetweenBatches.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("pauseBetweenBatches"), strategy.PauseBetweenBatches.Duration.String(), "must not be negative"))
+	}
+	if p := strategy.MaxFailurePercentage; p != nil && (*p < 0 || *p > 100) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxFailurePercentage"), *p, "must be between 0 and 100"))
+	}
+	return allErrs
+}
@@ -3,6 +3,7 @@ package v1
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +11,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 )
 
 func TestSelectorSyncSetValidatingResource(t *testing.T) {
@@ -228,6 +231,141 @@ func TestSelectorSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid Report driftDetection create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DriftDetection = "Report"
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid Correct driftDetection update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DriftDetection = "Correct"
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid driftDetection create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DriftDetection = "report"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid driftDetection update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.DriftDetection = "Ignore"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid resource template create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{ .ClusterDeployment.Name }}"}}`)
+				ss.Spec.EnableResourceTemplates = true
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resource template update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{ .ClusterDeployment.Name"}}`)
+				ss.Spec.EnableResourceTemplates = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid rollout strategy batch size create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{
+					BatchSize: intstr.FromInt(5),
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid rollout strategy percentage update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{
+					BatchSize:            intstr.FromString("10%"),
+					PauseBetweenBatches:  &metav1.Duration{Duration: time.Hour},
+					MaxFailurePercentage: pointer.Int32(20),
+				}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid rollout strategy zero batch size create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{
+					BatchSize: intstr.FromInt(0),
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid rollout strategy batch size string update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{
+					BatchSize: intstr.FromString("ten"),
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid rollout strategy percentage create",
+			operation: admissionv1beta1.Create,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{
+					BatchSize: intstr.FromString("150%"),
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid rollout strategy max failure percentage update",
+			operation: admissionv1beta1.Update,
+			selectorSyncSet: func() *hivev1.SelectorSyncSet {
+				ss := testSelectorSyncSet()
+				ss.Spec.RolloutStrategy = &hivev1.SelectorSyncSetRolloutStrategy{
+					BatchSize:            intstr.FromInt(1),
+					MaxFailurePercentage: pointer.Int32(101),
+				}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable TypeMeta Resource create",
 			operation:       admissionv1beta1.Create,
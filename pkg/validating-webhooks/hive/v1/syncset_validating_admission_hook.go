@@ -17,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/openshift/hive/pkg/util/syncsettemplate"
 )
 
 const (
@@ -56,6 +57,19 @@ var (
 		}
 		return v
 	}()
+
+	validDriftDetections = map[hivev1.SyncSetDriftDetection]bool{
+		hivev1.CorrectSyncSetDriftDetection: true,
+		hivev1.ReportSyncSetDriftDetection:  true,
+	}
+
+	validDriftDetectionSlice = func() []string {
+		v := make([]string, 0, len(validDriftDetections))
+		for m := range validDriftDetections {
+			v = append(v, string(m))
+		}
+		return v
+	}()
 )
 
 // SyncSetValidatingAdmissionHook is a struct that is used to reference what code should be run by the generic-admission-server.
@@ -196,6 +210,9 @@ func (a *SyncSetValidatingAdmissionHook) validateCreate(admissionSpec *admission
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec").Child("secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateDriftDetection(newObject.Spec.DriftDetection, field.NewPath("spec", "driftDetection"))...)
+	allErrs = append(allErrs, validateResourceTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateServerSideApply(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -244,6 +261,9 @@ func (a *SyncSetValidatingAdmissionHook) validateUpdate(admissionSpec *admission
 	allErrs = append(allErrs, validateSecrets(newObject.Spec.Secrets, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateSourceSecretInSyncSetNamespace(newObject.Spec.Secrets, newObject.Namespace, field.NewPath("spec", "secretMappings"))...)
 	allErrs = append(allErrs, validateResourceApplyMode(newObject.Spec.ResourceApplyMode, field.NewPath("spec", "resourceApplyMode"))...)
+	allErrs = append(allErrs, validateDriftDetection(newObject.Spec.DriftDetection, field.NewPath("spec", "driftDetection"))...)
+	allErrs = append(allErrs, validateResourceTemplates(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateServerSideApply(&newObject.Spec.SyncSetCommonSpec, field.NewPath("spec"))...)
 
 	if len(allErrs) > 0 {
 		statusError := errors.NewInvalid(newObject.GroupVersionKind().GroupKind(), newObject.Name, allErrs).Status()
@@ -280,6 +300,14 @@ func validateResourceApplyMode(resourceApplyMode hivev1.SyncSetResourceApplyMode
 	return allErrs
 }
 
+func validateDriftDetection(driftDetection hivev1.SyncSetDriftDetection, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if driftDetection != "" && !validDriftDetections[driftDetection] {
+		allErrs = append(allErrs, field.NotSupported(fldPath, driftDetection, validDriftDetectionSlice))
+	}
+	return allErrs
+}
+
 func validateResources(resources []runtime.RawExtension, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, resource := range resources {
@@ -305,6 +333,51 @@ func validateResource(resource runtime.RawExtension, fldPath *field.Path) field.
 	return allErrs
 }
 
+func validateResourceTemplates(spec *hivev1.SyncSetCommonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if !spec.EnableResourceTemplates {
+		return allErrs
+	}
+	for i, resource := range spec.Resources {
+		u := map[string]interface{}{}
+		if err := json.Unmarshal(resource.Raw, &u); err != nil {
+			// Reported by validateResources
+			continue
+		}
+		if err := syncsettemplate.ValidateObject(u); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("resources").Index(i), string(resource.Raw), "Invalid template: "+err.Error()))
+		}
+	}
+	for i, patch := range spec.Patches {
+		patchPath := fldPath.Child("patches").Index(i)
+		if err := syncsettemplate.ValidateString(patch.Name); err != nil {
+			allErrs = append(allErrs, field.Invalid(patchPath.Child("name"), patch.Name, "Invalid template: "+err.Error()))
+		}
+		if err := syncsettemplate.ValidateString(patch.Namespace); err != nil {
+			allErrs = append(allErrs, field.Invalid(patchPath.Child("namespace"), patch.Namespace, "Invalid template: "+err.Error()))
+		}
+		if err := syncsettemplate.ValidateString(patch.Patch); err != nil {
+			allErrs = append(allErrs, field.Invalid(patchPath.Child("patch"), patch.Patch, "Invalid template: "+err.Error()))
+		}
+	}
+	return allErrs
+}
+
+func validateServerSideApply(spec *hivev1.SyncSetCommonSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if spec.ServerSideApply == nil {
+		return allErrs
+	}
+	if spec.ApplyBehavior != hivev1.ServerSideApplySyncSetApplyBehavior {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("serverSideApply"), spec.ServerSideApply,
+			"serverSideApply may only be set when applyBehavior is ServerSideApply"))
+	}
+	if len(spec.ServerSideApply.FieldManager) > 128 {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("serverSideApply", "fieldManager"), spec.ServerSideApply.FieldManager, 128))
+	}
+	return allErrs
+}
+
 func validateSecrets(secrets []hivev1.SecretMapping, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	for i, secret := range secrets {
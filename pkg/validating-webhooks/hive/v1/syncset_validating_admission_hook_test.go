@@ -2,6 +2,7 @@ package v1
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -242,6 +243,154 @@ func TestSyncSetValidate(t *testing.T) {
 			}(),
 			expectedAllowed: false,
 		},
+		{
+			name:      "Test valid Report driftDetection create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DriftDetection = "Report"
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid Correct driftDetection update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DriftDetection = "Correct"
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid driftDetection create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DriftDetection = "report"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid driftDetection update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.DriftDetection = "Ignore"
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid resource template create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{ .ClusterDeployment.Spec.ClusterName }}"}, "data": {"infraID": "{{ .ClusterMetadata.InfraID }}", "region": "{{ index .Labels \"region\" }}"}}`)
+				ss.Spec.EnableResourceTemplates = true
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid resource template of optional field update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm"}, "data": {"region": "{{ .ClusterDeployment.Spec.Platform.AWS.Region }}"}}`)
+				ss.Spec.EnableResourceTemplates = true
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid resource template syntax create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{ .ClusterDeployment.Spec.ClusterName"}}`)
+				ss.Spec.EnableResourceTemplates = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid resource template field update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm"}, "data": {"x": ["{{ .ClusterDeployment.Spec.NoSuchField }}"]}}`)
+				ss.Spec.EnableResourceTemplates = true
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test resource templates ignored when not enabled create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSetWithResources(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{ .ClusterDeployment.Spec.ClusterName"}}`)
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid patch template create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.EnableResourceTemplates = true
+				ss.Spec.Patches = []hivev1.SyncObjectPatch{{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Name:       "{{ .ClusterDeployment.Spec.NoSuchField }}",
+					Patch:      `{"data": {"key": "value"}}`,
+				}}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test valid serverSideApply create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = "ServerSideApply"
+				ss.Spec.ServerSideApply = &hivev1.SyncSetServerSideApply{FieldManager: "my-manager", Force: true}
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test valid serverSideApply without options update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = "ServerSideApply"
+				return ss
+			}(),
+			expectedAllowed: true,
+		},
+		{
+			name:      "Test invalid serverSideApply without applyBehavior create",
+			operation: admissionv1beta1.Create,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = "Apply"
+				ss.Spec.ServerSideApply = &hivev1.SyncSetServerSideApply{FieldManager: "my-manager"}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
+		{
+			name:      "Test invalid serverSideApply fieldManager update",
+			operation: admissionv1beta1.Update,
+			syncSet: func() *hivev1.SyncSet {
+				ss := testSyncSet()
+				ss.Spec.ApplyBehavior = "ServerSideApply"
+				ss.Spec.ServerSideApply = &hivev1.SyncSetServerSideApply{FieldManager: strings.Repeat("a", 129)}
+				return ss
+			}(),
+			expectedAllowed: false,
+		},
 		{
 			name:            "Test invalid unmarshalable Resource create",
 			operation:       admissionv1beta1.Create,
@@ -0,0 +1,122 @@
+package vsphereclient
+
+import (
+	"context"
+	"crypto/x509"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/view"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/hive/pkg/constants"
+)
+
+//go:generate mockgen -source=./client.go -destination=./mock/client_generated.go -package=mock
+
+// API represents the calls made to the vSphere API.
+type API interface {
+	ListVirtualMachines(ctx context.Context, infraID string) ([]mo.VirtualMachine, error)
+	PowerOnVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error
+	ShutdownVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error
+	Logout(ctx context.Context) error
+}
+
+// virtualMachineProperties are the properties retrieved when listing virtual machines.
+var virtualMachineProperties = []string{"name", "config.template", "runtime.powerState", "guest.guestState"}
+
+type vsphereClient struct {
+	client *govmomi.Client
+}
+
+// NewClientFromSecret creates a client for the given vCenter using the username and password in the given
+// credentials secret. If trustBundle is not empty it is used as the CA bundle when connecting to the vCenter.
+func NewClientFromSecret(ctx context.Context, secret *corev1.Secret, vCenter string, trustBundle []byte) (API, error) {
+	username, ok := secret.Data[constants.UsernameSecretKey]
+	if !ok {
+		return nil, errors.New("creds secret does not contain \"" + constants.UsernameSecretKey + "\" data")
+	}
+	password, ok := secret.Data[constants.PasswordSecretKey]
+	if !ok {
+		return nil, errors.New("creds secret does not contain \"" + constants.PasswordSecretKey + "\" data")
+	}
+	return NewClient(ctx, vCenter, string(username), string(password), trustBundle)
+}
+
+// NewClient creates a client for the given vCenter and logs in with the given username and password.
+func NewClient(ctx context.Context, vCenter, username, password string, trustBundle []byte) (API, error) {
+	u, err := soap.ParseURL(vCenter)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse vCenter URL")
+	}
+	soapClient := soap.NewClient(u, false)
+	if len(trustBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(trustBundle) {
+			return nil, errors.New("failed to parse vSphere CA bundle")
+		}
+		soapClient.DefaultTransport().TLSClientConfig.RootCAs = pool
+	}
+	vimClient, err := vim25.NewClient(ctx, soapClient)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create vSphere client")
+	}
+	c := &govmomi.Client{
+		Client:         vimClient,
+		SessionManager: session.NewManager(vimClient),
+	}
+	if err := c.Login(ctx, url.UserPassword(username, password)); err != nil {
+		return nil, errors.Wrap(err, "failed to log in to vCenter")
+	}
+	return &vsphereClient{client: c}, nil
+}
+
+// ListVirtualMachines returns the virtual machines, excluding templates, whose names start with the given infraID.
+func (c *vsphereClient) ListVirtualMachines(ctx context.Context, infraID string) ([]mo.VirtualMachine, error) {
+	m := view.NewManager(c.client.Client)
+	v, err := m.CreateContainerView(ctx, c.client.ServiceContent.RootFolder, []string{"VirtualMachine"}, true)
+	if err != nil {
+		return nil, err
+	}
+	defer v.Destroy(ctx)
+
+	var vms []mo.VirtualMachine
+	if err := v.Retrieve(ctx, []string{"VirtualMachine"}, virtualMachineProperties, &vms); err != nil {
+		return nil, err
+	}
+	var result []mo.VirtualMachine
+	for _, vm := range vms {
+		if !strings.HasPrefix(vm.Name, infraID+"-") {
+			continue
+		}
+		if vm.Config != nil && vm.Config.Template {
+			continue
+		}
+		result = append(result, vm)
+	}
+	return result, nil
+}
+
+// PowerOnVirtualMachine powers on the given virtual machine. It does not wait for the power on task to complete.
+func (c *vsphereClient) PowerOnVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error {
+	_, err := object.NewVirtualMachine(c.client.Client, vm.Reference()).PowerOn(ctx)
+	return err
+}
+
+// ShutdownVirtualMachine asks the guest OS of the given virtual machine to shut down.
+func (c *vsphereClient) ShutdownVirtualMachine(ctx context.Context, vm mo.VirtualMachine) error {
+	return object.NewVirtualMachine(c.client.Client, vm.Reference()).ShutdownGuest(ctx)
+}
+
+// Logout ends the client's session with the vCenter.
+func (c *vsphereClient) Logout(ctx context.Context) error {
+	return c.client.Logout(ctx)
+}